@@ -2,6 +2,7 @@
 package bifrostfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/bifrost"
@@ -10,10 +11,11 @@ import (
 )
 
 type FakeLRPConverter struct {
-	ConvertLRPStub        func(cf.DesireLRPRequest) (opi.LRP, error)
+	ConvertLRPStub        func(context.Context, cf.DesireLRPRequest) (opi.LRP, error)
 	convertLRPMutex       sync.RWMutex
 	convertLRPArgsForCall []struct {
-		arg1 cf.DesireLRPRequest
+		arg1 context.Context
+		arg2 cf.DesireLRPRequest
 	}
 	convertLRPReturns struct {
 		result1 opi.LRP
@@ -27,18 +29,19 @@ type FakeLRPConverter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLRPConverter) ConvertLRP(arg1 cf.DesireLRPRequest) (opi.LRP, error) {
+func (fake *FakeLRPConverter) ConvertLRP(arg1 context.Context, arg2 cf.DesireLRPRequest) (opi.LRP, error) {
 	fake.convertLRPMutex.Lock()
 	ret, specificReturn := fake.convertLRPReturnsOnCall[len(fake.convertLRPArgsForCall)]
 	fake.convertLRPArgsForCall = append(fake.convertLRPArgsForCall, struct {
-		arg1 cf.DesireLRPRequest
-	}{arg1})
+		arg1 context.Context
+		arg2 cf.DesireLRPRequest
+	}{arg1, arg2})
 	stub := fake.ConvertLRPStub
 	fakeReturns := fake.convertLRPReturns
-	fake.recordInvocation("ConvertLRP", []interface{}{arg1})
+	fake.recordInvocation("ConvertLRP", []interface{}{arg1, arg2})
 	fake.convertLRPMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -52,17 +55,17 @@ func (fake *FakeLRPConverter) ConvertLRPCallCount() int {
 	return len(fake.convertLRPArgsForCall)
 }
 
-func (fake *FakeLRPConverter) ConvertLRPCalls(stub func(cf.DesireLRPRequest) (opi.LRP, error)) {
+func (fake *FakeLRPConverter) ConvertLRPCalls(stub func(context.Context, cf.DesireLRPRequest) (opi.LRP, error)) {
 	fake.convertLRPMutex.Lock()
 	defer fake.convertLRPMutex.Unlock()
 	fake.ConvertLRPStub = stub
 }
 
-func (fake *FakeLRPConverter) ConvertLRPArgsForCall(i int) cf.DesireLRPRequest {
+func (fake *FakeLRPConverter) ConvertLRPArgsForCall(i int) (context.Context, cf.DesireLRPRequest) {
 	fake.convertLRPMutex.RLock()
 	defer fake.convertLRPMutex.RUnlock()
 	argsForCall := fake.convertLRPArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPConverter) ConvertLRPReturns(result1 opi.LRP, result2 error) {
@@ -2,6 +2,8 @@
 package bifrostfakes
 
 import (
+	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/bifrost"
@@ -10,12 +12,13 @@ import (
 )
 
 type FakeLRPDesirer struct {
-	DesireStub        func(string, *opi.LRP, ...k8s.DesireOption) error
+	DesireStub        func(context.Context, string, *opi.LRP, ...k8s.DesireOption) error
 	desireMutex       sync.RWMutex
 	desireArgsForCall []struct {
-		arg1 string
-		arg2 *opi.LRP
-		arg3 []k8s.DesireOption
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.LRP
+		arg4 []k8s.DesireOption
 	}
 	desireReturns struct {
 		result1 error
@@ -23,10 +26,11 @@ type FakeLRPDesirer struct {
 	desireReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetStub        func(opi.LRPIdentifier) (*opi.LRP, error)
+	GetStub        func(context.Context, opi.LRPIdentifier) (*opi.LRP, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	getReturns struct {
 		result1 *opi.LRP
@@ -36,10 +40,25 @@ type FakeLRPDesirer struct {
 		result1 *opi.LRP
 		result2 error
 	}
-	GetInstancesStub        func(opi.LRPIdentifier) ([]*opi.Instance, error)
+	GetInstanceStatsStub        func(context.Context, opi.LRPIdentifier) ([]*opi.InstanceStats, error)
+	getInstanceStatsMutex       sync.RWMutex
+	getInstanceStatsArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}
+	getInstanceStatsReturns struct {
+		result1 []*opi.InstanceStats
+		result2 error
+	}
+	getInstanceStatsReturnsOnCall map[int]struct {
+		result1 []*opi.InstanceStats
+		result2 error
+	}
+	GetInstancesStub        func(context.Context, opi.LRPIdentifier) ([]*opi.Instance, error)
 	getInstancesMutex       sync.RWMutex
 	getInstancesArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	getInstancesReturns struct {
 		result1 []*opi.Instance
@@ -49,9 +68,25 @@ type FakeLRPDesirer struct {
 		result1 []*opi.Instance
 		result2 error
 	}
-	ListStub        func() ([]*opi.LRP, error)
+	GetLogsStub        func(context.Context, opi.LRPIdentifier, opi.LogOptions) (io.ReadCloser, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 opi.LogOptions
+	}
+	getLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	ListStub        func(context.Context) ([]*opi.LRP, error)
 	listMutex       sync.RWMutex
 	listArgsForCall []struct {
+		arg1 context.Context
 	}
 	listReturns struct {
 		result1 []*opi.LRP
@@ -61,10 +96,38 @@ type FakeLRPDesirer struct {
 		result1 []*opi.LRP
 		result2 error
 	}
-	StopStub        func(opi.LRPIdentifier) error
+	RenderStub        func(string, *opi.LRP, ...k8s.DesireOption) (*k8s.RenderedLRP, error)
+	renderMutex       sync.RWMutex
+	renderArgsForCall []struct {
+		arg1 string
+		arg2 *opi.LRP
+		arg3 []k8s.DesireOption
+	}
+	renderReturns struct {
+		result1 *k8s.RenderedLRP
+		result2 error
+	}
+	renderReturnsOnCall map[int]struct {
+		result1 *k8s.RenderedLRP
+		result2 error
+	}
+	RestartStub        func(context.Context, opi.LRPIdentifier) error
+	restartMutex       sync.RWMutex
+	restartArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}
+	restartReturns struct {
+		result1 error
+	}
+	restartReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StopStub        func(context.Context, opi.LRPIdentifier) error
 	stopMutex       sync.RWMutex
 	stopArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	stopReturns struct {
 		result1 error
@@ -72,11 +135,12 @@ type FakeLRPDesirer struct {
 	stopReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StopInstanceStub        func(opi.LRPIdentifier, uint) error
+	StopInstanceStub        func(context.Context, opi.LRPIdentifier, uint) error
 	stopInstanceMutex       sync.RWMutex
 	stopInstanceArgsForCall []struct {
-		arg1 opi.LRPIdentifier
-		arg2 uint
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 uint
 	}
 	stopInstanceReturns struct {
 		result1 error
@@ -84,10 +148,11 @@ type FakeLRPDesirer struct {
 	stopInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
-	UpdateStub        func(*opi.LRP) error
+	UpdateStub        func(context.Context, *opi.LRP) error
 	updateMutex       sync.RWMutex
 	updateArgsForCall []struct {
-		arg1 *opi.LRP
+		arg1 context.Context
+		arg2 *opi.LRP
 	}
 	updateReturns struct {
 		result1 error
@@ -99,20 +164,21 @@ type FakeLRPDesirer struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLRPDesirer) Desire(arg1 string, arg2 *opi.LRP, arg3 ...k8s.DesireOption) error {
+func (fake *FakeLRPDesirer) Desire(arg1 context.Context, arg2 string, arg3 *opi.LRP, arg4 ...k8s.DesireOption) error {
 	fake.desireMutex.Lock()
 	ret, specificReturn := fake.desireReturnsOnCall[len(fake.desireArgsForCall)]
 	fake.desireArgsForCall = append(fake.desireArgsForCall, struct {
-		arg1 string
-		arg2 *opi.LRP
-		arg3 []k8s.DesireOption
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.LRP
+		arg4 []k8s.DesireOption
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DesireStub
 	fakeReturns := fake.desireReturns
-	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3, arg4})
 	fake.desireMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3...)
+		return stub(arg1, arg2, arg3, arg4...)
 	}
 	if specificReturn {
 		return ret.result1
@@ -126,17 +192,17 @@ func (fake *FakeLRPDesirer) DesireCallCount() int {
 	return len(fake.desireArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) DesireCalls(stub func(string, *opi.LRP, ...k8s.DesireOption) error) {
+func (fake *FakeLRPDesirer) DesireCalls(stub func(context.Context, string, *opi.LRP, ...k8s.DesireOption) error) {
 	fake.desireMutex.Lock()
 	defer fake.desireMutex.Unlock()
 	fake.DesireStub = stub
 }
 
-func (fake *FakeLRPDesirer) DesireArgsForCall(i int) (string, *opi.LRP, []k8s.DesireOption) {
+func (fake *FakeLRPDesirer) DesireArgsForCall(i int) (context.Context, string, *opi.LRP, []k8s.DesireOption) {
 	fake.desireMutex.RLock()
 	defer fake.desireMutex.RUnlock()
 	argsForCall := fake.desireArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeLRPDesirer) DesireReturns(result1 error) {
@@ -162,18 +228,19 @@ func (fake *FakeLRPDesirer) DesireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeLRPDesirer) Get(arg1 opi.LRPIdentifier) (*opi.LRP, error) {
+func (fake *FakeLRPDesirer) Get(arg1 context.Context, arg2 opi.LRPIdentifier) (*opi.LRP, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.GetStub
 	fakeReturns := fake.getReturns
-	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
 	fake.getMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -187,17 +254,17 @@ func (fake *FakeLRPDesirer) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) GetCalls(stub func(opi.LRPIdentifier) (*opi.LRP, error)) {
+func (fake *FakeLRPDesirer) GetCalls(stub func(context.Context, opi.LRPIdentifier) (*opi.LRP, error)) {
 	fake.getMutex.Lock()
 	defer fake.getMutex.Unlock()
 	fake.GetStub = stub
 }
 
-func (fake *FakeLRPDesirer) GetArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakeLRPDesirer) GetArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	argsForCall := fake.getArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPDesirer) GetReturns(result1 *opi.LRP, result2 error) {
@@ -226,18 +293,84 @@ func (fake *FakeLRPDesirer) GetReturnsOnCall(i int, result1 *opi.LRP, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeLRPDesirer) GetInstances(arg1 opi.LRPIdentifier) ([]*opi.Instance, error) {
+func (fake *FakeLRPDesirer) GetInstanceStats(arg1 context.Context, arg2 opi.LRPIdentifier) ([]*opi.InstanceStats, error) {
+	fake.getInstanceStatsMutex.Lock()
+	ret, specificReturn := fake.getInstanceStatsReturnsOnCall[len(fake.getInstanceStatsArgsForCall)]
+	fake.getInstanceStatsArgsForCall = append(fake.getInstanceStatsArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
+	stub := fake.GetInstanceStatsStub
+	fakeReturns := fake.getInstanceStatsReturns
+	fake.recordInvocation("GetInstanceStats", []interface{}{arg1, arg2})
+	fake.getInstanceStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPDesirer) GetInstanceStatsCallCount() int {
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
+	return len(fake.getInstanceStatsArgsForCall)
+}
+
+func (fake *FakeLRPDesirer) GetInstanceStatsCalls(stub func(context.Context, opi.LRPIdentifier) ([]*opi.InstanceStats, error)) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = stub
+}
+
+func (fake *FakeLRPDesirer) GetInstanceStatsArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
+	argsForCall := fake.getInstanceStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLRPDesirer) GetInstanceStatsReturns(result1 []*opi.InstanceStats, result2 error) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = nil
+	fake.getInstanceStatsReturns = struct {
+		result1 []*opi.InstanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) GetInstanceStatsReturnsOnCall(i int, result1 []*opi.InstanceStats, result2 error) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = nil
+	if fake.getInstanceStatsReturnsOnCall == nil {
+		fake.getInstanceStatsReturnsOnCall = make(map[int]struct {
+			result1 []*opi.InstanceStats
+			result2 error
+		})
+	}
+	fake.getInstanceStatsReturnsOnCall[i] = struct {
+		result1 []*opi.InstanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) GetInstances(arg1 context.Context, arg2 opi.LRPIdentifier) ([]*opi.Instance, error) {
 	fake.getInstancesMutex.Lock()
 	ret, specificReturn := fake.getInstancesReturnsOnCall[len(fake.getInstancesArgsForCall)]
 	fake.getInstancesArgsForCall = append(fake.getInstancesArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.GetInstancesStub
 	fakeReturns := fake.getInstancesReturns
-	fake.recordInvocation("GetInstances", []interface{}{arg1})
+	fake.recordInvocation("GetInstances", []interface{}{arg1, arg2})
 	fake.getInstancesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -251,17 +384,17 @@ func (fake *FakeLRPDesirer) GetInstancesCallCount() int {
 	return len(fake.getInstancesArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) GetInstancesCalls(stub func(opi.LRPIdentifier) ([]*opi.Instance, error)) {
+func (fake *FakeLRPDesirer) GetInstancesCalls(stub func(context.Context, opi.LRPIdentifier) ([]*opi.Instance, error)) {
 	fake.getInstancesMutex.Lock()
 	defer fake.getInstancesMutex.Unlock()
 	fake.GetInstancesStub = stub
 }
 
-func (fake *FakeLRPDesirer) GetInstancesArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakeLRPDesirer) GetInstancesArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.getInstancesMutex.RLock()
 	defer fake.getInstancesMutex.RUnlock()
 	argsForCall := fake.getInstancesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPDesirer) GetInstancesReturns(result1 []*opi.Instance, result2 error) {
@@ -290,17 +423,84 @@ func (fake *FakeLRPDesirer) GetInstancesReturnsOnCall(i int, result1 []*opi.Inst
 	}{result1, result2}
 }
 
-func (fake *FakeLRPDesirer) List() ([]*opi.LRP, error) {
+func (fake *FakeLRPDesirer) GetLogs(arg1 context.Context, arg2 opi.LRPIdentifier, arg3 opi.LogOptions) (io.ReadCloser, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 opi.LogOptions
+	}{arg1, arg2, arg3})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2, arg3})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPDesirer) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *FakeLRPDesirer) GetLogsCalls(stub func(context.Context, opi.LRPIdentifier, opi.LogOptions) (io.ReadCloser, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *FakeLRPDesirer) GetLogsArgsForCall(i int) (context.Context, opi.LRPIdentifier, opi.LogOptions) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLRPDesirer) GetLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) GetLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) List(arg1 context.Context) ([]*opi.LRP, error) {
 	fake.listMutex.Lock()
 	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
 	fake.listArgsForCall = append(fake.listArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.ListStub
 	fakeReturns := fake.listReturns
-	fake.recordInvocation("List", []interface{}{})
+	fake.recordInvocation("List", []interface{}{arg1})
 	fake.listMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -314,12 +514,19 @@ func (fake *FakeLRPDesirer) ListCallCount() int {
 	return len(fake.listArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) ListCalls(stub func() ([]*opi.LRP, error)) {
+func (fake *FakeLRPDesirer) ListCalls(stub func(context.Context) ([]*opi.LRP, error)) {
 	fake.listMutex.Lock()
 	defer fake.listMutex.Unlock()
 	fake.ListStub = stub
 }
 
+func (fake *FakeLRPDesirer) ListArgsForCall(i int) context.Context {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeLRPDesirer) ListReturns(result1 []*opi.LRP, result2 error) {
 	fake.listMutex.Lock()
 	defer fake.listMutex.Unlock()
@@ -346,18 +553,147 @@ func (fake *FakeLRPDesirer) ListReturnsOnCall(i int, result1 []*opi.LRP, result2
 	}{result1, result2}
 }
 
-func (fake *FakeLRPDesirer) Stop(arg1 opi.LRPIdentifier) error {
+func (fake *FakeLRPDesirer) Render(arg1 string, arg2 *opi.LRP, arg3 ...k8s.DesireOption) (*k8s.RenderedLRP, error) {
+	fake.renderMutex.Lock()
+	ret, specificReturn := fake.renderReturnsOnCall[len(fake.renderArgsForCall)]
+	fake.renderArgsForCall = append(fake.renderArgsForCall, struct {
+		arg1 string
+		arg2 *opi.LRP
+		arg3 []k8s.DesireOption
+	}{arg1, arg2, arg3})
+	stub := fake.RenderStub
+	fakeReturns := fake.renderReturns
+	fake.recordInvocation("Render", []interface{}{arg1, arg2, arg3})
+	fake.renderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPDesirer) RenderCallCount() int {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	return len(fake.renderArgsForCall)
+}
+
+func (fake *FakeLRPDesirer) RenderCalls(stub func(string, *opi.LRP, ...k8s.DesireOption) (*k8s.RenderedLRP, error)) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = stub
+}
+
+func (fake *FakeLRPDesirer) RenderArgsForCall(i int) (string, *opi.LRP, []k8s.DesireOption) {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	argsForCall := fake.renderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLRPDesirer) RenderReturns(result1 *k8s.RenderedLRP, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	fake.renderReturns = struct {
+		result1 *k8s.RenderedLRP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) RenderReturnsOnCall(i int, result1 *k8s.RenderedLRP, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	if fake.renderReturnsOnCall == nil {
+		fake.renderReturnsOnCall = make(map[int]struct {
+			result1 *k8s.RenderedLRP
+			result2 error
+		})
+	}
+	fake.renderReturnsOnCall[i] = struct {
+		result1 *k8s.RenderedLRP
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPDesirer) Restart(arg1 context.Context, arg2 opi.LRPIdentifier) error {
+	fake.restartMutex.Lock()
+	ret, specificReturn := fake.restartReturnsOnCall[len(fake.restartArgsForCall)]
+	fake.restartArgsForCall = append(fake.restartArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
+	stub := fake.RestartStub
+	fakeReturns := fake.restartReturns
+	fake.recordInvocation("Restart", []interface{}{arg1, arg2})
+	fake.restartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLRPDesirer) RestartCallCount() int {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	return len(fake.restartArgsForCall)
+}
+
+func (fake *FakeLRPDesirer) RestartCalls(stub func(context.Context, opi.LRPIdentifier) error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = stub
+}
+
+func (fake *FakeLRPDesirer) RestartArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	argsForCall := fake.restartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLRPDesirer) RestartReturns(result1 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	fake.restartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLRPDesirer) RestartReturnsOnCall(i int, result1 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	if fake.restartReturnsOnCall == nil {
+		fake.restartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLRPDesirer) Stop(arg1 context.Context, arg2 opi.LRPIdentifier) error {
 	fake.stopMutex.Lock()
 	ret, specificReturn := fake.stopReturnsOnCall[len(fake.stopArgsForCall)]
 	fake.stopArgsForCall = append(fake.stopArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.StopStub
 	fakeReturns := fake.stopReturns
-	fake.recordInvocation("Stop", []interface{}{arg1})
+	fake.recordInvocation("Stop", []interface{}{arg1, arg2})
 	fake.stopMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -371,17 +707,17 @@ func (fake *FakeLRPDesirer) StopCallCount() int {
 	return len(fake.stopArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) StopCalls(stub func(opi.LRPIdentifier) error) {
+func (fake *FakeLRPDesirer) StopCalls(stub func(context.Context, opi.LRPIdentifier) error) {
 	fake.stopMutex.Lock()
 	defer fake.stopMutex.Unlock()
 	fake.StopStub = stub
 }
 
-func (fake *FakeLRPDesirer) StopArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakeLRPDesirer) StopArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
 	argsForCall := fake.stopArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPDesirer) StopReturns(result1 error) {
@@ -407,19 +743,20 @@ func (fake *FakeLRPDesirer) StopReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeLRPDesirer) StopInstance(arg1 opi.LRPIdentifier, arg2 uint) error {
+func (fake *FakeLRPDesirer) StopInstance(arg1 context.Context, arg2 opi.LRPIdentifier, arg3 uint) error {
 	fake.stopInstanceMutex.Lock()
 	ret, specificReturn := fake.stopInstanceReturnsOnCall[len(fake.stopInstanceArgsForCall)]
 	fake.stopInstanceArgsForCall = append(fake.stopInstanceArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-		arg2 uint
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 uint
+	}{arg1, arg2, arg3})
 	stub := fake.StopInstanceStub
 	fakeReturns := fake.stopInstanceReturns
-	fake.recordInvocation("StopInstance", []interface{}{arg1, arg2})
+	fake.recordInvocation("StopInstance", []interface{}{arg1, arg2, arg3})
 	fake.stopInstanceMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -433,17 +770,17 @@ func (fake *FakeLRPDesirer) StopInstanceCallCount() int {
 	return len(fake.stopInstanceArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) StopInstanceCalls(stub func(opi.LRPIdentifier, uint) error) {
+func (fake *FakeLRPDesirer) StopInstanceCalls(stub func(context.Context, opi.LRPIdentifier, uint) error) {
 	fake.stopInstanceMutex.Lock()
 	defer fake.stopInstanceMutex.Unlock()
 	fake.StopInstanceStub = stub
 }
 
-func (fake *FakeLRPDesirer) StopInstanceArgsForCall(i int) (opi.LRPIdentifier, uint) {
+func (fake *FakeLRPDesirer) StopInstanceArgsForCall(i int) (context.Context, opi.LRPIdentifier, uint) {
 	fake.stopInstanceMutex.RLock()
 	defer fake.stopInstanceMutex.RUnlock()
 	argsForCall := fake.stopInstanceArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeLRPDesirer) StopInstanceReturns(result1 error) {
@@ -469,18 +806,19 @@ func (fake *FakeLRPDesirer) StopInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeLRPDesirer) Update(arg1 *opi.LRP) error {
+func (fake *FakeLRPDesirer) Update(arg1 context.Context, arg2 *opi.LRP) error {
 	fake.updateMutex.Lock()
 	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
 	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
-		arg1 *opi.LRP
-	}{arg1})
+		arg1 context.Context
+		arg2 *opi.LRP
+	}{arg1, arg2})
 	stub := fake.UpdateStub
 	fakeReturns := fake.updateReturns
-	fake.recordInvocation("Update", []interface{}{arg1})
+	fake.recordInvocation("Update", []interface{}{arg1, arg2})
 	fake.updateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -494,17 +832,17 @@ func (fake *FakeLRPDesirer) UpdateCallCount() int {
 	return len(fake.updateArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) UpdateCalls(stub func(*opi.LRP) error) {
+func (fake *FakeLRPDesirer) UpdateCalls(stub func(context.Context, *opi.LRP) error) {
 	fake.updateMutex.Lock()
 	defer fake.updateMutex.Unlock()
 	fake.UpdateStub = stub
 }
 
-func (fake *FakeLRPDesirer) UpdateArgsForCall(i int) *opi.LRP {
+func (fake *FakeLRPDesirer) UpdateArgsForCall(i int) (context.Context, *opi.LRP) {
 	fake.updateMutex.RLock()
 	defer fake.updateMutex.RUnlock()
 	argsForCall := fake.updateArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPDesirer) UpdateReturns(result1 error) {
@@ -537,10 +875,18 @@ func (fake *FakeLRPDesirer) Invocations() map[string][][]interface{} {
 	defer fake.desireMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
 	fake.getInstancesMutex.RLock()
 	defer fake.getInstancesMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
 	fake.stopInstanceMutex.RLock()
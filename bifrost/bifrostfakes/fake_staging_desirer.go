@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package bifrostfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/bifrost"
+	"code.cloudfoundry.org/eirini/opi"
+)
+
+type FakeStagingDesirer struct {
+	DesireStagingStub        func(context.Context, string, *opi.Task) error
+	desireStagingMutex       sync.RWMutex
+	desireStagingArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.Task
+	}
+	desireStagingReturns struct {
+		result1 error
+	}
+	desireStagingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStagingAnnotationsStub        func(context.Context, string) (map[string]string, error)
+	getStagingAnnotationsMutex       sync.RWMutex
+	getStagingAnnotationsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getStagingAnnotationsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getStagingAnnotationsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStagingDesirer) DesireStaging(arg1 context.Context, arg2 string, arg3 *opi.Task) error {
+	fake.desireStagingMutex.Lock()
+	ret, specificReturn := fake.desireStagingReturnsOnCall[len(fake.desireStagingArgsForCall)]
+	fake.desireStagingArgsForCall = append(fake.desireStagingArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.Task
+	}{arg1, arg2, arg3})
+	stub := fake.DesireStagingStub
+	fakeReturns := fake.desireStagingReturns
+	fake.recordInvocation("DesireStaging", []interface{}{arg1, arg2, arg3})
+	fake.desireStagingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStagingDesirer) DesireStagingCallCount() int {
+	fake.desireStagingMutex.RLock()
+	defer fake.desireStagingMutex.RUnlock()
+	return len(fake.desireStagingArgsForCall)
+}
+
+func (fake *FakeStagingDesirer) DesireStagingCalls(stub func(context.Context, string, *opi.Task) error) {
+	fake.desireStagingMutex.Lock()
+	defer fake.desireStagingMutex.Unlock()
+	fake.DesireStagingStub = stub
+}
+
+func (fake *FakeStagingDesirer) DesireStagingArgsForCall(i int) (context.Context, string, *opi.Task) {
+	fake.desireStagingMutex.RLock()
+	defer fake.desireStagingMutex.RUnlock()
+	argsForCall := fake.desireStagingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStagingDesirer) DesireStagingReturns(result1 error) {
+	fake.desireStagingMutex.Lock()
+	defer fake.desireStagingMutex.Unlock()
+	fake.DesireStagingStub = nil
+	fake.desireStagingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStagingDesirer) DesireStagingReturnsOnCall(i int, result1 error) {
+	fake.desireStagingMutex.Lock()
+	defer fake.desireStagingMutex.Unlock()
+	fake.DesireStagingStub = nil
+	if fake.desireStagingReturnsOnCall == nil {
+		fake.desireStagingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.desireStagingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotations(arg1 context.Context, arg2 string) (map[string]string, error) {
+	fake.getStagingAnnotationsMutex.Lock()
+	ret, specificReturn := fake.getStagingAnnotationsReturnsOnCall[len(fake.getStagingAnnotationsArgsForCall)]
+	fake.getStagingAnnotationsArgsForCall = append(fake.getStagingAnnotationsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetStagingAnnotationsStub
+	fakeReturns := fake.getStagingAnnotationsReturns
+	fake.recordInvocation("GetStagingAnnotations", []interface{}{arg1, arg2})
+	fake.getStagingAnnotationsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotationsCallCount() int {
+	fake.getStagingAnnotationsMutex.RLock()
+	defer fake.getStagingAnnotationsMutex.RUnlock()
+	return len(fake.getStagingAnnotationsArgsForCall)
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotationsCalls(stub func(context.Context, string) (map[string]string, error)) {
+	fake.getStagingAnnotationsMutex.Lock()
+	defer fake.getStagingAnnotationsMutex.Unlock()
+	fake.GetStagingAnnotationsStub = stub
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotationsArgsForCall(i int) (context.Context, string) {
+	fake.getStagingAnnotationsMutex.RLock()
+	defer fake.getStagingAnnotationsMutex.RUnlock()
+	argsForCall := fake.getStagingAnnotationsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotationsReturns(result1 map[string]string, result2 error) {
+	fake.getStagingAnnotationsMutex.Lock()
+	defer fake.getStagingAnnotationsMutex.Unlock()
+	fake.GetStagingAnnotationsStub = nil
+	fake.getStagingAnnotationsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStagingDesirer) GetStagingAnnotationsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.getStagingAnnotationsMutex.Lock()
+	defer fake.getStagingAnnotationsMutex.Unlock()
+	fake.GetStagingAnnotationsStub = nil
+	if fake.getStagingAnnotationsReturnsOnCall == nil {
+		fake.getStagingAnnotationsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getStagingAnnotationsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStagingDesirer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.desireStagingMutex.RLock()
+	defer fake.desireStagingMutex.RUnlock()
+	fake.getStagingAnnotationsMutex.RLock()
+	defer fake.getStagingAnnotationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStagingDesirer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ bifrost.StagingDesirer = new(FakeStagingDesirer)
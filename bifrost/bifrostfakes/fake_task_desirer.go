@@ -2,6 +2,8 @@
 package bifrostfakes
 
 import (
+	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/bifrost"
@@ -10,12 +12,13 @@ import (
 )
 
 type FakeTaskDesirer struct {
-	DesireStub        func(string, *opi.Task, ...k8s.DesireOption) error
+	DesireStub        func(context.Context, string, *opi.Task, ...k8s.DesireOption) error
 	desireMutex       sync.RWMutex
 	desireArgsForCall []struct {
-		arg1 string
-		arg2 *opi.Task
-		arg3 []k8s.DesireOption
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.Task
+		arg4 []k8s.DesireOption
 	}
 	desireReturns struct {
 		result1 error
@@ -23,10 +26,11 @@ type FakeTaskDesirer struct {
 	desireReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetStub        func(string) (*opi.Task, error)
+	GetStub        func(context.Context, string) (*opi.Task, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getReturns struct {
 		result1 *opi.Task
@@ -36,9 +40,25 @@ type FakeTaskDesirer struct {
 		result1 *opi.Task
 		result2 error
 	}
-	ListStub        func() ([]*opi.Task, error)
+	GetLogsStub        func(context.Context, string, opi.LogOptions) (io.ReadCloser, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 opi.LogOptions
+	}
+	getLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	ListStub        func(context.Context) ([]*opi.Task, error)
 	listMutex       sync.RWMutex
 	listArgsForCall []struct {
+		arg1 context.Context
 	}
 	listReturns struct {
 		result1 []*opi.Task
@@ -48,24 +68,40 @@ type FakeTaskDesirer struct {
 		result1 []*opi.Task
 		result2 error
 	}
+	RenderStub        func(string, *opi.Task, ...k8s.DesireOption) (*k8s.RenderedTask, error)
+	renderMutex       sync.RWMutex
+	renderArgsForCall []struct {
+		arg1 string
+		arg2 *opi.Task
+		arg3 []k8s.DesireOption
+	}
+	renderReturns struct {
+		result1 *k8s.RenderedTask
+		result2 error
+	}
+	renderReturnsOnCall map[int]struct {
+		result1 *k8s.RenderedTask
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTaskDesirer) Desire(arg1 string, arg2 *opi.Task, arg3 ...k8s.DesireOption) error {
+func (fake *FakeTaskDesirer) Desire(arg1 context.Context, arg2 string, arg3 *opi.Task, arg4 ...k8s.DesireOption) error {
 	fake.desireMutex.Lock()
 	ret, specificReturn := fake.desireReturnsOnCall[len(fake.desireArgsForCall)]
 	fake.desireArgsForCall = append(fake.desireArgsForCall, struct {
-		arg1 string
-		arg2 *opi.Task
-		arg3 []k8s.DesireOption
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.Task
+		arg4 []k8s.DesireOption
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DesireStub
 	fakeReturns := fake.desireReturns
-	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3, arg4})
 	fake.desireMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3...)
+		return stub(arg1, arg2, arg3, arg4...)
 	}
 	if specificReturn {
 		return ret.result1
@@ -79,17 +115,17 @@ func (fake *FakeTaskDesirer) DesireCallCount() int {
 	return len(fake.desireArgsForCall)
 }
 
-func (fake *FakeTaskDesirer) DesireCalls(stub func(string, *opi.Task, ...k8s.DesireOption) error) {
+func (fake *FakeTaskDesirer) DesireCalls(stub func(context.Context, string, *opi.Task, ...k8s.DesireOption) error) {
 	fake.desireMutex.Lock()
 	defer fake.desireMutex.Unlock()
 	fake.DesireStub = stub
 }
 
-func (fake *FakeTaskDesirer) DesireArgsForCall(i int) (string, *opi.Task, []k8s.DesireOption) {
+func (fake *FakeTaskDesirer) DesireArgsForCall(i int) (context.Context, string, *opi.Task, []k8s.DesireOption) {
 	fake.desireMutex.RLock()
 	defer fake.desireMutex.RUnlock()
 	argsForCall := fake.desireArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTaskDesirer) DesireReturns(result1 error) {
@@ -115,18 +151,19 @@ func (fake *FakeTaskDesirer) DesireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTaskDesirer) Get(arg1 string) (*opi.Task, error) {
+func (fake *FakeTaskDesirer) Get(arg1 context.Context, arg2 string) (*opi.Task, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetStub
 	fakeReturns := fake.getReturns
-	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
 	fake.getMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -140,17 +177,17 @@ func (fake *FakeTaskDesirer) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeTaskDesirer) GetCalls(stub func(string) (*opi.Task, error)) {
+func (fake *FakeTaskDesirer) GetCalls(stub func(context.Context, string) (*opi.Task, error)) {
 	fake.getMutex.Lock()
 	defer fake.getMutex.Unlock()
 	fake.GetStub = stub
 }
 
-func (fake *FakeTaskDesirer) GetArgsForCall(i int) string {
+func (fake *FakeTaskDesirer) GetArgsForCall(i int) (context.Context, string) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	argsForCall := fake.getArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeTaskDesirer) GetReturns(result1 *opi.Task, result2 error) {
@@ -179,17 +216,84 @@ func (fake *FakeTaskDesirer) GetReturnsOnCall(i int, result1 *opi.Task, result2
 	}{result1, result2}
 }
 
-func (fake *FakeTaskDesirer) List() ([]*opi.Task, error) {
+func (fake *FakeTaskDesirer) GetLogs(arg1 context.Context, arg2 string, arg3 opi.LogOptions) (io.ReadCloser, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 opi.LogOptions
+	}{arg1, arg2, arg3})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2, arg3})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskDesirer) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *FakeTaskDesirer) GetLogsCalls(stub func(context.Context, string, opi.LogOptions) (io.ReadCloser, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *FakeTaskDesirer) GetLogsArgsForCall(i int) (context.Context, string, opi.LogOptions) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDesirer) GetLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDesirer) GetLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDesirer) List(arg1 context.Context) ([]*opi.Task, error) {
 	fake.listMutex.Lock()
 	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
 	fake.listArgsForCall = append(fake.listArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.ListStub
 	fakeReturns := fake.listReturns
-	fake.recordInvocation("List", []interface{}{})
+	fake.recordInvocation("List", []interface{}{arg1})
 	fake.listMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -203,12 +307,19 @@ func (fake *FakeTaskDesirer) ListCallCount() int {
 	return len(fake.listArgsForCall)
 }
 
-func (fake *FakeTaskDesirer) ListCalls(stub func() ([]*opi.Task, error)) {
+func (fake *FakeTaskDesirer) ListCalls(stub func(context.Context) ([]*opi.Task, error)) {
 	fake.listMutex.Lock()
 	defer fake.listMutex.Unlock()
 	fake.ListStub = stub
 }
 
+func (fake *FakeTaskDesirer) ListArgsForCall(i int) context.Context {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	argsForCall := fake.listArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeTaskDesirer) ListReturns(result1 []*opi.Task, result2 error) {
 	fake.listMutex.Lock()
 	defer fake.listMutex.Unlock()
@@ -235,6 +346,72 @@ func (fake *FakeTaskDesirer) ListReturnsOnCall(i int, result1 []*opi.Task, resul
 	}{result1, result2}
 }
 
+func (fake *FakeTaskDesirer) Render(arg1 string, arg2 *opi.Task, arg3 ...k8s.DesireOption) (*k8s.RenderedTask, error) {
+	fake.renderMutex.Lock()
+	ret, specificReturn := fake.renderReturnsOnCall[len(fake.renderArgsForCall)]
+	fake.renderArgsForCall = append(fake.renderArgsForCall, struct {
+		arg1 string
+		arg2 *opi.Task
+		arg3 []k8s.DesireOption
+	}{arg1, arg2, arg3})
+	stub := fake.RenderStub
+	fakeReturns := fake.renderReturns
+	fake.recordInvocation("Render", []interface{}{arg1, arg2, arg3})
+	fake.renderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskDesirer) RenderCallCount() int {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	return len(fake.renderArgsForCall)
+}
+
+func (fake *FakeTaskDesirer) RenderCalls(stub func(string, *opi.Task, ...k8s.DesireOption) (*k8s.RenderedTask, error)) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = stub
+}
+
+func (fake *FakeTaskDesirer) RenderArgsForCall(i int) (string, *opi.Task, []k8s.DesireOption) {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	argsForCall := fake.renderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDesirer) RenderReturns(result1 *k8s.RenderedTask, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	fake.renderReturns = struct {
+		result1 *k8s.RenderedTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDesirer) RenderReturnsOnCall(i int, result1 *k8s.RenderedTask, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	if fake.renderReturnsOnCall == nil {
+		fake.renderReturnsOnCall = make(map[int]struct {
+			result1 *k8s.RenderedTask
+			result2 error
+		})
+	}
+	fake.renderReturnsOnCall[i] = struct {
+		result1 *k8s.RenderedTask
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskDesirer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -242,8 +419,12 @@ func (fake *FakeTaskDesirer) Invocations() map[string][][]interface{} {
 	defer fake.desireMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,84 @@
+package bifrost
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//counterfeiter:generate . StagingConverter
+//counterfeiter:generate . StagingDesirer
+//counterfeiter:generate . StagingDeleter
+
+type StagingConverter interface {
+	ConvertStaging(stagingGUID string, request cf.StagingRequest) (opi.Task, error)
+}
+
+type StagingDesirer interface {
+	DesireStaging(ctx context.Context, namespace string, task *opi.Task) error
+	GetStagingAnnotations(ctx context.Context, stagingGUID string) (map[string]string, error)
+}
+
+type StagingDeleter interface {
+	DeleteStaging(ctx context.Context, guid string) error
+}
+
+type BuildpackStaging struct {
+	Logger           lager.Logger
+	Namespacer       TaskNamespacer
+	Converter        StagingConverter
+	StagingDesirer   StagingDesirer
+	StagingDeleter   StagingDeleter
+	StagingCompleter StagingCompleter
+}
+
+func (s *BuildpackStaging) TransferStaging(ctx context.Context, stagingGUID string, request cf.StagingRequest) error {
+	logger := s.Logger.Session("transfer-staging", lager.Data{"staging-guid": stagingGUID})
+
+	stagingTask, err := s.Converter.ConvertStaging(stagingGUID, request)
+	if err != nil {
+		logger.Error("failed-to-convert-staging-request", err)
+
+		return errors.Wrap(err, "failed to convert staging request")
+	}
+
+	namespace := s.Namespacer.GetNamespace("")
+
+	return errors.Wrap(s.StagingDesirer.DesireStaging(ctx, namespace, &stagingTask), "failed to desire staging")
+}
+
+func (s *BuildpackStaging) CompleteStaging(ctx context.Context, taskCompletedRequest cf.StagingCompletedRequest) error {
+	logger := s.Logger.Session("complete-staging", lager.Data{"staging-guid": taskCompletedRequest.TaskGUID})
+
+	ctx, span := s.startCompletionSpan(ctx, logger, taskCompletedRequest.TaskGUID)
+	defer span.End()
+
+	completeErr := s.StagingCompleter.CompleteStaging(ctx, taskCompletedRequest)
+	if completeErr != nil {
+		logger.Error("failed-to-complete-staging", completeErr)
+	}
+
+	if err := s.StagingDeleter.DeleteStaging(ctx, taskCompletedRequest.TaskGUID); err != nil {
+		logger.Error("failed-to-delete-staging-job", err)
+
+		return errors.Wrap(err, "failed to delete staging job")
+	}
+
+	return errors.Wrap(completeErr, "failed to complete staging")
+}
+
+// startCompletionSpan links the completion, which is reported by the staging
+// job rather than CC, back to the request that started the staging.
+func (s *BuildpackStaging) startCompletionSpan(ctx context.Context, logger lager.Logger, stagingGUID string) (context.Context, trace.Span) {
+	annotations, err := s.StagingDesirer.GetStagingAnnotations(ctx, stagingGUID)
+	if err != nil {
+		logger.Info("failed-to-get-staging-trace", lager.Data{"error": err.Error()})
+	}
+
+	return tracing.StartLinked(ctx, "complete-staging", annotations)
+}
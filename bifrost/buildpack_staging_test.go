@@ -0,0 +1,223 @@
+package bifrost_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini/bifrost"
+	"code.cloudfoundry.org/eirini/bifrost/bifrostfakes"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("BuildpackStaging", func() {
+	var (
+		stager           *bifrost.BuildpackStaging
+		converter        *bifrostfakes.FakeStagingConverter
+		desirer          *bifrostfakes.FakeStagingDesirer
+		deleter          *bifrostfakes.FakeStagingDeleter
+		stagingCompleter *bifrostfakes.FakeStagingCompleter
+		namespacer       *bifrostfakes.FakeTaskNamespacer
+	)
+
+	BeforeEach(func() {
+		converter = new(bifrostfakes.FakeStagingConverter)
+		desirer = new(bifrostfakes.FakeStagingDesirer)
+		deleter = new(bifrostfakes.FakeStagingDeleter)
+		stagingCompleter = new(bifrostfakes.FakeStagingCompleter)
+		namespacer = new(bifrostfakes.FakeTaskNamespacer)
+		namespacer.GetNamespaceReturns("staging-ns")
+
+		stager = &bifrost.BuildpackStaging{
+			Logger:           lagertest.NewTestLogger("buildpack-staging"),
+			Namespacer:       namespacer,
+			Converter:        converter,
+			StagingDesirer:   desirer,
+			StagingDeleter:   deleter,
+			StagingCompleter: stagingCompleter,
+		}
+	})
+
+	Describe("TransferStaging", func() {
+		var (
+			stagingErr     error
+			stagingRequest cf.StagingRequest
+		)
+
+		BeforeEach(func() {
+			stagingRequest = cf.StagingRequest{
+				AppGUID: "app-guid",
+				Lifecycle: cf.StagingLifecycle{
+					BuildpackLifecycle: &cf.StagingBuildpackLifecycle{
+						AppBitsDownloadURI: "example.com/download",
+					},
+				},
+			}
+			converter.ConvertStagingReturns(opi.Task{GUID: "stg-guid", AppGUID: "app-guid"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			stagingErr = stager.TransferStaging(context.Background(), "stg-guid", stagingRequest)
+		})
+
+		It("should succeed", func() {
+			Expect(stagingErr).NotTo(HaveOccurred())
+		})
+
+		It("should convert the staging request", func() {
+			Expect(converter.ConvertStagingCallCount()).To(Equal(1))
+			guid, request := converter.ConvertStagingArgsForCall(0)
+			Expect(guid).To(Equal("stg-guid"))
+			Expect(request).To(Equal(stagingRequest))
+		})
+
+		It("should desire the staging task in the default namespace", func() {
+			Expect(desirer.DesireStagingCallCount()).To(Equal(1))
+			_, namespace, task := desirer.DesireStagingArgsForCall(0)
+			Expect(namespace).To(Equal("staging-ns"))
+			Expect(task).To(Equal(&opi.Task{GUID: "stg-guid", AppGUID: "app-guid"}))
+		})
+
+		When("converting the staging request fails", func() {
+			BeforeEach(func() {
+				converter.ConvertStagingReturns(opi.Task{}, errors.New("boom"))
+			})
+
+			It("should return an error", func() {
+				Expect(stagingErr).To(MatchError(ContainSubstring("boom")))
+			})
+
+			It("should not desire the staging task", func() {
+				Expect(desirer.DesireStagingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("desiring the staging task fails", func() {
+			BeforeEach(func() {
+				desirer.DesireStagingReturns(errors.New("boom"))
+			})
+
+			It("should return an error", func() {
+				Expect(stagingErr).To(MatchError(ContainSubstring("failed to desire staging")))
+			})
+		})
+	})
+
+	Describe("CompleteStaging", func() {
+		var (
+			completeErr      error
+			completedRequest cf.StagingCompletedRequest
+		)
+
+		BeforeEach(func() {
+			completedRequest = cf.StagingCompletedRequest{
+				TaskGUID:   "stg-guid",
+				Result:     `{"lifecycle_type":"buildpack"}`,
+				Annotation: `{"completion_callback": "example.com/call/me"}`,
+			}
+		})
+
+		JustBeforeEach(func() {
+			completeErr = stager.CompleteStaging(context.Background(), completedRequest)
+		})
+
+		It("should succeed", func() {
+			Expect(completeErr).NotTo(HaveOccurred())
+		})
+
+		It("should report the staging result to the CC", func() {
+			Expect(stagingCompleter.CompleteStagingCallCount()).To(Equal(1))
+			_, actualRequest := stagingCompleter.CompleteStagingArgsForCall(0)
+			Expect(actualRequest).To(Equal(completedRequest))
+		})
+
+		It("should delete the staging job", func() {
+			Expect(deleter.DeleteStagingCallCount()).To(Equal(1))
+			_, stagingGUID := deleter.DeleteStagingArgsForCall(0)
+			Expect(stagingGUID).To(Equal("stg-guid"))
+		})
+
+		When("the staging was desired by a traced request", func() {
+			var (
+				exporter    *tracetest.InMemoryExporter
+				desireSpan  trace.Span
+				annotations map[string]string
+			)
+
+			BeforeEach(func() {
+				exporter = tracetest.NewInMemoryExporter()
+				otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+				var ctx context.Context
+				ctx, desireSpan = tracing.Start(context.Background(), "desire-staging")
+				annotations = map[string]string{}
+				tracing.InjectAnnotations(ctx, annotations)
+				desireSpan.End()
+				exporter.Reset()
+
+				desirer.GetStagingAnnotationsReturns(annotations, nil)
+			})
+
+			It("should look up the staging job", func() {
+				_, stagingGUID := desirer.GetStagingAnnotationsArgsForCall(0)
+				Expect(stagingGUID).To(Equal("stg-guid"))
+			})
+
+			It("should link the completion back to the staging request", func() {
+				spans := exporter.GetSpans()
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("complete-staging"))
+				Expect(spans[0].Links).To(HaveLen(1))
+				Expect(spans[0].Links[0].SpanContext.SpanID()).To(Equal(desireSpan.SpanContext().SpanID()))
+			})
+
+			It("should report to the CC as part of the completion span", func() {
+				ctx, _ := stagingCompleter.CompleteStagingArgsForCall(0)
+				Expect(trace.SpanContextFromContext(ctx).SpanID()).To(Equal(exporter.GetSpans()[0].SpanContext.SpanID()))
+			})
+		})
+
+		When("looking up the staging job fails", func() {
+			BeforeEach(func() {
+				desirer.GetStagingAnnotationsReturns(nil, errors.New("boom"))
+			})
+
+			It("should still complete the staging", func() {
+				Expect(completeErr).NotTo(HaveOccurred())
+				Expect(stagingCompleter.CompleteStagingCallCount()).To(Equal(1))
+			})
+		})
+
+		When("reporting to the CC fails", func() {
+			BeforeEach(func() {
+				stagingCompleter.CompleteStagingReturns(errors.New("cc is down"))
+			})
+
+			It("should return an error", func() {
+				Expect(completeErr).To(MatchError(ContainSubstring("cc is down")))
+			})
+
+			It("should still delete the staging job", func() {
+				Expect(deleter.DeleteStagingCallCount()).To(Equal(1))
+			})
+		})
+
+		When("deleting the staging job fails", func() {
+			BeforeEach(func() {
+				deleter.DeleteStagingReturns(errors.New("boom"))
+			})
+
+			It("should return an error", func() {
+				Expect(completeErr).To(MatchError(ContainSubstring("failed to delete staging job")))
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package bifrost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -8,6 +9,7 @@ import (
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/containers/image/types"
 	"github.com/pkg/errors"
@@ -23,6 +25,7 @@ type lifecycleOptions struct {
 	image           string
 	privateRegistry *opi.PrivateRegistry
 	runsAsRoot      bool
+	droplet         *opi.Droplet
 }
 
 type OPIConverter struct {
@@ -30,18 +33,29 @@ type OPIConverter struct {
 	imageMetadataFetcher ImageMetadataFetcher
 	imageRefParser       ImageRefParser
 	allowRunImageAsRoot  bool
+	stackImages          map[string]string
 }
 
-func NewOPIConverter(logger lager.Logger, imageMetadataFetcher ImageMetadataFetcher, imageRefParser ImageRefParser, allowRunImageAsRoot bool) *OPIConverter {
+func NewOPIConverter(
+	logger lager.Logger,
+	imageMetadataFetcher ImageMetadataFetcher,
+	imageRefParser ImageRefParser,
+	allowRunImageAsRoot bool,
+	stackImages map[string]string,
+) *OPIConverter {
 	return &OPIConverter{
 		logger:               logger,
 		imageMetadataFetcher: imageMetadataFetcher,
 		imageRefParser:       imageRefParser,
 		allowRunImageAsRoot:  allowRunImageAsRoot,
+		stackImages:          stackImages,
 	}
 }
 
-func (c *OPIConverter) ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error) {
+func (c *OPIConverter) ConvertLRP(ctx context.Context, request cf.DesireLRPRequest) (opi.LRP, error) {
+	ctx, span := tracing.Start(ctx, "convert-lrp")
+	defer span.End()
+
 	env := map[string]string{
 		"LANG": "en_US.UTF-8",
 	}
@@ -61,7 +75,7 @@ func (c *OPIConverter) ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error)
 		Port:      port,
 	}
 
-	lrpLifecycleOptions, err := c.getLifecycleOptions(request)
+	lrpLifecycleOptions, err := c.getLifecycleOptions(ctx, request)
 	if err != nil {
 		return opi.LRP{}, err
 	}
@@ -81,6 +95,11 @@ func (c *OPIConverter) ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error)
 		return opi.LRP{}, err
 	}
 
+	egressRules, err := getEgressRules(request)
+	if err != nil {
+		return opi.LRP{}, err
+	}
+
 	return opi.LRP{
 		AppName:                request.AppName,
 		AppGUID:                request.AppGUID,
@@ -106,6 +125,10 @@ func (c *OPIConverter) ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error)
 		UserDefinedAnnotations: request.UserDefinedAnnotations,
 		PrivateRegistry:        lrpLifecycleOptions.privateRegistry,
 		RunsAsRoot:             lrpLifecycleOptions.runsAsRoot,
+		PlacementTags:          request.PlacementTags,
+		EgressRules:            egressRules,
+		Sidecars:               getSidecars(request),
+		Droplet:                lrpLifecycleOptions.droplet,
 	}, nil
 }
 
@@ -129,22 +152,36 @@ func (c *OPIConverter) ConvertTask(taskGUID string, request cf.TaskRequest) (opi
 		SpaceName:          request.SpaceName,
 		OrgGUID:            request.OrgGUID,
 		SpaceGUID:          request.SpaceGUID,
+		MemoryMB:           request.MemoryMB,
+		DiskMB:             request.DiskMB,
+		CPUWeight:          request.CPUWeight,
+		PlacementTags:      request.PlacementTags,
 	}
 
-	if request.Lifecycle.DockerLifecycle == nil {
-		return opi.Task{}, errors.New("docker is the only supported lifecycle")
-	}
-
-	lifecycle := request.Lifecycle.DockerLifecycle
-	task.Command = lifecycle.Command
-	task.Image = lifecycle.Image
-
-	if lifecycle.RegistryUsername != "" || lifecycle.RegistryPassword != "" {
-		task.PrivateRegistry = &opi.PrivateRegistry{
-			Server:   parseRegistryHost(lifecycle.Image),
-			Username: lifecycle.RegistryUsername,
-			Password: lifecycle.RegistryPassword,
+	switch {
+	case request.Lifecycle.DockerLifecycle != nil:
+		lifecycle := request.Lifecycle.DockerLifecycle
+		task.Command = lifecycle.Command
+		task.Image = lifecycle.Image
+
+		if lifecycle.RegistryUsername != "" || lifecycle.RegistryPassword != "" {
+			task.PrivateRegistry = &opi.PrivateRegistry{
+				Server:   parseRegistryHost(lifecycle.Image),
+				Username: lifecycle.RegistryUsername,
+				Password: lifecycle.RegistryPassword,
+			}
 		}
+	case request.Lifecycle.BuildpackLifecycle != nil:
+		options, err := c.getBuildpackLifecycleOptions(request.Lifecycle.BuildpackLifecycle)
+		if err != nil {
+			return opi.Task{}, err
+		}
+
+		task.Command = options.command
+		task.Image = options.image
+		task.Droplet = options.droplet
+	default:
+		return opi.Task{}, errors.Wrap(eirini.ErrInvalidRequest, "missing lifecycle data")
 	}
 
 	task.Env = mergeEnvs(request.Environment, env)
@@ -152,12 +189,51 @@ func (c *OPIConverter) ConvertTask(taskGUID string, request cf.TaskRequest) (opi
 	return task, nil
 }
 
-func (c *OPIConverter) isAllowedToRunAsRoot(lifecycle *cf.DockerLifecycle) (bool, error) {
+func (c *OPIConverter) ConvertStaging(stagingGUID string, request cf.StagingRequest) (opi.Task, error) {
+	c.logger.Debug("convert-staging", lager.Data{"app-id": request.AppGUID, "staging-guid": stagingGUID})
+
+	lifecycle := request.Lifecycle.BuildpackLifecycle
+	if lifecycle == nil {
+		return opi.Task{}, errors.Wrap(eirini.ErrInvalidRequest, "missing buildpack lifecycle data")
+	}
+
+	buildpacksJSON, err := json.Marshal(lifecycle.Buildpacks)
+	if err != nil {
+		return opi.Task{}, errors.Wrap(err, "failed to marshal buildpacks")
+	}
+
+	env := map[string]string{
+		eirini.EnvDownloadURL:        lifecycle.AppBitsDownloadURI,
+		eirini.EnvDropletUploadURL:   lifecycle.DropletUploadURI,
+		eirini.EnvBuildpacks:         string(buildpacksJSON),
+		eirini.EnvCFStack:            lifecycle.Stack,
+		eirini.EnvAppID:              request.AppGUID,
+		eirini.EnvStagingGUID:        stagingGUID,
+		eirini.EnvCompletionCallback: request.CompletionCallback,
+	}
+
+	return opi.Task{
+		GUID:               stagingGUID,
+		CompletionCallback: request.CompletionCallback,
+		AppName:            request.AppName,
+		AppGUID:            request.AppGUID,
+		OrgName:            request.OrgName,
+		OrgGUID:            request.OrgGUID,
+		SpaceName:          request.SpaceName,
+		SpaceGUID:          request.SpaceGUID,
+		Env:                mergeEnvs(request.Environment, env),
+		MemoryMB:           request.MemoryMB,
+		DiskMB:             request.DiskMB,
+		CPUWeight:          request.CPUWeight,
+	}, nil
+}
+
+func (c *OPIConverter) isAllowedToRunAsRoot(ctx context.Context, lifecycle *cf.DockerLifecycle) (bool, error) {
 	if !c.allowRunImageAsRoot {
 		return false, nil
 	}
 
-	user, err := c.getImageUser(lifecycle)
+	user, err := c.getImageUser(ctx, lifecycle)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get the user of the image")
 	}
@@ -165,13 +241,13 @@ func (c *OPIConverter) isAllowedToRunAsRoot(lifecycle *cf.DockerLifecycle) (bool
 	return user == "" || user == "root" || user == "0", nil
 }
 
-func (c *OPIConverter) getImageUser(lifecycle *cf.DockerLifecycle) (string, error) {
+func (c *OPIConverter) getImageUser(ctx context.Context, lifecycle *cf.DockerLifecycle) (string, error) {
 	dockerRef, err := c.imageRefParser.Parse(lifecycle.Image)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to parse image ref")
 	}
 
-	imgMetadata, err := c.imageMetadataFetcher.Fetch(dockerRef, types.SystemContext{
+	imgMetadata, err := c.imageMetadataFetcher.Fetch(ctx, dockerRef, types.SystemContext{
 		DockerAuthConfig: &types.DockerAuthConfig{
 			Username: lifecycle.RegistryUsername,
 			Password: lifecycle.RegistryPassword,
@@ -206,6 +282,48 @@ func getRequestedRoutes(request cf.DesireLRPRequest) ([]opi.Route, error) {
 	return routes, nil
 }
 
+func getSidecars(request cf.DesireLRPRequest) []opi.Sidecar {
+	sidecars := []opi.Sidecar{}
+
+	for _, sidecar := range request.Sidecars {
+		sidecars = append(sidecars, opi.Sidecar{
+			Name:     sidecar.Name,
+			Command:  sidecar.Command,
+			MemoryMB: sidecar.MemoryMB,
+		})
+	}
+
+	return sidecars
+}
+
+func getEgressRules(request cf.DesireLRPRequest) ([]opi.EgressRule, error) {
+	egressRules := []opi.EgressRule{}
+
+	for _, rawRule := range request.EgressRules {
+		var rule cf.EgressRule
+		if err := json.Unmarshal(rawRule, &rule); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal egress rule")
+		}
+
+		egressRule := opi.EgressRule{
+			Protocol:     rule.Protocol,
+			Destinations: rule.Destinations,
+			Ports:        rule.Ports,
+		}
+
+		if rule.PortRange != nil {
+			egressRule.PortRange = &opi.PortRange{
+				Start: rule.PortRange.Start,
+				End:   rule.PortRange.End,
+			}
+		}
+
+		egressRules = append(egressRules, egressRule)
+	}
+
+	return egressRules, nil
+}
+
 func mergeMaps(maps ...map[string]string) map[string]string {
 	result := make(map[string]string)
 
@@ -241,11 +359,15 @@ func mergeEnvs(requestEnv []cf.EnvironmentVariable, appliedEnv map[string]string
 	return result
 }
 
-func (c *OPIConverter) getLifecycleOptions(request cf.DesireLRPRequest) (*lifecycleOptions, error) {
+func (c *OPIConverter) getLifecycleOptions(ctx context.Context, request cf.DesireLRPRequest) (*lifecycleOptions, error) {
 	options := &lifecycleOptions{}
 
+	if request.Lifecycle.BuildpackLifecycle != nil {
+		return c.getBuildpackLifecycleOptions(request.Lifecycle.BuildpackLifecycle)
+	}
+
 	if request.Lifecycle.DockerLifecycle == nil {
-		return nil, fmt.Errorf("missing lifecycle data")
+		return nil, errors.Wrap(eirini.ErrInvalidRequest, "missing lifecycle data")
 	}
 
 	var err error
@@ -253,7 +375,7 @@ func (c *OPIConverter) getLifecycleOptions(request cf.DesireLRPRequest) (*lifecy
 	lifecycle := request.Lifecycle.DockerLifecycle
 	options.image = lifecycle.Image
 	options.command = lifecycle.Command
-	options.runsAsRoot, err = c.isAllowedToRunAsRoot(lifecycle)
+	options.runsAsRoot, err = c.isAllowedToRunAsRoot(ctx, lifecycle)
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to verify if docker image needs root user")
@@ -273,6 +395,32 @@ func (c *OPIConverter) getLifecycleOptions(request cf.DesireLRPRequest) (*lifecy
 	return options, nil
 }
 
+func (c *OPIConverter) getBuildpackLifecycleOptions(lifecycle *cf.BuildpackLifecycle) (*lifecycleOptions, error) {
+	image, ok := c.stackImages[lifecycle.Stack]
+	if !ok {
+		return nil, errors.Wrapf(eirini.ErrInvalidRequest, "stack %q is not configured", lifecycle.Stack)
+	}
+
+	if lifecycle.DropletURI == "" {
+		return nil, errors.Wrap(eirini.ErrInvalidRequest, "missing droplet uri")
+	}
+
+	return &lifecycleOptions{
+		image:   image,
+		command: []string{eirini.LauncherPath, eirini.DropletAppDir, lifecycle.StartCommand, ""},
+		env: map[string]string{
+			"HOME":   eirini.DropletAppDir,
+			"PATH":   "/usr/local/bin:/usr/bin:/bin",
+			"USER":   "vcap",
+			"TMPDIR": "/home/vcap/tmp",
+		},
+		droplet: &opi.Droplet{
+			URL:  lifecycle.DropletURI,
+			Hash: lifecycle.DropletHash,
+		},
+	}, nil
+}
+
 func convertVolumeMounts(request cf.DesireLRPRequest) []opi.VolumeMount {
 	volumeMounts := []opi.VolumeMount{}
 	for _, vm := range request.VolumeMounts {
@@ -287,7 +435,7 @@ func convertVolumeMounts(request cf.DesireLRPRequest) []opi.VolumeMount {
 
 func (c *OPIConverter) validateRequest(request cf.DesireLRPRequest) error {
 	if request.DiskMB == 0 {
-		return errors.New("DiskMB cannot be 0")
+		return errors.Wrap(eirini.ErrInvalidRequest, "DiskMB cannot be 0")
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package bifrost_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
@@ -39,6 +40,7 @@ var _ = Describe("OPI Converter", func() {
 			imgMetadataFetcher.Spy,
 			imgRefParser.Spy,
 			allowRunImageAsRoot,
+			map[string]string{"cflinuxfs3": "cloudfoundry/cflinuxfs3:latest"},
 		)
 	})
 
@@ -110,7 +112,7 @@ var _ = Describe("OPI Converter", func() {
 		})
 
 		JustBeforeEach(func() {
-			lrp, err = converter.ConvertLRP(desireLRPRequest)
+			lrp, err = converter.ConvertLRP(context.Background(), desireLRPRequest)
 		})
 
 		It("should not error", func() {
@@ -212,6 +214,56 @@ var _ = Describe("OPI Converter", func() {
 			Expect(lrp.LRP).To(Equal("full LRP request"))
 		})
 
+		When("egress rules are provided", func() {
+			BeforeEach(func() {
+				desireLRPRequest.EgressRules = []json.RawMessage{
+					json.RawMessage(`{"protocol":"tcp","destinations":["10.0.0.0/8"],"ports":[80,443],"log":false}`),
+					json.RawMessage(`{"protocol":"udp","destinations":["1.1.1.1-1.1.1.10"],"port_range":{"start":53,"end":54}}`),
+				}
+			})
+
+			It("should set the egress rules", func() {
+				Expect(lrp.EgressRules).To(Equal([]opi.EgressRule{
+					{Protocol: "tcp", Destinations: []string{"10.0.0.0/8"}, Ports: []int32{80, 443}},
+					{Protocol: "udp", Destinations: []string{"1.1.1.1-1.1.1.10"}, PortRange: &opi.PortRange{Start: 53, End: 54}},
+				}))
+			})
+
+			When("an egress rule is not valid json", func() {
+				BeforeEach(func() {
+					desireLRPRequest.EgressRules = []json.RawMessage{json.RawMessage(`{"protocol":`)}
+				})
+
+				It("should fail", func() {
+					Expect(err).To(MatchError(ContainSubstring("failed to unmarshal egress rule")))
+				})
+			})
+		})
+
+		When("sidecars are provided", func() {
+			BeforeEach(func() {
+				desireLRPRequest.Sidecars = []cf.Sidecar{
+					{Name: "logger", Command: []string{"/bin/logger", "--verbose"}, MemoryMB: 32},
+				}
+			})
+
+			It("should set the sidecars", func() {
+				Expect(lrp.Sidecars).To(Equal([]opi.Sidecar{
+					{Name: "logger", Command: []string{"/bin/logger", "--verbose"}, MemoryMB: 32},
+				}))
+			})
+		})
+
+		When("placement tags are requested", func() {
+			BeforeEach(func() {
+				desireLRPRequest.PlacementTags = []string{"segment-a"}
+			})
+
+			It("should set the placement tags", func() {
+				Expect(lrp.PlacementTags).To(ConsistOf("segment-a"))
+			})
+		})
+
 		It("should set user defined annotation", func() {
 			Expect(lrp.UserDefinedAnnotations["prometheus.io/scrape"]).To(Equal("scrape"))
 		})
@@ -241,8 +293,68 @@ var _ = Describe("OPI Converter", func() {
 				desireLRPRequest.DiskMB = 0
 			})
 
-			It("fails", func() {
-				Expect(err).To(MatchError("DiskMB cannot be 0"))
+			It("fails with an invalid request error", func() {
+				Expect(err).To(MatchError("DiskMB cannot be 0: invalid request"))
+				Expect(errors.Is(err, eirini.ErrInvalidRequest)).To(BeTrue())
+			})
+		})
+
+		Context("When the app is using buildpack lifecycle", func() {
+			BeforeEach(func() {
+				desireLRPRequest.Lifecycle = cf.Lifecycle{
+					BuildpackLifecycle: &cf.BuildpackLifecycle{
+						DropletGUID:  "droplet-guid",
+						DropletHash:  "droplet-hash",
+						DropletURI:   "https://cc-uploader/droplet",
+						Stack:        "cflinuxfs3",
+						StartCommand: "bundle exec rackup",
+					},
+				}
+			})
+
+			It("should run on the stack image", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lrp.Image).To(Equal("cloudfoundry/cflinuxfs3:latest"))
+			})
+
+			It("should run the start command through the launcher", func() {
+				Expect(lrp.Command).To(Equal([]string{"/lifecycle/launcher", "/home/vcap/app", "bundle exec rackup", ""}))
+			})
+
+			It("should set the droplet", func() {
+				Expect(lrp.Droplet).To(Equal(&opi.Droplet{
+					URL:  "https://cc-uploader/droplet",
+					Hash: "droplet-hash",
+				}))
+			})
+
+			It("should set the vcap environment", func() {
+				Expect(lrp.Env).To(HaveKeyWithValue("HOME", "/home/vcap/app"))
+				Expect(lrp.Env).To(HaveKeyWithValue("USER", "vcap"))
+			})
+
+			It("should not fetch the image metadata", func() {
+				Expect(imgMetadataFetcher.CallCount()).To(Equal(0))
+			})
+
+			When("the stack is not configured", func() {
+				BeforeEach(func() {
+					desireLRPRequest.Lifecycle.BuildpackLifecycle.Stack = "cflinuxfs2"
+				})
+
+				It("fails with a useful message", func() {
+					Expect(err).To(MatchError(`stack "cflinuxfs2" is not configured: invalid request`))
+				})
+			})
+
+			When("the droplet uri is missing", func() {
+				BeforeEach(func() {
+					desireLRPRequest.Lifecycle.BuildpackLifecycle.DropletURI = ""
+				})
+
+				It("fails", func() {
+					Expect(err).To(MatchError("missing droplet uri: invalid request"))
+				})
 			})
 		})
 
@@ -457,6 +569,9 @@ var _ = Describe("OPI Converter", func() {
 							Command: []string{"some", "command"},
 						},
 					},
+					MemoryMB:  256,
+					DiskMB:    512,
+					CPUWeight: 25,
 				}
 			})
 
@@ -474,8 +589,11 @@ var _ = Describe("OPI Converter", func() {
 						"USER":   "vcap",
 						"TMPDIR": "/home/vcap/tmp",
 					},
-					Command: []string{"some", "command"},
-					Image:   "some/image",
+					Command:   []string{"some", "command"},
+					Image:     "some/image",
+					MemoryMB:  256,
+					DiskMB:    512,
+					CPUWeight: 25,
 				}))
 			})
 
@@ -496,7 +614,47 @@ var _ = Describe("OPI Converter", func() {
 			})
 		})
 
-		When("the task does not have any docker lifecycle information", func() {
+		When("the task has a buildpack lifecycle", func() {
+			BeforeEach(func() {
+				taskRequest = cf.TaskRequest{
+					AppGUID: "our-app-id",
+					Name:    "task-name",
+					Lifecycle: cf.Lifecycle{
+						BuildpackLifecycle: &cf.BuildpackLifecycle{
+							DropletHash:  "droplet-hash",
+							DropletURI:   "https://cc-uploader/droplet",
+							Stack:        "cflinuxfs3",
+							StartCommand: "rake db:migrate",
+						},
+					},
+				}
+			})
+
+			It("should run the droplet on the stack image", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.Image).To(Equal("cloudfoundry/cflinuxfs3:latest"))
+				Expect(task.Droplet).To(Equal(&opi.Droplet{
+					URL:  "https://cc-uploader/droplet",
+					Hash: "droplet-hash",
+				}))
+			})
+
+			It("should run the start command through the launcher", func() {
+				Expect(task.Command).To(Equal([]string{"/lifecycle/launcher", "/home/vcap/app", "rake db:migrate", ""}))
+			})
+
+			When("the stack is not configured", func() {
+				BeforeEach(func() {
+					taskRequest.Lifecycle.BuildpackLifecycle.Stack = "windows"
+				})
+
+				It("fails with a useful message", func() {
+					Expect(err).To(MatchError(`stack "windows" is not configured: invalid request`))
+				})
+			})
+		})
+
+		When("the task does not have any lifecycle information", func() {
 			BeforeEach(func() {
 				taskRequest = cf.TaskRequest{
 					AppGUID:            "our-app-id",
@@ -508,7 +666,77 @@ var _ = Describe("OPI Converter", func() {
 			})
 
 			It("fails with a useful message", func() {
-				Expect(err).To(MatchError("docker is the only supported lifecycle"))
+				Expect(err).To(MatchError("missing lifecycle data: invalid request"))
+			})
+		})
+	})
+
+	Describe("Convert Staging", func() {
+		var (
+			stagingRequest cf.StagingRequest
+			task           opi.Task
+		)
+
+		BeforeEach(func() {
+			stagingRequest = cf.StagingRequest{
+				AppGUID:            "our-app-id",
+				AppName:            "our-app",
+				SpaceName:          "our-space",
+				CompletionCallback: "example.com/call/me/maybe",
+				Environment:        []cf.EnvironmentVariable{{Name: "HOWARD", Value: "the alien"}},
+				Lifecycle: cf.StagingLifecycle{
+					BuildpackLifecycle: &cf.StagingBuildpackLifecycle{
+						AppBitsDownloadURI: "example.com/download",
+						DropletUploadURI:   "example.com/upload",
+						Buildpacks: []cf.Buildpack{
+							{Name: "ruby", Key: "ruby-key", URL: "example.com/ruby", SkipDetect: true},
+						},
+						Stack: "cflinuxfs3",
+					},
+				},
+				MemoryMB:  256,
+				DiskMB:    512,
+				CPUWeight: 25,
+			}
+		})
+
+		JustBeforeEach(func() {
+			task, err = converter.ConvertStaging("staging-guid", stagingRequest)
+		})
+
+		It("should convert the staging request", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.GUID).To(Equal("staging-guid"))
+			Expect(task.AppGUID).To(Equal("our-app-id"))
+			Expect(task.AppName).To(Equal("our-app"))
+			Expect(task.SpaceName).To(Equal("our-space"))
+			Expect(task.CompletionCallback).To(Equal("example.com/call/me/maybe"))
+			Expect(task.MemoryMB).To(Equal(int64(256)))
+			Expect(task.DiskMB).To(Equal(int64(512)))
+			Expect(task.CPUWeight).To(Equal(uint8(25)))
+		})
+
+		It("should pass the staging details as environment variables", func() {
+			Expect(task.Env).To(SatisfyAll(
+				HaveKeyWithValue("HOWARD", "the alien"),
+				HaveKeyWithValue(eirini.EnvDownloadURL, "example.com/download"),
+				HaveKeyWithValue(eirini.EnvDropletUploadURL, "example.com/upload"),
+				HaveKeyWithValue(eirini.EnvCFStack, "cflinuxfs3"),
+				HaveKeyWithValue(eirini.EnvAppID, "our-app-id"),
+				HaveKeyWithValue(eirini.EnvStagingGUID, "staging-guid"),
+				HaveKeyWithValue(eirini.EnvCompletionCallback, "example.com/call/me/maybe"),
+			))
+			Expect(task.Env).To(HaveKeyWithValue(eirini.EnvBuildpacks,
+				MatchJSON(`[{"name":"ruby","key":"ruby-key","url":"example.com/ruby","skip_detect":true}]`)))
+		})
+
+		When("the staging request has no buildpack lifecycle", func() {
+			BeforeEach(func() {
+				stagingRequest.Lifecycle = cf.StagingLifecycle{}
+			})
+
+			It("fails with a useful message", func() {
+				Expect(err).To(MatchError("missing buildpack lifecycle data: invalid request"))
 			})
 		})
 	})
@@ -6,10 +6,13 @@ import (
 	"fmt"
 
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/containers/image/types"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //counterfeiter:generate . ImageMetadataFetcher
@@ -17,13 +20,16 @@ import (
 //counterfeiter:generate . StagingCompleter
 
 type StagingCompleter interface {
-	CompleteStaging(cf.StagingCompletedRequest) error
+	CompleteStaging(ctx context.Context, request cf.StagingCompletedRequest) error
 }
 
 type ImageMetadataFetcher func(string, types.SystemContext) (*v1.ImageConfig, error)
 
-func (f ImageMetadataFetcher) Fetch(dockerRef string, sysCtx types.SystemContext) (*v1.ImageConfig, error) {
-	return f(dockerRef, sysCtx)
+func (f ImageMetadataFetcher) Fetch(ctx context.Context, dockerRef string, sysCtx types.SystemContext) (*v1.ImageConfig, error) {
+	_, span := tracing.Start(ctx, "fetch-image-metadata", trace.WithAttributes(attribute.String("image", dockerRef)))
+	config, err := f(dockerRef, sysCtx)
+
+	return config, tracing.End(span, err)
 }
 
 type ImageRefParser func(string) (string, error)
@@ -72,50 +78,50 @@ func (s DockerStaging) TransferStaging(ctx context.Context, stagingGUID string,
 		Annotation: fmt.Sprintf(`{"completion_callback": "%s"}`, request.CompletionCallback),
 	}
 
-	imageConfig, err := s.getImageConfig(request.Lifecycle.DockerLifecycle)
+	imageConfig, err := s.getImageConfig(ctx, request.Lifecycle.DockerLifecycle)
 	if err != nil {
 		logger.Error("failed-to-get-image-config", err)
 
-		return s.respondWithFailure(taskCallbackResponse, errors.Wrap(err, "failed to get image config"))
+		return s.respondWithFailure(ctx, taskCallbackResponse, errors.Wrap(err, "failed to get image config"))
 	}
 
 	ports, err := parseExposedPorts(imageConfig)
 	if err != nil {
 		logger.Error("failed-to-parse-exposed-ports", err)
 
-		return s.respondWithFailure(taskCallbackResponse, errors.Wrap(err, "failed to parse exposed ports"))
+		return s.respondWithFailure(ctx, taskCallbackResponse, errors.Wrap(err, "failed to parse exposed ports"))
 	}
 
 	stagingResult, err := buildStagingResult(request.Lifecycle.DockerLifecycle.Image, ports)
 	if err != nil {
 		logger.Error("failed-to-build-staging-result", err)
 
-		return s.respondWithFailure(taskCallbackResponse, errors.Wrap(err, "failed to build staging result"))
+		return s.respondWithFailure(ctx, taskCallbackResponse, errors.Wrap(err, "failed to build staging result"))
 	}
 
 	taskCallbackResponse.Result = stagingResult
 
-	return s.CompleteStaging(taskCallbackResponse)
+	return s.CompleteStaging(ctx, taskCallbackResponse)
 }
 
-func (s DockerStaging) respondWithFailure(taskCompletedRequest cf.StagingCompletedRequest, err error) error {
+func (s DockerStaging) respondWithFailure(ctx context.Context, taskCompletedRequest cf.StagingCompletedRequest, err error) error {
 	taskCompletedRequest.Failed = true
 	taskCompletedRequest.FailureReason = err.Error()
 
-	return s.CompleteStaging(taskCompletedRequest)
+	return s.CompleteStaging(ctx, taskCompletedRequest)
 }
 
-func (s DockerStaging) CompleteStaging(taskCompletedRequest cf.StagingCompletedRequest) error {
-	return s.StagingCompleter.CompleteStaging(taskCompletedRequest)
+func (s DockerStaging) CompleteStaging(ctx context.Context, taskCompletedRequest cf.StagingCompletedRequest) error {
+	return s.StagingCompleter.CompleteStaging(ctx, taskCompletedRequest)
 }
 
-func (s DockerStaging) getImageConfig(lifecycle *cf.StagingDockerLifecycle) (*v1.ImageConfig, error) {
+func (s DockerStaging) getImageConfig(ctx context.Context, lifecycle *cf.StagingDockerLifecycle) (*v1.ImageConfig, error) {
 	dockerRef, err := s.ImageRefParser.Parse(lifecycle.Image)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse image ref")
 	}
 
-	imgMetadata, err := s.ImageMetadataFetcher.Fetch(dockerRef, types.SystemContext{
+	imgMetadata, err := s.ImageMetadataFetcher.Fetch(ctx, dockerRef, types.SystemContext{
 		DockerAuthConfig: &types.DockerAuthConfig{
 			Username: lifecycle.RegistryUsername,
 			Password: lifecycle.RegistryPassword,
@@ -3,11 +3,13 @@ package bifrost
 import (
 	"context"
 	"encoding/json"
+	"io"
 
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 )
 
 //counterfeiter:generate . LRPConverter
@@ -15,17 +17,21 @@ import (
 //counterfeiter:generate . LRPNamespacer
 
 type LRPConverter interface {
-	ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error)
+	ConvertLRP(ctx context.Context, request cf.DesireLRPRequest) (opi.LRP, error)
 }
 
 type LRPDesirer interface {
-	Desire(namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) error
-	List() ([]*opi.LRP, error)
-	Get(identifier opi.LRPIdentifier) (*opi.LRP, error)
-	GetInstances(identifier opi.LRPIdentifier) ([]*opi.Instance, error)
-	Update(lrp *opi.LRP) error
-	Stop(identifier opi.LRPIdentifier) error
-	StopInstance(identifier opi.LRPIdentifier, index uint) error
+	Desire(ctx context.Context, namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) error
+	Render(namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) (*k8s.RenderedLRP, error)
+	List(ctx context.Context) ([]*opi.LRP, error)
+	Get(ctx context.Context, identifier opi.LRPIdentifier) (*opi.LRP, error)
+	GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.Instance, error)
+	GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.InstanceStats, error)
+	GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error)
+	Update(ctx context.Context, lrp *opi.LRP) error
+	Stop(ctx context.Context, identifier opi.LRPIdentifier) error
+	StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error
+	Restart(ctx context.Context, identifier opi.LRPIdentifier) error
 }
 
 type LRPNamespacer interface {
@@ -39,18 +45,36 @@ type LRP struct {
 }
 
 func (l *LRP) Transfer(ctx context.Context, request cf.DesireLRPRequest) error {
-	desiredLRP, err := l.Converter.ConvertLRP(request)
+	desiredLRP, err := l.Converter.ConvertLRP(ctx, request)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert request")
 	}
 
 	namespace := l.Namespacer.GetNamespace(request.Namespace)
 
-	return errors.Wrap(l.Desirer.Desire(namespace, &desiredLRP), "failed to desire")
+	return errors.Wrap(l.Desirer.Desire(ctx, namespace, &desiredLRP), "failed to desire")
+}
+
+// Render converts the request and returns the objects Transfer would create
+// for it, without creating them.
+func (l *LRP) Render(ctx context.Context, request cf.DesireLRPRequest) (*corev1.List, error) {
+	desiredLRP, err := l.Converter.ConvertLRP(ctx, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert request")
+	}
+
+	namespace := l.Namespacer.GetNamespace(request.Namespace)
+
+	rendered, err := l.Desirer.Render(namespace, &desiredLRP)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render")
+	}
+
+	return k8s.ToList(rendered.Objects())
 }
 
 func (l *LRP) List(ctx context.Context) ([]cf.DesiredLRPSchedulingInfo, error) {
-	lrps, err := l.Desirer.List()
+	lrps, err := l.Desirer.List(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list desired LRPs")
 	}
@@ -79,7 +103,7 @@ func (l *LRP) Update(ctx context.Context, request cf.UpdateDesiredLRPRequest) er
 		Version: request.Version,
 	}
 
-	lrp, err := l.Desirer.Get(identifier)
+	lrp, err := l.Desirer.Get(ctx, identifier)
 	if err != nil {
 		return errors.Wrap(err, "failed to get app")
 	}
@@ -94,20 +118,50 @@ func (l *LRP) Update(ctx context.Context, request cf.UpdateDesiredLRPRequest) er
 
 	lrp.Image = request.Update.Image
 
-	return errors.Wrap(l.Desirer.Update(lrp), "failed to update")
+	return errors.Wrap(l.Desirer.Update(ctx, lrp), "failed to update")
 }
 
 func (l *LRP) GetApp(ctx context.Context, identifier opi.LRPIdentifier) (cf.DesiredLRP, error) {
-	lrp, err := l.Desirer.Get(identifier)
+	lrp, err := l.Desirer.Get(ctx, identifier)
 	if err != nil {
 		return cf.DesiredLRP{}, errors.Wrap(err, "failed to get app")
 	}
 
 	desiredLRP := cf.DesiredLRP{
-		ProcessGUID: identifier.ProcessGUID(),
-		Instances:   int32(lrp.TargetInstances),
-		Annotation:  lrp.LastUpdated,
-		Image:       lrp.Image,
+		ProcessGUID:             identifier.ProcessGUID(),
+		ProcessType:             lrp.ProcessType,
+		AppGUID:                 lrp.AppGUID,
+		AppName:                 lrp.AppName,
+		SpaceGUID:               lrp.SpaceGUID,
+		SpaceName:               lrp.SpaceName,
+		OrganizationGUID:        lrp.OrgGUID,
+		OrganizationName:        lrp.OrgName,
+		Instances:               int32(lrp.TargetInstances),
+		Annotation:              lrp.LastUpdated,
+		Image:                   lrp.Image,
+		Command:                 lrp.Command,
+		Environment:             lrp.Env,
+		Ports:                   lrp.Ports,
+		HealthCheckType:         lrp.Health.Type,
+		HealthCheckHTTPEndpoint: lrp.Health.Endpoint,
+		HealthCheckTimeoutMs:    lrp.Health.TimeoutMs,
+		MemoryMB:                lrp.MemoryMB,
+		DiskMB:                  lrp.DiskMB,
+		CPUWeight:               lrp.CPUWeight,
+		RunsAsRoot:              lrp.RunsAsRoot,
+		PlacementTags:           lrp.PlacementTags,
+		VolumeMounts:            toCFVolumeMounts(lrp.VolumeMounts),
+		Sidecars:                toCFSidecars(lrp.Sidecars),
+		UserDefinedAnnotations:  lrp.UserDefinedAnnotations,
+	}
+
+	for _, rule := range lrp.EgressRules {
+		data, err := json.Marshal(rule)
+		if err != nil {
+			return cf.DesiredLRP{}, errors.Wrap(err, "failed to marshal egress rule")
+		}
+
+		desiredLRP.EgressRules = append(desiredLRP.EgressRules, data)
 	}
 
 	if len(lrp.AppURIs) > 0 {
@@ -123,20 +177,51 @@ func (l *LRP) GetApp(ctx context.Context, identifier opi.LRPIdentifier) (cf.Desi
 	return desiredLRP, nil
 }
 
+func toCFVolumeMounts(volumeMounts []opi.VolumeMount) []cf.VolumeMount {
+	var cfVolumeMounts []cf.VolumeMount
+	for _, vm := range volumeMounts {
+		cfVolumeMounts = append(cfVolumeMounts, cf.VolumeMount{
+			VolumeID: vm.ClaimName,
+			MountDir: vm.MountPath,
+		})
+	}
+
+	return cfVolumeMounts
+}
+
+func toCFSidecars(sidecars []opi.Sidecar) []cf.Sidecar {
+	var cfSidecars []cf.Sidecar
+	for _, sidecar := range sidecars {
+		cfSidecars = append(cfSidecars, cf.Sidecar{
+			Name:     sidecar.Name,
+			Command:  sidecar.Command,
+			MemoryMB: sidecar.MemoryMB,
+		})
+	}
+
+	return cfSidecars
+}
+
 func (l *LRP) Stop(ctx context.Context, identifier opi.LRPIdentifier) error {
-	return errors.Wrap(l.Desirer.Stop(identifier), "failed to stop app")
+	return errors.Wrap(l.Desirer.Stop(ctx, identifier), "failed to stop app")
 }
 
 func (l *LRP) StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error {
-	if err := l.Desirer.StopInstance(identifier, index); err != nil {
+	if err := l.Desirer.StopInstance(ctx, identifier, index); err != nil {
 		return errors.Wrap(err, "failed to stop instance")
 	}
 
 	return nil
 }
 
+// Restart replaces the instances of the app one at a time, so that it stays
+// available throughout.
+func (l *LRP) Restart(ctx context.Context, identifier opi.LRPIdentifier) error {
+	return errors.Wrap(l.Desirer.Restart(ctx, identifier), "failed to restart app")
+}
+
 func (l *LRP) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.Instance, error) {
-	opiInstances, err := l.Desirer.GetInstances(identifier)
+	opiInstances, err := l.Desirer.GetInstances(ctx, identifier)
 	if err != nil {
 		return []*cf.Instance{}, errors.Wrap(err, "failed to get instances for app")
 	}
@@ -148,12 +233,57 @@ func (l *LRP) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([
 			Index:          i.Index,
 			State:          i.State,
 			PlacementError: i.PlacementError,
+			Outdated:       i.Outdated,
 		})
 	}
 
 	return cfInstances, nil
 }
 
+func (l *LRP) GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.InstanceStats, error) {
+	opiStats, err := l.Desirer.GetInstanceStats(ctx, identifier)
+	if err != nil {
+		return []*cf.InstanceStats{}, errors.Wrap(err, "failed to get instance stats for app")
+	}
+
+	cfStats := make([]*cf.InstanceStats, 0, len(opiStats))
+	for _, s := range opiStats {
+		ports := make([]cf.PortMapping, 0, len(s.Ports))
+		for _, p := range s.Ports {
+			ports = append(ports, cf.PortMapping{ContainerPort: p.ContainerPort, HostPort: p.HostPort})
+		}
+
+		cfStats = append(cfStats, &cf.InstanceStats{
+			Instance: cf.Instance{
+				Since:          s.Since,
+				Index:          s.Index,
+				State:          s.State,
+				PlacementError: s.PlacementError,
+				Outdated:       s.Outdated,
+			},
+			Host:       s.Host,
+			InternalIP: s.InternalIP,
+			Ports:      ports,
+			Uptime:     s.Uptime,
+			MemQuota:   uint64(s.Usage.MemoryQuota),
+			DiskQuota:  uint64(s.Usage.DiskQuota),
+			Usage: cf.Usage{
+				CPU:  s.Usage.CPU,
+				Mem:  uint64(s.Usage.Memory),
+				Disk: uint64(s.Usage.Disk),
+			},
+		})
+	}
+
+	return cfStats, nil
+}
+
+func (l *LRP) GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error) {
+	logs, err := l.Desirer.GetLogs(ctx, identifier, opts)
+
+	return logs, errors.Wrap(err, "failed to get logs for app")
+}
+
 func getURIs(update cf.DesiredLRPUpdate) ([]opi.Route, error) {
 	cfRouterRoutes, hasRoutes := update.Routes["cf-router"]
 	if !hasRoutes {
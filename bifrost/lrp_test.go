@@ -4,13 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
 
 	"code.cloudfoundry.org/eirini/bifrost"
 	"code.cloudfoundry.org/eirini/bifrost/bifrostfakes"
+	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Bifrost LRP", func() {
@@ -45,9 +52,19 @@ var _ = Describe("Bifrost LRP", func() {
 
 	Describe("Transfer LRP", func() {
 		Context("When lrp is transferred successfully", func() {
-			var lrp opi.LRP
+			var (
+				lrp    opi.LRP
+				ctx    context.Context
+				cancel context.CancelFunc
+			)
+
 			JustBeforeEach(func() {
-				err = lrpBifrost.Transfer(context.Background(), request)
+				ctx, cancel = context.WithCancel(context.Background())
+				err = lrpBifrost.Transfer(ctx, request)
+			})
+
+			AfterEach(func() {
+				cancel()
 			})
 
 			BeforeEach(func() {
@@ -63,20 +80,27 @@ var _ = Describe("Bifrost LRP", func() {
 
 			It("should use Converter", func() {
 				Expect(lrpConverter.ConvertLRPCallCount()).To(Equal(1))
-				Expect(lrpConverter.ConvertLRPArgsForCall(0)).To(Equal(request))
+				_, actualRequest := lrpConverter.ConvertLRPArgsForCall(0)
+				Expect(actualRequest).To(Equal(request))
 			})
 
 			It("should use Desirer with the converted LRP", func() {
 				Expect(lrpDesirer.DesireCallCount()).To(Equal(1))
-				_, desired, _ := lrpDesirer.DesireArgsForCall(0)
+				_, _, desired, _ := lrpDesirer.DesireArgsForCall(0)
 				Expect(desired).To(Equal(&lrp))
 			})
 
 			It("should desire the LRP in the requested namespace", func() {
 				Expect(lrpDesirer.DesireCallCount()).To(Equal(1))
-				namespace, _, _ := lrpDesirer.DesireArgsForCall(0)
+				_, namespace, _, _ := lrpDesirer.DesireArgsForCall(0)
 				Expect(namespace).To(Equal("my-namespace"))
 			})
+
+			It("should pass the request context to the Desirer", func() {
+				Expect(lrpDesirer.DesireCallCount()).To(Equal(1))
+				desireCtx, _, _, _ := lrpDesirer.DesireArgsForCall(0)
+				Expect(desireCtx).To(BeIdenticalTo(ctx))
+			})
 		})
 
 		Context("When lrp transfer fails", func() {
@@ -93,7 +117,8 @@ var _ = Describe("Bifrost LRP", func() {
 				It("should use Converter", func() {
 					Expect(lrpBifrost.Transfer(context.Background(), request)).ToNot(Succeed())
 					Expect(lrpConverter.ConvertLRPCallCount()).To(Equal(1))
-					Expect(lrpConverter.ConvertLRPArgsForCall(0)).To(Equal(request))
+					_, actualRequest := lrpConverter.ConvertLRPArgsForCall(0)
+					Expect(actualRequest).To(Equal(request))
 				})
 
 				It("should not use Desirer", func() {
@@ -114,6 +139,58 @@ var _ = Describe("Bifrost LRP", func() {
 		})
 	})
 
+	Describe("Render LRP", func() {
+		var list *corev1.List
+
+		BeforeEach(func() {
+			lrpConverter.ConvertLRPReturns(opi.LRP{Image: "docker.png"}, nil)
+			lrpDesirer.RenderReturns(&k8s.RenderedLRP{
+				StatefulSet: &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "the-statefulset"}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			list, err = lrpBifrost.Render(context.Background(), request)
+		})
+
+		It("renders the converted LRP in the requested namespace", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrpDesirer.RenderCallCount()).To(Equal(1))
+			namespace, lrp, _ := lrpDesirer.RenderArgsForCall(0)
+			Expect(namespace).To(Equal("my-namespace"))
+			Expect(lrp.Image).To(Equal("docker.png"))
+		})
+
+		It("returns the rendered objects", func() {
+			Expect(list.Items).To(HaveLen(1))
+			Expect(string(list.Items[0].Raw)).To(ContainSubstring("the-statefulset"))
+		})
+
+		It("does not desire the LRP", func() {
+			Expect(lrpDesirer.DesireCallCount()).To(Equal(0))
+		})
+
+		When("converting the request fails", func() {
+			BeforeEach(func() {
+				lrpConverter.ConvertLRPReturns(opi.LRP{}, errors.New("failed-to-convert"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to convert request")))
+			})
+		})
+
+		When("rendering fails", func() {
+			BeforeEach(func() {
+				lrpDesirer.RenderReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to render")))
+			})
+		})
+	})
+
 	Describe("List LRP", func() {
 		createLRP := func(processGUID, version, lastUpdated string) *opi.LRP {
 			return &opi.LRP{
@@ -228,14 +305,14 @@ var _ = Describe("Bifrost LRP", func() {
 
 		It("should get the existing LRP", func() {
 			Expect(lrpDesirer.GetCallCount()).To(Equal(1))
-			identifier := lrpDesirer.GetArgsForCall(0)
+			_, identifier := lrpDesirer.GetArgsForCall(0)
 			Expect(identifier.GUID).To(Equal("guid_1234"))
 			Expect(identifier.Version).To(Equal("version_1234"))
 		})
 
 		It("should submit the updated LRP", func() {
 			Expect(lrpDesirer.UpdateCallCount()).To(Equal(1))
-			lrp := lrpDesirer.UpdateArgsForCall(0)
+			_, lrp := lrpDesirer.UpdateArgsForCall(0)
 			Expect(lrp.TargetInstances).To(Equal(int(5)))
 			Expect(lrp.LastUpdated).To(Equal("21421321.3"))
 			Expect(lrp.AppURIs).To(Equal([]opi.Route{
@@ -262,7 +339,7 @@ var _ = Describe("Bifrost LRP", func() {
 
 			It("should update it to an empty array", func() {
 				Expect(lrpDesirer.UpdateCallCount()).To(Equal(1))
-				lrp := lrpDesirer.UpdateArgsForCall(0)
+				_, lrp := lrpDesirer.UpdateArgsForCall(0)
 				Expect(lrp.AppURIs).To(BeEmpty())
 			})
 		})
@@ -304,7 +381,33 @@ var _ = Describe("Bifrost LRP", func() {
 						{Hostname: "route1.io", Port: 6666},
 						{Hostname: "route2.io", Port: 9999},
 					},
-					Image: "the/image",
+					Image:       "the/image",
+					ProcessType: "web",
+					AppGUID:     "app-guid",
+					AppName:     "app-name",
+					SpaceGUID:   "space-guid",
+					SpaceName:   "space-name",
+					OrgGUID:     "org-guid",
+					OrgName:     "org-name",
+					Command:     []string{"/bin/sh", "-c", "run"},
+					Env:         map[string]string{"FOO": "bar"},
+					Ports:       []int32{8080},
+					Health: opi.Healtcheck{
+						Type:      "http",
+						Endpoint:  "/healthz",
+						TimeoutMs: 3000,
+					},
+					MemoryMB:      256,
+					DiskMB:        512,
+					CPUWeight:     10,
+					RunsAsRoot:    true,
+					PlacementTags: []string{"tag"},
+					EgressRules: []opi.EgressRule{
+						{Protocol: "tcp", Destinations: []string{"10.0.0.1"}, Ports: []int32{443}},
+					},
+					VolumeMounts:           []opi.VolumeMount{{MountPath: "/data", ClaimName: "claim"}},
+					Sidecars:               []opi.Sidecar{{Name: "side", Command: []string{"echo"}, MemoryMB: 64}},
+					UserDefinedAnnotations: map[string]string{"prometheus.io/scrape": "true"},
 				}
 
 				lrpDesirer.GetReturns(lrp, nil)
@@ -314,7 +417,8 @@ var _ = Describe("Bifrost LRP", func() {
 				_, err = lrpBifrost.GetApp(context.Background(), identifier)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(lrpDesirer.GetCallCount()).To(Equal(1))
-				Expect(lrpDesirer.GetArgsForCall(0)).To(Equal(identifier))
+				_, actualIdentifier := lrpDesirer.GetArgsForCall(0)
+				Expect(actualIdentifier).To(Equal(identifier))
 			})
 
 			It("should return a DesiredLRP", func() {
@@ -326,6 +430,33 @@ var _ = Describe("Bifrost LRP", func() {
 				Expect(desiredLRP.Routes).To(HaveKeyWithValue("cf-router", json.RawMessage(`[{"hostname":"route1.io","port":6666},{"hostname":"route2.io","port":9999}]`)))
 				Expect(desiredLRP.Image).To(Equal("the/image"))
 			})
+
+			It("should return the fields the workload was desired with", func() {
+				desiredLRP, _ := lrpBifrost.GetApp(context.Background(), identifier)
+				Expect(desiredLRP.ProcessType).To(Equal("web"))
+				Expect(desiredLRP.AppGUID).To(Equal("app-guid"))
+				Expect(desiredLRP.AppName).To(Equal("app-name"))
+				Expect(desiredLRP.SpaceGUID).To(Equal("space-guid"))
+				Expect(desiredLRP.SpaceName).To(Equal("space-name"))
+				Expect(desiredLRP.OrganizationGUID).To(Equal("org-guid"))
+				Expect(desiredLRP.OrganizationName).To(Equal("org-name"))
+				Expect(desiredLRP.Command).To(Equal([]string{"/bin/sh", "-c", "run"}))
+				Expect(desiredLRP.Environment).To(Equal(map[string]string{"FOO": "bar"}))
+				Expect(desiredLRP.Ports).To(Equal([]int32{8080}))
+				Expect(desiredLRP.HealthCheckType).To(Equal("http"))
+				Expect(desiredLRP.HealthCheckHTTPEndpoint).To(Equal("/healthz"))
+				Expect(desiredLRP.HealthCheckTimeoutMs).To(Equal(uint(3000)))
+				Expect(desiredLRP.MemoryMB).To(Equal(int64(256)))
+				Expect(desiredLRP.DiskMB).To(Equal(int64(512)))
+				Expect(desiredLRP.CPUWeight).To(Equal(uint8(10)))
+				Expect(desiredLRP.RunsAsRoot).To(BeTrue())
+				Expect(desiredLRP.PlacementTags).To(Equal([]string{"tag"}))
+				Expect(desiredLRP.EgressRules).To(HaveLen(1))
+				Expect(desiredLRP.EgressRules[0]).To(MatchJSON(`{"protocol":"tcp","destinations":["10.0.0.1"],"ports":[443]}`))
+				Expect(desiredLRP.VolumeMounts).To(Equal([]cf.VolumeMount{{VolumeID: "claim", MountDir: "/data"}}))
+				Expect(desiredLRP.Sidecars).To(Equal([]cf.Sidecar{{Name: "side", Command: []string{"echo"}, MemoryMB: 64}}))
+				Expect(desiredLRP.UserDefinedAnnotations).To(Equal(map[string]string{"prometheus.io/scrape": "true"}))
+			})
 		})
 
 		Context("when the app does not exist", func() {
@@ -350,7 +481,7 @@ var _ = Describe("Bifrost LRP", func() {
 		})
 
 		It("should call the desirer with the expected guid", func() {
-			identifier := lrpDesirer.StopArgsForCall(0)
+			_, identifier := lrpDesirer.StopArgsForCall(0)
 			Expect(identifier.GUID).To(Equal("guid_1234"))
 			Expect(identifier.Version).To(Equal("version_1234"))
 		})
@@ -376,7 +507,7 @@ var _ = Describe("Bifrost LRP", func() {
 		})
 
 		It("should call the desirer with the expected guid and index", func() {
-			identifier, index := lrpDesirer.StopInstanceArgsForCall(0)
+			_, identifier, index := lrpDesirer.StopInstanceArgsForCall(0)
 			Expect(identifier.GUID).To(Equal("guid_1234"))
 			Expect(identifier.Version).To(Equal("version_1234"))
 			Expect(index).To(Equal(uint(1)))
@@ -393,6 +524,33 @@ var _ = Describe("Bifrost LRP", func() {
 		})
 	})
 
+	Describe("Restart an app", func() {
+		JustBeforeEach(func() {
+			err = lrpBifrost.Restart(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+		})
+
+		It("should not return an error", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should call the desirer with the expected identifier", func() {
+			Expect(lrpDesirer.RestartCallCount()).To(Equal(1))
+			_, identifier := lrpDesirer.RestartArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid_1234"))
+			Expect(identifier.Version).To(Equal("version_1234"))
+		})
+
+		Context("when desirer's restart fails", func() {
+			BeforeEach(func() {
+				lrpDesirer.RestartReturns(errors.New("failed-to-restart"))
+			})
+
+			It("returns a meaningful error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to restart app")))
+			})
+		})
+	})
+
 	Describe("Get all instances of an app", func() {
 		var (
 			instances    []*cf.Instance
@@ -403,7 +561,7 @@ var _ = Describe("Bifrost LRP", func() {
 			opiInstances = []*opi.Instance{
 				{Index: 0, Since: 123, State: opi.RunningState},
 				{Index: 1, Since: 345, State: opi.CrashedState},
-				{Index: 2, Since: 678, State: opi.ErrorState, PlacementError: "this is not the place"},
+				{Index: 2, Since: 678, State: opi.ErrorState, PlacementError: "this is not the place", Outdated: true},
 			}
 
 			lrpDesirer.GetInstancesReturns(opiInstances, nil)
@@ -415,7 +573,7 @@ var _ = Describe("Bifrost LRP", func() {
 
 		It("should get the app instances from Desirer", func() {
 			Expect(lrpDesirer.GetInstancesCallCount()).To(Equal(1))
-			identifier := lrpDesirer.GetInstancesArgsForCall(0)
+			_, identifier := lrpDesirer.GetInstancesArgsForCall(0)
 			Expect(identifier.GUID).To(Equal("guid_1234"))
 			Expect(identifier.Version).To(Equal("version_1234"))
 		})
@@ -428,7 +586,7 @@ var _ = Describe("Bifrost LRP", func() {
 			Expect(instances).To(Equal([]*cf.Instance{
 				{Index: 0, Since: 123, State: opi.RunningState},
 				{Index: 1, Since: 345, State: opi.CrashedState},
-				{Index: 2, Since: 678, State: opi.ErrorState, PlacementError: "this is not the place"},
+				{Index: 2, Since: 678, State: opi.ErrorState, PlacementError: "this is not the place", Outdated: true},
 			}))
 		})
 
@@ -442,4 +600,107 @@ var _ = Describe("Bifrost LRP", func() {
 			})
 		})
 	})
+
+	Describe("Get the stats of all instances of an app", func() {
+		var stats []*cf.InstanceStats
+
+		BeforeEach(func() {
+			lrpDesirer.GetInstanceStatsReturns([]*opi.InstanceStats{
+				{
+					Instance:   opi.Instance{Index: 0, Since: 123, State: opi.RunningState},
+					Host:       "10.0.0.1",
+					InternalIP: "172.16.0.1",
+					Ports:      []opi.PortMapping{{ContainerPort: 8080}, {ContainerPort: 2222, HostPort: 32222}},
+					Uptime:     60,
+					Usage:      opi.InstanceUsage{CPU: 12.5, Memory: 1024, MemoryQuota: 2048, Disk: 4096, DiskQuota: 8192},
+				},
+				{
+					Instance: opi.Instance{Index: 1, State: opi.ErrorState, PlacementError: "this is not the place"},
+					Ports:    []opi.PortMapping{},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			stats, err = lrpBifrost.GetInstanceStats(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+		})
+
+		It("should get the instance stats from Desirer", func() {
+			Expect(lrpDesirer.GetInstanceStatsCallCount()).To(Equal(1))
+			_, identifier := lrpDesirer.GetInstanceStatsArgsForCall(0)
+			Expect(identifier).To(Equal(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}))
+		})
+
+		It("should return the stats of all instances", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stats).To(Equal([]*cf.InstanceStats{
+				{
+					Instance:   cf.Instance{Index: 0, Since: 123, State: opi.RunningState},
+					Host:       "10.0.0.1",
+					InternalIP: "172.16.0.1",
+					Ports:      []cf.PortMapping{{ContainerPort: 8080}, {ContainerPort: 2222, HostPort: 32222}},
+					Uptime:     60,
+					MemQuota:   2048,
+					DiskQuota:  8192,
+					Usage:      cf.Usage{CPU: 12.5, Mem: 1024, Disk: 4096},
+				},
+				{
+					Instance: cf.Instance{Index: 1, State: opi.ErrorState, PlacementError: "this is not the place"},
+					Ports:    []cf.PortMapping{},
+				},
+			}))
+		})
+
+		Context("when the desirer fails", func() {
+			BeforeEach(func() {
+				lrpDesirer.GetInstanceStatsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to get instance stats for app")))
+			})
+		})
+	})
+
+	Describe("Get the logs of an app", func() {
+		var (
+			logs io.ReadCloser
+			opts opi.LogOptions
+		)
+
+		BeforeEach(func() {
+			index := 1
+			opts = opi.LogOptions{Index: &index, Follow: true}
+			lrpDesirer.GetLogsReturns(ioutil.NopCloser(strings.NewReader("hello")), nil)
+		})
+
+		JustBeforeEach(func() {
+			logs, err = lrpBifrost.GetLogs(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, opts)
+		})
+
+		It("should get the logs from Desirer", func() {
+			Expect(lrpDesirer.GetLogsCallCount()).To(Equal(1))
+			_, identifier, actualOpts := lrpDesirer.GetLogsArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid_1234"))
+			Expect(identifier.Version).To(Equal("version_1234"))
+			Expect(actualOpts).To(Equal(opts))
+		})
+
+		It("should return the logs", func() {
+			Expect(err).ToNot(HaveOccurred())
+			content, readErr := ioutil.ReadAll(logs)
+			Expect(readErr).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		Context("when the desirer fails", func() {
+			BeforeEach(func() {
+				lrpDesirer.GetLogsReturns(nil, errors.New("not found"))
+			})
+
+			It("returns a meaningful error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to get logs for app")))
+			})
+		})
+	})
 })
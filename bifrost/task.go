@@ -2,11 +2,15 @@ package bifrost
 
 import (
 	"context"
+	"io"
 
+	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 //counterfeiter:generate . TaskConverter
@@ -20,13 +24,15 @@ type TaskConverter interface {
 }
 
 type TaskDesirer interface {
-	Desire(namespace string, task *opi.Task, opts ...k8s.DesireOption) error
-	Get(guid string) (*opi.Task, error)
-	List() ([]*opi.Task, error)
+	Desire(ctx context.Context, namespace string, task *opi.Task, opts ...k8s.DesireOption) error
+	Render(namespace string, task *opi.Task, opts ...k8s.DesireOption) (*k8s.RenderedTask, error)
+	Get(ctx context.Context, guid string) (*opi.Task, error)
+	List(ctx context.Context) ([]*opi.Task, error)
+	GetLogs(ctx context.Context, guid string, opts opi.LogOptions) (io.ReadCloser, error)
 }
 
 type TaskDeleter interface {
-	Delete(guid string) (string, error)
+	Delete(ctx context.Context, guid string) (string, error)
 }
 
 type JSONClient interface {
@@ -45,24 +51,32 @@ type Task struct {
 	JSONClient  JSONClient
 }
 
-func (t *Task) GetTask(taskGUID string) (cf.TaskResponse, error) {
-	task, err := t.TaskDesirer.Get(taskGUID)
+func (t *Task) GetTask(ctx context.Context, taskGUID string) (cf.TaskResponse, error) {
+	task, err := t.TaskDesirer.Get(ctx, taskGUID)
 	if err != nil {
 		return cf.TaskResponse{}, errors.Wrap(err, "failed to get task")
 	}
 
-	return cf.TaskResponse{GUID: task.GUID}, nil
+	return toTaskResponse(task), nil
 }
 
-func (t *Task) ListTasks() (cf.TasksResponse, error) {
-	tasks, err := t.TaskDesirer.List()
+func (t *Task) ListTasks(ctx context.Context, filter cf.TasksFilter) (cf.TasksResponse, error) {
+	tasks, err := t.TaskDesirer.List(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list tasks")
 	}
 
 	tasksResp := cf.TasksResponse{}
 	for _, task := range tasks {
-		tasksResp = append(tasksResp, cf.TaskResponse{GUID: task.GUID})
+		if filter.AppGUID != "" && task.AppGUID != filter.AppGUID {
+			continue
+		}
+
+		if filter.State != "" && task.Status.State != filter.State {
+			continue
+		}
+
+		tasksResp = append(tasksResp, toTaskResponse(task))
 	}
 
 	return tasksResp, nil
@@ -76,11 +90,40 @@ func (t *Task) TransferTask(ctx context.Context, taskGUID string, taskRequest cf
 
 	namespace := t.Namespacer.GetNamespace(taskRequest.Namespace)
 
-	return errors.Wrap(t.TaskDesirer.Desire(namespace, &desiredTask), "failed to desire")
+	err = t.TaskDesirer.Desire(ctx, namespace, &desiredTask)
+	if apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(eirini.ErrConflict, "task %s already exists", taskGUID)
+	}
+
+	return errors.Wrap(err, "failed to desire")
 }
 
-func (t *Task) CancelTask(taskGUID string) error {
-	callbackURL, err := t.TaskDeleter.Delete(taskGUID)
+// RenderTask converts the request and returns the objects TransferTask would
+// create for it, without creating them.
+func (t *Task) RenderTask(ctx context.Context, taskGUID string, taskRequest cf.TaskRequest) (*corev1.List, error) {
+	desiredTask, err := t.Converter.ConvertTask(taskGUID, taskRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert task")
+	}
+
+	namespace := t.Namespacer.GetNamespace(taskRequest.Namespace)
+
+	rendered, err := t.TaskDesirer.Render(namespace, &desiredTask)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render")
+	}
+
+	return k8s.ToList(rendered.Objects())
+}
+
+func (t *Task) GetTaskLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error) {
+	logs, err := t.TaskDesirer.GetLogs(ctx, taskGUID, opts)
+
+	return logs, errors.Wrap(err, "failed to get task logs")
+}
+
+func (t *Task) CancelTask(ctx context.Context, taskGUID string) error {
+	callbackURL, err := t.TaskDeleter.Delete(ctx, taskGUID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete task %s", taskGUID)
 	}
@@ -99,3 +142,16 @@ func (t *Task) CancelTask(taskGUID string) error {
 
 	return nil
 }
+
+func toTaskResponse(task *opi.Task) cf.TaskResponse {
+	return cf.TaskResponse{
+		GUID:          task.GUID,
+		AppGUID:       task.AppGUID,
+		AppName:       task.AppName,
+		State:         task.Status.State,
+		StartedAt:     task.Status.StartTime,
+		FinishedAt:    task.Status.EndTime,
+		ExitCode:      task.Status.ExitCode,
+		FailureReason: task.Status.FailureReason,
+	}
+}
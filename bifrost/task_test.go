@@ -2,14 +2,24 @@ package bifrost_test
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"strings"
 
+	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/bifrost"
 	"code.cloudfoundry.org/eirini/bifrost/bifrostfakes"
+	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var _ = Describe("Task", func() {
@@ -82,7 +92,7 @@ var _ = Describe("Task", func() {
 			Expect(actualTaskRequest).To(Equal(taskRequest))
 
 			Expect(taskDesirer.DesireCallCount()).To(Equal(1))
-			namespace, desiredTask, _ := taskDesirer.DesireArgsForCall(0)
+			_, namespace, desiredTask, _ := taskDesirer.DesireArgsForCall(0)
 			Expect(desiredTask.GUID).To(Equal("my-guid"))
 			Expect(namespace).To(Equal("our-namespace"))
 		})
@@ -110,17 +120,88 @@ var _ = Describe("Task", func() {
 				Expect(err).To(MatchError(ContainSubstring("desire-task-err")))
 			})
 		})
+
+		When("the task already exists", func() {
+			BeforeEach(func() {
+				taskDesirer.DesireReturns(errors.Wrap(apierrors.NewAlreadyExists(schema.GroupResource{}, "my-job"), "failed to create job"))
+			})
+
+			It("returns a conflict error", func() {
+				Expect(errors.Is(err, eirini.ErrConflict)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("RenderTask", func() {
+		var (
+			taskRequest cf.TaskRequest
+			list        *corev1.List
+		)
+
+		BeforeEach(func() {
+			taskRequest = cf.TaskRequest{Namespace: "my-namespace"}
+			taskDesirer.RenderReturns(&k8s.RenderedTask{
+				Job: &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "the-job"}},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			list, err = taskBifrost.RenderTask(context.Background(), taskGUID, taskRequest)
+		})
+
+		It("renders the converted task in the requested namespace", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			actualTaskGUID, _ := taskConverter.ConvertTaskArgsForCall(0)
+			Expect(actualTaskGUID).To(Equal(taskGUID))
+
+			Expect(taskDesirer.RenderCallCount()).To(Equal(1))
+			namespace, renderedTask, _ := taskDesirer.RenderArgsForCall(0)
+			Expect(namespace).To(Equal("our-namespace"))
+			Expect(renderedTask.GUID).To(Equal("my-guid"))
+		})
+
+		It("returns the rendered objects", func() {
+			Expect(list.Items).To(HaveLen(1))
+			Expect(string(list.Items[0].Raw)).To(ContainSubstring("the-job"))
+		})
+
+		It("does not desire the task", func() {
+			Expect(taskDesirer.DesireCallCount()).To(Equal(0))
+		})
+
+		When("rendering the task fails", func() {
+			BeforeEach(func() {
+				taskDesirer.RenderReturns(nil, errors.New("render-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(ContainSubstring("render-err")))
+			})
+		})
 	})
 
 	Describe("GetTask", func() {
 		var taskResponse cf.TaskResponse
 
 		BeforeEach(func() {
-			taskDesirer.GetReturns(&opi.Task{GUID: taskGUID}, nil)
+			exitCode := int32(1)
+			taskDesirer.GetReturns(&opi.Task{
+				GUID:    taskGUID,
+				AppGUID: "app-guid",
+				AppName: "app-name",
+				Status: opi.TaskStatus{
+					State:         opi.TaskFailedState,
+					StartTime:     123,
+					EndTime:       456,
+					ExitCode:      &exitCode,
+					FailureReason: "Error",
+				},
+			}, nil)
 		})
 
 		JustBeforeEach(func() {
-			taskResponse, err = taskBifrost.GetTask(taskGUID)
+			taskResponse, err = taskBifrost.GetTask(context.Background(), taskGUID)
 		})
 
 		It("succeeds", func() {
@@ -129,10 +210,25 @@ var _ = Describe("Task", func() {
 
 		It("finds a task by GUID", func() {
 			Expect(taskDesirer.GetCallCount()).To(Equal(1))
-			Expect(taskDesirer.GetArgsForCall(0)).To(Equal(taskGUID))
+			_, guid := taskDesirer.GetArgsForCall(0)
+			Expect(guid).To(Equal(taskGUID))
 			Expect(taskResponse.GUID).To(Equal(taskGUID))
 		})
 
+		It("returns the task status", func() {
+			exitCode := int32(1)
+			Expect(taskResponse).To(Equal(cf.TaskResponse{
+				GUID:          taskGUID,
+				AppGUID:       "app-guid",
+				AppName:       "app-name",
+				State:         "FAILED",
+				StartedAt:     123,
+				FinishedAt:    456,
+				ExitCode:      &exitCode,
+				FailureReason: "Error",
+			}))
+		})
+
 		When("finding the task fails", func() {
 			BeforeEach(func() {
 				taskDesirer.GetReturns(nil, errors.New("task-error"))
@@ -144,15 +240,59 @@ var _ = Describe("Task", func() {
 		})
 	})
 
+	Describe("GetTaskLogs", func() {
+		var (
+			logs io.ReadCloser
+			opts opi.LogOptions
+		)
+
+		BeforeEach(func() {
+			opts = opi.LogOptions{Follow: true}
+			taskDesirer.GetLogsReturns(ioutil.NopCloser(strings.NewReader("hello")), nil)
+		})
+
+		JustBeforeEach(func() {
+			logs, err = taskBifrost.GetTaskLogs(context.Background(), taskGUID, opts)
+		})
+
+		It("gets the logs of the task", func() {
+			Expect(taskDesirer.GetLogsCallCount()).To(Equal(1))
+			_, guid, actualOpts := taskDesirer.GetLogsArgsForCall(0)
+			Expect(guid).To(Equal(taskGUID))
+			Expect(actualOpts).To(Equal(opts))
+		})
+
+		It("returns the logs", func() {
+			Expect(err).NotTo(HaveOccurred())
+			content, readErr := ioutil.ReadAll(logs)
+			Expect(readErr).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		When("getting the logs fails", func() {
+			BeforeEach(func() {
+				taskDesirer.GetLogsReturns(nil, errors.New("logs-error"))
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("logs-error")))
+			})
+		})
+	})
+
 	Describe("ListTasks", func() {
-		var tasksResponse cf.TasksResponse
+		var (
+			tasksResponse cf.TasksResponse
+			filter        cf.TasksFilter
+		)
 
 		BeforeEach(func() {
+			filter = cf.TasksFilter{}
 			taskDesirer.ListReturns([]*opi.Task{{GUID: taskGUID}}, nil)
 		})
 
 		JustBeforeEach(func() {
-			tasksResponse, err = taskBifrost.ListTasks()
+			tasksResponse, err = taskBifrost.ListTasks(context.Background(), filter)
 		})
 
 		It("succeeds", func() {
@@ -165,6 +305,56 @@ var _ = Describe("Task", func() {
 			Expect(tasksResponse[0].GUID).To(Equal(taskGUID))
 		})
 
+		When("filtering", func() {
+			BeforeEach(func() {
+				taskDesirer.ListReturns([]*opi.Task{
+					{GUID: "running-1", AppGUID: "app-1", Status: opi.TaskStatus{State: opi.TaskRunningState}},
+					{GUID: "failed-1", AppGUID: "app-1", Status: opi.TaskStatus{State: opi.TaskFailedState}},
+					{GUID: "running-2", AppGUID: "app-2", Status: opi.TaskStatus{State: opi.TaskRunningState}},
+				}, nil)
+			})
+
+			guids := func() []string {
+				result := []string{}
+				for _, t := range tasksResponse {
+					result = append(result, t.GUID)
+				}
+
+				return result
+			}
+
+			When("by app guid", func() {
+				BeforeEach(func() {
+					filter.AppGUID = "app-1"
+				})
+
+				It("returns only the tasks of the app", func() {
+					Expect(guids()).To(ConsistOf("running-1", "failed-1"))
+				})
+			})
+
+			When("by state", func() {
+				BeforeEach(func() {
+					filter.State = opi.TaskRunningState
+				})
+
+				It("returns only the tasks in that state", func() {
+					Expect(guids()).To(ConsistOf("running-1", "running-2"))
+				})
+			})
+
+			When("by app guid and state", func() {
+				BeforeEach(func() {
+					filter.AppGUID = "app-1"
+					filter.State = opi.TaskRunningState
+				})
+
+				It("returns only the tasks matching both", func() {
+					Expect(guids()).To(ConsistOf("running-1"))
+				})
+			})
+		})
+
 		When("listing tasks fails", func() {
 			BeforeEach(func() {
 				taskDesirer.ListReturns(nil, errors.New("list-tasks-error"))
@@ -194,7 +384,7 @@ var _ = Describe("Task", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = taskBifrost.CancelTask(taskGUID)
+			err = taskBifrost.CancelTask(context.Background(), taskGUID)
 		})
 
 		It("succeeds", func() {
@@ -203,7 +393,8 @@ var _ = Describe("Task", func() {
 
 		It("deletes the task", func() {
 			Expect(taskDeleter.DeleteCallCount()).To(Equal(1))
-			Expect(taskDeleter.DeleteArgsForCall(0)).To(Equal(taskGUID))
+			_, guid := taskDeleter.DeleteArgsForCall(0)
+			Expect(guid).To(Equal(taskGUID))
 		})
 
 		When("deleting the task fails", func() {
@@ -260,7 +451,7 @@ var _ = Describe("Task", func() {
 					return nil
 				}
 
-				err = taskBifrost.CancelTask(taskGUID)
+				err = taskBifrost.CancelTask(context.Background(), taskGUID)
 
 				Expect(err).NotTo(HaveOccurred())
 
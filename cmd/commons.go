@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"code.cloudfoundry.org/eirini/tracing"
 	"k8s.io/client-go/kubernetes"
 
 	// Kubernetes has a tricky way to add authentication
@@ -33,12 +35,27 @@ func CreateKubeClient(kubeConfigPath string) kubernetes.Interface {
 	config, err := clientcmd.BuildConfigFromFlags("", kubeConfigPath)
 	ExitfIfError(err, "Failed to get kubeconfig")
 
+	config.Wrap(tracing.WrapTransport)
+
 	clientset, err := kubernetes.NewForConfig(config)
 	ExitfIfError(err, "Failed to create k8s client")
 
 	return clientset
 }
 
+// InitTracing installs the tracer provider for the configured exporter. The
+// returned function flushes the spans that have not been exported yet.
+func InitTracing(serviceName, exporter string) func() {
+	shutdown, err := tracing.Init(serviceName, exporter, os.Stdout)
+	ExitfIfError(err, "Failed to initialize tracing")
+
+	return func() {
+		if err := shutdown(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, fmt.Errorf("failed to flush spans: %w", err))
+		}
+	}
+}
+
 func ExitIfError(err error) {
 	ExitfIfError(err, "an unexpected error occurred")
 }
@@ -54,6 +71,16 @@ func Exitf(messageFormat string, args ...interface{}) {
 	ExitIfError(fmt.Errorf(messageFormat, args...))
 }
 
+// GetMetricsBindAddress returns the address controller-runtime managers
+// should serve metrics on. A zero port disables the metrics endpoint.
+func GetMetricsBindAddress(port int) string {
+	if port == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf(":%d", port)
+}
+
 func GetOrDefault(actualValue, defaultValue string) string {
 	if actualValue != "" {
 		return actualValue
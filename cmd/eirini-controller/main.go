@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/eirini/k8s/client"
 	eirinievent "code.cloudfoundry.org/eirini/k8s/informers/event"
 	"code.cloudfoundry.org/eirini/k8s/reconciler"
+	"code.cloudfoundry.org/eirini/k8s/webhook"
 	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
 	eirinischeme "code.cloudfoundry.org/eirini/pkg/generated/clientset/versioned/scheme"
 	"code.cloudfoundry.org/eirini/util"
@@ -28,8 +29,11 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 type options struct {
@@ -61,10 +65,11 @@ func main() {
 	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
 
 	managerOptions := manager.Options{
-		// do not serve prometheus metrics; disabled because port clashes during integration tests
-		MetricsBindAddress: "0",
+		MetricsBindAddress: cmdcommons.GetMetricsBindAddress(eiriniCfg.Properties.MetricsPort),
 		Scheme:             eirinischeme.Scheme,
 		Logger:             util.NewLagerLogr(logger),
+		Port:               eiriniCfg.Properties.CRDValidationWebhookPort,
+		CertDir:            eiriniCfg.Properties.CRDValidationWebhookCertDir,
 	}
 
 	mgr, err := manager.New(kubeConfig, managerOptions)
@@ -78,6 +83,10 @@ func main() {
 		ControllerManagedBy(mgr).
 		For(&eiriniv1.LRP{}).
 		Owns(&appsv1.StatefulSet{}).
+		Watches(
+			&source.Kind{Type: &corev1.Pod{}},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: reconciler.NewPodToLRP(logger, mgr.GetClient())},
+		).
 		Complete(lrpReconciler)
 	cmdcommons.ExitfIfError(err, "Failed to build LRP reconciler")
 
@@ -95,6 +104,12 @@ func main() {
 		Complete(podCrashReconciler)
 	cmdcommons.ExitfIfError(err, "Failed to build Pod Crash reconciler")
 
+	if eiriniCfg.Properties.CRDValidationWebhookPort != 0 {
+		webhookServer := mgr.GetWebhookServer()
+		webhookServer.Register(webhook.ValidateLRPPath, &ctrlwebhook.Admission{Handler: webhook.NewLRPValidator(logger)})
+		webhookServer.Register(webhook.ValidateTaskPath, &ctrlwebhook.Admission{Handler: webhook.NewTaskValidator(logger)})
+	}
+
 	err = mgr.Start(ctrl.SetupSignalHandler())
 	cmdcommons.ExitfIfError(err, "Failed to start manager")
 }
@@ -123,6 +138,7 @@ func createLRPReconciler(
 		Secrets:                           client.NewSecret(clientset),
 		StatefulSets:                      client.NewStatefulSet(clientset, eiriniCfg.WorkloadsNamespace),
 		PodDisruptionBudgets:              client.NewPodDisruptionBudget(clientset),
+		NetworkPolicies:                   client.NewNetworkPolicy(clientset),
 		EventsClient:                      client.NewEvent(clientset),
 		StatefulSetToLRPMapper:            k8s.StatefulSetToLRP,
 		RegistrySecretName:                eiriniCfg.Properties.RegistrySecretName,
@@ -131,6 +147,8 @@ func createLRPReconciler(
 		Logger:                            logger.Session("stateful-set-desirer"),
 		ApplicationServiceAccount:         eiriniCfg.Properties.ApplicationServiceAccount,
 		AllowAutomountServiceAccountToken: eiriniCfg.Properties.UnsafeAllowAutomountServiceAccountToken,
+		PlacementTags:                     eiriniCfg.Properties.PlacementTags,
+		DropletDownloaderImage:            eiriniCfg.Properties.DropletDownloaderImage,
 	}
 
 	return reconciler.NewLRP(
@@ -150,10 +168,13 @@ func createTaskReconciler(
 	taskDesirer := k8s.NewTaskDesirer(
 		logger,
 		client.NewJob(clientset, eiriniCfg.WorkloadsNamespace),
+		client.NewPod(clientset, eiriniCfg.WorkloadsNamespace),
 		client.NewSecret(clientset),
 		eiriniCfg.Properties.ApplicationServiceAccount,
 		eiriniCfg.Properties.RegistrySecretName,
 		eiriniCfg.Properties.UnsafeAllowAutomountServiceAccountToken,
+		eiriniCfg.Properties.PlacementTags,
+		eiriniCfg.Properties.DropletDownloaderImage,
 	)
 
 	return reconciler.NewTask(logger, controllerClient, taskDesirer, scheme)
@@ -41,6 +41,8 @@ func main() {
 	cfg, err := readConfigFile(opts.ConfigFile)
 	cmdcommons.ExitfIfError(err, "Failed to read config file")
 
+	defer cmdcommons.InitTracing("event-reporter", cfg.TracingExporter)()
+
 	clientset := cmdcommons.CreateKubeClient(cfg.ConfigPath)
 
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", cfg.ConfigPath)
@@ -73,8 +75,7 @@ func main() {
 	)
 
 	mgr, err := manager.New(kubeConfig, manager.Options{
-		// do not serve prometheus metrics; disabled because port clashes during integration tests
-		MetricsBindAddress: "0",
+		MetricsBindAddress: cmdcommons.GetMetricsBindAddress(cfg.MetricsPort),
 		Namespace:          cfg.WorkloadsNamespace,
 		Scheme:             kscheme.Scheme,
 		Logger:             util.NewLagerLogr(crashLogger),
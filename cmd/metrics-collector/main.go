@@ -90,9 +90,7 @@ func launchMetricsEmitter(
 	metricsCollectorLogger := metricsLogger.Session("metrics-collector", lager.Data{})
 	diskClientLogger := metricsCollectorLogger.Session("disk-metrics-client", lager.Data{})
 	kubeletClient := kubelet.NewClient(clientset.CoreV1().RESTClient())
-	diskClient := kubelet.NewDiskMetricsClient(clientset.CoreV1().Nodes(),
-		kubeletClient,
-		diskClientLogger)
+	diskClient := kubelet.NewDiskMetricsClient(kubeletClient, diskClientLogger)
 	collector := k8s.NewMetricsCollector(podMetricsClient, podClient, diskClient, metricsCollectorLogger)
 
 	emitter := metrics.NewLoggregatorEmitter(loggregatorClient)
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/bifrost"
@@ -13,11 +19,13 @@ import (
 	"code.cloudfoundry.org/eirini/handler"
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/client"
+	"code.cloudfoundry.org/eirini/k8s/kubelet"
 	"code.cloudfoundry.org/eirini/stager"
 	"code.cloudfoundry.org/eirini/stager/docker"
 	"code.cloudfoundry.org/eirini/util"
 	"code.cloudfoundry.org/lager"
-	"code.cloudfoundry.org/tlsconfig"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	yaml "gopkg.in/yaml.v2"
 	"k8s.io/client-go/kubernetes"
@@ -26,6 +34,16 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 )
 
+const (
+	defaultReadTimeout        = 30 * time.Second
+	defaultWriteTimeout       = 60 * time.Second
+	defaultIdleTimeout        = 120 * time.Second
+	defaultShutdownTimeout    = 20 * time.Second
+	defaultCertReloadInterval = time.Minute
+	defaultRequestTimeout     = 30 * time.Second
+	noResync                  = 0
+)
+
 func connect(cmd *cobra.Command, args []string) {
 	path, err := cmd.Flags().GetString("config")
 	cmdcommons.ExitfIfError(err, "Failed to get config flag")
@@ -35,53 +53,220 @@ func connect(cmd *cobra.Command, args []string) {
 	}
 
 	cfg := setConfigFromFile(path)
-	clientset := cmdcommons.CreateKubeClient(cfg.Properties.ConfigPath)
 
-	dockerStagingBifrost := initDockerStagingBifrost(cfg)
-	taskBifrost := initTaskBifrost(cfg, clientset)
-	bifrost := initLRPBifrost(clientset, cfg)
+	defer cmdcommons.InitTracing("opi", cfg.Properties.TracingExporter)()
 
 	handlerLogger := lager.NewLogger("handler")
 	handlerLogger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
-	handler := handler.New(bifrost, dockerStagingBifrost, taskBifrost, handlerLogger)
+
+	metrics := initMetrics(cfg, handlerLogger)
+	clientset := cmdcommons.CreateKubeClient(cfg.Properties.ConfigPath)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	cache := initCache(cfg, clientset, stop)
+	initHealth(cfg, cache, handlerLogger)
+
+	dockerStagingBifrost := initDockerStagingBifrost(cfg)
+	buildpackStagingBifrost := initBuildpackStagingBifrost(cfg, clientset)
+	taskBifrost := initTaskBifrost(cfg, clientset, cache)
+	bifrost := initLRPBifrost(clientset, cfg, cache)
+
+	requestTimeout := secondsOrDefault(cfg.Properties.RequestTimeoutSeconds, defaultRequestTimeout)
+	allowlist, err := handler.NewClientAllowlist(cfg.Properties.ClientCNAllowlist)
+	cmdcommons.ExitfIfError(err, "Invalid client CN allowlist")
+
+	auditLog := initAuditLog(cfg, handlerLogger)
+	handler := handler.New(bifrost, dockerStagingBifrost, buildpackStagingBifrost, taskBifrost, handlerLogger, metrics, requestTimeout, auditLog, allowlist)
+	waitForCache(cache, handlerLogger, stop)
 	handlerLogger.Info("opi-connected")
 
+	servers := []*http.Server{tlsServer(cfg, handler, handlerLogger)}
 	if cfg.Properties.ServePlaintext {
-		servePlaintext(cfg, handler, handlerLogger)
+		servers = append(servers, plaintextServer(cfg, handler))
 	}
 
-	serveTLS(cfg, handler, handlerLogger)
+	serve(cfg, servers, handlerLogger)
 }
 
-func serveTLS(cfg *eirini.Config, handler http.Handler, logger lager.Logger) {
-	var server *http.Server
+// serve runs all servers until one of them fails or a SIGTERM or SIGINT is
+// received, in which case in-flight requests are drained before returning.
+func serve(cfg *eirini.Config, servers []*http.Server, logger lager.Logger) {
+	serverErrs := make(chan error, len(servers))
+
+	for _, server := range servers {
+		go func(server *http.Server) {
+			if server.TLSConfig != nil {
+				serverErrs <- server.ListenAndServeTLS("", "")
+			} else {
+				serverErrs <- server.ListenAndServe()
+			}
+		}(server)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	select {
+	case err := <-serverErrs:
+		logger.Fatal("opi-crashed", err)
+	case sig := <-signals:
+		logger.Info("shutting-down", lager.Data{"signal": sig.String()})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secondsOrDefault(cfg.Properties.ShutdownTimeoutSeconds, defaultShutdownTimeout))
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, server := range servers {
+		wg.Add(1)
+
+		go func(server *http.Server) {
+			defer wg.Done()
 
+			if err := server.Shutdown(ctx); err != nil {
+				logger.Error("failed-to-drain-requests", err, lager.Data{"address": server.Addr})
+			}
+		}(server)
+	}
+
+	wg.Wait()
+	logger.Info("shut-down")
+}
+
+func tlsServer(cfg *eirini.Config, handler http.Handler, logger lager.Logger) *http.Server {
 	crtPath := cmdcommons.GetOrDefault(cfg.Properties.ServerCertPath, eirini.EiriniCrtPath)
 	keyPath := cmdcommons.GetOrDefault(cfg.Properties.ServerKeyPath, eirini.EiriniKeyPath)
 	caPath := cmdcommons.GetOrDefault(cfg.Properties.ClientCAPath, eirini.EiriniCAPath)
 
-	tlsConfig, err := tlsconfig.Build(
-		tlsconfig.WithInternalServiceDefaults(),
-	).Server(
-		tlsconfig.WithClientAuthenticationFromFile(caPath),
-	)
-	cmdcommons.ExitfIfError(err, "Failed to build TLS config")
+	reloader, err := util.NewCertReloader(logger.Session("cert-reloader"), crtPath, keyPath, caPath,
+		secondsOrDefault(cfg.Properties.CertReloadIntervalSeconds, defaultCertReloadInterval))
+	cmdcommons.ExitfIfError(err, "Failed to load TLS certificates")
+
+	server := newServer(cfg, cfg.Properties.TLSPort, handler)
+	server.TLSConfig = reloader.TLSConfig()
+
+	return server
+}
+
+func plaintextServer(cfg *eirini.Config, handler http.Handler) *http.Server {
+	return newServer(cfg, cfg.Properties.PlaintextPort, handler)
+}
+
+func newServer(cfg *eirini.Config, port int, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:         fmt.Sprintf("0.0.0.0:%d", port),
+		Handler:      handler,
+		ReadTimeout:  secondsOrDefault(cfg.Properties.ServerReadTimeoutSeconds, defaultReadTimeout),
+		WriteTimeout: secondsOrDefault(cfg.Properties.ServerWriteTimeoutSeconds, defaultWriteTimeout),
+		IdleTimeout:  defaultIdleTimeout,
+	}
+}
+
+func secondsOrDefault(seconds int, defaultDuration time.Duration) time.Duration {
+	if seconds <= 0 {
+		return defaultDuration
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// initMetrics registers the OPI metrics and serves them on the metrics
+// port. It returns nil when metrics are disabled.
+func initMetrics(cfg *eirini.Config, logger lager.Logger) *handler.Metrics {
+	if cfg.Properties.MetricsPort == 0 {
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	err := registry.Register(prometheus.NewGoCollector())
+	cmdcommons.ExitfIfError(err, "Failed to register go metrics")
+
+	err = registry.Register(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	cmdcommons.ExitfIfError(err, "Failed to register process metrics")
+
+	err = client.RegisterMetrics(registry)
+	cmdcommons.ExitfIfError(err, "Failed to register kubernetes client metrics")
+
+	metrics, err := handler.NewMetrics(registry)
+	cmdcommons.ExitfIfError(err, "Failed to register opi metrics")
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.Properties.MetricsPort),
+		Handler: mux,
+	}
+
+	go func() {
+		logger.Fatal("metrics-server-crashed", server.ListenAndServe())
+	}()
+
+	return metrics
+}
+
+// initCache starts the informers the OPI read paths are served from.
+func initCache(cfg *eirini.Config, clientset kubernetes.Interface, stop <-chan struct{}) *client.Cache {
+	cache := client.NewCache(clientset, cfg.WorkloadsNamespace, noResync)
+	cache.Start(stop)
+
+	return cache
+}
+
+// waitForCache blocks until the initial lists have been cached, as reads
+// fail until then.
+func waitForCache(cache *client.Cache, logger lager.Logger, stop <-chan struct{}) {
+	logger.Info("waiting-for-cache-sync")
 
-	server = &http.Server{
-		Addr:      fmt.Sprintf("0.0.0.0:%d", cfg.Properties.TLSPort),
-		Handler:   handler,
-		TLSConfig: tlsConfig,
+	if !cache.WaitForSync(stop) {
+		cmdcommons.Exitf("Failed to sync the cache")
 	}
-	logger.Fatal("opi-crashed",
-		server.ListenAndServeTLS(crtPath, keyPath))
+
+	logger.Info("cache-synced")
 }
 
-func servePlaintext(cfg *eirini.Config, handler http.Handler, logger lager.Logger) {
+// initHealth serves the readiness check on the health port. It does nothing
+// when the health port is not set.
+func initHealth(cfg *eirini.Config, cache *client.Cache, logger lager.Logger) {
+	if cfg.Properties.HealthPort == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !cache.HasSynced() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
 	server := &http.Server{
-		Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.Properties.PlaintextPort),
-		Handler: handler,
+		Addr:    fmt.Sprintf("0.0.0.0:%d", cfg.Properties.HealthPort),
+		Handler: mux,
+	}
+
+	go func() {
+		logger.Fatal("health-server-crashed", server.ListenAndServe())
+	}()
+}
+
+// initAuditLog opens the audit log for appending. It returns nil when
+// auditing is disabled.
+func initAuditLog(cfg *eirini.Config, logger lager.Logger) *handler.AuditLog {
+	if cfg.Properties.AuditLogPath == "" {
+		return nil
 	}
-	logger.Fatal("opi-crashed", server.ListenAndServe())
+
+	file, err := os.OpenFile(filepath.Clean(cfg.Properties.AuditLogPath), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	cmdcommons.ExitfIfError(err, "Failed to open audit log")
+
+	return handler.NewAuditLog(logger.Session("audit"), file)
 }
 
 func initRetryableJSONClient(cfg *eirini.Config) *util.RetryableJSONClient {
@@ -117,17 +302,17 @@ func initStagingCompleter(cfg *eirini.Config, logger lager.Logger) *stager.Callb
 	return stager.NewCallbackStagingCompleter(logger, retryableJSONClient)
 }
 
-func initTaskDesirer(cfg *eirini.Config, clientset kubernetes.Interface) *k8s.TaskDesirer {
-	logger := lager.NewLogger("task-desirer")
-	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
-
+func initTaskDesirer(cfg *eirini.Config, clientset kubernetes.Interface, cache *client.Cache, logger lager.Logger) *k8s.TaskDesirer {
 	return k8s.NewTaskDesirer(
 		logger,
-		client.NewJob(clientset, cfg.WorkloadsNamespace),
+		client.NewCachedJob(clientset, cfg.WorkloadsNamespace, cache),
+		client.NewCachedPod(clientset, cfg.WorkloadsNamespace, cache),
 		client.NewSecret(clientset),
 		cfg.Properties.ApplicationServiceAccount,
 		cfg.Properties.RegistrySecretName,
 		cfg.Properties.UnsafeAllowAutomountServiceAccountToken,
+		cfg.Properties.PlacementTags,
+		cfg.Properties.DropletDownloaderImage,
 	)
 }
 
@@ -155,9 +340,36 @@ func initDockerStagingBifrost(cfg *eirini.Config) *bifrost.DockerStaging {
 	}
 }
 
-func initTaskBifrost(cfg *eirini.Config, clientset kubernetes.Interface) *bifrost.Task {
-	converter := initConverter(cfg)
-	taskDesirer := initTaskDesirer(cfg, clientset)
+func initBuildpackStagingBifrost(cfg *eirini.Config, clientset kubernetes.Interface) *bifrost.BuildpackStaging {
+	logger := lager.NewLogger("buildpack-staging-bifrost")
+	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
+	stagingCompleter := initStagingCompleter(cfg, logger)
+	jobClient := client.NewStagingJob(clientset, cfg.WorkloadsNamespace)
+
+	stagingDesirer := &k8s.StagingDesirer{
+		Logger:                            logger,
+		JobClient:                         jobClient,
+		ServiceAccountName:                cfg.Properties.ApplicationServiceAccount,
+		AllowAutomountServiceAccountToken: cfg.Properties.UnsafeAllowAutomountServiceAccountToken,
+		DownloaderImage:                   cfg.Properties.DownloaderImage,
+		ExecutorImage:                     cfg.Properties.ExecutorImage,
+		UploaderImage:                     cfg.Properties.UploaderImage,
+		EiriniAddress:                     cfg.Properties.EiriniAddress,
+	}
+
+	return &bifrost.BuildpackStaging{
+		Logger:           logger,
+		Namespacer:       bifrost.NewNamespacer(cfg.Properties.DefaultWorkloadsNamespace),
+		Converter:        initConverter(cfg, newLogger("convert", os.Stdout)),
+		StagingDesirer:   stagingDesirer,
+		StagingDeleter:   initTaskDeleter(clientset, jobClient),
+		StagingCompleter: stagingCompleter,
+	}
+}
+
+func initTaskBifrost(cfg *eirini.Config, clientset kubernetes.Interface, cache *client.Cache) *bifrost.Task {
+	converter := initConverter(cfg, newLogger("convert", os.Stdout))
+	taskDesirer := initTaskDesirer(cfg, clientset, cache, newLogger("task-desirer", os.Stdout))
 	jobClient := client.NewJob(clientset, cfg.WorkloadsNamespace)
 	taskDeleter := initTaskDeleter(clientset, jobClient)
 	retryableJSONClient := initRetryableJSONClient(cfg)
@@ -172,6 +384,13 @@ func initTaskBifrost(cfg *eirini.Config, clientset kubernetes.Interface) *bifros
 	}
 }
 
+func newLogger(component string, sink io.Writer) lager.Logger {
+	logger := lager.NewLogger(component)
+	logger.RegisterSink(lager.NewPrettySink(sink, lager.DEBUG))
+
+	return logger
+}
+
 func setConfigFromFile(path string) *eirini.Config {
 	fileBytes, err := ioutil.ReadFile(filepath.Clean(path))
 	cmdcommons.ExitfIfError(err, "Failed to read config file")
@@ -183,42 +402,60 @@ func setConfigFromFile(path string) *eirini.Config {
 	return &conf
 }
 
-func initLRPBifrost(clientset kubernetes.Interface, cfg *eirini.Config) *bifrost.LRP {
-	desireLogger := lager.NewLogger("desirer")
-	desireLogger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
+func initLRPBifrost(clientset kubernetes.Interface, cfg *eirini.Config, cache *client.Cache) *bifrost.LRP {
+	converter := initConverter(cfg, newLogger("convert", os.Stdout))
+	namespacer := bifrost.NewNamespacer(cfg.Properties.DefaultWorkloadsNamespace)
+
+	desirer := initStatefulSetDesirer(clientset, cfg, newLogger("desirer", os.Stdout))
+	desirer.Pods = client.NewCachedPod(clientset, cfg.WorkloadsNamespace, cache)
+	desirer.StatefulSetLister = client.NewCachedStatefulSet(clientset, cfg.WorkloadsNamespace, cache)
+	desirer.EventsClient = client.NewCachedEvent(clientset, cache)
+	desirer.PodUsage = initPodUsageCollector(clientset, cfg)
+
+	return &bifrost.LRP{
+		Converter:  converter,
+		Desirer:    desirer,
+		Namespacer: namespacer,
+	}
+}
+
+func initPodUsageCollector(clientset kubernetes.Interface, cfg *eirini.Config) k8s.PodUsageCollector {
+	logger := newLogger("pod-usage-collector", os.Stdout)
+	metricsClient := cmdcommons.CreateMetricsClient(cfg.Properties.ConfigPath)
+	diskClient := kubelet.NewDiskMetricsClient(
+		kubelet.NewClient(clientset.CoreV1().RESTClient()),
+		logger.Session("disk-metrics-client"),
+	)
+
+	return k8s.NewPodUsageCollector(metricsClient.MetricsV1beta1().PodMetricses(cfg.WorkloadsNamespace), diskClient, logger)
+}
 
-	desirer := &k8s.StatefulSetDesirer{
+func initStatefulSetDesirer(clientset kubernetes.Interface, cfg *eirini.Config, logger lager.Logger) *k8s.StatefulSetDesirer {
+	return &k8s.StatefulSetDesirer{
 		Pods:                              client.NewPod(clientset, cfg.WorkloadsNamespace),
 		Secrets:                           client.NewSecret(clientset),
 		StatefulSets:                      client.NewStatefulSet(clientset, cfg.WorkloadsNamespace),
 		PodDisruptionBudgets:              client.NewPodDisruptionBudget(clientset),
+		NetworkPolicies:                   client.NewNetworkPolicy(clientset),
 		EventsClient:                      client.NewEvent(clientset),
 		StatefulSetToLRPMapper:            k8s.StatefulSetToLRP,
 		RegistrySecretName:                cfg.Properties.RegistrySecretName,
 		LivenessProbeCreator:              k8s.CreateLivenessProbe,
 		ReadinessProbeCreator:             k8s.CreateReadinessProbe,
-		Logger:                            desireLogger,
+		Logger:                            logger,
 		ApplicationServiceAccount:         cfg.Properties.ApplicationServiceAccount,
 		AllowAutomountServiceAccountToken: cfg.Properties.UnsafeAllowAutomountServiceAccountToken,
-	}
-	converter := initConverter(cfg)
-	namespacer := bifrost.NewNamespacer(cfg.Properties.DefaultWorkloadsNamespace)
-
-	return &bifrost.LRP{
-		Converter:  converter,
-		Desirer:    desirer,
-		Namespacer: namespacer,
+		PlacementTags:                     cfg.Properties.PlacementTags,
+		DropletDownloaderImage:            cfg.Properties.DropletDownloaderImage,
 	}
 }
 
-func initConverter(cfg *eirini.Config) *bifrost.OPIConverter {
-	convertLogger := lager.NewLogger("convert")
-	convertLogger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
-
+func initConverter(cfg *eirini.Config, logger lager.Logger) *bifrost.OPIConverter {
 	return bifrost.NewOPIConverter(
-		convertLogger,
+		logger,
 		docker.Fetch,
 		docker.Parse,
 		cfg.Properties.AllowRunImageAsRoot,
+		cfg.Properties.StackImages,
 	)
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/eirini/bifrost"
+	cmdcommons "code.cloudfoundry.org/eirini/cmd"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// render prints the Kubernetes objects OPI would create for an LRP or task
+// request with the given config, without talking to Kubernetes. Logs go to
+// stderr so that the output can be piped to kubectl or diff.
+func render(cmd *cobra.Command, args []string) {
+	path, err := cmd.Flags().GetString("config")
+	cmdcommons.ExitfIfError(err, "Failed to get config flag")
+
+	lrpRequestPath, err := cmd.Flags().GetString("lrp")
+	cmdcommons.ExitfIfError(err, "Failed to get lrp flag")
+
+	taskRequestPath, err := cmd.Flags().GetString("task")
+	cmdcommons.ExitfIfError(err, "Failed to get task flag")
+
+	output, err := cmd.Flags().GetString("output")
+	cmdcommons.ExitfIfError(err, "Failed to get output flag")
+
+	if path == "" {
+		cmdcommons.Exitf("--config is missing")
+	}
+
+	if (lrpRequestPath == "") == (taskRequestPath == "") {
+		cmdcommons.Exitf("exactly one of --lrp and --task is required")
+	}
+
+	cfg := setConfigFromFile(path)
+	converter := initConverter(cfg, newLogger("convert", os.Stderr))
+	namespacer := bifrost.NewNamespacer(cfg.Properties.DefaultWorkloadsNamespace)
+
+	var list *corev1.List
+
+	// The desirers only build objects when rendering, so they are given no
+	// Kubernetes clientset.
+	if lrpRequestPath != "" {
+		var request cf.DesireLRPRequest
+		request.LRP = readRequest(lrpRequestPath, &request)
+
+		lrpBifrost := &bifrost.LRP{
+			Converter:  converter,
+			Desirer:    initStatefulSetDesirer(nil, cfg, newLogger("desirer", os.Stderr)),
+			Namespacer: namespacer,
+		}
+
+		list, err = lrpBifrost.Render(context.Background(), request)
+		cmdcommons.ExitfIfError(err, "Failed to render LRP")
+	} else {
+		var request cf.TaskRequest
+		readRequest(taskRequestPath, &request)
+
+		taskBifrost := &bifrost.Task{
+			Converter:   converter,
+			TaskDesirer: initTaskDesirer(cfg, nil, nil, newLogger("task-desirer", os.Stderr)),
+			Namespacer:  namespacer,
+		}
+
+		list, err = taskBifrost.RenderTask(context.Background(), request.GUID, request)
+		cmdcommons.ExitfIfError(err, "Failed to render task")
+	}
+
+	printList(list, output)
+}
+
+// readRequest decodes the JSON request in the file and returns its raw
+// contents.
+func readRequest(path string, request interface{}) string {
+	requestBytes, err := ioutil.ReadFile(filepath.Clean(path))
+	cmdcommons.ExitfIfError(err, "Failed to read request file")
+
+	err = json.Unmarshal(requestBytes, request)
+	cmdcommons.ExitfIfError(err, "Failed to decode request file")
+
+	return string(requestBytes)
+}
+
+func printList(list *corev1.List, output string) {
+	var (
+		listBytes []byte
+		err       error
+	)
+
+	switch output {
+	case "yaml":
+		listBytes, err = yaml.Marshal(list)
+	case "json":
+		listBytes, err = json.MarshalIndent(list, "", "  ")
+	default:
+		cmdcommons.Exitf("unsupported output format %q", output)
+	}
+
+	cmdcommons.ExitfIfError(err, "Failed to encode rendered objects")
+	fmt.Println(string(listBytes))
+}
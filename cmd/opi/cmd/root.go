@@ -20,7 +20,17 @@ func Execute() {
 	}
 	connectCmd.Flags().StringP("config", "c", "", "Path to the Eirini config file")
 
-	rootCmd.AddCommand(connectCmd)
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "prints the Kubernetes objects an LRP or task request would create",
+		Run:   render,
+	}
+	renderCmd.Flags().StringP("config", "c", "", "Path to the Eirini config file")
+	renderCmd.Flags().String("lrp", "", "Path to a desire LRP request, as sent by CC")
+	renderCmd.Flags().String("task", "", "Path to a task request, as sent by CC")
+	renderCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml or json")
+
+	rootCmd.AddCommand(connectCmd, renderCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	"code.cloudfoundry.org/lager"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func main() {
@@ -30,7 +32,7 @@ func main() {
 	stager := &StagerSimulator{}
 	task := &TaskSimulator{}
 
-	handler := handler.New(lrpBifrost, stager, task, handlerLogger)
+	handler := handler.New(lrpBifrost, stager, stager, task, handlerLogger, nil, 0, nil, nil)
 
 	fmt.Println("Starting to listen at 127.0.0.1:8085")
 	handlerLogger.Fatal("simulator-crashed", http.ListenAndServe("127.0.0.1:8085", handler))
@@ -38,26 +40,30 @@ func main() {
 
 type DesirerSimulator struct{}
 
-func (d *DesirerSimulator) Desire(namespace string, klrps *opi.LRP, opts ...k8s.DesireOption) error {
+func (d *DesirerSimulator) Desire(ctx context.Context, namespace string, klrps *opi.LRP, opts ...k8s.DesireOption) error {
 	return nil
 }
 
-func (d *DesirerSimulator) List() ([]*opi.LRP, error) {
+func (d *DesirerSimulator) Render(namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) (*k8s.RenderedLRP, error) {
 	panic("not implemented")
 }
 
-func (d *DesirerSimulator) Get(identifier opi.LRPIdentifier) (*opi.LRP, error) {
+func (d *DesirerSimulator) List(ctx context.Context) ([]*opi.LRP, error) {
+	panic("not implemented")
+}
+
+func (d *DesirerSimulator) Get(ctx context.Context, identifier opi.LRPIdentifier) (*opi.LRP, error) {
 	return &opi.LRP{
 		TargetInstances:  4,
 		RunningInstances: 2,
 	}, nil
 }
 
-func (d *DesirerSimulator) Update(updated *opi.LRP) error {
+func (d *DesirerSimulator) Update(ctx context.Context, updated *opi.LRP) error {
 	return nil
 }
 
-func (d *DesirerSimulator) GetInstances(identifier opi.LRPIdentifier) ([]*opi.Instance, error) {
+func (d *DesirerSimulator) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.Instance, error) {
 	if identifier.GUID == "jeff" && identifier.Version == "0.1.0" {
 		return []*opi.Instance{
 			{Index: 0, Since: 123456, State: opi.RunningState},
@@ -68,17 +74,29 @@ func (d *DesirerSimulator) GetInstances(identifier opi.LRPIdentifier) ([]*opi.In
 	return []*opi.Instance{}, errors.New("no such app")
 }
 
-func (d *DesirerSimulator) Stop(identifier opi.LRPIdentifier) error {
+func (d *DesirerSimulator) GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.InstanceStats, error) {
+	panic("not implemented")
+}
+
+func (d *DesirerSimulator) GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error) {
+	panic("not implemented")
+}
+
+func (d *DesirerSimulator) Stop(ctx context.Context, identifier opi.LRPIdentifier) error {
 	panic("not implemented")
 }
 
-func (d *DesirerSimulator) StopInstance(identifier opi.LRPIdentifier, index uint) error {
+func (d *DesirerSimulator) StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error {
+	return nil
+}
+
+func (d *DesirerSimulator) Restart(ctx context.Context, identifier opi.LRPIdentifier) error {
 	return nil
 }
 
 type ConverterSimulator struct{}
 
-func (c *ConverterSimulator) ConvertLRP(request cf.DesireLRPRequest) (opi.LRP, error) {
+func (c *ConverterSimulator) ConvertLRP(ctx context.Context, request cf.DesireLRPRequest) (opi.LRP, error) {
 	return opi.LRP{}, nil
 }
 
@@ -92,17 +110,17 @@ func (s *StagerSimulator) TransferStaging(ctx context.Context, stagingGUID strin
 	return nil
 }
 
-func (s *StagerSimulator) CompleteStaging(cf.StagingCompletedRequest) error {
+func (s *StagerSimulator) CompleteStaging(context.Context, cf.StagingCompletedRequest) error {
 	return nil
 }
 
 type TaskSimulator struct{}
 
-func (t *TaskSimulator) GetTask(taskGUID string) (cf.TaskResponse, error) {
+func (t *TaskSimulator) GetTask(ctx context.Context, taskGUID string) (cf.TaskResponse, error) {
 	return cf.TaskResponse{}, nil
 }
 
-func (t *TaskSimulator) ListTasks() (cf.TasksResponse, error) {
+func (t *TaskSimulator) ListTasks(ctx context.Context, filter cf.TasksFilter) (cf.TasksResponse, error) {
 	return cf.TasksResponse{}, nil
 }
 
@@ -110,6 +128,14 @@ func (t *TaskSimulator) TransferTask(ctx context.Context, taskGUID string, reque
 	return nil
 }
 
-func (t *TaskSimulator) CancelTask(taskGUID string) error {
+func (t *TaskSimulator) RenderTask(ctx context.Context, taskGUID string, request cf.TaskRequest) (*corev1.List, error) {
+	panic("not implemented")
+}
+
+func (t *TaskSimulator) GetTaskLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error) {
+	panic("not implemented")
+}
+
+func (t *TaskSimulator) CancelTask(ctx context.Context, taskGUID string) error {
 	return nil
 }
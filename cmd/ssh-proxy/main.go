@@ -0,0 +1,135 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"code.cloudfoundry.org/eirini"
+	cmdcommons "code.cloudfoundry.org/eirini/cmd"
+	k8sclient "code.cloudfoundry.org/eirini/k8s/client"
+	"code.cloudfoundry.org/eirini/sshproxy"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/tlsconfig"
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	defaultListenAddress = ":2222"
+	defaultUAAClientName = "ssh-proxy"
+)
+
+type options struct {
+	ConfigFile string `short:"c" long:"config" description:"Config for running ssh-proxy"`
+}
+
+func main() {
+	var opts options
+	_, err := flags.ParseArgs(&opts, os.Args)
+	cmdcommons.ExitfIfError(err, "Failed to parse args")
+
+	cfg, err := readConfigFile(opts.ConfigFile)
+	cmdcommons.ExitfIfError(err, "Failed to read config file")
+
+	hostKey, err := readHostKey(cfg.HostKeyPath)
+	cmdcommons.ExitfIfError(err, "Failed to read host key")
+
+	httpClient, err := createHTTPClient(cfg)
+	cmdcommons.ExitfIfError(err, "Failed to create http client")
+
+	uaaClientSecret, err := readSecret(cfg.UAAClientSecretPath)
+	cmdcommons.ExitfIfError(err, "Failed to read UAA client secret")
+
+	clientset := cmdcommons.CreateKubeClient(cfg.ConfigPath)
+
+	// exec and port forwarding upgrade the connection, which the tracing
+	// transport of the clientset does not support
+	kubeConfig, err := clientcmd.BuildConfigFromFlags("", cfg.ConfigPath)
+	cmdcommons.ExitfIfError(err, "Failed to build kubeconfig")
+
+	logger := lager.NewLogger("ssh-proxy")
+	logger.RegisterSink(lager.NewPrettySink(os.Stdout, lager.DEBUG))
+
+	proxy := sshproxy.NewProxy(
+		logger,
+		hostKey,
+		sshproxy.NewCCAuthenticator(
+			httpClient,
+			cfg.CCAPIURL,
+			cfg.UAATokenURL,
+			cmdcommons.GetOrDefault(cfg.UAAClientName, defaultUAAClientName),
+			uaaClientSecret,
+		),
+		k8sclient.NewStatefulSet(clientset, cfg.WorkloadsNamespace),
+		k8sclient.NewPodSession(kubeConfig, clientset),
+	)
+
+	listener, err := net.Listen("tcp", cmdcommons.GetOrDefault(cfg.ListenAddress, defaultListenAddress))
+	cmdcommons.ExitfIfError(err, "Failed to listen")
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+
+	go func() {
+		<-sigs
+		listener.Close()
+	}()
+
+	logger.Info("listening", lager.Data{"address": listener.Addr().String()})
+	err = proxy.Serve(listener)
+	cmdcommons.ExitfIfError(err, "Failed to serve")
+}
+
+func readConfigFile(path string) (eirini.SSHProxyConfig, error) {
+	fileBytes, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return eirini.SSHProxyConfig{}, errors.Wrap(err, "failed to read file")
+	}
+
+	var conf eirini.SSHProxyConfig
+	err = yaml.Unmarshal(fileBytes, &conf)
+
+	return conf, errors.Wrap(err, "failed to unmarshal yaml")
+}
+
+func readHostKey(path string) (ssh.Signer, error) {
+	keyBytes, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read file")
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyBytes)
+
+	return signer, errors.Wrap(err, "failed to parse private key")
+}
+
+func readSecret(path string) (string, error) {
+	secret, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read file")
+	}
+
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// createHTTPClient trusts the configured CA bundle only, as the image has no
+// system roots.
+func createHTTPClient(cfg eirini.SSHProxyConfig) (*http.Client, error) {
+	cmdcommons.VerifyFileExists(cfg.CACertPath, "CA cert")
+
+	tlsConfig, err := tlsconfig.Build(tlsconfig.WithExternalServiceDefaults()).Client(tlsconfig.WithAuthorityFromFile(cfg.CACertPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build tls config")
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}, nil
+}
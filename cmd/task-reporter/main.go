@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/eirini/k8s/client"
 	k8stask "code.cloudfoundry.org/eirini/k8s/informers/task"
 	"code.cloudfoundry.org/eirini/k8s/reconciler"
+	eirinischeme "code.cloudfoundry.org/eirini/pkg/generated/clientset/versioned/scheme"
 	"code.cloudfoundry.org/eirini/util"
 	"code.cloudfoundry.org/lager"
 	"github.com/jessevdk/go-flags"
@@ -34,6 +35,10 @@ type options struct {
 const defaultCompletionCallbackRetryLimit = 10
 
 func main() {
+	if err := kscheme.AddToScheme(eirinischeme.Scheme); err != nil {
+		cmdcommons.Exitf("failed to add the k8s scheme to the Task CRD scheme: %v", err)
+	}
+
 	var opts options
 	_, err := flags.ParseArgs(&opts, os.Args)
 	cmdcommons.ExitfIfError(err, "Failed to parse args")
@@ -41,6 +46,8 @@ func main() {
 	cfg, err := readConfigFile(opts.ConfigFile)
 	cmdcommons.ExitfIfError(err, "Failed to read config file")
 
+	defer cmdcommons.InitTracing("task-reporter", cfg.TracingExporter)()
+
 	clientset := cmdcommons.CreateKubeClient(cfg.ConfigPath)
 
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", cfg.ConfigPath)
@@ -66,9 +73,8 @@ func main() {
 	}
 
 	mgrOptions := manager.Options{
-		// do not serve prometheus metrics; disabled because port clashes during integration tests
-		MetricsBindAddress: "0",
-		Scheme:             kscheme.Scheme,
+		MetricsBindAddress: cmdcommons.GetMetricsBindAddress(cfg.MetricsPort),
+		Scheme:             eirinischeme.Scheme,
 		Logger:             util.NewLagerLogr(taskLogger),
 		Namespace:          cfg.WorkloadsNamespace,
 	}
@@ -1,8 +1,13 @@
 package events
 
 import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/runtimeschema/cc_messages"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //counterfeiter:generate . CcClient
@@ -14,6 +19,10 @@ type CcClient interface {
 type CrashEvent struct {
 	ProcessGUID string
 	cc_messages.AppCrashedRequest
+
+	// SpanContext is the trace context of the request that desired the
+	// crashed app. It is invalid when the app was desired without tracing.
+	SpanContext trace.SpanContext
 }
 
 type CcCrashEmitter struct {
@@ -29,5 +38,10 @@ func NewCcCrashEmitter(logger lager.Logger, client CcClient) *CcCrashEmitter {
 }
 
 func (c *CcCrashEmitter) Emit(event CrashEvent) error {
-	return c.client.AppCrashed(event.ProcessGUID, event.AppCrashedRequest, c.logger)
+	_, span := tracing.StartLinkedTo(context.Background(), "report-app-crash", event.SpanContext,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("eirini.process_guid", event.ProcessGUID)),
+	)
+
+	return tracing.End(span, c.client.AppCrashed(event.ProcessGUID, event.AppCrashedRequest, c.logger))
 }
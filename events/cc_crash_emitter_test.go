@@ -5,10 +5,14 @@ import (
 
 	. "code.cloudfoundry.org/eirini/events"
 	"code.cloudfoundry.org/eirini/events/eventsfakes"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager/lagertest"
 	"code.cloudfoundry.org/runtimeschema/cc_messages"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 var _ = Describe("Crashreporter", func() {
@@ -62,6 +66,27 @@ var _ = Describe("Crashreporter", func() {
 			Expect(report.CrashTimestamp).To(Equal(int64(112233)))
 		})
 
+		When("the app was desired by a traced request", func() {
+			var exporter *tracetest.InMemoryExporter
+
+			BeforeEach(func() {
+				exporter = tracetest.NewInMemoryExporter()
+				otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+				crashEvent.SpanContext = tracing.SpanContextFromAnnotations(map[string]string{
+					tracing.AnnotationTraceParent: "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01",
+				})
+			})
+
+			It("should link the report back to the request", func() {
+				spans := exporter.GetSpans()
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("report-app-crash"))
+				Expect(spans[0].Links).To(HaveLen(1))
+				Expect(spans[0].Links[0].SpanContext.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+			})
+		})
+
 		Context("event could not be submitted", func() {
 			BeforeEach(func() {
 				ccClient.AppCrashedReturns(errors.New("boom"))
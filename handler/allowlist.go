@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Endpoint groups that client certificates can be allowlisted for.
+const (
+	EndpointGroupApps  = "apps"
+	EndpointGroupStage = "stage"
+	EndpointGroupTasks = "tasks"
+)
+
+var errClientNotAllowed = errors.New("client certificate is not allowed to use this endpoint")
+
+// ClientAllowlist maps endpoint groups to the common names of the client
+// certificates allowed to call them. Groups without an entry are open to any
+// client the TLS server accepts. Requests without a client certificate, such
+// as those to the plaintext port, are rejected by groups with an entry.
+type ClientAllowlist map[string][]string
+
+func NewClientAllowlist(commonNames map[string][]string) (ClientAllowlist, error) {
+	for group := range commonNames {
+		switch group {
+		case EndpointGroupApps, EndpointGroupStage, EndpointGroupTasks:
+		default:
+			return nil, fmt.Errorf("unknown endpoint group %q", group)
+		}
+	}
+
+	return ClientAllowlist(commonNames), nil
+}
+
+func (l ClientAllowlist) allows(group, commonName string) bool {
+	allowed, ok := l[group]
+	if !ok {
+		return true
+	}
+
+	for _, cn := range allowed {
+		if cn == commonName && commonName != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restrictRoute rejects requests from clients that are not allowlisted for
+// the endpoint group of the route.
+func (l ClientAllowlist) restrictRoute(logger lager.Logger, route string, handle httprouter.Handle) httprouter.Handle {
+	group := endpointGroup(route)
+	if _, ok := l[group]; !ok {
+		return handle
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		commonName := clientCommonName(req)
+		if !l.allows(group, commonName) {
+			logger.Info("client-not-allowed", lager.Data{"route": route, "common-name": commonName, "request-id": req.Header.Get(RequestIDHeader)})
+			writeError(logger, resp, req, http.StatusForbidden, ErrorCodeForbidden, errClientNotAllowed)
+
+			return
+		}
+
+		handle(resp, req, ps)
+	}
+}
+
+func endpointGroup(route string) string {
+	return strings.SplitN(strings.TrimPrefix(route, "/"), "/", 2)[0]
+}
+
+func clientCommonName(req *http.Request) string {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return req.TLS.PeerCertificates[0].Subject.CommonName
+}
@@ -31,14 +31,14 @@ func (a *App) Desire(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 	buf := new(bytes.Buffer)
 	if _, err := buf.ReadFrom(r.Body); err != nil {
 		loggerSession.Error("request-body-cannot-be-read", err)
-		w.WriteHeader(http.StatusBadRequest)
+		writeBadRequestResponse(loggerSession, w, r, err)
 
 		return
 	}
 
 	if err := json.Unmarshal(buf.Bytes(), &request); err != nil {
 		loggerSession.Error("request-body-decoding-failed", err)
-		w.WriteHeader(http.StatusBadRequest)
+		writeBadRequestResponse(loggerSession, w, r, err)
 
 		return
 	}
@@ -47,9 +47,22 @@ func (a *App) Desire(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 
 	request.LRP = buf.String()
 
+	dryRun, err := isDryRun(r)
+	if err != nil {
+		writeBadRequestResponse(loggerSession, w, r, err)
+
+		return
+	}
+
+	if dryRun {
+		a.render(loggerSession, w, r, request)
+
+		return
+	}
+
 	if err := a.lrpBifrost.Transfer(r.Context(), request); err != nil {
 		loggerSession.Error("bifrost-failed", err)
-		w.WriteHeader(http.StatusBadRequest)
+		writeErrorResponse(loggerSession, w, r, err)
 
 		return
 	}
@@ -57,6 +70,18 @@ func (a *App) Desire(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 	w.WriteHeader(http.StatusAccepted)
 }
 
+func (a *App) render(logger lager.Logger, w http.ResponseWriter, r *http.Request, request cf.DesireLRPRequest) {
+	list, err := a.lrpBifrost.Render(r.Context(), request)
+	if err != nil {
+		logger.Error("bifrost-failed", err)
+		writeErrorResponse(logger, w, r, err)
+
+		return
+	}
+
+	writeRendered(logger, w, r, list)
+}
+
 func (a *App) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	loggerSession := a.logger.Session("list-apps")
 	loggerSession.Debug("requested")
@@ -64,7 +89,7 @@ func (a *App) List(w http.ResponseWriter, r *http.Request, _ httprouter.Params)
 	desiredLRPSchedulingInfos, err := a.lrpBifrost.List(r.Context())
 	if err != nil {
 		loggerSession.Error("bifrost-failed", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		writeErrorResponse(loggerSession, w, r, err)
 
 		return
 	}
@@ -101,13 +126,11 @@ func (a *App) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	if err != nil {
 		if errors.Is(err, eirini.ErrNotFound) {
 			loggerSession.Info("app-not-found")
-			w.WriteHeader(http.StatusNotFound)
-
-			return
+		} else {
+			loggerSession.Error("failed-to-get-lrp", err, lager.Data{"guid": identifier.GUID})
 		}
 
-		loggerSession.Error("failed-to-get-lrp", err, lager.Data{"guid": identifier.GUID})
-		w.WriteHeader(http.StatusInternalServerError)
+		writeErrorResponse(loggerSession, w, r, err)
 
 		return
 	}
@@ -155,6 +178,64 @@ func (a *App) GetInstances(w http.ResponseWriter, r *http.Request, ps httprouter
 	}
 }
 
+func (a *App) GetStats(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	loggerSession := a.logger.Session("get-app-stats", lager.Data{"guid": ps.ByName("process_guid"), "version": ps.ByName("version_guid")})
+	loggerSession.Debug("requested")
+
+	identifier := opi.LRPIdentifier{
+		GUID:    ps.ByName("process_guid"),
+		Version: ps.ByName("version_guid"),
+	}
+	response := cf.GetInstanceStatsResponse{ProcessGUID: identifier.ProcessGUID()}
+	stats, err := a.lrpBifrost.GetInstanceStats(r.Context(), identifier)
+	response.Instances = stats
+
+	if err != nil {
+		loggerSession.Error("bifrost-failed", err)
+		response.Error = err.Error()
+		response.Instances = []*cf.InstanceStats{}
+	}
+
+	if errors.Is(err, eirini.ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		loggerSession.Error("encoding-response-failed", err)
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+}
+
+func (a *App) GetLogs(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	loggerSession := a.logger.Session("get-app-logs", lager.Data{"guid": ps.ByName("process_guid"), "version": ps.ByName("version_guid")})
+	loggerSession.Debug("requested")
+
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		writeBadRequestResponse(loggerSession, w, r, err)
+
+		return
+	}
+
+	identifier := opi.LRPIdentifier{
+		GUID:    ps.ByName("process_guid"),
+		Version: ps.ByName("version_guid"),
+	}
+
+	logs, err := a.lrpBifrost.GetLogs(r.Context(), identifier, opts)
+	if err != nil {
+		loggerSession.Error("bifrost-failed", err)
+		writeErrorResponse(loggerSession, w, r, err)
+
+		return
+	}
+
+	streamLogs(loggerSession, w, logs)
+}
+
 func (a *App) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	loggerSession := a.logger.Session("update-app", lager.Data{"guid": ps.ByName("process_guid")})
 
@@ -162,8 +243,7 @@ func (a *App) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		loggerSession.Error("json-decoding-failed", err)
-
-		writeUpdateErrorResponse(w, err, http.StatusBadRequest, loggerSession)
+		writeUpdateErrorResponse(loggerSession, w, r, http.StatusBadRequest, ErrorCodeBadRequest, err)
 
 		return
 	}
@@ -172,7 +252,9 @@ func (a *App) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 
 	if err := a.lrpBifrost.Update(r.Context(), request); err != nil {
 		loggerSession.Error("bifrost-failed", err)
-		writeUpdateErrorResponse(w, err, http.StatusInternalServerError, loggerSession)
+
+		status, code := statusForError(err)
+		writeUpdateErrorResponse(loggerSession, w, r, status, code, err)
 	}
 }
 
@@ -187,9 +269,7 @@ func (a *App) Stop(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 
 	if err := a.lrpBifrost.Stop(r.Context(), identifier); err != nil {
 		loggerSession.Error("bifrost-failed", err)
-
-		statusCode := http.StatusInternalServerError
-		w.WriteHeader(statusCode)
+		writeErrorResponse(loggerSession, w, r, err)
 	}
 }
 
@@ -205,39 +285,49 @@ func (a *App) StopInstance(w http.ResponseWriter, r *http.Request, ps httprouter
 	index, err := strconv.ParseUint(ps.ByName("instance"), 10, 32)
 	if err != nil {
 		loggerSession.Error("parsing-instance-index-failed", err)
-		w.WriteHeader(http.StatusBadRequest)
+		writeBadRequestResponse(loggerSession, w, r, err)
+
+		return
 	}
 
 	if err := a.lrpBifrost.StopInstance(r.Context(), identifier, uint(index)); err != nil {
 		loggerSession.Error("bifrost-failed", err)
-
-		statusCode := http.StatusInternalServerError
-
-		if errors.Is(err, eirini.ErrInvalidInstanceIndex) {
-			statusCode = http.StatusBadRequest
-		}
-
-		w.WriteHeader(statusCode)
+		writeErrorResponse(loggerSession, w, r, err)
 	}
 }
 
-func writeUpdateErrorResponse(w http.ResponseWriter, err error, statusCode int, loggerSession lager.Logger) {
-	w.WriteHeader(statusCode)
+// Restart triggers a rolling restart of the app and returns without waiting
+// for it to complete. The progress shows in the instances of the app.
+func (a *App) Restart(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	loggerSession := a.logger.Session("restart-app", lager.Data{"guid": ps.ByName("process_guid"), "version": ps.ByName("version_guid")})
+	loggerSession.Debug("requested")
 
-	response := cf.DesiredLRPLifecycleResponse{
-		Error: cf.Error{
-			Message: err.Error(),
-		},
+	identifier := opi.LRPIdentifier{
+		GUID:    ps.ByName("process_guid"),
+		Version: ps.ByName("version_guid"),
 	}
 
-	body, marshalError := json.Marshal(response)
-	if marshalError != nil {
-		loggerSession.Error("could-not-marshal-response", marshalError)
+	if err := a.lrpBifrost.Restart(r.Context(), identifier); err != nil {
+		loggerSession.Error("bifrost-failed", err)
+		writeErrorResponse(loggerSession, w, r, err)
 
 		return
 	}
 
-	if _, err = w.Write(body); err != nil {
-		loggerSession.Error("could-not-write-response", err)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// writeUpdateErrorResponse nests the error in a lifecycle response, which is
+// what CC expects from the update endpoint.
+func writeUpdateErrorResponse(logger lager.Logger, w http.ResponseWriter, r *http.Request, status int, code string, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	response := cf.DesiredLRPLifecycleResponse{
+		Error: newError(r, code, err),
+	}
+
+	if encodingErr := json.NewEncoder(w).Encode(response); encodingErr != nil {
+		logger.Error("could-not-write-response", encodingErr)
 	}
 }
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/eirini"
 	. "code.cloudfoundry.org/eirini/handler"
@@ -18,6 +20,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var _ = Describe("AppHandler", func() {
@@ -30,7 +35,7 @@ var _ = Describe("AppHandler", func() {
 	BeforeEach(func() {
 		lrpBifrost = new(handlerfakes.FakeLRPBifrost)
 		lager = lagertest.NewTestLogger("app-handler-test")
-		ts = httptest.NewServer(New(lrpBifrost, nil, nil, lager))
+		ts = httptest.NewServer(New(lrpBifrost, nil, nil, nil, lager, nil, 0, nil, nil))
 	})
 
 	AfterEach(func() {
@@ -56,11 +61,13 @@ var _ = Describe("AppHandler", func() {
 		var (
 			path     string
 			body     string
+			accept   string
 			response *http.Response
 		)
 
 		BeforeEach(func() {
 			path = "/apps/myguid"
+			accept = ""
 			body = `{
 				"guid": "guid",
 				"process_guid" : "myguid",
@@ -105,6 +112,7 @@ var _ = Describe("AppHandler", func() {
 		JustBeforeEach(func() {
 			req, err := http.NewRequest("PUT", ts.URL+path, bytes.NewReader([]byte(body)))
 			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Accept", accept)
 
 			client := &http.Client{}
 			response, err = client.Do(req)
@@ -163,8 +171,17 @@ var _ = Describe("AppHandler", func() {
 				lrpBifrost.TransferReturns(errors.New("aaargh"))
 			})
 
-			It("should return BadRequest status", func() {
-				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			It("should return InternalServerError status", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+
+			It("should describe the error in the response body", func() {
+				var errorResponse cf.Error
+				Expect(json.NewDecoder(response.Body).Decode(&errorResponse)).To(Succeed())
+				Expect(errorResponse.Code).To(Equal(ErrorCodeInternal))
+				Expect(errorResponse.Message).To(Equal("aaargh"))
+				Expect(errorResponse.RequestID).To(Equal(response.Header.Get(RequestIDHeader)))
+				Expect(errorResponse.RequestID).NotTo(BeEmpty())
 			})
 
 			It("should provide a helpful log message", findLog("app-handler-test.desire-app.bifrost-failed", "myguid"))
@@ -185,6 +202,73 @@ var _ = Describe("AppHandler", func() {
 				Expect(lrpBifrost.TransferCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when it is a dry run", func() {
+			BeforeEach(func() {
+				path = "/apps/myguid?dry_run=true"
+				lrpBifrost.RenderReturns(&corev1.List{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+					Items:    []runtime.RawExtension{{Raw: []byte(`{"kind":"StatefulSet"}`)}},
+				}, nil)
+			})
+
+			It("should render the request instead of transferring it", func() {
+				Expect(lrpBifrost.TransferCallCount()).To(Equal(0))
+				Expect(lrpBifrost.RenderCallCount()).To(Equal(1))
+				_, request := lrpBifrost.RenderArgsForCall(0)
+				Expect(request.ProcessGUID).To(Equal("myguid"))
+				Expect(request.LRP).To(Equal(body))
+			})
+
+			It("should return the rendered objects as JSON", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(responseBody).To(MatchJSON(`{"apiVersion":"v1","kind":"List","metadata":{},"items":[{"kind":"StatefulSet"}]}`))
+			})
+
+			Context("and the client accepts YAML", func() {
+				BeforeEach(func() {
+					accept = "application/yaml"
+				})
+
+				It("should return the rendered objects as YAML", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/yaml"))
+
+					responseBody, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(responseBody).To(MatchYAML("apiVersion: v1\nkind: List\nmetadata: {}\nitems:\n- kind: StatefulSet\n"))
+				})
+			})
+
+			Context("and rendering fails", func() {
+				BeforeEach(func() {
+					lrpBifrost.RenderReturns(nil, errors.New("aaargh"))
+				})
+
+				It("should return InternalServerError status", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the dry run parameter is invalid", func() {
+			BeforeEach(func() {
+				path = "/apps/myguid?dry_run=maybe"
+			})
+
+			It("should return a 400 Bad Request HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should not update the app", func() {
+				Expect(lrpBifrost.TransferCallCount()).To(Equal(0))
+				Expect(lrpBifrost.RenderCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Context("List Apps", func() {
@@ -291,12 +375,17 @@ var _ = Describe("AppHandler", func() {
 		JustBeforeEach(func() {
 			req, err := http.NewRequest("GET", ts.URL+path, nil)
 			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(RequestIDHeader, "the-request-id")
 
 			client := &http.Client{}
 			response, err = client.Do(req)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should echo the request ID", func() {
+			Expect(response.Header.Get(RequestIDHeader)).To(Equal("the-request-id"))
+		})
+
 		It("should use the bifrost to get the app", func() {
 			Expect(lrpBifrost.GetAppCallCount()).To(Equal(1))
 			_, identifier := lrpBifrost.GetAppArgsForCall(0)
@@ -336,6 +425,17 @@ var _ = Describe("AppHandler", func() {
 			It("should return a 404 HTTP status code", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
 			})
+
+			It("should return a not found error", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(string(body)).To(MatchJSON(`{
+					"code": "NotFound",
+					"message": "bar: foo: not found",
+					"request_id": "the-request-id"
+				}`))
+			})
 		})
 
 		Context("when getting tha app fails", func() {
@@ -361,7 +461,7 @@ var _ = Describe("AppHandler", func() {
 			instances := []*cf.Instance{
 				{Index: 0, Since: 123, State: "RUNNING"},
 				{Index: 1, Since: 456, State: "RUNNING"},
-				{Index: 2, Since: 789, State: "UNCLAIMED", PlacementError: "this is not the place"},
+				{Index: 2, Since: 789, State: "UNCLAIMED", PlacementError: "this is not the place", Outdated: true},
 			}
 			lrpBifrost.GetInstancesReturns(instances, nil)
 		})
@@ -401,7 +501,8 @@ var _ = Describe("AppHandler", func() {
 							"index": 2,
 							"since": 789,
 							"state": "UNCLAIMED",
-							"placement_error": "this is not the place"
+							"placement_error": "this is not the place",
+							"outdated": true
 						}
 					]
 				}`
@@ -450,6 +551,254 @@ var _ = Describe("AppHandler", func() {
 		})
 	})
 
+	Context("Get the stats of the instances", func() {
+		var (
+			path     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			path = "/apps/guid_1234/version_1234/stats"
+
+			stats := []*cf.InstanceStats{
+				{
+					Instance:   cf.Instance{Index: 0, Since: 123, State: "RUNNING"},
+					Host:       "10.0.0.1",
+					InternalIP: "172.16.0.1",
+					Ports:      []cf.PortMapping{{ContainerPort: 8080}, {ContainerPort: 2222, HostPort: 32222}},
+					Uptime:     60,
+					MemQuota:   2048,
+					DiskQuota:  8192,
+					Usage:      cf.Usage{CPU: 12.5, Mem: 1024, Disk: 4096},
+				},
+			}
+			lrpBifrost.GetInstanceStatsReturns(stats, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", ts.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			client := &http.Client{}
+			response, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should use bifrost to get the stats", func() {
+			Expect(lrpBifrost.GetInstanceStatsCallCount()).To(Equal(1))
+			_, identifier := lrpBifrost.GetInstanceStatsArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid_1234"))
+			Expect(identifier.Version).To(Equal("version_1234"))
+		})
+
+		It("should return the stats in the response", func() {
+			expectedResponse := `
+				{
+					"process_guid": "guid_1234-version_1234",
+					"instances": [
+						{
+							"index": 0,
+							"since": 123,
+							"state": "RUNNING",
+							"host": "10.0.0.1",
+							"internal_ip": "172.16.0.1",
+							"ports": [
+								{"container_port": 8080},
+								{"container_port": 2222, "host_port": 32222}
+							],
+							"uptime": 60,
+							"mem_quota": 2048,
+							"disk_quota": 8192,
+							"usage": {
+								"cpu": 12.5,
+								"mem": 1024,
+								"disk": 4096
+							}
+						}
+					]
+				}`
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(MatchJSON(expectedResponse))
+		})
+
+		Context("when Bifrost returns an error", func() {
+			BeforeEach(func() {
+				lrpBifrost.GetInstanceStatsReturns([]*cf.InstanceStats{}, errors.New("failed to get stats"))
+			})
+
+			It("returns the error in the response", func() {
+				expectedResponse := `
+					{
+						"error": "failed to get stats",
+						"process_guid": "guid_1234-version_1234",
+						"instances": []
+					}`
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(body)).To(MatchJSON(expectedResponse))
+			})
+
+			It("should provide a helpful log message", findLog("app-handler-test.get-app-stats.bifrost-failed", "guid_1234"))
+		})
+
+		Context("when the app is not found", func() {
+			BeforeEach(func() {
+				lrpBifrost.GetInstanceStatsReturns([]*cf.InstanceStats{}, errors.Wrap(eirini.ErrNotFound, "failed to get stats"))
+			})
+
+			It("returns a 404 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Context("Get the logs of an app", func() {
+		var (
+			path     string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			path = "/apps/guid_1234/version_1234/logs"
+			lrpBifrost.GetLogsReturns(ioutil.NopCloser(strings.NewReader("[0] hello\n")), nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", ts.URL+path, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			client := &http.Client{}
+			response, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should use bifrost to get the logs", func() {
+			Expect(lrpBifrost.GetLogsCallCount()).To(Equal(1))
+			_, identifier, opts := lrpBifrost.GetLogsArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("guid_1234"))
+			Expect(identifier.Version).To(Equal("version_1234"))
+			Expect(opts.Index).To(BeNil())
+			Expect(opts.Follow).To(BeFalse())
+			Expect(opts.SinceTime).To(BeNil())
+		})
+
+		It("should stream the logs as plain text", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("[0] hello\n"))
+		})
+
+		Context("when the logs outlast the server write timeout", func() {
+			BeforeEach(func() {
+				ts.Close()
+				ts = httptest.NewUnstartedServer(New(lrpBifrost, nil, nil, nil, lager, nil, 0, nil, nil))
+				ts.Config.WriteTimeout = 100 * time.Millisecond
+				ts.Start()
+
+				logs, logsWriter := io.Pipe()
+				go func() {
+					defer GinkgoRecover()
+
+					_, err := logsWriter.Write([]byte("[0] hello\n"))
+					Expect(err).NotTo(HaveOccurred())
+					time.Sleep(300 * time.Millisecond)
+					_, err = logsWriter.Write([]byte("[0] later\n"))
+					Expect(err).NotTo(HaveOccurred())
+					logsWriter.Close()
+				}()
+				lrpBifrost.GetLogsReturns(logs, nil)
+			})
+
+			It("should keep streaming", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(body)).To(Equal("[0] hello\n[0] later\n"))
+			})
+		})
+
+		Context("when options are provided", func() {
+			BeforeEach(func() {
+				path = "/apps/guid_1234/version_1234/logs?index=2&follow=true&since=2020-09-13T12:26:40Z"
+			})
+
+			It("should pass them to bifrost", func() {
+				_, _, opts := lrpBifrost.GetLogsArgsForCall(0)
+				Expect(*opts.Index).To(Equal(2))
+				Expect(opts.Follow).To(BeTrue())
+				Expect(opts.SinceTime.Equal(time.Unix(1600000000, 0))).To(BeTrue())
+			})
+		})
+
+		Context("when since is a duration", func() {
+			BeforeEach(func() {
+				path = "/apps/guid_1234/version_1234/logs?since=10m"
+			})
+
+			It("should pass a time relative to now", func() {
+				_, _, opts := lrpBifrost.GetLogsArgsForCall(0)
+				Expect(*opts.SinceTime).To(BeTemporally("~", time.Now().Add(-10*time.Minute), time.Minute))
+			})
+		})
+
+		Context("when the index is invalid", func() {
+			BeforeEach(func() {
+				path = "/apps/guid_1234/version_1234/logs?index=-1"
+			})
+
+			It("should return a 400 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(lrpBifrost.GetLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when follow is invalid", func() {
+			BeforeEach(func() {
+				path = "/apps/guid_1234/version_1234/logs?follow=maybe"
+			})
+
+			It("should return a 400 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when since is invalid", func() {
+			BeforeEach(func() {
+				path = "/apps/guid_1234/version_1234/logs?since=yesterday"
+			})
+
+			It("should return a 400 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the app is not found", func() {
+			BeforeEach(func() {
+				lrpBifrost.GetLogsReturns(nil, errors.Wrap(eirini.ErrNotFound, "failed to get logs"))
+			})
+
+			It("should return a 404 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+
+			It("should provide a helpful log message", findLog("app-handler-test.get-app-logs.bifrost-failed", "guid_1234"))
+		})
+
+		Context("when Bifrost returns an error", func() {
+			BeforeEach(func() {
+				lrpBifrost.GetLogsReturns(nil, errors.New("boom"))
+			})
+
+			It("should return a 500 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	Context("Update an app", func() {
 		var (
 			path     string
@@ -579,6 +928,52 @@ var _ = Describe("AppHandler", func() {
 		})
 	})
 
+	Context("Restart an app", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", ts.URL+"/apps/app_1234/version_1234/restart", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			client := &http.Client{}
+			response, err = client.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return a 202 Accepted HTTP code", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+		})
+
+		It("should restart the app with the given identifier", func() {
+			Expect(lrpBifrost.RestartCallCount()).To(Equal(1))
+			_, identifier := lrpBifrost.RestartArgsForCall(0)
+			Expect(identifier.GUID).To(Equal("app_1234"))
+			Expect(identifier.Version).To(Equal("version_1234"))
+		})
+
+		Context("when the app does not exist", func() {
+			BeforeEach(func() {
+				lrpBifrost.RestartReturns(errors.Wrap(eirini.ErrNotFound, "failed to restart app"))
+			})
+
+			It("should return a 404 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when the restart fails", func() {
+			BeforeEach(func() {
+				lrpBifrost.RestartReturns(errors.New("boom"))
+			})
+
+			It("should return a 500 HTTP status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+
+			It("should provide a helpful log message", findLog("app-handler-test.restart-app.bifrost-failed", "app_1234"))
+		})
+	})
+
 	Context("Stop an app instance", func() {
 		var (
 			path     string
@@ -619,8 +1014,8 @@ var _ = Describe("AppHandler", func() {
 					lrpBifrost.StopInstanceReturns(errors.Wrap(eirini.ErrInvalidInstanceIndex, "something-bad-happened"))
 				})
 
-				It("should return a 404 HTTP status code", func() {
-					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				It("should return a 422 HTTP status code", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
 				})
 
 				It("should provide a helpful log message", findLog("app-handler-test.stop-app-instance.bifrost-failed", "app_1234"))
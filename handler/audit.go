@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Outcomes recorded in audit events.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomeDenied  = "denied"
+)
+
+// AuditEvent records a single mutating call to the OPI API. DryRun is set
+// for requests that only rendered the Kubernetes objects and changed
+// nothing.
+type AuditEvent struct {
+	Time           time.Time         `json:"time"`
+	RequestID      string            `json:"request_id"`
+	ClientSubject  string            `json:"client_subject"`
+	Method         string            `json:"method"`
+	Route          string            `json:"route"`
+	DryRun         bool              `json:"dry_run"`
+	GUIDs          map[string]string `json:"guids"`
+	StatusCode     int               `json:"status_code"`
+	Outcome        string            `json:"outcome"`
+	LatencySeconds float64           `json:"latency_seconds"`
+}
+
+// AuditLog writes an AuditEvent per mutating request as a line of JSON. A
+// nil *AuditLog is valid and records nothing.
+type AuditLog struct {
+	logger lager.Logger
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+func NewAuditLog(logger lager.Logger, writer io.Writer) *AuditLog {
+	return &AuditLog{
+		logger: logger,
+		writer: writer,
+	}
+}
+
+func (a *AuditLog) Record(event AuditEvent) {
+	line, err := json.Marshal(event)
+	if err != nil {
+		a.logger.Error("failed-to-encode-audit-event", err)
+
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if _, err := a.writer.Write(append(line, '\n')); err != nil {
+		a.logger.Error("failed-to-write-audit-event", err, lager.Data{"request-id": event.RequestID})
+	}
+}
+
+// auditRoute records an event for every request to a mutating route. Reads
+// are not audited.
+func (a *AuditLog) auditRoute(method, route string, handle httprouter.Handle) httprouter.Handle {
+	if a == nil || method == http.MethodGet {
+		return handle
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: resp, status: http.StatusOK}
+
+		handle(recorder, req, ps)
+
+		a.Record(AuditEvent{
+			Time:           start.UTC(),
+			RequestID:      req.Header.Get(RequestIDHeader),
+			ClientSubject:  clientSubject(req),
+			Method:         method,
+			Route:          route,
+			DryRun:         auditedDryRun(req),
+			GUIDs:          guids(ps),
+			StatusCode:     recorder.status,
+			Outcome:        outcome(recorder.status),
+			LatencySeconds: time.Since(start).Seconds(),
+		})
+	}
+}
+
+// auditedDryRun treats an invalid dry run parameter as a real request, which
+// the handler rejects.
+func auditedDryRun(req *http.Request) bool {
+	dryRun, err := isDryRun(req)
+
+	return err == nil && dryRun
+}
+
+func clientSubject(req *http.Request) string {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return ""
+	}
+
+	return req.TLS.PeerCertificates[0].Subject.String()
+}
+
+func guids(ps httprouter.Params) map[string]string {
+	result := map[string]string{}
+
+	for _, p := range ps {
+		if strings.HasSuffix(p.Key, "_guid") {
+			result[p.Key] = p.Value
+		}
+	}
+
+	return result
+}
+
+func outcome(status int) string {
+	switch {
+	case status == http.StatusForbidden:
+		return AuditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		return AuditOutcomeFailure
+	default:
+		return AuditOutcomeSuccess
+	}
+}
@@ -0,0 +1,215 @@
+package handler_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/eirini/handler/handlerfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Audit", func() {
+	var (
+		handler     http.Handler
+		auditBuffer *bytes.Buffer
+		allowlist   ClientAllowlist
+		lrpBifrost  *handlerfakes.FakeLRPBifrost
+		taskBifrost *handlerfakes.FakeTaskBifrost
+		commonName  string
+		method      string
+		path        string
+		body        string
+		resp        *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		auditBuffer = new(bytes.Buffer)
+		allowlist = nil
+		lrpBifrost = new(handlerfakes.FakeLRPBifrost)
+		taskBifrost = new(handlerfakes.FakeTaskBifrost)
+		commonName = "cloud-controller"
+		method = http.MethodPut
+		path = "/apps/app-guid/version-guid/stop"
+		body = ""
+	})
+
+	JustBeforeEach(func() {
+		logger := lagertest.NewTestLogger("audit-test")
+		stagingBifrost := new(handlerfakes.FakeStagingBifrost)
+		handler = New(lrpBifrost, stagingBifrost, stagingBifrost, taskBifrost, logger, nil, 0, NewAuditLog(logger, auditBuffer), allowlist)
+
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(RequestIDHeader, "request-id")
+		req.TLS = &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{
+				{Subject: pkix.Name{CommonName: commonName, Organization: []string{"cf"}}},
+			},
+		}
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+	})
+
+	auditEvents := func() []AuditEvent {
+		events := []AuditEvent{}
+		decoder := json.NewDecoder(auditBuffer)
+
+		for decoder.More() {
+			var event AuditEvent
+			Expect(decoder.Decode(&event)).To(Succeed())
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	It("records the mutating request", func() {
+		events := auditEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].RequestID).To(Equal("request-id"))
+		Expect(events[0].ClientSubject).To(Equal("CN=cloud-controller,O=cf"))
+		Expect(events[0].Method).To(Equal(http.MethodPut))
+		Expect(events[0].Route).To(Equal("/apps/:process_guid/:version_guid/stop"))
+		Expect(events[0].GUIDs).To(Equal(map[string]string{"process_guid": "app-guid", "version_guid": "version-guid"}))
+		Expect(events[0].StatusCode).To(Equal(http.StatusOK))
+		Expect(events[0].Outcome).To(Equal(AuditOutcomeSuccess))
+		Expect(events[0].LatencySeconds).To(BeNumerically(">=", 0))
+		Expect(events[0].Time).NotTo(BeZero())
+	})
+
+	When("the request fails", func() {
+		BeforeEach(func() {
+			lrpBifrost.StopReturns(errors.New("boom"))
+		})
+
+		It("records the failure", func() {
+			events := auditEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(events[0].Outcome).To(Equal(AuditOutcomeFailure))
+		})
+	})
+
+	When("the request is a read", func() {
+		BeforeEach(func() {
+			method = http.MethodGet
+			path = "/apps"
+		})
+
+		It("does not record it", func() {
+			Expect(auditEvents()).To(BeEmpty())
+		})
+	})
+
+	It("does not mark the request as a dry run", func() {
+		events := auditEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].DryRun).To(BeFalse())
+	})
+
+	When("the request is a dry run", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+			path = "/tasks/task-guid?dry_run=true"
+			body = `{"app_guid": "app-guid"}`
+		})
+
+		It("records it as a dry run", func() {
+			Expect(taskBifrost.RenderTaskCallCount()).To(Equal(1))
+			Expect(taskBifrost.TransferTaskCallCount()).To(BeZero())
+
+			events := auditEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Route).To(Equal("/tasks/:task_guid"))
+			Expect(events[0].DryRun).To(BeTrue())
+		})
+	})
+
+	When("the request cancels a task", func() {
+		BeforeEach(func() {
+			method = http.MethodDelete
+			path = "/tasks/task-guid"
+		})
+
+		It("records the task GUID", func() {
+			events := auditEvents()
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].GUIDs).To(Equal(map[string]string{"task_guid": "task-guid"}))
+		})
+	})
+
+	When("the endpoint group has an allowlist", func() {
+		BeforeEach(func() {
+			var err error
+			allowlist, err = NewClientAllowlist(map[string][]string{"apps": {"cloud-controller"}})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("serves allowlisted clients", func() {
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(lrpBifrost.StopCallCount()).To(Equal(1))
+		})
+
+		When("the client is not allowlisted", func() {
+			BeforeEach(func() {
+				commonName = "someone-else"
+			})
+
+			It("rejects the request", func() {
+				Expect(resp.Code).To(Equal(http.StatusForbidden))
+				Expect(resp.Body.String()).To(ContainSubstring(ErrorCodeForbidden))
+				Expect(lrpBifrost.StopCallCount()).To(Equal(0))
+			})
+
+			It("records the denial", func() {
+				events := auditEvents()
+				Expect(events).To(HaveLen(1))
+				Expect(events[0].ClientSubject).To(Equal("CN=someone-else,O=cf"))
+				Expect(events[0].Outcome).To(Equal(AuditOutcomeDenied))
+			})
+		})
+
+		When("the client is not allowlisted for reads", func() {
+			BeforeEach(func() {
+				commonName = "someone-else"
+				method = http.MethodGet
+				path = "/apps"
+			})
+
+			It("rejects the request", func() {
+				Expect(resp.Code).To(Equal(http.StatusForbidden))
+				Expect(lrpBifrost.ListCallCount()).To(Equal(0))
+			})
+		})
+
+		When("another endpoint group is called", func() {
+			BeforeEach(func() {
+				commonName = "someone-else"
+				method = http.MethodDelete
+				path = "/tasks/task-guid"
+			})
+
+			It("serves the request", func() {
+				Expect(resp.Code).To(Equal(http.StatusNoContent))
+				Expect(taskBifrost.CancelTaskCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("NewClientAllowlist", func() {
+		It("rejects unknown endpoint groups", func() {
+			_, err := NewClientAllowlist(map[string][]string{"droplets": {"cloud-controller"}})
+			Expect(err).To(MatchError(ContainSubstring(`unknown endpoint group "droplets"`)))
+		})
+	})
+})
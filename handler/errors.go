@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/lager"
+	"github.com/hashicorp/go-uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+// RequestIDHeader carries the ID of a request. CC sets it on every request it
+// makes; requests without one get a generated ID. Either way it is echoed in
+// the response and in error bodies.
+const RequestIDHeader = "X-Vcap-Request-Id"
+
+// Error codes returned in the code field of error responses.
+const (
+	ErrorCodeBadRequest           = "BadRequest"
+	ErrorCodeForbidden            = "Forbidden"
+	ErrorCodeNotFound             = "NotFound"
+	ErrorCodeConflict             = "Conflict"
+	ErrorCodeInvalidRequest       = "InvalidRequest"
+	ErrorCodeInvalidInstanceIndex = "InvalidInstanceIndex"
+	ErrorCodeTimeout              = "Timeout"
+	ErrorCodeInternal             = "InternalError"
+)
+
+// statusForError maps the eirini sentinel errors, possibly wrapped, to a
+// status code and an error code. Requests that ran out of time are reported
+// as gateway timeouts. Anything else is an internal error.
+func statusForError(err error) (int, string) {
+	switch {
+	case errors.Is(err, eirini.ErrNotFound):
+		return http.StatusNotFound, ErrorCodeNotFound
+	case errors.Is(err, eirini.ErrConflict):
+		return http.StatusConflict, ErrorCodeConflict
+	case errors.Is(err, eirini.ErrInvalidRequest):
+		return http.StatusUnprocessableEntity, ErrorCodeInvalidRequest
+	case errors.Is(err, eirini.ErrInvalidInstanceIndex):
+		return http.StatusUnprocessableEntity, ErrorCodeInvalidInstanceIndex
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, ErrorCodeTimeout
+	default:
+		return http.StatusInternalServerError, ErrorCodeInternal
+	}
+}
+
+// writeErrorResponse responds with the status code matching err.
+func writeErrorResponse(logger lager.Logger, resp http.ResponseWriter, req *http.Request, err error) {
+	status, code := statusForError(err)
+	writeError(logger, resp, req, status, code, err)
+}
+
+func writeBadRequestResponse(logger lager.Logger, resp http.ResponseWriter, req *http.Request, err error) {
+	writeError(logger, resp, req, http.StatusBadRequest, ErrorCodeBadRequest, err)
+}
+
+func writeError(logger lager.Logger, resp http.ResponseWriter, req *http.Request, status int, code string, err error) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+
+	encodingErr := json.NewEncoder(resp).Encode(newError(req, code, err))
+	if encodingErr != nil {
+		logger.Error("failed-to-encode-error", encodingErr)
+	}
+}
+
+func newError(req *http.Request, code string, err error) cf.Error {
+	return cf.Error{
+		Code:      code,
+		Message:   err.Error(),
+		RequestID: req.Header.Get(RequestIDHeader),
+	}
+}
+
+// withRequestID makes sure the request carries an ID and echoes it in the
+// response.
+func withRequestID(handle httprouter.Handle) httprouter.Handle {
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		requestID := req.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID, _ = uuid.GenerateUUID()
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+
+		resp.Header().Set(RequestIDHeader, requestID)
+		handle(resp, req, ps)
+	}
+}
@@ -2,12 +2,15 @@ package handler
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
 	"code.cloudfoundry.org/lager"
 	"github.com/julienschmidt/httprouter"
+	corev1 "k8s.io/api/core/v1"
 )
 
 //counterfeiter:generate . LRPBifrost
@@ -16,34 +19,64 @@ import (
 
 type LRPBifrost interface {
 	Transfer(ctx context.Context, request cf.DesireLRPRequest) error
+	Render(ctx context.Context, request cf.DesireLRPRequest) (*corev1.List, error)
 	List(ctx context.Context) ([]cf.DesiredLRPSchedulingInfo, error)
 	Update(ctx context.Context, update cf.UpdateDesiredLRPRequest) error
 	Stop(ctx context.Context, identifier opi.LRPIdentifier) error
 	StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error
+	Restart(ctx context.Context, identifier opi.LRPIdentifier) error
 	GetApp(ctx context.Context, identifier opi.LRPIdentifier) (cf.DesiredLRP, error)
 	GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.Instance, error)
+	GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.InstanceStats, error)
+	GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error)
 }
 
 type TaskBifrost interface {
-	GetTask(taskGUID string) (cf.TaskResponse, error)
-	ListTasks() (cf.TasksResponse, error)
+	GetTask(ctx context.Context, taskGUID string) (cf.TaskResponse, error)
+	ListTasks(ctx context.Context, filter cf.TasksFilter) (cf.TasksResponse, error)
 	TransferTask(ctx context.Context, taskGUID string, request cf.TaskRequest) error
-	CancelTask(taskGUID string) error
+	RenderTask(ctx context.Context, taskGUID string, request cf.TaskRequest) (*corev1.List, error)
+	GetTaskLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error)
+	CancelTask(ctx context.Context, taskGUID string) error
 }
 
 type StagingBifrost interface {
 	TransferStaging(ctx context.Context, stagingGUID string, request cf.StagingRequest) error
-	CompleteStaging(cf.StagingCompletedRequest) error
+	CompleteStaging(ctx context.Context, request cf.StagingCompletedRequest) error
 }
 
 func New(lrpBifrost LRPBifrost,
 	dockerStagingBifrost StagingBifrost,
+	buildpackStagingBifrost StagingBifrost,
 	taskBifrost TaskBifrost,
-	lager lager.Logger) http.Handler {
-	handler := httprouter.New()
+	lager lager.Logger,
+	metrics *Metrics,
+	requestTimeout time.Duration,
+	auditLog *AuditLog,
+	allowlist ClientAllowlist) http.Handler {
+	if metrics != nil {
+		lrpBifrost = instrumentedLRPBifrost{delegate: lrpBifrost, metrics: metrics}
+		dockerStagingBifrost = instrumentedStagingBifrost{name: "docker_staging", delegate: dockerStagingBifrost, metrics: metrics}
+		buildpackStagingBifrost = instrumentedStagingBifrost{name: "buildpack_staging", delegate: buildpackStagingBifrost, metrics: metrics}
+		taskBifrost = instrumentedTaskBifrost{delegate: taskBifrost, metrics: metrics}
+	}
+
+	lrpBifrost = tracedLRPBifrost{delegate: lrpBifrost}
+	dockerStagingBifrost = tracedStagingBifrost{name: "docker_staging", delegate: dockerStagingBifrost}
+	buildpackStagingBifrost = tracedStagingBifrost{name: "buildpack_staging", delegate: buildpackStagingBifrost}
+	taskBifrost = tracedTaskBifrost{delegate: taskBifrost}
+
+	handler := &router{
+		Router:         httprouter.New(),
+		logger:         lager,
+		metrics:        metrics,
+		requestTimeout: requestTimeout,
+		auditLog:       auditLog,
+		allowlist:      allowlist,
+	}
 
 	appHandler := NewAppHandler(lrpBifrost, lager)
-	stageHandler := NewStageHandler(dockerStagingBifrost, lager)
+	stageHandler := NewStageHandler(dockerStagingBifrost, buildpackStagingBifrost, lager)
 	taskHandler := NewTaskHandler(lager, taskBifrost)
 
 	registerAppsEndpoints(handler, appHandler)
@@ -53,23 +86,79 @@ func New(lrpBifrost LRPBifrost,
 	return handler
 }
 
-func registerAppsEndpoints(handler *httprouter.Router, appHandler *App) {
-	handler.GET("/apps", appHandler.List)
-	handler.PUT("/apps/:process_guid", appHandler.Desire)
-	handler.POST("/apps/:process_guid", appHandler.Update)
-	handler.PUT("/apps/:process_guid/:version_guid/stop", appHandler.Stop)
-	handler.PUT("/apps/:process_guid/:version_guid/stop/:instance", appHandler.StopInstance)
-	handler.GET("/apps/:process_guid/:version_guid/instances", appHandler.GetInstances)
-	handler.GET("/apps/:process_guid/:version_guid", appHandler.Get)
+// router registers every route with the metrics, so that requests are
+// recorded against the route pattern rather than the requested path. Each
+// request is traced and its context is given the configured deadline, so
+// that the Kubernetes calls made on its behalf are cancelled once it expires
+// or the client goes away. Mutating requests are audited, including those
+// rejected by the client allowlist.
+type router struct {
+	*httprouter.Router
+	logger         lager.Logger
+	metrics        *Metrics
+	requestTimeout time.Duration
+	auditLog       *AuditLog
+	allowlist      ClientAllowlist
+}
+
+func (r *router) Handle(method, path string, handle httprouter.Handle) {
+	r.handle(method, path, withTimeout(r.requestTimeout, handle))
+}
+
+// HandleStream registers a route that streams its response, such as followed
+// logs, without the request deadline. streamLogs also clears the server write
+// timeout, so the stream only ends when it is complete or the client goes away.
+func (r *router) HandleStream(method, path string, handle httprouter.Handle) {
+	r.handle(method, path, handle)
+}
+
+func (r *router) handle(method, path string, handle httprouter.Handle) {
+	handle = r.allowlist.restrictRoute(r.logger, path, handle)
+	handle = r.auditLog.auditRoute(method, path, handle)
+	handle = withTracing(method, path, handle)
+	handle = withRequestID(handle)
+	handle = r.metrics.instrumentRoute(method, path, handle)
+
+	r.Router.Handle(method, path, handle)
+}
+
+// withTimeout sets a deadline on the request context. A zero timeout leaves
+// the context untouched.
+func withTimeout(timeout time.Duration, handle httprouter.Handle) httprouter.Handle {
+	if timeout <= 0 {
+		return handle
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		handle(resp, req.WithContext(ctx), ps)
+	}
+}
+
+func registerAppsEndpoints(handler *router, appHandler *App) {
+	handler.Handle(http.MethodGet, "/apps", appHandler.List)
+	handler.Handle(http.MethodPut, "/apps/:process_guid", appHandler.Desire)
+	handler.Handle(http.MethodPost, "/apps/:process_guid", appHandler.Update)
+	handler.Handle(http.MethodPut, "/apps/:process_guid/:version_guid/stop", appHandler.Stop)
+	handler.Handle(http.MethodPut, "/apps/:process_guid/:version_guid/stop/:instance", appHandler.StopInstance)
+	handler.Handle(http.MethodPost, "/apps/:process_guid/:version_guid/restart", appHandler.Restart)
+	handler.Handle(http.MethodGet, "/apps/:process_guid/:version_guid/instances", appHandler.GetInstances)
+	handler.Handle(http.MethodGet, "/apps/:process_guid/:version_guid/stats", appHandler.GetStats)
+	handler.HandleStream(http.MethodGet, "/apps/:process_guid/:version_guid/logs", appHandler.GetLogs)
+	handler.Handle(http.MethodGet, "/apps/:process_guid/:version_guid", appHandler.Get)
 }
 
-func registerStageEndpoint(handler *httprouter.Router, stageHandler *Stage) {
-	handler.POST("/stage/:staging_guid", stageHandler.Run)
+func registerStageEndpoint(handler *router, stageHandler *Stage) {
+	handler.Handle(http.MethodPost, "/stage/:staging_guid", stageHandler.Run)
+	handler.Handle(http.MethodPut, "/stage/:staging_guid/completed", stageHandler.Complete)
 }
 
-func registerTaskEndpoints(handler *httprouter.Router, taskHandler *Task) {
-	handler.GET("/tasks", taskHandler.List)
-	handler.GET("/tasks/:task_guid", taskHandler.Get)
-	handler.POST("/tasks/:task_guid", taskHandler.Run)
-	handler.DELETE("/tasks/:task_guid", taskHandler.Cancel)
+func registerTaskEndpoints(handler *router, taskHandler *Task) {
+	handler.Handle(http.MethodGet, "/tasks", taskHandler.List)
+	handler.Handle(http.MethodGet, "/tasks/:task_guid", taskHandler.Get)
+	handler.HandleStream(http.MethodGet, "/tasks/:task_guid/logs", taskHandler.GetLogs)
+	handler.Handle(http.MethodPost, "/tasks/:task_guid", taskHandler.Run)
+	handler.Handle(http.MethodDelete, "/tasks/:task_guid", taskHandler.Cancel)
 }
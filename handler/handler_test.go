@@ -2,41 +2,54 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "code.cloudfoundry.org/eirini/handler"
 	"code.cloudfoundry.org/eirini/handler/handlerfakes"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 )
 
 var _ = Describe("Handler", func() {
 	var (
-		ts                   *httptest.Server
-		client               *http.Client
-		lrpBifrost           *handlerfakes.FakeLRPBifrost
-		dockerStagingBifrost *handlerfakes.FakeStagingBifrost
-		taskBifrost          *handlerfakes.FakeTaskBifrost
-		handlerClient        http.Handler
+		ts                      *httptest.Server
+		client                  *http.Client
+		lrpBifrost              *handlerfakes.FakeLRPBifrost
+		dockerStagingBifrost    *handlerfakes.FakeStagingBifrost
+		buildpackStagingBifrost *handlerfakes.FakeStagingBifrost
+		taskBifrost             *handlerfakes.FakeTaskBifrost
+		handlerClient           http.Handler
+		requestTimeout          time.Duration
 	)
 
 	BeforeEach(func() {
 		client = &http.Client{}
 		lrpBifrost = new(handlerfakes.FakeLRPBifrost)
 		dockerStagingBifrost = new(handlerfakes.FakeStagingBifrost)
+		buildpackStagingBifrost = new(handlerfakes.FakeStagingBifrost)
 		taskBifrost = new(handlerfakes.FakeTaskBifrost)
 
-		lager := lagertest.NewTestLogger("handler-test")
-		handlerClient = New(lrpBifrost, dockerStagingBifrost, taskBifrost, lager)
+		requestTimeout = 0
 	})
 
 	JustBeforeEach(func() {
+		lager := lagertest.NewTestLogger("handler-test")
+		handlerClient = New(lrpBifrost, dockerStagingBifrost, buildpackStagingBifrost, taskBifrost, lager, nil, requestTimeout, nil, nil)
 		ts = httptest.NewServer(handlerClient)
 	})
 
+	AfterEach(func() {
+		ts.Close()
+	})
+
 	Context("Routes", func() {
 		var (
 			method         string
@@ -156,6 +169,18 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("PUT /stage/:staging_guid/completed", func() {
+			BeforeEach(func() {
+				method = "PUT"
+				path = "/stage/stage_123/completed"
+				expectedStatus = http.StatusOK
+			})
+
+			It("serves the endpoint", func() {
+				assertEndpoint()
+			})
+		})
+
 		Context("POST /tasks/:id", func() {
 			BeforeEach(func() {
 				method = "POST"
@@ -185,4 +210,56 @@ var _ = Describe("Handler", func() {
 			})
 		})
 	})
+
+	Context("Request deadlines", func() {
+		var resp *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = client.Get(ts.URL + "/apps/guid/version")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(resp.Body.Close()).To(Succeed())
+		})
+
+		It("does not set a deadline by default", func() {
+			ctx, _ := lrpBifrost.GetAppArgsForCall(0)
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeFalse())
+		})
+
+		When("a request timeout is configured", func() {
+			BeforeEach(func() {
+				requestTimeout = time.Minute
+			})
+
+			It("passes a context with the deadline to the bifrost", func() {
+				ctx, _ := lrpBifrost.GetAppArgsForCall(0)
+				deadline, hasDeadline := ctx.Deadline()
+				Expect(hasDeadline).To(BeTrue())
+				Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+			})
+		})
+
+		When("the deadline is exceeded", func() {
+			BeforeEach(func() {
+				requestTimeout = 10 * time.Millisecond
+				lrpBifrost.GetAppStub = func(ctx context.Context, _ opi.LRPIdentifier) (cf.DesiredLRP, error) {
+					<-ctx.Done()
+
+					return cf.DesiredLRP{}, errors.Wrap(ctx.Err(), "failed to get app")
+				}
+			})
+
+			It("responds with a gateway timeout", func() {
+				Expect(resp.StatusCode).To(Equal(http.StatusGatewayTimeout))
+
+				var body cf.Error
+				Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+				Expect(body.Code).To(Equal(ErrorCodeTimeout))
+			})
+		})
+	})
 })
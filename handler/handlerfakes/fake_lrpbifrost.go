@@ -3,11 +3,13 @@ package handlerfakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/handler"
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/opi"
+	v1 "k8s.io/api/core/v1"
 )
 
 type FakeLRPBifrost struct {
@@ -25,6 +27,20 @@ type FakeLRPBifrost struct {
 		result1 cf.DesiredLRP
 		result2 error
 	}
+	GetInstanceStatsStub        func(context.Context, opi.LRPIdentifier) ([]*cf.InstanceStats, error)
+	getInstanceStatsMutex       sync.RWMutex
+	getInstanceStatsArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}
+	getInstanceStatsReturns struct {
+		result1 []*cf.InstanceStats
+		result2 error
+	}
+	getInstanceStatsReturnsOnCall map[int]struct {
+		result1 []*cf.InstanceStats
+		result2 error
+	}
 	GetInstancesStub        func(context.Context, opi.LRPIdentifier) ([]*cf.Instance, error)
 	getInstancesMutex       sync.RWMutex
 	getInstancesArgsForCall []struct {
@@ -39,6 +55,21 @@ type FakeLRPBifrost struct {
 		result1 []*cf.Instance
 		result2 error
 	}
+	GetLogsStub        func(context.Context, opi.LRPIdentifier, opi.LogOptions) (io.ReadCloser, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 opi.LogOptions
+	}
+	getLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	ListStub        func(context.Context) ([]cf.DesiredLRPSchedulingInfo, error)
 	listMutex       sync.RWMutex
 	listArgsForCall []struct {
@@ -52,6 +83,32 @@ type FakeLRPBifrost struct {
 		result1 []cf.DesiredLRPSchedulingInfo
 		result2 error
 	}
+	RenderStub        func(context.Context, cf.DesireLRPRequest) (*v1.List, error)
+	renderMutex       sync.RWMutex
+	renderArgsForCall []struct {
+		arg1 context.Context
+		arg2 cf.DesireLRPRequest
+	}
+	renderReturns struct {
+		result1 *v1.List
+		result2 error
+	}
+	renderReturnsOnCall map[int]struct {
+		result1 *v1.List
+		result2 error
+	}
+	RestartStub        func(context.Context, opi.LRPIdentifier) error
+	restartMutex       sync.RWMutex
+	restartArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}
+	restartReturns struct {
+		result1 error
+	}
+	restartReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StopStub        func(context.Context, opi.LRPIdentifier) error
 	stopMutex       sync.RWMutex
 	stopArgsForCall []struct {
@@ -170,6 +227,71 @@ func (fake *FakeLRPBifrost) GetAppReturnsOnCall(i int, result1 cf.DesiredLRP, re
 	}{result1, result2}
 }
 
+func (fake *FakeLRPBifrost) GetInstanceStats(arg1 context.Context, arg2 opi.LRPIdentifier) ([]*cf.InstanceStats, error) {
+	fake.getInstanceStatsMutex.Lock()
+	ret, specificReturn := fake.getInstanceStatsReturnsOnCall[len(fake.getInstanceStatsArgsForCall)]
+	fake.getInstanceStatsArgsForCall = append(fake.getInstanceStatsArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
+	stub := fake.GetInstanceStatsStub
+	fakeReturns := fake.getInstanceStatsReturns
+	fake.recordInvocation("GetInstanceStats", []interface{}{arg1, arg2})
+	fake.getInstanceStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPBifrost) GetInstanceStatsCallCount() int {
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
+	return len(fake.getInstanceStatsArgsForCall)
+}
+
+func (fake *FakeLRPBifrost) GetInstanceStatsCalls(stub func(context.Context, opi.LRPIdentifier) ([]*cf.InstanceStats, error)) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = stub
+}
+
+func (fake *FakeLRPBifrost) GetInstanceStatsArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
+	argsForCall := fake.getInstanceStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLRPBifrost) GetInstanceStatsReturns(result1 []*cf.InstanceStats, result2 error) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = nil
+	fake.getInstanceStatsReturns = struct {
+		result1 []*cf.InstanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPBifrost) GetInstanceStatsReturnsOnCall(i int, result1 []*cf.InstanceStats, result2 error) {
+	fake.getInstanceStatsMutex.Lock()
+	defer fake.getInstanceStatsMutex.Unlock()
+	fake.GetInstanceStatsStub = nil
+	if fake.getInstanceStatsReturnsOnCall == nil {
+		fake.getInstanceStatsReturnsOnCall = make(map[int]struct {
+			result1 []*cf.InstanceStats
+			result2 error
+		})
+	}
+	fake.getInstanceStatsReturnsOnCall[i] = struct {
+		result1 []*cf.InstanceStats
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLRPBifrost) GetInstances(arg1 context.Context, arg2 opi.LRPIdentifier) ([]*cf.Instance, error) {
 	fake.getInstancesMutex.Lock()
 	ret, specificReturn := fake.getInstancesReturnsOnCall[len(fake.getInstancesArgsForCall)]
@@ -235,6 +357,72 @@ func (fake *FakeLRPBifrost) GetInstancesReturnsOnCall(i int, result1 []*cf.Insta
 	}{result1, result2}
 }
 
+func (fake *FakeLRPBifrost) GetLogs(arg1 context.Context, arg2 opi.LRPIdentifier, arg3 opi.LogOptions) (io.ReadCloser, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+		arg3 opi.LogOptions
+	}{arg1, arg2, arg3})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2, arg3})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPBifrost) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *FakeLRPBifrost) GetLogsCalls(stub func(context.Context, opi.LRPIdentifier, opi.LogOptions) (io.ReadCloser, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *FakeLRPBifrost) GetLogsArgsForCall(i int) (context.Context, opi.LRPIdentifier, opi.LogOptions) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeLRPBifrost) GetLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPBifrost) GetLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeLRPBifrost) List(arg1 context.Context) ([]cf.DesiredLRPSchedulingInfo, error) {
 	fake.listMutex.Lock()
 	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
@@ -299,6 +487,133 @@ func (fake *FakeLRPBifrost) ListReturnsOnCall(i int, result1 []cf.DesiredLRPSche
 	}{result1, result2}
 }
 
+func (fake *FakeLRPBifrost) Render(arg1 context.Context, arg2 cf.DesireLRPRequest) (*v1.List, error) {
+	fake.renderMutex.Lock()
+	ret, specificReturn := fake.renderReturnsOnCall[len(fake.renderArgsForCall)]
+	fake.renderArgsForCall = append(fake.renderArgsForCall, struct {
+		arg1 context.Context
+		arg2 cf.DesireLRPRequest
+	}{arg1, arg2})
+	stub := fake.RenderStub
+	fakeReturns := fake.renderReturns
+	fake.recordInvocation("Render", []interface{}{arg1, arg2})
+	fake.renderMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLRPBifrost) RenderCallCount() int {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	return len(fake.renderArgsForCall)
+}
+
+func (fake *FakeLRPBifrost) RenderCalls(stub func(context.Context, cf.DesireLRPRequest) (*v1.List, error)) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = stub
+}
+
+func (fake *FakeLRPBifrost) RenderArgsForCall(i int) (context.Context, cf.DesireLRPRequest) {
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	argsForCall := fake.renderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLRPBifrost) RenderReturns(result1 *v1.List, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	fake.renderReturns = struct {
+		result1 *v1.List
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPBifrost) RenderReturnsOnCall(i int, result1 *v1.List, result2 error) {
+	fake.renderMutex.Lock()
+	defer fake.renderMutex.Unlock()
+	fake.RenderStub = nil
+	if fake.renderReturnsOnCall == nil {
+		fake.renderReturnsOnCall = make(map[int]struct {
+			result1 *v1.List
+			result2 error
+		})
+	}
+	fake.renderReturnsOnCall[i] = struct {
+		result1 *v1.List
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLRPBifrost) Restart(arg1 context.Context, arg2 opi.LRPIdentifier) error {
+	fake.restartMutex.Lock()
+	ret, specificReturn := fake.restartReturnsOnCall[len(fake.restartArgsForCall)]
+	fake.restartArgsForCall = append(fake.restartArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
+	stub := fake.RestartStub
+	fakeReturns := fake.restartReturns
+	fake.recordInvocation("Restart", []interface{}{arg1, arg2})
+	fake.restartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeLRPBifrost) RestartCallCount() int {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	return len(fake.restartArgsForCall)
+}
+
+func (fake *FakeLRPBifrost) RestartCalls(stub func(context.Context, opi.LRPIdentifier) error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = stub
+}
+
+func (fake *FakeLRPBifrost) RestartArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
+	argsForCall := fake.restartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLRPBifrost) RestartReturns(result1 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	fake.restartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLRPBifrost) RestartReturnsOnCall(i int, result1 error) {
+	fake.restartMutex.Lock()
+	defer fake.restartMutex.Unlock()
+	fake.RestartStub = nil
+	if fake.restartReturnsOnCall == nil {
+		fake.restartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.restartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeLRPBifrost) Stop(arg1 context.Context, arg2 opi.LRPIdentifier) error {
 	fake.stopMutex.Lock()
 	ret, specificReturn := fake.stopReturnsOnCall[len(fake.stopArgsForCall)]
@@ -553,10 +868,18 @@ func (fake *FakeLRPBifrost) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.getAppMutex.RLock()
 	defer fake.getAppMutex.RUnlock()
+	fake.getInstanceStatsMutex.RLock()
+	defer fake.getInstanceStatsMutex.RUnlock()
 	fake.getInstancesMutex.RLock()
 	defer fake.getInstancesMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
+	fake.renderMutex.RLock()
+	defer fake.renderMutex.RUnlock()
+	fake.restartMutex.RLock()
+	defer fake.restartMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
 	fake.stopInstanceMutex.RLock()
@@ -10,10 +10,11 @@ import (
 )
 
 type FakeStagingBifrost struct {
-	CompleteStagingStub        func(cf.StagingCompletedRequest) error
+	CompleteStagingStub        func(context.Context, cf.StagingCompletedRequest) error
 	completeStagingMutex       sync.RWMutex
 	completeStagingArgsForCall []struct {
-		arg1 cf.StagingCompletedRequest
+		arg1 context.Context
+		arg2 cf.StagingCompletedRequest
 	}
 	completeStagingReturns struct {
 		result1 error
@@ -38,18 +39,19 @@ type FakeStagingBifrost struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStagingBifrost) CompleteStaging(arg1 cf.StagingCompletedRequest) error {
+func (fake *FakeStagingBifrost) CompleteStaging(arg1 context.Context, arg2 cf.StagingCompletedRequest) error {
 	fake.completeStagingMutex.Lock()
 	ret, specificReturn := fake.completeStagingReturnsOnCall[len(fake.completeStagingArgsForCall)]
 	fake.completeStagingArgsForCall = append(fake.completeStagingArgsForCall, struct {
-		arg1 cf.StagingCompletedRequest
-	}{arg1})
+		arg1 context.Context
+		arg2 cf.StagingCompletedRequest
+	}{arg1, arg2})
 	stub := fake.CompleteStagingStub
 	fakeReturns := fake.completeStagingReturns
-	fake.recordInvocation("CompleteStaging", []interface{}{arg1})
+	fake.recordInvocation("CompleteStaging", []interface{}{arg1, arg2})
 	fake.completeStagingMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -63,17 +65,17 @@ func (fake *FakeStagingBifrost) CompleteStagingCallCount() int {
 	return len(fake.completeStagingArgsForCall)
 }
 
-func (fake *FakeStagingBifrost) CompleteStagingCalls(stub func(cf.StagingCompletedRequest) error) {
+func (fake *FakeStagingBifrost) CompleteStagingCalls(stub func(context.Context, cf.StagingCompletedRequest) error) {
 	fake.completeStagingMutex.Lock()
 	defer fake.completeStagingMutex.Unlock()
 	fake.CompleteStagingStub = stub
 }
 
-func (fake *FakeStagingBifrost) CompleteStagingArgsForCall(i int) cf.StagingCompletedRequest {
+func (fake *FakeStagingBifrost) CompleteStagingArgsForCall(i int) (context.Context, cf.StagingCompletedRequest) {
 	fake.completeStagingMutex.RLock()
 	defer fake.completeStagingMutex.RUnlock()
 	argsForCall := fake.completeStagingArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStagingBifrost) CompleteStagingReturns(result1 error) {
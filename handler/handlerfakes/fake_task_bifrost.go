@@ -3,17 +3,21 @@ package handlerfakes
 
 import (
 	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/handler"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
+	v1 "k8s.io/api/core/v1"
 )
 
 type FakeTaskBifrost struct {
-	CancelTaskStub        func(string) error
+	CancelTaskStub        func(context.Context, string) error
 	cancelTaskMutex       sync.RWMutex
 	cancelTaskArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	cancelTaskReturns struct {
 		result1 error
@@ -21,10 +25,11 @@ type FakeTaskBifrost struct {
 	cancelTaskReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetTaskStub        func(string) (cf.TaskResponse, error)
+	GetTaskStub        func(context.Context, string) (cf.TaskResponse, error)
 	getTaskMutex       sync.RWMutex
 	getTaskArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getTaskReturns struct {
 		result1 cf.TaskResponse
@@ -34,9 +39,26 @@ type FakeTaskBifrost struct {
 		result1 cf.TaskResponse
 		result2 error
 	}
-	ListTasksStub        func() (cf.TasksResponse, error)
+	GetTaskLogsStub        func(context.Context, string, opi.LogOptions) (io.ReadCloser, error)
+	getTaskLogsMutex       sync.RWMutex
+	getTaskLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 opi.LogOptions
+	}
+	getTaskLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getTaskLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	ListTasksStub        func(context.Context, cf.TasksFilter) (cf.TasksResponse, error)
 	listTasksMutex       sync.RWMutex
 	listTasksArgsForCall []struct {
+		arg1 context.Context
+		arg2 cf.TasksFilter
 	}
 	listTasksReturns struct {
 		result1 cf.TasksResponse
@@ -46,6 +68,21 @@ type FakeTaskBifrost struct {
 		result1 cf.TasksResponse
 		result2 error
 	}
+	RenderTaskStub        func(context.Context, string, cf.TaskRequest) (*v1.List, error)
+	renderTaskMutex       sync.RWMutex
+	renderTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 cf.TaskRequest
+	}
+	renderTaskReturns struct {
+		result1 *v1.List
+		result2 error
+	}
+	renderTaskReturnsOnCall map[int]struct {
+		result1 *v1.List
+		result2 error
+	}
 	TransferTaskStub        func(context.Context, string, cf.TaskRequest) error
 	transferTaskMutex       sync.RWMutex
 	transferTaskArgsForCall []struct {
@@ -63,18 +100,19 @@ type FakeTaskBifrost struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTaskBifrost) CancelTask(arg1 string) error {
+func (fake *FakeTaskBifrost) CancelTask(arg1 context.Context, arg2 string) error {
 	fake.cancelTaskMutex.Lock()
 	ret, specificReturn := fake.cancelTaskReturnsOnCall[len(fake.cancelTaskArgsForCall)]
 	fake.cancelTaskArgsForCall = append(fake.cancelTaskArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.CancelTaskStub
 	fakeReturns := fake.cancelTaskReturns
-	fake.recordInvocation("CancelTask", []interface{}{arg1})
+	fake.recordInvocation("CancelTask", []interface{}{arg1, arg2})
 	fake.cancelTaskMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -88,17 +126,17 @@ func (fake *FakeTaskBifrost) CancelTaskCallCount() int {
 	return len(fake.cancelTaskArgsForCall)
 }
 
-func (fake *FakeTaskBifrost) CancelTaskCalls(stub func(string) error) {
+func (fake *FakeTaskBifrost) CancelTaskCalls(stub func(context.Context, string) error) {
 	fake.cancelTaskMutex.Lock()
 	defer fake.cancelTaskMutex.Unlock()
 	fake.CancelTaskStub = stub
 }
 
-func (fake *FakeTaskBifrost) CancelTaskArgsForCall(i int) string {
+func (fake *FakeTaskBifrost) CancelTaskArgsForCall(i int) (context.Context, string) {
 	fake.cancelTaskMutex.RLock()
 	defer fake.cancelTaskMutex.RUnlock()
 	argsForCall := fake.cancelTaskArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeTaskBifrost) CancelTaskReturns(result1 error) {
@@ -124,18 +162,19 @@ func (fake *FakeTaskBifrost) CancelTaskReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTaskBifrost) GetTask(arg1 string) (cf.TaskResponse, error) {
+func (fake *FakeTaskBifrost) GetTask(arg1 context.Context, arg2 string) (cf.TaskResponse, error) {
 	fake.getTaskMutex.Lock()
 	ret, specificReturn := fake.getTaskReturnsOnCall[len(fake.getTaskArgsForCall)]
 	fake.getTaskArgsForCall = append(fake.getTaskArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetTaskStub
 	fakeReturns := fake.getTaskReturns
-	fake.recordInvocation("GetTask", []interface{}{arg1})
+	fake.recordInvocation("GetTask", []interface{}{arg1, arg2})
 	fake.getTaskMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -149,17 +188,17 @@ func (fake *FakeTaskBifrost) GetTaskCallCount() int {
 	return len(fake.getTaskArgsForCall)
 }
 
-func (fake *FakeTaskBifrost) GetTaskCalls(stub func(string) (cf.TaskResponse, error)) {
+func (fake *FakeTaskBifrost) GetTaskCalls(stub func(context.Context, string) (cf.TaskResponse, error)) {
 	fake.getTaskMutex.Lock()
 	defer fake.getTaskMutex.Unlock()
 	fake.GetTaskStub = stub
 }
 
-func (fake *FakeTaskBifrost) GetTaskArgsForCall(i int) string {
+func (fake *FakeTaskBifrost) GetTaskArgsForCall(i int) (context.Context, string) {
 	fake.getTaskMutex.RLock()
 	defer fake.getTaskMutex.RUnlock()
 	argsForCall := fake.getTaskArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeTaskBifrost) GetTaskReturns(result1 cf.TaskResponse, result2 error) {
@@ -188,17 +227,85 @@ func (fake *FakeTaskBifrost) GetTaskReturnsOnCall(i int, result1 cf.TaskResponse
 	}{result1, result2}
 }
 
-func (fake *FakeTaskBifrost) ListTasks() (cf.TasksResponse, error) {
+func (fake *FakeTaskBifrost) GetTaskLogs(arg1 context.Context, arg2 string, arg3 opi.LogOptions) (io.ReadCloser, error) {
+	fake.getTaskLogsMutex.Lock()
+	ret, specificReturn := fake.getTaskLogsReturnsOnCall[len(fake.getTaskLogsArgsForCall)]
+	fake.getTaskLogsArgsForCall = append(fake.getTaskLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 opi.LogOptions
+	}{arg1, arg2, arg3})
+	stub := fake.GetTaskLogsStub
+	fakeReturns := fake.getTaskLogsReturns
+	fake.recordInvocation("GetTaskLogs", []interface{}{arg1, arg2, arg3})
+	fake.getTaskLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskBifrost) GetTaskLogsCallCount() int {
+	fake.getTaskLogsMutex.RLock()
+	defer fake.getTaskLogsMutex.RUnlock()
+	return len(fake.getTaskLogsArgsForCall)
+}
+
+func (fake *FakeTaskBifrost) GetTaskLogsCalls(stub func(context.Context, string, opi.LogOptions) (io.ReadCloser, error)) {
+	fake.getTaskLogsMutex.Lock()
+	defer fake.getTaskLogsMutex.Unlock()
+	fake.GetTaskLogsStub = stub
+}
+
+func (fake *FakeTaskBifrost) GetTaskLogsArgsForCall(i int) (context.Context, string, opi.LogOptions) {
+	fake.getTaskLogsMutex.RLock()
+	defer fake.getTaskLogsMutex.RUnlock()
+	argsForCall := fake.getTaskLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskBifrost) GetTaskLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getTaskLogsMutex.Lock()
+	defer fake.getTaskLogsMutex.Unlock()
+	fake.GetTaskLogsStub = nil
+	fake.getTaskLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBifrost) GetTaskLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getTaskLogsMutex.Lock()
+	defer fake.getTaskLogsMutex.Unlock()
+	fake.GetTaskLogsStub = nil
+	if fake.getTaskLogsReturnsOnCall == nil {
+		fake.getTaskLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getTaskLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBifrost) ListTasks(arg1 context.Context, arg2 cf.TasksFilter) (cf.TasksResponse, error) {
 	fake.listTasksMutex.Lock()
 	ret, specificReturn := fake.listTasksReturnsOnCall[len(fake.listTasksArgsForCall)]
 	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
-	}{})
+		arg1 context.Context
+		arg2 cf.TasksFilter
+	}{arg1, arg2})
 	stub := fake.ListTasksStub
 	fakeReturns := fake.listTasksReturns
-	fake.recordInvocation("ListTasks", []interface{}{})
+	fake.recordInvocation("ListTasks", []interface{}{arg1, arg2})
 	fake.listTasksMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -212,12 +319,19 @@ func (fake *FakeTaskBifrost) ListTasksCallCount() int {
 	return len(fake.listTasksArgsForCall)
 }
 
-func (fake *FakeTaskBifrost) ListTasksCalls(stub func() (cf.TasksResponse, error)) {
+func (fake *FakeTaskBifrost) ListTasksCalls(stub func(context.Context, cf.TasksFilter) (cf.TasksResponse, error)) {
 	fake.listTasksMutex.Lock()
 	defer fake.listTasksMutex.Unlock()
 	fake.ListTasksStub = stub
 }
 
+func (fake *FakeTaskBifrost) ListTasksArgsForCall(i int) (context.Context, cf.TasksFilter) {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	argsForCall := fake.listTasksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskBifrost) ListTasksReturns(result1 cf.TasksResponse, result2 error) {
 	fake.listTasksMutex.Lock()
 	defer fake.listTasksMutex.Unlock()
@@ -244,6 +358,72 @@ func (fake *FakeTaskBifrost) ListTasksReturnsOnCall(i int, result1 cf.TasksRespo
 	}{result1, result2}
 }
 
+func (fake *FakeTaskBifrost) RenderTask(arg1 context.Context, arg2 string, arg3 cf.TaskRequest) (*v1.List, error) {
+	fake.renderTaskMutex.Lock()
+	ret, specificReturn := fake.renderTaskReturnsOnCall[len(fake.renderTaskArgsForCall)]
+	fake.renderTaskArgsForCall = append(fake.renderTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 cf.TaskRequest
+	}{arg1, arg2, arg3})
+	stub := fake.RenderTaskStub
+	fakeReturns := fake.renderTaskReturns
+	fake.recordInvocation("RenderTask", []interface{}{arg1, arg2, arg3})
+	fake.renderTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskBifrost) RenderTaskCallCount() int {
+	fake.renderTaskMutex.RLock()
+	defer fake.renderTaskMutex.RUnlock()
+	return len(fake.renderTaskArgsForCall)
+}
+
+func (fake *FakeTaskBifrost) RenderTaskCalls(stub func(context.Context, string, cf.TaskRequest) (*v1.List, error)) {
+	fake.renderTaskMutex.Lock()
+	defer fake.renderTaskMutex.Unlock()
+	fake.RenderTaskStub = stub
+}
+
+func (fake *FakeTaskBifrost) RenderTaskArgsForCall(i int) (context.Context, string, cf.TaskRequest) {
+	fake.renderTaskMutex.RLock()
+	defer fake.renderTaskMutex.RUnlock()
+	argsForCall := fake.renderTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskBifrost) RenderTaskReturns(result1 *v1.List, result2 error) {
+	fake.renderTaskMutex.Lock()
+	defer fake.renderTaskMutex.Unlock()
+	fake.RenderTaskStub = nil
+	fake.renderTaskReturns = struct {
+		result1 *v1.List
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskBifrost) RenderTaskReturnsOnCall(i int, result1 *v1.List, result2 error) {
+	fake.renderTaskMutex.Lock()
+	defer fake.renderTaskMutex.Unlock()
+	fake.RenderTaskStub = nil
+	if fake.renderTaskReturnsOnCall == nil {
+		fake.renderTaskReturnsOnCall = make(map[int]struct {
+			result1 *v1.List
+			result2 error
+		})
+	}
+	fake.renderTaskReturnsOnCall[i] = struct {
+		result1 *v1.List
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskBifrost) TransferTask(arg1 context.Context, arg2 string, arg3 cf.TaskRequest) error {
 	fake.transferTaskMutex.Lock()
 	ret, specificReturn := fake.transferTaskReturnsOnCall[len(fake.transferTaskArgsForCall)]
@@ -314,8 +494,12 @@ func (fake *FakeTaskBifrost) Invocations() map[string][][]interface{} {
 	defer fake.cancelTaskMutex.RUnlock()
 	fake.getTaskMutex.RLock()
 	defer fake.getTaskMutex.RUnlock()
+	fake.getTaskLogsMutex.RLock()
+	defer fake.getTaskLogsMutex.RUnlock()
 	fake.listTasksMutex.RLock()
 	defer fake.listTasksMutex.RUnlock()
+	fake.renderTaskMutex.RLock()
+	defer fake.renderTaskMutex.RUnlock()
 	fake.transferTaskMutex.RLock()
 	defer fake.transferTaskMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
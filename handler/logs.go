@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+)
+
+// parseLogOptions reads the index, follow and since query parameters. Since
+// is either an RFC 3339 timestamp or a duration such as 10m, relative to now.
+func parseLogOptions(req *http.Request) (opi.LogOptions, error) {
+	query := req.URL.Query()
+	opts := opi.LogOptions{}
+
+	if value := query.Get("index"); value != "" {
+		index, err := strconv.Atoi(value)
+		if err != nil || index < 0 {
+			return opi.LogOptions{}, errors.Errorf("invalid index parameter %q", value)
+		}
+
+		opts.Index = &index
+	}
+
+	if value := query.Get("follow"); value != "" {
+		follow, err := strconv.ParseBool(value)
+		if err != nil {
+			return opi.LogOptions{}, errors.Errorf("invalid follow parameter %q", value)
+		}
+
+		opts.Follow = follow
+	}
+
+	if value := query.Get("since"); value != "" {
+		since, err := parseSince(value)
+		if err != nil {
+			return opi.LogOptions{}, err
+		}
+
+		opts.SinceTime = &since
+	}
+
+	return opts, nil
+}
+
+func parseSince(value string) (time.Time, error) {
+	if since, err := time.Parse(time.RFC3339, value); err == nil {
+		return since, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return time.Time{}, errors.Errorf("invalid since parameter %q", value)
+	}
+
+	return time.Now().Add(-duration), nil
+}
+
+// streamLogs copies the logs to the response as they arrive, until they end
+// or the client goes away. Followed logs can take any time, so the server
+// write timeout is cleared for the stream.
+func streamLogs(logger lager.Logger, resp http.ResponseWriter, logs io.ReadCloser) {
+	defer logs.Close()
+
+	if err := http.NewResponseController(resp).SetWriteDeadline(time.Time{}); err != nil {
+		logger.Info("failed-to-clear-write-deadline", lager.Data{"reason": err.Error()})
+	}
+
+	resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	resp.Header().Set("X-Content-Type-Options", "nosniff")
+	resp.WriteHeader(http.StatusOK)
+
+	flusher, _ := resp.(http.Flusher)
+	if _, err := io.Copy(flushWriter{writer: resp, flusher: flusher}, logs); err != nil {
+		logger.Info("log-stream-ended", lager.Data{"reason": err.Error()})
+	}
+}
+
+type flushWriter struct {
+	writer  io.Writer
+	flusher http.Flusher
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	if w.flusher != nil {
+		w.flusher.Flush()
+	}
+
+	return n, err
+}
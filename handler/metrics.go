@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
+	"github.com/julienschmidt/httprouter"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	metricsNamespace = "eirini"
+	metricsSubsystem = "opi"
+)
+
+// Metrics instruments the OPI API. A nil *Metrics is valid and records
+// nothing.
+type Metrics struct {
+	requests       *prometheus.CounterVec
+	requestLatency *prometheus.HistogramVec
+	bifrostErrors  *prometheus.CounterVec
+}
+
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "http_requests_total",
+			Help:      "Number of requests served by the OPI API, by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		requestLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to serve requests to the OPI API, by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+		bifrostErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "bifrost_operation_errors_total",
+			Help:      "Number of failed bifrost operations, by bifrost and operation.",
+		}, []string{"bifrost", "operation"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.requests, m.requestLatency, m.bifrostErrors} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, errors.Wrap(err, "failed to register opi metrics")
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) instrumentRoute(method, route string, handle httprouter.Handle) httprouter.Handle {
+	if m == nil {
+		return handle
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: resp, status: http.StatusOK}
+
+		handle(recorder, req, ps)
+
+		code := strconv.Itoa(recorder.status)
+		m.requests.WithLabelValues(route, method, code).Inc()
+		m.requestLatency.WithLabelValues(route, method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (m *Metrics) countError(bifrost, operation string, err error) error {
+	if m != nil && err != nil {
+		m.bifrostErrors.WithLabelValues(bifrost, operation).Inc()
+	}
+
+	return err
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying connection.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush lets streamed responses, such as followed logs, through the recorder.
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type instrumentedLRPBifrost struct {
+	delegate LRPBifrost
+	metrics  *Metrics
+}
+
+func (b instrumentedLRPBifrost) Transfer(ctx context.Context, request cf.DesireLRPRequest) error {
+	return b.metrics.countError("lrp", "transfer", b.delegate.Transfer(ctx, request))
+}
+
+func (b instrumentedLRPBifrost) Render(ctx context.Context, request cf.DesireLRPRequest) (*corev1.List, error) {
+	list, err := b.delegate.Render(ctx, request)
+
+	return list, b.metrics.countError("lrp", "render", err)
+}
+
+func (b instrumentedLRPBifrost) List(ctx context.Context) ([]cf.DesiredLRPSchedulingInfo, error) {
+	infos, err := b.delegate.List(ctx)
+
+	return infos, b.metrics.countError("lrp", "list", err)
+}
+
+func (b instrumentedLRPBifrost) Update(ctx context.Context, update cf.UpdateDesiredLRPRequest) error {
+	return b.metrics.countError("lrp", "update", b.delegate.Update(ctx, update))
+}
+
+func (b instrumentedLRPBifrost) Stop(ctx context.Context, identifier opi.LRPIdentifier) error {
+	return b.metrics.countError("lrp", "stop", b.delegate.Stop(ctx, identifier))
+}
+
+func (b instrumentedLRPBifrost) StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error {
+	return b.metrics.countError("lrp", "stop_instance", b.delegate.StopInstance(ctx, identifier, index))
+}
+
+func (b instrumentedLRPBifrost) Restart(ctx context.Context, identifier opi.LRPIdentifier) error {
+	return b.metrics.countError("lrp", "restart", b.delegate.Restart(ctx, identifier))
+}
+
+func (b instrumentedLRPBifrost) GetApp(ctx context.Context, identifier opi.LRPIdentifier) (cf.DesiredLRP, error) {
+	lrp, err := b.delegate.GetApp(ctx, identifier)
+
+	return lrp, b.metrics.countError("lrp", "get_app", err)
+}
+
+func (b instrumentedLRPBifrost) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.Instance, error) {
+	instances, err := b.delegate.GetInstances(ctx, identifier)
+
+	return instances, b.metrics.countError("lrp", "get_instances", err)
+}
+
+func (b instrumentedLRPBifrost) GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.InstanceStats, error) {
+	stats, err := b.delegate.GetInstanceStats(ctx, identifier)
+
+	return stats, b.metrics.countError("lrp", "get_instance_stats", err)
+}
+
+func (b instrumentedLRPBifrost) GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error) {
+	logs, err := b.delegate.GetLogs(ctx, identifier, opts)
+
+	return logs, b.metrics.countError("lrp", "get_logs", err)
+}
+
+type instrumentedTaskBifrost struct {
+	delegate TaskBifrost
+	metrics  *Metrics
+}
+
+func (b instrumentedTaskBifrost) GetTask(ctx context.Context, taskGUID string) (cf.TaskResponse, error) {
+	task, err := b.delegate.GetTask(ctx, taskGUID)
+
+	return task, b.metrics.countError("task", "get_task", err)
+}
+
+func (b instrumentedTaskBifrost) ListTasks(ctx context.Context, filter cf.TasksFilter) (cf.TasksResponse, error) {
+	tasks, err := b.delegate.ListTasks(ctx, filter)
+
+	return tasks, b.metrics.countError("task", "list_tasks", err)
+}
+
+func (b instrumentedTaskBifrost) TransferTask(ctx context.Context, taskGUID string, request cf.TaskRequest) error {
+	return b.metrics.countError("task", "transfer_task", b.delegate.TransferTask(ctx, taskGUID, request))
+}
+
+func (b instrumentedTaskBifrost) RenderTask(ctx context.Context, taskGUID string, request cf.TaskRequest) (*corev1.List, error) {
+	list, err := b.delegate.RenderTask(ctx, taskGUID, request)
+
+	return list, b.metrics.countError("task", "render_task", err)
+}
+
+func (b instrumentedTaskBifrost) GetTaskLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error) {
+	logs, err := b.delegate.GetTaskLogs(ctx, taskGUID, opts)
+
+	return logs, b.metrics.countError("task", "get_task_logs", err)
+}
+
+func (b instrumentedTaskBifrost) CancelTask(ctx context.Context, taskGUID string) error {
+	return b.metrics.countError("task", "cancel_task", b.delegate.CancelTask(ctx, taskGUID))
+}
+
+type instrumentedStagingBifrost struct {
+	name     string
+	delegate StagingBifrost
+	metrics  *Metrics
+}
+
+func (b instrumentedStagingBifrost) TransferStaging(ctx context.Context, stagingGUID string, request cf.StagingRequest) error {
+	return b.metrics.countError(b.name, "transfer_staging", b.delegate.TransferStaging(ctx, stagingGUID, request))
+}
+
+func (b instrumentedStagingBifrost) CompleteStaging(ctx context.Context, request cf.StagingCompletedRequest) error {
+	return b.metrics.countError(b.name, "complete_staging", b.delegate.CompleteStaging(ctx, request))
+}
@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/eirini/handler/handlerfakes"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Metrics", func() {
+	var (
+		ts          *httptest.Server
+		registry    *prometheus.Registry
+		lrpBifrost  *handlerfakes.FakeLRPBifrost
+		taskBifrost *handlerfakes.FakeTaskBifrost
+	)
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry()
+		metrics, err := NewMetrics(registry)
+		Expect(err).NotTo(HaveOccurred())
+
+		lrpBifrost = new(handlerfakes.FakeLRPBifrost)
+		taskBifrost = new(handlerfakes.FakeTaskBifrost)
+		stagingBifrost := new(handlerfakes.FakeStagingBifrost)
+		logger := lagertest.NewTestLogger("metrics-test")
+
+		ts = httptest.NewServer(New(lrpBifrost, stagingBifrost, stagingBifrost, taskBifrost, logger, metrics, 0, nil, nil))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	get := func(path string) {
+		resp, err := http.Get(ts.URL + path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+	}
+
+	It("counts requests by route pattern, method and status code", func() {
+		get("/apps/guid-1/version-1")
+		get("/apps/guid-2/version-2")
+
+		expected := `
+# HELP eirini_opi_http_requests_total Number of requests served by the OPI API, by route, method and status code.
+# TYPE eirini_opi_http_requests_total counter
+eirini_opi_http_requests_total{code="200",method="GET",route="/apps/:process_guid/:version_guid"} 2
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "eirini_opi_http_requests_total")).To(Succeed())
+	})
+
+	It("records request latencies", func() {
+		get("/tasks")
+
+		Expect(testutil.GatherAndCount(registry, "eirini_opi_http_request_duration_seconds")).To(Equal(1))
+	})
+
+	It("records the status code written by the handler", func() {
+		lrpBifrost.GetAppReturns(cf.DesiredLRP{}, errors.New("boom"))
+		get("/apps/guid/version")
+
+		expected := `
+# HELP eirini_opi_http_requests_total Number of requests served by the OPI API, by route, method and status code.
+# TYPE eirini_opi_http_requests_total counter
+eirini_opi_http_requests_total{code="500",method="GET",route="/apps/:process_guid/:version_guid"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "eirini_opi_http_requests_total")).To(Succeed())
+	})
+
+	It("counts failed bifrost operations", func() {
+		taskBifrost.ListTasksReturns(nil, errors.New("boom"))
+		lrpBifrost.GetAppReturns(cf.DesiredLRP{}, errors.New("boom"))
+		get("/tasks")
+		get("/apps/guid/version")
+		get("/apps/guid/version")
+
+		expected := `
+# HELP eirini_opi_bifrost_operation_errors_total Number of failed bifrost operations, by bifrost and operation.
+# TYPE eirini_opi_bifrost_operation_errors_total counter
+eirini_opi_bifrost_operation_errors_total{bifrost="lrp",operation="get_app"} 2
+eirini_opi_bifrost_operation_errors_total{bifrost="task",operation="list_tasks"} 1
+`
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(expected), "eirini_opi_bifrost_operation_errors_total")).To(Succeed())
+	})
+
+	It("fails to register the metrics twice", func() {
+		_, err := NewMetrics(registry)
+		Expect(err).To(MatchError(ContainSubstring("failed to register opi metrics")))
+	})
+})
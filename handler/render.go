@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const dryRunParam = "dry_run"
+
+// isDryRun tells whether the request asks for the Kubernetes objects to be
+// rendered rather than created.
+func isDryRun(req *http.Request) (bool, error) {
+	value := req.URL.Query().Get(dryRunParam)
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+
+	return dryRun, errors.Wrapf(err, "invalid %s parameter", dryRunParam)
+}
+
+// writeRendered responds with the rendered objects as YAML when the client
+// accepts it, and as JSON otherwise.
+func writeRendered(logger lager.Logger, resp http.ResponseWriter, req *http.Request, list *corev1.List) {
+	contentType := "application/json"
+	marshal := json.Marshal
+
+	if strings.Contains(req.Header.Get("Accept"), "yaml") {
+		contentType = "application/yaml"
+		marshal = yaml.Marshal
+	}
+
+	body, err := marshal(list)
+	if err != nil {
+		logger.Error("failed-to-encode-rendered-objects", err)
+		writeErrorResponse(logger, resp, req, err)
+
+		return
+	}
+
+	resp.Header().Set("Content-Type", contentType)
+	resp.WriteHeader(http.StatusOK)
+
+	if _, err := resp.Write(body); err != nil {
+		logger.Error("failed-to-write-response", err)
+	}
+}
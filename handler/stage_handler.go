@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,16 +12,18 @@ import (
 )
 
 type Stage struct {
-	dockerStagingBifrost StagingBifrost
-	logger               lager.Logger
+	dockerStagingBifrost    StagingBifrost
+	buildpackStagingBifrost StagingBifrost
+	logger                  lager.Logger
 }
 
-func NewStageHandler(dockerStagingBifrost StagingBifrost, logger lager.Logger) *Stage {
+func NewStageHandler(dockerStagingBifrost, buildpackStagingBifrost StagingBifrost, logger lager.Logger) *Stage {
 	logger = logger.Session("staging-handler")
 
 	return &Stage{
-		dockerStagingBifrost: dockerStagingBifrost,
-		logger:               logger,
+		dockerStagingBifrost:    dockerStagingBifrost,
+		buildpackStagingBifrost: buildpackStagingBifrost,
+		logger:                  logger,
 	}
 }
 
@@ -33,23 +34,23 @@ func (s *Stage) Run(resp http.ResponseWriter, req *http.Request, ps httprouter.P
 	var stagingRequest cf.StagingRequest
 	if err := json.NewDecoder(req.Body).Decode(&stagingRequest); err != nil {
 		logger.Error("staging-request-body-decoding-failed", err)
-		writeErrorResponse(logger, resp, http.StatusBadRequest, err)
+		writeBadRequestResponse(logger, resp, req, err)
 
 		return
 	}
 
-	if stagingRequest.Lifecycle.DockerLifecycle == nil {
-		err := errors.New("docker is the only supported lifecycle")
+	stagingBifrost, err := s.getStagingBifrost(stagingRequest)
+	if err != nil {
 		logger.Error("staging-failed", err)
-		writeErrorResponse(logger, resp, http.StatusBadRequest, err)
+		writeBadRequestResponse(logger, resp, req, err)
 
 		return
 	}
 
-	if err := s.stage(stagingGUID, stagingRequest); err != nil {
+	if err := stagingBifrost.TransferStaging(req.Context(), stagingGUID, stagingRequest); err != nil {
 		reason := fmt.Sprintf("failed to stage task with guid %q", stagingGUID)
 		logger.Error("staging-failed", errors.Wrap(err, reason))
-		writeErrorResponse(logger, resp, http.StatusInternalServerError, errors.Wrap(err, reason))
+		writeErrorResponse(logger, resp, req, errors.Wrap(err, reason))
 
 		return
 	}
@@ -57,19 +58,38 @@ func (s *Stage) Run(resp http.ResponseWriter, req *http.Request, ps httprouter.P
 	resp.WriteHeader(http.StatusAccepted)
 }
 
-func (s *Stage) stage(stagingGUID string, stagingRequest cf.StagingRequest) error {
-	return s.dockerStagingBifrost.TransferStaging(
-		context.Background(),
-		stagingGUID,
-		stagingRequest,
-	)
-}
+func (s *Stage) Complete(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	stagingGUID := ps.ByName("staging_guid")
+	logger := s.logger.Session("staging-complete", lager.Data{"staging-guid": stagingGUID})
+
+	var completedRequest cf.StagingCompletedRequest
+	if err := json.NewDecoder(req.Body).Decode(&completedRequest); err != nil {
+		logger.Error("staging-completed-request-body-decoding-failed", err)
+		writeBadRequestResponse(logger, resp, req, err)
+
+		return
+	}
 
-func writeErrorResponse(logger lager.Logger, resp http.ResponseWriter, status int, err error) {
-	resp.WriteHeader(status)
-	encodingErr := json.NewEncoder(resp).Encode(&cf.Error{Message: err.Error()})
+	completedRequest.TaskGUID = stagingGUID
+
+	if err := s.buildpackStagingBifrost.CompleteStaging(req.Context(), completedRequest); err != nil {
+		reason := fmt.Sprintf("failed to complete staging task with guid %q", stagingGUID)
+		logger.Error("staging-completion-failed", errors.Wrap(err, reason))
+		writeErrorResponse(logger, resp, req, errors.Wrap(err, reason))
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusOK)
+}
 
-	if encodingErr != nil {
-		logger.Error("failed-to-encode-error", encodingErr)
+func (s *Stage) getStagingBifrost(stagingRequest cf.StagingRequest) (StagingBifrost, error) {
+	switch {
+	case stagingRequest.Lifecycle.DockerLifecycle != nil:
+		return s.dockerStagingBifrost, nil
+	case stagingRequest.Lifecycle.BuildpackLifecycle != nil:
+		return s.buildpackStagingBifrost, nil
+	default:
+		return nil, errors.New("missing lifecycle data")
 	}
 }
@@ -2,6 +2,7 @@ package handler_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -21,22 +22,24 @@ var _ = Describe("StageHandler", func() {
 		ts     *httptest.Server
 		logger *lagertest.TestLogger
 
-		dockerStagingClient *handlerfakes.FakeStagingBifrost
-		bifrostTaskClient   *handlerfakes.FakeTaskBifrost
-		response            *http.Response
-		body                string
-		path                string
-		method              string
+		dockerStagingClient    *handlerfakes.FakeStagingBifrost
+		buildpackStagingClient *handlerfakes.FakeStagingBifrost
+		bifrostTaskClient      *handlerfakes.FakeTaskBifrost
+		response               *http.Response
+		body                   string
+		path                   string
+		method                 string
 	)
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
 		dockerStagingClient = new(handlerfakes.FakeStagingBifrost)
+		buildpackStagingClient = new(handlerfakes.FakeStagingBifrost)
 		bifrostTaskClient = new(handlerfakes.FakeTaskBifrost)
 	})
 
 	JustBeforeEach(func() {
-		handler := New(nil, dockerStagingClient, bifrostTaskClient, logger)
+		handler := New(nil, dockerStagingClient, buildpackStagingClient, bifrostTaskClient, logger, nil, 0, nil, nil)
 		ts = httptest.NewServer(handler)
 		req, err := http.NewRequest(method, ts.URL+path, bytes.NewReader([]byte(body)))
 		Expect(err).NotTo(HaveOccurred())
@@ -89,22 +92,57 @@ var _ = Describe("StageHandler", func() {
 			}))
 		})
 
-		Context("and the lifecycle type is unsupported", func() {
+		It("should pass the request context to the staging client", func() {
+			ctx, _, _ := dockerStagingClient.TransferStagingArgsForCall(0)
+			Expect(ctx.Err()).To(MatchError(context.Canceled))
+		})
 
+		Context("and the app uses the buildpack lifecycle", func() {
 			BeforeEach(func() {
 				body = `{
 				"app_guid": "our-app-id",
-				"environment": [{"name": "HOWARD", "value": "the alien"}],
 				"lifecycle": {
 					"buildpack_lifecycle": {
 						"app_bits_download_uri": "example.com/download",
-						"droplet_upload_uri": "example.com/upload"
+						"droplet_upload_uri": "example.com/upload",
+						"buildpacks": [{"name": "ruby", "key": "ruby-key", "url": "example.com/ruby", "skip_detect": true}],
+						"stack": "cflinuxfs3"
 					}
 				},
 				"completion_callback": "example.com/call/me/maybe"
 			}`
 			})
 
+			It("should return 202 Accepted code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusAccepted))
+			})
+
+			It("should stage it using the buildpack staging client", func() {
+				Expect(dockerStagingClient.TransferStagingCallCount()).To(Equal(0))
+				Expect(buildpackStagingClient.TransferStagingCallCount()).To(Equal(1))
+				_, stagingGUID, stagingRequest := buildpackStagingClient.TransferStagingArgsForCall(0)
+
+				Expect(stagingGUID).To(Equal("guid_1234"))
+				Expect(stagingRequest.Lifecycle.BuildpackLifecycle).To(Equal(&cf.StagingBuildpackLifecycle{
+					AppBitsDownloadURI: "example.com/download",
+					DropletUploadURI:   "example.com/upload",
+					Buildpacks: []cf.Buildpack{
+						{Name: "ruby", Key: "ruby-key", URL: "example.com/ruby", SkipDetect: true},
+					},
+					Stack: "cflinuxfs3",
+				}))
+			})
+		})
+
+		Context("and the lifecycle is missing", func() {
+			BeforeEach(func() {
+				body = `{
+				"app_guid": "our-app-id",
+				"lifecycle": {},
+				"completion_callback": "example.com/call/me/maybe"
+			}`
+			})
+
 			It("should return a 400 Bad Request status code", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
 			})
@@ -114,11 +152,12 @@ var _ = Describe("StageHandler", func() {
 				stagingError := cf.Error{}
 				err := json.Unmarshal(bytes, &stagingError)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(stagingError.Message).To(ContainSubstring("docker is the only supported lifecycle"))
+				Expect(stagingError.Message).To(ContainSubstring("missing lifecycle data"))
 			})
 
 			It("should not desire a task", func() {
 				Expect(dockerStagingClient.TransferStagingCallCount()).To(Equal(0))
+				Expect(buildpackStagingClient.TransferStagingCallCount()).To(Equal(0))
 			})
 		})
 
@@ -168,4 +207,62 @@ var _ = Describe("StageHandler", func() {
 			})
 		})
 	})
+
+	Context("When a staging task completes", func() {
+		BeforeEach(func() {
+			method = "PUT"
+			path = "/stage/guid_1234/completed"
+			body = `{
+				"failed": false,
+				"result": "{\"lifecycle_type\":\"buildpack\"}",
+				"annotation": "{\"completion_callback\": \"example.com/call/me/maybe\"}"
+			}`
+		})
+
+		It("should return 200 OK", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("should complete the staging using the buildpack staging client", func() {
+			Expect(buildpackStagingClient.CompleteStagingCallCount()).To(Equal(1))
+			_, request := buildpackStagingClient.CompleteStagingArgsForCall(0)
+			Expect(request).To(Equal(cf.StagingCompletedRequest{
+				TaskGUID:   "guid_1234",
+				Result:     `{"lifecycle_type":"buildpack"}`,
+				Annotation: `{"completion_callback": "example.com/call/me/maybe"}`,
+			}))
+		})
+
+		Context("and the body is invalid", func() {
+			BeforeEach(func() {
+				body = "{ this json is invalid"
+			})
+
+			It("should return a 400 Bad Request status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("should not complete the staging", func() {
+				Expect(buildpackStagingClient.CompleteStagingCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("and completing the staging fails", func() {
+			BeforeEach(func() {
+				buildpackStagingClient.CompleteStagingReturns(errors.New("underlying-err"))
+			})
+
+			It("should return a 500 Internal Server Error", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+
+			It("should return a high level error in the response body", func() {
+				bytes, _ := ioutil.ReadAll(response.Body)
+				stagingError := cf.Error{}
+				err := json.Unmarshal(bytes, &stagingError)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stagingError.Message).To(Equal(`failed to complete staging task with guid "guid_1234": underlying-err`))
+			})
+		})
+	})
 })
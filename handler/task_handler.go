@@ -3,10 +3,13 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
 	"code.cloudfoundry.org/lager"
 	"github.com/julienschmidt/httprouter"
 )
@@ -27,17 +30,15 @@ func (t *Task) Get(resp http.ResponseWriter, req *http.Request, ps httprouter.Pa
 	taskGUID := ps.ByName("task_guid")
 	logger := t.logger.Session("get-task-request", lager.Data{"task-guid": taskGUID})
 
-	response, err := t.taskBifrost.GetTask(taskGUID)
+	response, err := t.taskBifrost.GetTask(req.Context(), taskGUID)
 	if err != nil {
 		if errors.Is(err, eirini.ErrNotFound) {
 			logger.Info("task-not-found")
-			writeErrorResponse(logger, resp, http.StatusNotFound, err)
-
-			return
+		} else {
+			logger.Error("get-task-request-failed", err)
 		}
 
-		logger.Error("get-task-request-failed", err)
-		writeErrorResponse(logger, resp, http.StatusInternalServerError, err)
+		writeErrorResponse(logger, resp, req, err)
 
 		return
 	}
@@ -48,6 +49,28 @@ func (t *Task) Get(resp http.ResponseWriter, req *http.Request, ps httprouter.Pa
 	}
 }
 
+func (t *Task) GetLogs(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+	taskGUID := ps.ByName("task_guid")
+	logger := t.logger.Session("get-task-logs-request", lager.Data{"task-guid": taskGUID})
+
+	opts, err := parseLogOptions(req)
+	if err != nil {
+		writeBadRequestResponse(logger, resp, req, err)
+
+		return
+	}
+
+	logs, err := t.taskBifrost.GetTaskLogs(req.Context(), taskGUID, opts)
+	if err != nil {
+		logger.Error("get-task-logs-request-failed", err)
+		writeErrorResponse(logger, resp, req, err)
+
+		return
+	}
+
+	streamLogs(logger, resp, logs)
+}
+
 func (t *Task) Run(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 	taskGUID := ps.ByName("task_guid")
 	logger := t.logger.Session("task-request", lager.Data{"task-guid": taskGUID})
@@ -55,14 +78,27 @@ func (t *Task) Run(resp http.ResponseWriter, req *http.Request, ps httprouter.Pa
 	var taskRequest cf.TaskRequest
 	if err := json.NewDecoder(req.Body).Decode(&taskRequest); err != nil {
 		logger.Error("task-request-body-decoding-failed", err)
-		writeErrorResponse(logger, resp, http.StatusBadRequest, err)
+		writeBadRequestResponse(logger, resp, req, err)
+
+		return
+	}
+
+	dryRun, err := isDryRun(req)
+	if err != nil {
+		writeBadRequestResponse(logger, resp, req, err)
+
+		return
+	}
+
+	if dryRun {
+		t.render(logger, resp, req, taskGUID, taskRequest)
 
 		return
 	}
 
 	if err := t.taskBifrost.TransferTask(req.Context(), taskGUID, taskRequest); err != nil {
 		logger.Error("task-request-task-create-failed", err)
-		writeErrorResponse(logger, resp, http.StatusInternalServerError, err)
+		writeErrorResponse(logger, resp, req, err)
 
 		return
 	}
@@ -70,13 +106,25 @@ func (t *Task) Run(resp http.ResponseWriter, req *http.Request, ps httprouter.Pa
 	resp.WriteHeader(http.StatusAccepted)
 }
 
+func (t *Task) render(logger lager.Logger, resp http.ResponseWriter, req *http.Request, taskGUID string, taskRequest cf.TaskRequest) {
+	list, err := t.taskBifrost.RenderTask(req.Context(), taskGUID, taskRequest)
+	if err != nil {
+		logger.Error("task-request-task-render-failed", err)
+		writeErrorResponse(logger, resp, req, err)
+
+		return
+	}
+
+	writeRendered(logger, resp, req, list)
+}
+
 func (t *Task) Cancel(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 	taskGUID := ps.ByName("task_guid")
 	logger := t.logger.Session("task-cancel", lager.Data{"task-guid": taskGUID})
 
-	if err := t.taskBifrost.CancelTask(taskGUID); err != nil {
+	if err := t.taskBifrost.CancelTask(req.Context(), taskGUID); err != nil {
 		logger.Error("task-request-task-delete-failed", err)
-		writeErrorResponse(logger, resp, http.StatusInternalServerError, err)
+		writeErrorResponse(logger, resp, req, err)
 
 		return
 	}
@@ -87,10 +135,18 @@ func (t *Task) Cancel(resp http.ResponseWriter, req *http.Request, ps httprouter
 func (t *Task) List(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
 	logger := t.logger.Session("list-tasks")
 
-	tasks, err := t.taskBifrost.ListTasks()
+	filter, err := parseTasksFilter(req)
+	if err != nil {
+		logger.Error("invalid-tasks-filter", err)
+		writeBadRequestResponse(logger, resp, req, err)
+
+		return
+	}
+
+	tasks, err := t.taskBifrost.ListTasks(req.Context(), filter)
 	if err != nil {
 		logger.Error("list-tasks-request-failed", err)
-		resp.WriteHeader(http.StatusInternalServerError)
+		writeErrorResponse(logger, resp, req, err)
 
 		return
 	}
@@ -100,3 +156,18 @@ func (t *Task) List(resp http.ResponseWriter, req *http.Request, ps httprouter.P
 		resp.WriteHeader(http.StatusInternalServerError)
 	}
 }
+
+func parseTasksFilter(req *http.Request) (cf.TasksFilter, error) {
+	query := req.URL.Query()
+	filter := cf.TasksFilter{
+		AppGUID: query.Get("app_guid"),
+		State:   strings.ToUpper(query.Get("state")),
+	}
+
+	switch filter.State {
+	case "", opi.TaskPendingState, opi.TaskRunningState, opi.TaskSucceededState, opi.TaskFailedState:
+		return filter, nil
+	default:
+		return cf.TasksFilter{}, fmt.Errorf("invalid task state %q", query.Get("state"))
+	}
+}
@@ -3,8 +3,10 @@ package handler_test
 import (
 	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"code.cloudfoundry.org/eirini"
 	. "code.cloudfoundry.org/eirini/handler"
@@ -14,6 +16,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var _ = Describe("TaskHandler", func() {
@@ -39,7 +44,7 @@ var _ = Describe("TaskHandler", func() {
 
 	JustBeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
-		handler := New(nil, nil, taskBifrost, logger)
+		handler := New(nil, nil, nil, taskBifrost, logger, nil, 0, nil, nil)
 		ts = httptest.NewServer(handler)
 		req, err := http.NewRequest(method, ts.URL+path, bytes.NewReader([]byte(body)))
 		Expect(err).NotTo(HaveOccurred())
@@ -117,6 +122,23 @@ var _ = Describe("TaskHandler", func() {
 			})
 		})
 
+		When("the task already exists", func() {
+			BeforeEach(func() {
+				taskBifrost.TransferTaskReturns(errors.Wrap(eirini.ErrConflict, "task guid_1234 already exists"))
+			})
+
+			It("should return 409 Conflict code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusConflict))
+			})
+
+			It("should return a conflict error", func() {
+				var errorResponse cf.Error
+				Expect(json.NewDecoder(response.Body).Decode(&errorResponse)).To(Succeed())
+				Expect(errorResponse.Code).To(Equal(ErrorCodeConflict))
+				Expect(errorResponse.Message).To(Equal("task guid_1234 already exists: conflict"))
+			})
+		})
+
 		Context("when the request body cannot be unmarshalled", func() {
 			BeforeEach(func() {
 				body = "random stuff"
@@ -130,6 +152,42 @@ var _ = Describe("TaskHandler", func() {
 				Expect(taskBifrost.TransferTaskCallCount()).To(Equal(0))
 			})
 		})
+
+		When("it is a dry run", func() {
+			BeforeEach(func() {
+				path = "/tasks/guid_1234?dry_run=true"
+				taskBifrost.RenderTaskReturns(&corev1.List{
+					TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+					Items:    []runtime.RawExtension{{Raw: []byte(`{"kind":"Job"}`)}},
+				}, nil)
+			})
+
+			It("should render the task instead of transferring it", func() {
+				Expect(taskBifrost.TransferTaskCallCount()).To(Equal(0))
+				Expect(taskBifrost.RenderTaskCallCount()).To(Equal(1))
+				_, actualTaskGUID, actualTaskRequest := taskBifrost.RenderTaskArgsForCall(0)
+				Expect(actualTaskGUID).To(Equal("guid_1234"))
+				Expect(actualTaskRequest.GUID).To(Equal("some-guid"))
+			})
+
+			It("should return the rendered objects", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(responseBody).To(MatchJSON(`{"apiVersion":"v1","kind":"List","metadata":{},"items":[{"kind":"Job"}]}`))
+			})
+
+			When("rendering fails", func() {
+				BeforeEach(func() {
+					taskBifrost.RenderTaskReturns(nil, errors.New("render-task-err"))
+				})
+
+				It("should return 500 Internal Server Error code", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
 	})
 
 	Describe("Cancel", func() {
@@ -149,7 +207,8 @@ var _ = Describe("TaskHandler", func() {
 
 		It("cancels the task", func() {
 			Expect(taskBifrost.CancelTaskCallCount()).To(Equal(1))
-			Expect(taskBifrost.CancelTaskArgsForCall(0)).To(Equal("guid_1234"))
+			_, guid := taskBifrost.CancelTaskArgsForCall(0)
+			Expect(guid).To(Equal("guid_1234"))
 		})
 
 		When("cancelling the task fails", func() {
@@ -161,6 +220,16 @@ var _ = Describe("TaskHandler", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 			})
 		})
+
+		When("the task does not exist", func() {
+			BeforeEach(func() {
+				taskBifrost.CancelTaskReturns(errors.Wrap(eirini.ErrNotFound, "job with guid guid_1234 does not exist"))
+			})
+
+			It("returns 404 status code", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
 	})
 
 	Describe("Get", func() {
@@ -176,7 +245,7 @@ var _ = Describe("TaskHandler", func() {
 
 		It("retrives a task", func() {
 			Expect(taskBifrost.GetTaskCallCount()).To(Equal(1))
-			actualGUID := taskBifrost.GetTaskArgsForCall(0)
+			_, actualGUID := taskBifrost.GetTaskArgsForCall(0)
 			Expect(actualGUID).To(Equal("guid_1234"))
 
 			var taskResponse cf.TaskResponse
@@ -207,6 +276,59 @@ var _ = Describe("TaskHandler", func() {
 		})
 	})
 
+	Describe("GetLogs", func() {
+		BeforeEach(func() {
+			method = "GET"
+			path = "/tasks/guid_1234/logs?follow=true"
+			body = ""
+
+			taskBifrost.GetTaskLogsReturns(ioutil.NopCloser(strings.NewReader("hello\n")), nil)
+		})
+
+		It("streams the task logs", func() {
+			Expect(taskBifrost.GetTaskLogsCallCount()).To(Equal(1))
+			_, actualGUID, opts := taskBifrost.GetTaskLogsArgsForCall(0)
+			Expect(actualGUID).To(Equal("guid_1234"))
+			Expect(opts.Follow).To(BeTrue())
+
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			logs, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(logs)).To(Equal("hello\n"))
+		})
+
+		When("the since parameter is invalid", func() {
+			BeforeEach(func() {
+				path = "/tasks/guid_1234/logs?since=-5m"
+			})
+
+			It("returns a 400 status", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(taskBifrost.GetTaskLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("there is no task with the required guid", func() {
+			BeforeEach(func() {
+				taskBifrost.GetTaskLogsReturns(nil, errors.Wrap(eirini.ErrNotFound, "foo"))
+			})
+
+			It("returns a 404 status", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("getting the logs fails", func() {
+			BeforeEach(func() {
+				taskBifrost.GetTaskLogsReturns(nil, errors.New("logs-error"))
+			})
+
+			It("returns a 500 status", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
 	Describe("List", func() {
 		BeforeEach(func() {
 			method = "GET"
@@ -229,6 +351,39 @@ var _ = Describe("TaskHandler", func() {
 			Expect(taskResponse[0].GUID).To(Equal("guid_1234"))
 		})
 
+		It("does not filter by default", func() {
+			_, filter := taskBifrost.ListTasksArgsForCall(0)
+			Expect(filter).To(Equal(cf.TasksFilter{}))
+		})
+
+		When("filters are provided", func() {
+			BeforeEach(func() {
+				path = "/tasks?app_guid=app-guid&state=running"
+			})
+
+			It("passes them to the bifrost", func() {
+				_, filter := taskBifrost.ListTasksArgsForCall(0)
+				Expect(filter).To(Equal(cf.TasksFilter{
+					AppGUID: "app-guid",
+					State:   "RUNNING",
+				}))
+			})
+		})
+
+		When("the state filter is invalid", func() {
+			BeforeEach(func() {
+				path = "/tasks?state=sleeping"
+			})
+
+			It("returns a 400 status", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+
+			It("does not list tasks", func() {
+				Expect(taskBifrost.ListTasksCallCount()).To(Equal(0))
+			})
+		})
+
 		When("listing tasks fails", func() {
 			BeforeEach(func() {
 				taskBifrost.ListTasksReturns(nil, errors.New("task-error"))
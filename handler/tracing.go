@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
+	"github.com/julienschmidt/httprouter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// withTracing starts a server span for the request, as a child of the span
+// CC sent in the W3C or B3 headers, if any.
+func withTracing(method, route string, handle httprouter.Handle) httprouter.Handle {
+	return func(resp http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		ctx := tracing.Propagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := tracing.Start(ctx, method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", method),
+				attribute.String("http.route", route),
+				attribute.String("eirini.request_id", req.Header.Get(RequestIDHeader)),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: resp, status: http.StatusOK}
+		handle(recorder, req.WithContext(ctx), ps)
+
+		span.SetAttributes(attribute.Int("http.status_code", recorder.status))
+
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	}
+}
+
+func traceBifrost(ctx context.Context, bifrost, operation string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "bifrost."+bifrost+"."+operation)
+}
+
+type tracedLRPBifrost struct {
+	delegate LRPBifrost
+}
+
+func (b tracedLRPBifrost) Transfer(ctx context.Context, request cf.DesireLRPRequest) error {
+	ctx, span := traceBifrost(ctx, "lrp", "transfer")
+
+	return tracing.End(span, b.delegate.Transfer(ctx, request))
+}
+
+func (b tracedLRPBifrost) Render(ctx context.Context, request cf.DesireLRPRequest) (*corev1.List, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "render")
+	list, err := b.delegate.Render(ctx, request)
+
+	return list, tracing.End(span, err)
+}
+
+func (b tracedLRPBifrost) List(ctx context.Context) ([]cf.DesiredLRPSchedulingInfo, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "list")
+	infos, err := b.delegate.List(ctx)
+
+	return infos, tracing.End(span, err)
+}
+
+func (b tracedLRPBifrost) Update(ctx context.Context, update cf.UpdateDesiredLRPRequest) error {
+	ctx, span := traceBifrost(ctx, "lrp", "update")
+
+	return tracing.End(span, b.delegate.Update(ctx, update))
+}
+
+func (b tracedLRPBifrost) Stop(ctx context.Context, identifier opi.LRPIdentifier) error {
+	ctx, span := traceBifrost(ctx, "lrp", "stop")
+
+	return tracing.End(span, b.delegate.Stop(ctx, identifier))
+}
+
+func (b tracedLRPBifrost) StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error {
+	ctx, span := traceBifrost(ctx, "lrp", "stop_instance")
+
+	return tracing.End(span, b.delegate.StopInstance(ctx, identifier, index))
+}
+
+func (b tracedLRPBifrost) Restart(ctx context.Context, identifier opi.LRPIdentifier) error {
+	ctx, span := traceBifrost(ctx, "lrp", "restart")
+
+	return tracing.End(span, b.delegate.Restart(ctx, identifier))
+}
+
+func (b tracedLRPBifrost) GetApp(ctx context.Context, identifier opi.LRPIdentifier) (cf.DesiredLRP, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "get_app")
+	lrp, err := b.delegate.GetApp(ctx, identifier)
+
+	return lrp, tracing.End(span, err)
+}
+
+func (b tracedLRPBifrost) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.Instance, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "get_instances")
+	instances, err := b.delegate.GetInstances(ctx, identifier)
+
+	return instances, tracing.End(span, err)
+}
+
+func (b tracedLRPBifrost) GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*cf.InstanceStats, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "get_instance_stats")
+	stats, err := b.delegate.GetInstanceStats(ctx, identifier)
+
+	return stats, tracing.End(span, err)
+}
+
+func (b tracedLRPBifrost) GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error) {
+	ctx, span := traceBifrost(ctx, "lrp", "get_logs")
+	logs, err := b.delegate.GetLogs(ctx, identifier, opts)
+
+	return logs, tracing.End(span, err)
+}
+
+type tracedTaskBifrost struct {
+	delegate TaskBifrost
+}
+
+func (b tracedTaskBifrost) GetTask(ctx context.Context, taskGUID string) (cf.TaskResponse, error) {
+	ctx, span := traceBifrost(ctx, "task", "get_task")
+	task, err := b.delegate.GetTask(ctx, taskGUID)
+
+	return task, tracing.End(span, err)
+}
+
+func (b tracedTaskBifrost) ListTasks(ctx context.Context, filter cf.TasksFilter) (cf.TasksResponse, error) {
+	ctx, span := traceBifrost(ctx, "task", "list_tasks")
+	tasks, err := b.delegate.ListTasks(ctx, filter)
+
+	return tasks, tracing.End(span, err)
+}
+
+func (b tracedTaskBifrost) TransferTask(ctx context.Context, taskGUID string, request cf.TaskRequest) error {
+	ctx, span := traceBifrost(ctx, "task", "transfer_task")
+
+	return tracing.End(span, b.delegate.TransferTask(ctx, taskGUID, request))
+}
+
+func (b tracedTaskBifrost) RenderTask(ctx context.Context, taskGUID string, request cf.TaskRequest) (*corev1.List, error) {
+	ctx, span := traceBifrost(ctx, "task", "render_task")
+	list, err := b.delegate.RenderTask(ctx, taskGUID, request)
+
+	return list, tracing.End(span, err)
+}
+
+func (b tracedTaskBifrost) GetTaskLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error) {
+	ctx, span := traceBifrost(ctx, "task", "get_task_logs")
+	logs, err := b.delegate.GetTaskLogs(ctx, taskGUID, opts)
+
+	return logs, tracing.End(span, err)
+}
+
+func (b tracedTaskBifrost) CancelTask(ctx context.Context, taskGUID string) error {
+	ctx, span := traceBifrost(ctx, "task", "cancel_task")
+
+	return tracing.End(span, b.delegate.CancelTask(ctx, taskGUID))
+}
+
+type tracedStagingBifrost struct {
+	name     string
+	delegate StagingBifrost
+}
+
+func (b tracedStagingBifrost) TransferStaging(ctx context.Context, stagingGUID string, request cf.StagingRequest) error {
+	ctx, span := traceBifrost(ctx, b.name, "transfer_staging")
+
+	return tracing.End(span, b.delegate.TransferStaging(ctx, stagingGUID, request))
+}
+
+func (b tracedStagingBifrost) CompleteStaging(ctx context.Context, request cf.StagingCompletedRequest) error {
+	ctx, span := traceBifrost(ctx, b.name, "complete_staging")
+
+	return tracing.End(span, b.delegate.CompleteStaging(ctx, request))
+}
@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "code.cloudfoundry.org/eirini/handler"
+	"code.cloudfoundry.org/eirini/handler/handlerfakes"
+	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/tracing"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Tracing", func() {
+	var (
+		ts         *httptest.Server
+		exporter   *tracetest.InMemoryExporter
+		lrpBifrost *handlerfakes.FakeLRPBifrost
+		header     http.Header
+		method     string
+		path       string
+		body       string
+	)
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+		lrpBifrost = new(handlerfakes.FakeLRPBifrost)
+		stagingBifrost := new(handlerfakes.FakeStagingBifrost)
+		taskBifrost := new(handlerfakes.FakeTaskBifrost)
+		logger := lagertest.NewTestLogger("tracing-test")
+
+		ts = httptest.NewServer(New(lrpBifrost, stagingBifrost, stagingBifrost, taskBifrost, logger, nil, 0, nil, nil))
+		header = http.Header{}
+		method = http.MethodGet
+		path = "/apps/guid/version"
+		body = ""
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header = header
+
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+	})
+
+	spanNamed := func(name string) tracetest.SpanStub {
+		for _, span := range exporter.GetSpans() {
+			if span.Name == name {
+				return span
+			}
+		}
+
+		Fail("no span named " + name)
+
+		return tracetest.SpanStub{}
+	}
+
+	It("starts a server span for the route", func() {
+		span := spanNamed("GET /apps/:process_guid/:version_guid")
+		Expect(span.SpanKind).To(Equal(trace.SpanKindServer))
+		Expect(span.Parent.IsValid()).To(BeFalse())
+		Expect(span.Attributes).To(ContainElement(attribute.String("http.route", "/apps/:process_guid/:version_guid")))
+		Expect(span.Attributes).To(ContainElement(attribute.Int("http.status_code", http.StatusOK)))
+	})
+
+	It("starts a span for the bifrost operation", func() {
+		server := spanNamed("GET /apps/:process_guid/:version_guid")
+		span := spanNamed("bifrost.lrp.get_app")
+		Expect(span.Parent.SpanID()).To(Equal(server.SpanContext.SpanID()))
+	})
+
+	It("passes the span to the bifrost", func() {
+		ctx, _ := lrpBifrost.GetAppArgsForCall(0)
+		span := spanNamed("bifrost.lrp.get_app")
+		Expect(trace.SpanContextFromContext(ctx).SpanID()).To(Equal(span.SpanContext.SpanID()))
+	})
+
+	When("CC requests staging", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+			path = "/stage/staging-guid"
+			body = `{"lifecycle": {"docker_lifecycle": {"image": "eirini/repo"}}}`
+		})
+
+		It("starts the staging span as a child of the server span", func() {
+			server := spanNamed("POST /stage/:staging_guid")
+			span := spanNamed("bifrost.docker_staging.transfer_staging")
+			Expect(span.SpanContext.TraceID()).To(Equal(server.SpanContext.TraceID()))
+			Expect(span.Parent.SpanID()).To(Equal(server.SpanContext.SpanID()))
+		})
+	})
+
+	When("CC sends W3C trace context headers", func() {
+		BeforeEach(func() {
+			header.Set("traceparent", "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01")
+		})
+
+		It("continues the trace", func() {
+			span := spanNamed("GET /apps/:process_guid/:version_guid")
+			Expect(span.SpanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(span.Parent.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+			Expect(span.Parent.IsRemote()).To(BeTrue())
+		})
+	})
+
+	When("CC sends B3 headers", func() {
+		BeforeEach(func() {
+			header.Set("X-B3-TraceId", "463ac35c9f6413ad48485a3953bb6124")
+			header.Set("X-B3-SpanId", "a2fb4a1d1a96d312")
+			header.Set("X-B3-Sampled", "1")
+		})
+
+		It("continues the trace", func() {
+			span := spanNamed("GET /apps/:process_guid/:version_guid")
+			Expect(span.SpanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(span.Parent.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+		})
+	})
+
+	When("the bifrost fails", func() {
+		BeforeEach(func() {
+			lrpBifrost.GetAppReturns(cf.DesiredLRP{}, errors.New("boom"))
+		})
+
+		It("records the error on the spans", func() {
+			Expect(spanNamed("bifrost.lrp.get_app").Status.Code).To(Equal(codes.Error))
+			Expect(spanNamed("bifrost.lrp.get_app").Status.Description).To(Equal("boom"))
+
+			server := spanNamed("GET /apps/:process_guid/:version_guid")
+			Expect(server.Status.Code).To(Equal(codes.Error))
+			Expect(server.Attributes).To(ContainElement(attribute.Int("http.status_code", http.StatusInternalServerError)))
+		})
+	})
+})
@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/opi"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const eventsByInvolvedObjectIndex = "involvedObject"
+
+var ErrCacheNotSynced = errors.New("cache has not synced yet")
+
+// Cache keeps the eirini statefulsets, jobs and pods, and the events of all
+// pods, in shared informers, so that reads do not hit the API server. Events
+// are indexed by the pod they are about.
+type Cache struct {
+	workloads    informers.SharedInformerFactory
+	events       informers.SharedInformerFactory
+	statefulSets appslisters.StatefulSetLister
+	jobs         batchlisters.JobLister
+	pods         corelisters.PodLister
+	eventIndex   cache.Indexer
+	synced       []cache.InformerSynced
+}
+
+func NewCache(clientSet kubernetes.Interface, workloadsNamespace string, resync time.Duration) *Cache {
+	workloads := informers.NewSharedInformerFactoryWithOptions(clientSet, resync,
+		informers.WithNamespace(workloadsNamespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = k8s.LabelSourceType
+		}),
+	)
+	events := informers.NewSharedInformerFactoryWithOptions(clientSet, resync,
+		informers.WithNamespace(workloadsNamespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("involvedObject.kind", "Pod").String()
+		}),
+	)
+
+	statefulSetInformer := workloads.Apps().V1().StatefulSets()
+	jobInformer := workloads.Batch().V1().Jobs()
+	podInformer := workloads.Core().V1().Pods()
+	eventInformer := events.Core().V1().Events().Informer()
+
+	// Indexers can only be added before the informer is started, which
+	// happens in Start, so this cannot fail.
+	_ = eventInformer.AddIndexers(cache.Indexers{eventsByInvolvedObjectIndex: indexByInvolvedObject})
+
+	return &Cache{
+		workloads:    workloads,
+		events:       events,
+		statefulSets: statefulSetInformer.Lister(),
+		jobs:         jobInformer.Lister(),
+		pods:         podInformer.Lister(),
+		eventIndex:   eventInformer.GetIndexer(),
+		synced: []cache.InformerSynced{
+			statefulSetInformer.Informer().HasSynced,
+			jobInformer.Informer().HasSynced,
+			podInformer.Informer().HasSynced,
+			eventInformer.HasSynced,
+		},
+	}
+}
+
+// Start runs the informers until stop is closed.
+func (c *Cache) Start(stop <-chan struct{}) {
+	c.workloads.Start(stop)
+	c.events.Start(stop)
+}
+
+// WaitForSync blocks until the initial list of every informer has been
+// cached. It returns false if stop is closed first.
+func (c *Cache) WaitForSync(stop <-chan struct{}) bool {
+	return cache.WaitForCacheSync(stop, c.synced...)
+}
+
+func (c *Cache) HasSynced() bool {
+	for _, synced := range c.synced {
+		if !synced() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Cache) listStatefulSets(labelSelector string) ([]appsv1.StatefulSet, error) {
+	selector, err := c.selector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := c.statefulSets.List(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cached statefulsets")
+	}
+
+	statefulSets := make([]appsv1.StatefulSet, 0, len(cached))
+	for _, s := range cached {
+		statefulSets = append(statefulSets, *s.DeepCopy())
+	}
+
+	return statefulSets, nil
+}
+
+func (c *Cache) listJobs(labelSelector string) ([]batchv1.Job, error) {
+	selector, err := c.selector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := c.jobs.List(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cached jobs")
+	}
+
+	jobs := make([]batchv1.Job, 0, len(cached))
+	for _, j := range cached {
+		jobs = append(jobs, *j.DeepCopy())
+	}
+
+	return jobs, nil
+}
+
+func (c *Cache) listPods(labelSelector string) ([]corev1.Pod, error) {
+	selector, err := c.selector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := c.pods.List(selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cached pods")
+	}
+
+	pods := make([]corev1.Pod, 0, len(cached))
+	for _, p := range cached {
+		pods = append(pods, *p.DeepCopy())
+	}
+
+	return pods, nil
+}
+
+// listEvents returns the events about the pod sorted by name, which is the
+// order the API server lists them in.
+func (c *Cache) listEvents(pod corev1.Pod) ([]corev1.Event, error) {
+	if !c.HasSynced() {
+		return nil, ErrCacheNotSynced
+	}
+
+	cached, err := c.eventIndex.ByIndex(eventsByInvolvedObjectIndex, involvedObjectKey(pod.Namespace, pod.Name, string(pod.UID)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list cached events")
+	}
+
+	events := make([]corev1.Event, 0, len(cached))
+	for _, obj := range cached {
+		events = append(events, *obj.(*corev1.Event).DeepCopy())
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Name < events[j].Name
+	})
+
+	return events, nil
+}
+
+// selector refuses to serve from a cache that is still being filled, as an
+// empty list would be taken for the absence of apps and tasks.
+func (c *Cache) selector(labelSelector string) (labels.Selector, error) {
+	if !c.HasSynced() {
+		return nil, ErrCacheNotSynced
+	}
+
+	selector, err := labels.Parse(labelSelector)
+
+	return selector, errors.Wrap(err, "failed to parse label selector")
+}
+
+func indexByInvolvedObject(obj interface{}) ([]string, error) {
+	event, ok := obj.(*corev1.Event)
+	if !ok {
+		return nil, nil
+	}
+
+	ref := event.InvolvedObject
+
+	return []string{involvedObjectKey(ref.Namespace, ref.Name, string(ref.UID))}, nil
+}
+
+func involvedObjectKey(namespace, name, uid string) string {
+	return namespace + "/" + name + "/" + uid
+}
+
+// CachedPod serves the pod reads from the cache and everything else from
+// the API server.
+type CachedPod struct {
+	*Pod
+	cache *Cache
+}
+
+func NewCachedPod(clientSet kubernetes.Interface, workloadsNamespace string, cache *Cache) *CachedPod {
+	return &CachedPod{
+		Pod:   NewPod(clientSet, workloadsNamespace),
+		cache: cache,
+	}
+}
+
+func (c *CachedPod) GetAll(ctx context.Context) ([]corev1.Pod, error) {
+	return c.cache.listPods(eiriniPodsSelector())
+}
+
+func (c *CachedPod) GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]corev1.Pod, error) {
+	return c.cache.listPods(lrpIdentifierSelector(id))
+}
+
+func (c *CachedPod) GetByTaskGUID(ctx context.Context, guid string) ([]corev1.Pod, error) {
+	return c.cache.listPods(taskGUIDSelector(guid))
+}
+
+func (c *CachedPod) GetBySourceType(ctx context.Context, sourceType string) ([]corev1.Pod, error) {
+	return c.cache.listPods(sourceTypeSelector(sourceType))
+}
+
+// CachedStatefulSet serves the statefulset lists from the cache and
+// everything else from the API server. The cache may be behind, so it is
+// only meant for reads; statefulsets that are about to be updated should be
+// read from the API server.
+type CachedStatefulSet struct {
+	*StatefulSet
+	cache *Cache
+}
+
+func NewCachedStatefulSet(clientSet kubernetes.Interface, workloadsNamespace string, cache *Cache) *CachedStatefulSet {
+	return &CachedStatefulSet{
+		StatefulSet: NewStatefulSet(clientSet, workloadsNamespace),
+		cache:       cache,
+	}
+}
+
+func (c *CachedStatefulSet) GetBySourceType(ctx context.Context, sourceType string) ([]appsv1.StatefulSet, error) {
+	return c.cache.listStatefulSets(sourceTypeSelector(sourceType))
+}
+
+func (c *CachedStatefulSet) GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]appsv1.StatefulSet, error) {
+	return c.cache.listStatefulSets(lrpIdentifierSelector(id))
+}
+
+// CachedJob serves the job lists from the cache and everything else from the
+// API server.
+type CachedJob struct {
+	*Job
+	cache *Cache
+}
+
+func NewCachedJob(clientSet kubernetes.Interface, workloadsNamespace string, cache *Cache) *CachedJob {
+	return &CachedJob{
+		Job:   NewJob(clientSet, workloadsNamespace),
+		cache: cache,
+	}
+}
+
+func (c *CachedJob) GetByGUID(ctx context.Context, guid string, includeCompleted bool) ([]batchv1.Job, error) {
+	return c.cache.listJobs(c.guidSelector(guid, includeCompleted))
+}
+
+func (c *CachedJob) List(ctx context.Context, includeCompleted bool) ([]batchv1.Job, error) {
+	return c.cache.listJobs(c.listSelector(includeCompleted))
+}
+
+// CachedEvent serves the events of a pod from the cache and everything else
+// from the API server.
+type CachedEvent struct {
+	*Event
+	cache *Cache
+}
+
+func NewCachedEvent(clientSet kubernetes.Interface, cache *Cache) *CachedEvent {
+	return &CachedEvent{
+		Event: NewEvent(clientSet),
+		cache: cache,
+	}
+}
+
+func (c *CachedEvent) GetByPod(ctx context.Context, pod corev1.Pod) ([]corev1.Event, error) {
+	return c.cache.listEvents(pod)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/patching"
@@ -11,6 +12,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,12 +31,9 @@ func NewPod(clientSet kubernetes.Interface, workloadsNamespace string) *Pod {
 	}
 }
 
-func (c *Pod) GetAll() ([]corev1.Pod, error) {
-	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(context.Background(), metav1.ListOptions{
-		LabelSelector: fmt.Sprintf(
-			"%s in (%s,%s,%s)",
-			k8s.LabelSourceType, "STG", "APP", "TASK",
-		),
+func (c *Pod) GetAll(ctx context.Context) ([]corev1.Pod, error) {
+	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: eiriniPodsSelector(),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list pods")
@@ -43,13 +42,9 @@ func (c *Pod) GetAll() ([]corev1.Pod, error) {
 	return podList.Items, nil
 }
 
-func (c *Pod) GetByLRPIdentifier(id opi.LRPIdentifier) ([]corev1.Pod, error) {
-	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(context.Background(), metav1.ListOptions{
-		LabelSelector: fmt.Sprintf(
-			"%s=%s,%s=%s",
-			k8s.LabelGUID, id.GUID,
-			k8s.LabelVersion, id.Version,
-		),
+func (c *Pod) GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]corev1.Pod, error) {
+	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: lrpIdentifierSelector(id),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list pods by lrp identifier")
@@ -58,15 +53,43 @@ func (c *Pod) GetByLRPIdentifier(id opi.LRPIdentifier) ([]corev1.Pod, error) {
 	return podList.Items, nil
 }
 
-func (c *Pod) Delete(namespace, name string) error {
-	return c.clientSet.CoreV1().Pods(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+func (c *Pod) GetByTaskGUID(ctx context.Context, guid string) ([]corev1.Pod, error) {
+	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: taskGUIDSelector(guid),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods by task guid")
+	}
+
+	return podList.Items, nil
+}
+
+func (c *Pod) GetBySourceType(ctx context.Context, sourceType string) ([]corev1.Pod, error) {
+	podList, err := c.clientSet.CoreV1().Pods(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: sourceTypeSelector(sourceType),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods by source type")
+	}
+
+	return podList.Items, nil
 }
 
-func (c *Pod) SetAnnotation(pod *corev1.Pod, key, value string) (*corev1.Pod, error) {
+func (c *Pod) GetLogs(ctx context.Context, namespace, name string, options *corev1.PodLogOptions) (io.ReadCloser, error) {
+	stream, err := c.clientSet.CoreV1().Pods(namespace).GetLogs(name, options).Stream(ctx)
+
+	return stream, errors.Wrap(err, "failed to stream pod logs")
+}
+
+func (c *Pod) Delete(ctx context.Context, namespace, name string) error {
+	return c.clientSet.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c *Pod) SetAnnotation(ctx context.Context, pod *corev1.Pod, key, value string) (*corev1.Pod, error) {
 	patchBytes := patching.NewAnnotation(key, value).GetJSONPatchBytes()
 
 	return c.clientSet.CoreV1().Pods(pod.Namespace).Patch(
-		context.Background(),
+		ctx,
 		pod.Name,
 		types.JSONPatchType,
 		patchBytes,
@@ -82,12 +105,12 @@ func NewPodDisruptionBudget(clientSet kubernetes.Interface) *PodDisruptionBudget
 	return &PodDisruptionBudget{clientSet: clientSet}
 }
 
-func (c *PodDisruptionBudget) Create(namespace string, podDisruptionBudget *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
-	return c.clientSet.PolicyV1beta1().PodDisruptionBudgets(namespace).Create(context.Background(), podDisruptionBudget, metav1.CreateOptions{})
+func (c *PodDisruptionBudget) Create(ctx context.Context, namespace string, podDisruptionBudget *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	return c.clientSet.PolicyV1beta1().PodDisruptionBudgets(namespace).Create(ctx, podDisruptionBudget, metav1.CreateOptions{})
 }
 
-func (c *PodDisruptionBudget) Delete(namespace string, name string) error {
-	return c.clientSet.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+func (c *PodDisruptionBudget) Delete(ctx context.Context, namespace string, name string) error {
+	return c.clientSet.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 type StatefulSet struct {
@@ -102,17 +125,17 @@ func NewStatefulSet(clientSet kubernetes.Interface, workloadsNamespace string) *
 	}
 }
 
-func (c *StatefulSet) Create(namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
-	return c.clientSet.AppsV1().StatefulSets(namespace).Create(context.Background(), statefulSet, metav1.CreateOptions{})
+func (c *StatefulSet) Create(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	return c.clientSet.AppsV1().StatefulSets(namespace).Create(ctx, statefulSet, metav1.CreateOptions{})
 }
 
-func (c *StatefulSet) Get(namespace, name string) (*appsv1.StatefulSet, error) {
-	return c.clientSet.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+func (c *StatefulSet) Get(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	return c.clientSet.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (c *StatefulSet) GetBySourceType(sourceType string) ([]appsv1.StatefulSet, error) {
-	statefulSetList, err := c.clientSet.AppsV1().StatefulSets(c.workloadsNamespace).List(context.Background(), metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("%s=%s", k8s.LabelSourceType, sourceType),
+func (c *StatefulSet) GetBySourceType(ctx context.Context, sourceType string) ([]appsv1.StatefulSet, error) {
+	statefulSetList, err := c.clientSet.AppsV1().StatefulSets(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: sourceTypeSelector(sourceType),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list statefulsets by resource type")
@@ -121,13 +144,9 @@ func (c *StatefulSet) GetBySourceType(sourceType string) ([]appsv1.StatefulSet,
 	return statefulSetList.Items, nil
 }
 
-func (c *StatefulSet) GetByLRPIdentifier(id opi.LRPIdentifier) ([]appsv1.StatefulSet, error) {
-	statefulSetList, err := c.clientSet.AppsV1().StatefulSets(c.workloadsNamespace).List(context.Background(), metav1.ListOptions{
-		LabelSelector: fmt.Sprintf(
-			"%s=%s,%s=%s",
-			k8s.LabelGUID, id.GUID,
-			k8s.LabelVersion, id.Version,
-		),
+func (c *StatefulSet) GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]appsv1.StatefulSet, error) {
+	statefulSetList, err := c.clientSet.AppsV1().StatefulSets(c.workloadsNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: lrpIdentifierSelector(id),
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list statefulsets by lrp identifier")
@@ -136,14 +155,14 @@ func (c *StatefulSet) GetByLRPIdentifier(id opi.LRPIdentifier) ([]appsv1.Statefu
 	return statefulSetList.Items, nil
 }
 
-func (c *StatefulSet) Update(namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
-	return c.clientSet.AppsV1().StatefulSets(namespace).Update(context.Background(), statefulSet, metav1.UpdateOptions{})
+func (c *StatefulSet) Update(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+	return c.clientSet.AppsV1().StatefulSets(namespace).Update(ctx, statefulSet, metav1.UpdateOptions{})
 }
 
-func (c *StatefulSet) Delete(namespace string, name string) error {
+func (c *StatefulSet) Delete(ctx context.Context, namespace string, name string) error {
 	backgroundPropagation := metav1.DeletePropagationBackground
 
-	return c.clientSet.AppsV1().StatefulSets(namespace).Delete(context.Background(), name, metav1.DeleteOptions{
+	return c.clientSet.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{
 		PropagationPolicy: &backgroundPropagation,
 	})
 }
@@ -159,7 +178,7 @@ func NewJob(clientSet kubernetes.Interface, workloadsNamespace string) *Job {
 	return &Job{
 		clientSet:          clientSet,
 		workloadsNamespace: workloadsNamespace,
-		jobType:            "TASK",
+		jobType:            k8s.TaskSourceType,
 		guidLabel:          k8s.LabelGUID,
 	}
 }
@@ -168,62 +187,66 @@ func NewStagingJob(clientSet kubernetes.Interface, workloadsNamespace string) *J
 	return &Job{
 		clientSet:          clientSet,
 		workloadsNamespace: workloadsNamespace,
-		jobType:            "STG",
+		jobType:            k8s.StagingSourceType,
 		guidLabel:          k8s.LabelStagingGUID,
 	}
 }
 
-func (c *Job) Create(namespace string, job *batchv1.Job) (*batchv1.Job, error) {
-	return c.clientSet.BatchV1().Jobs(namespace).Create(context.Background(), job, metav1.CreateOptions{})
+func (c *Job) Create(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error) {
+	return c.clientSet.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
 }
 
-func (c *Job) Delete(namespace string, name string) error {
+func (c *Job) Delete(ctx context.Context, namespace string, name string) error {
 	backgroundPropagation := metav1.DeletePropagationBackground
 	deleteOpts := metav1.DeleteOptions{
 		PropagationPolicy: &backgroundPropagation,
 	}
 
-	return c.clientSet.BatchV1().Jobs(namespace).Delete(context.Background(), name, deleteOpts)
+	return c.clientSet.BatchV1().Jobs(namespace).Delete(ctx, name, deleteOpts)
 }
 
-func (c *Job) GetByGUID(guid string, includeCompleted bool) ([]batchv1.Job, error) {
-	labelSelector := fmt.Sprintf("%s=%s", c.getGUIDLabel(), guid)
-
-	if !includeCompleted {
-		labelSelector += fmt.Sprintf(",%s!=%s", k8s.LabelTaskCompleted, k8s.TaskCompletedTrue)
-	}
-
-	listOpts := metav1.ListOptions{LabelSelector: labelSelector}
-	jobs, err := c.clientSet.BatchV1().Jobs(c.workloadsNamespace).List(context.Background(), listOpts)
+func (c *Job) GetByGUID(ctx context.Context, guid string, includeCompleted bool) ([]batchv1.Job, error) {
+	listOpts := metav1.ListOptions{LabelSelector: c.guidSelector(guid, includeCompleted)}
+	jobs, err := c.clientSet.BatchV1().Jobs(c.workloadsNamespace).List(ctx, listOpts)
 
 	return jobs.Items, errors.Wrap(err, "failed to list jobs by guid")
 }
 
-func (c *Job) List(includeCompleted bool) ([]batchv1.Job, error) {
-	labelSelector := fmt.Sprintf("%s=%s", k8s.LabelSourceType, c.jobType)
-
-	if !includeCompleted {
-		labelSelector += fmt.Sprintf(",%s!=%s", k8s.LabelTaskCompleted, k8s.TaskCompletedTrue)
-	}
-
-	listOpts := metav1.ListOptions{LabelSelector: labelSelector}
-	jobs, err := c.clientSet.BatchV1().Jobs(c.workloadsNamespace).List(context.Background(), listOpts)
+func (c *Job) List(ctx context.Context, includeCompleted bool) ([]batchv1.Job, error) {
+	listOpts := metav1.ListOptions{LabelSelector: c.listSelector(includeCompleted)}
+	jobs, err := c.clientSet.BatchV1().Jobs(c.workloadsNamespace).List(ctx, listOpts)
 
 	return jobs.Items, errors.Wrap(err, "failed to list jobs")
 }
 
-func (c *Job) SetLabel(job *batchv1.Job, label, value string) (*batchv1.Job, error) {
+func (c *Job) SetLabel(ctx context.Context, job *batchv1.Job, label, value string) (*batchv1.Job, error) {
 	patchBytes := patching.NewLabel(label, value).GetJSONPatchBytes()
 
 	return c.clientSet.BatchV1().Jobs(job.Namespace).Patch(
-		context.Background(),
+		ctx,
 		job.Name,
 		types.JSONPatchType,
 		patchBytes, metav1.PatchOptions{})
 }
 
+func (c *Job) guidSelector(guid string, includeCompleted bool) string {
+	return withoutCompleted(fmt.Sprintf("%s=%s", c.getGUIDLabel(), guid), includeCompleted)
+}
+
+func (c *Job) listSelector(includeCompleted bool) string {
+	return withoutCompleted(sourceTypeSelector(c.jobType), includeCompleted)
+}
+
+func withoutCompleted(labelSelector string, includeCompleted bool) string {
+	if includeCompleted {
+		return labelSelector
+	}
+
+	return labelSelector + fmt.Sprintf(",%s!=%s", k8s.LabelTaskCompleted, k8s.TaskCompletedTrue)
+}
+
 func (c *Job) getGUIDLabel() string {
-	if c.jobType == "TASK" {
+	if c.jobType == k8s.TaskSourceType {
 		return k8s.LabelGUID
 	}
 
@@ -238,20 +261,20 @@ func NewSecret(clientSet kubernetes.Interface) *Secret {
 	return &Secret{clientSet: clientSet}
 }
 
-func (c *Secret) Get(namespace, name string) (*corev1.Secret, error) {
-	return c.clientSet.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+func (c *Secret) Get(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	return c.clientSet.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
-func (c *Secret) Create(namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
-	return c.clientSet.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+func (c *Secret) Create(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	return c.clientSet.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
 }
 
-func (c *Secret) Update(namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
-	return c.clientSet.CoreV1().Secrets(namespace).Update(context.Background(), secret, metav1.UpdateOptions{})
+func (c *Secret) Update(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error) {
+	return c.clientSet.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
 }
 
-func (c *Secret) Delete(namespace string, name string) error {
-	return c.clientSet.CoreV1().Secrets(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+func (c *Secret) Delete(ctx context.Context, namespace string, name string) error {
+	return c.clientSet.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
 type Event struct {
@@ -264,8 +287,8 @@ func NewEvent(clientSet kubernetes.Interface) *Event {
 	}
 }
 
-func (c *Event) GetByPod(pod corev1.Pod) ([]corev1.Event, error) {
-	eventList, err := c.clientSet.CoreV1().Events("").List(context.Background(), metav1.ListOptions{
+func (c *Event) GetByPod(ctx context.Context, pod corev1.Pod) ([]corev1.Event, error) {
+	eventList, err := c.clientSet.CoreV1().Events("").List(ctx, metav1.ListOptions{
 		FieldSelector: fmt.Sprintf(
 			"involvedObject.namespace=%s,involvedObject.uid=%s,involvedObject.name=%s",
 			pod.Namespace,
@@ -280,7 +303,7 @@ func (c *Event) GetByPod(pod corev1.Pod) ([]corev1.Event, error) {
 	return eventList.Items, nil
 }
 
-func (c *Event) GetByInstanceAndReason(namespace string, ownerRef metav1.OwnerReference, instanceIndex int, reason string) (*corev1.Event, error) {
+func (c *Event) GetByInstanceAndReason(ctx context.Context, namespace string, ownerRef metav1.OwnerReference, instanceIndex int, reason string) (*corev1.Event, error) {
 	fieldSelector := fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s,involvedObject.namespace=%s,reason=%s",
 		ownerRef.Kind,
 		ownerRef.Name,
@@ -289,7 +312,7 @@ func (c *Event) GetByInstanceAndReason(namespace string, ownerRef metav1.OwnerRe
 	)
 	labelSelector := fmt.Sprintf("cloudfoundry.org/instance_index=%d", instanceIndex)
 
-	kubeEvents, err := c.clientSet.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+	kubeEvents, err := c.clientSet.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: fieldSelector,
 		LabelSelector: labelSelector,
 	})
@@ -304,10 +327,46 @@ func (c *Event) GetByInstanceAndReason(namespace string, ownerRef metav1.OwnerRe
 	return nil, nil
 }
 
-func (c *Event) Create(namespace string, event *corev1.Event) (*corev1.Event, error) {
-	return c.clientSet.CoreV1().Events(namespace).Create(context.Background(), event, metav1.CreateOptions{})
+func (c *Event) Create(ctx context.Context, namespace string, event *corev1.Event) (*corev1.Event, error) {
+	return c.clientSet.CoreV1().Events(namespace).Create(ctx, event, metav1.CreateOptions{})
+}
+
+func (c *Event) Update(ctx context.Context, namespace string, event *corev1.Event) (*corev1.Event, error) {
+	return c.clientSet.CoreV1().Events(namespace).Update(ctx, event, metav1.UpdateOptions{})
+}
+
+type NetworkPolicy struct {
+	clientSet kubernetes.Interface
+}
+
+func NewNetworkPolicy(clientSet kubernetes.Interface) *NetworkPolicy {
+	return &NetworkPolicy{clientSet: clientSet}
+}
+
+func (c *NetworkPolicy) Create(ctx context.Context, namespace string, networkPolicy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	return c.clientSet.NetworkingV1().NetworkPolicies(namespace).Create(ctx, networkPolicy, metav1.CreateOptions{})
+}
+
+func (c *NetworkPolicy) Update(ctx context.Context, namespace string, networkPolicy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	return c.clientSet.NetworkingV1().NetworkPolicies(namespace).Update(ctx, networkPolicy, metav1.UpdateOptions{})
+}
+
+func (c *NetworkPolicy) Delete(ctx context.Context, namespace string, name string) error {
+	return c.clientSet.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func eiriniPodsSelector() string {
+	return fmt.Sprintf("%s in (%s,%s,%s)", k8s.LabelSourceType, k8s.StagingSourceType, k8s.AppSourceType, k8s.TaskSourceType)
+}
+
+func sourceTypeSelector(sourceType string) string {
+	return fmt.Sprintf("%s=%s", k8s.LabelSourceType, sourceType)
+}
+
+func lrpIdentifierSelector(id opi.LRPIdentifier) string {
+	return fmt.Sprintf("%s=%s,%s=%s", k8s.LabelGUID, id.GUID, k8s.LabelVersion, id.Version)
 }
 
-func (c *Event) Update(namespace string, event *corev1.Event) (*corev1.Event, error) {
-	return c.clientSet.CoreV1().Events(namespace).Update(context.Background(), event, metav1.UpdateOptions{})
+func taskGUIDSelector(guid string) string {
+	return fmt.Sprintf("%s=%s,%s=%s", k8s.LabelSourceType, k8s.TaskSourceType, k8s.LabelGUID, guid)
 }
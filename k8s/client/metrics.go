@@ -0,0 +1,71 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	clientmetrics "k8s.io/client-go/tools/metrics"
+)
+
+// RegisterMetrics records the latency of all requests made by client-go
+// rest clients. client-go only accepts a single set of metrics per process,
+// so this must be called before any client is created.
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "eirini",
+		Subsystem: "k8s_client",
+		Name:      "request_duration_seconds",
+		Help:      "Time taken by requests to the Kubernetes API, by verb and resource.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"verb", "resource"})
+
+	if err := registerer.Register(latency); err != nil {
+		return errors.Wrap(err, "failed to register kubernetes client metrics")
+	}
+
+	clientmetrics.Register(clientmetrics.RegisterOpts{
+		RequestLatency: requestLatency{histogram: latency},
+	})
+
+	return nil
+}
+
+type requestLatency struct {
+	histogram *prometheus.HistogramVec
+}
+
+func (l requestLatency) Observe(verb string, u url.URL, latency time.Duration) {
+	l.histogram.WithLabelValues(verb, resourceFromPath(u.Path)).Observe(latency.Seconds())
+}
+
+// resourceFromPath extracts the resource (and subresource) from a
+// Kubernetes API path, leaving out names to keep the cardinality low. For
+// example /api/v1/namespaces/ns/pods/name/log becomes pods/log.
+func resourceFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return "unknown"
+	}
+
+	if len(segments) > 2 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+
+	switch len(segments) {
+	case 0:
+		return "unknown"
+	case 1, 2:
+		return segments[0]
+	default:
+		return segments[0] + "/" + segments[2]
+	}
+}
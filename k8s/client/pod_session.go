@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const portForwardProtocolV1Name = "portforward.k8s.io"
+
+// PodSession opens interactive sessions into the containers of a pod, the
+// same way kubectl exec and kubectl port-forward do.
+type PodSession struct {
+	config    *rest.Config
+	clientSet kubernetes.Interface
+}
+
+func NewPodSession(config *rest.Config, clientSet kubernetes.Interface) *PodSession {
+	return &PodSession{
+		config:    config,
+		clientSet: clientSet,
+	}
+}
+
+// Exec runs the command in the container and blocks until it exits. The
+// error implements k8s.io/client-go/util/exec.ExitError when the command
+// exits with a non-zero status.
+func (s *PodSession) Exec(namespace, name, container string, command []string, streams remotecommand.StreamOptions) error {
+	req := s.clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    streams.Stderr != nil,
+			TTY:       streams.Tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(s.config, http.MethodPost, req.URL())
+	if err != nil {
+		return errors.Wrap(err, "failed to create executor")
+	}
+
+	return executor.Stream(streams)
+}
+
+// PortForward connects the stream to the port of the pod and copies data
+// both ways until the pod closes the connection, the stream ends or the
+// context is done.
+func (s *PodSession) PortForward(ctx context.Context, namespace, name string, port int, stream io.ReadWriter) error {
+	req := s.clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(s.config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create round tripper")
+	}
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	conn, _, err := dialer.Dial(portForwardProtocolV1Name)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial pod")
+	}
+	defer conn.Close()
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(port))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+
+	errorStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return errors.Wrap(err, "failed to create error stream")
+	}
+	// the error stream is only read from
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+
+	dataStream, err := conn.CreateStream(headers)
+	if err != nil {
+		return errors.Wrap(err, "failed to create data stream")
+	}
+
+	forwardErr := make(chan error, 2)
+
+	go func() {
+		message, readErr := ioutil.ReadAll(errorStream)
+		if readErr == nil && len(message) > 0 {
+			readErr = errors.New(strings.TrimSpace(string(message)))
+		}
+
+		if readErr != nil {
+			forwardErr <- errors.Wrapf(readErr, "failed to forward port %d", port)
+		}
+	}()
+
+	go func() {
+		// closing the data stream tells the pod that no more data is coming
+		_, _ = io.Copy(dataStream, stream)
+		dataStream.Close()
+	}()
+
+	go func() {
+		_, copyErr := io.Copy(stream, dataStream)
+		forwardErr <- errors.Wrap(copyErr, "failed to copy from pod")
+	}()
+
+	select {
+	case err = <-forwardErr:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
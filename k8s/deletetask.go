@@ -1,9 +1,11 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s/utils"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
@@ -14,12 +16,12 @@ import (
 //counterfeiter:generate . SecretsDeleter
 
 type JobDeletingClient interface {
-	GetByGUID(guid string, includeCompleted bool) ([]batchv1.Job, error)
-	Delete(namespace string, name string) error
+	GetByGUID(ctx context.Context, guid string, includeCompleted bool) ([]batchv1.Job, error)
+	Delete(ctx context.Context, namespace string, name string) error
 }
 
 type SecretsDeleter interface {
-	Delete(namespace, name string) error
+	Delete(ctx context.Context, namespace, name string) error
 }
 
 type TaskDeleter struct {
@@ -40,31 +42,37 @@ func NewTaskDeleter(
 	}
 }
 
-func (d *TaskDeleter) Delete(guid string) (string, error) {
+func (d *TaskDeleter) Delete(ctx context.Context, guid string) (string, error) {
 	logger := d.logger.Session("delete", lager.Data{"guid": guid})
 
-	job, err := d.getJobByGUID(logger, guid)
+	job, err := d.getJobByGUID(ctx, logger, guid)
 	if err != nil {
 		return "", err
 	}
 
-	return d.delete(logger, job)
+	return d.delete(ctx, logger, job)
 }
 
-func (d *TaskDeleter) DeleteStaging(guid string) error {
-	_, err := d.Delete(guid)
+func (d *TaskDeleter) DeleteStaging(ctx context.Context, guid string) error {
+	_, err := d.Delete(ctx, guid)
 
 	return err
 }
 
-func (d *TaskDeleter) getJobByGUID(logger lager.Logger, guid string) (batchv1.Job, error) {
-	jobs, err := d.jobClient.GetByGUID(guid, true)
+func (d *TaskDeleter) getJobByGUID(ctx context.Context, logger lager.Logger, guid string) (batchv1.Job, error) {
+	jobs, err := d.jobClient.GetByGUID(ctx, guid, true)
 	if err != nil {
 		logger.Error("failed-to-list-jobs", err)
 
 		return batchv1.Job{}, errors.Wrap(err, "failed to list jobs")
 	}
 
+	if len(jobs) == 0 {
+		logger.Info("job-does-not-exist")
+
+		return batchv1.Job{}, errors.Wrapf(eirini.ErrNotFound, "job with guid %s does not exist", guid)
+	}
+
 	if len(jobs) != 1 {
 		logger.Error("job-does-not-have-1-instance", nil, lager.Data{"instances": len(jobs)})
 
@@ -74,8 +82,8 @@ func (d *TaskDeleter) getJobByGUID(logger lager.Logger, guid string) (batchv1.Jo
 	return jobs[0], nil
 }
 
-func (d *TaskDeleter) delete(logger lager.Logger, job batchv1.Job) (string, error) {
-	if err := d.deleteDockerRegistrySecret(logger, job); err != nil {
+func (d *TaskDeleter) delete(ctx context.Context, logger lager.Logger, job batchv1.Job) (string, error) {
+	if err := d.deleteDockerRegistrySecret(ctx, logger, job); err != nil {
 		return "", err
 	}
 
@@ -85,7 +93,7 @@ func (d *TaskDeleter) delete(logger lager.Logger, job batchv1.Job) (string, erro
 		return callbackURL, nil
 	}
 
-	if err := d.jobClient.Delete(job.Namespace, job.Name); err != nil {
+	if err := d.jobClient.Delete(ctx, job.Namespace, job.Name); err != nil {
 		logger.Error("failed-to-delete-job", err)
 
 		return "", errors.Wrap(err, "failed to delete job")
@@ -94,7 +102,7 @@ func (d *TaskDeleter) delete(logger lager.Logger, job batchv1.Job) (string, erro
 	return callbackURL, nil
 }
 
-func (d *TaskDeleter) deleteDockerRegistrySecret(logger lager.Logger, job batchv1.Job) error {
+func (d *TaskDeleter) deleteDockerRegistrySecret(ctx context.Context, logger lager.Logger, job batchv1.Job) error {
 	dockerSecretNamePrefix := dockerImagePullSecretNamePrefix(
 		job.Annotations[AnnotationAppName],
 		job.Annotations[AnnotationSpaceName],
@@ -106,7 +114,7 @@ func (d *TaskDeleter) deleteDockerRegistrySecret(logger lager.Logger, job batchv
 			continue
 		}
 
-		if err := d.secretsDeleter.Delete(job.Namespace, secret.Name); err != nil {
+		if err := d.secretsDeleter.Delete(ctx, job.Namespace, secret.Name); err != nil {
 			logger.Error("failed-to-delete-secret", err, lager.Data{"name": secret.Name, "namespace": job.Namespace})
 
 			return errors.Wrap(err, "failed to delete secret")
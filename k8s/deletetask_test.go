@@ -1,8 +1,10 @@
 package k8s_test
 
 import (
+	"context"
 	"fmt"
 
+	"code.cloudfoundry.org/eirini"
 	. "code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini/opi"
@@ -64,7 +66,7 @@ var _ = Describe("TaskDeleter", func() {
 
 	Describe("Delete", func() {
 		It("deletes the job", func() {
-			completionCallback, err := deleter.Delete(taskGUID)
+			completionCallback, err := deleter.Delete(context.Background(), taskGUID)
 
 			By("succeeding")
 			Expect(err).To(Succeed())
@@ -74,7 +76,7 @@ var _ = Describe("TaskDeleter", func() {
 
 			By("selecting the job using the task label guid and the eirini label")
 			Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
-			guid, includeCompleted := jobClient.GetByGUIDArgsForCall(0)
+			_, guid, includeCompleted := jobClient.GetByGUIDArgsForCall(0)
 			Expect(guid).To(Equal(taskGUID))
 			Expect(includeCompleted).To(Equal(true))
 		})
@@ -92,7 +94,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("does not delete the job", func() {
-				_, err := deleter.Delete(taskGUID)
+				_, err := deleter.Delete(context.Background(), taskGUID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(jobClient.DeleteCallCount()).To(Equal(0))
 			})
@@ -104,8 +106,9 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := deleter.Delete(taskGUID)
-				Expect(err).To(MatchError(fmt.Sprintf("job with guid %s should have 1 instance, but it has: %d", taskGUID, 0)))
+				_, err := deleter.Delete(context.Background(), taskGUID)
+				Expect(err).To(MatchError(fmt.Sprintf("job with guid %s does not exist: not found", taskGUID)))
+				Expect(errors.Is(err, eirini.ErrNotFound)).To(BeTrue())
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
 			})
@@ -117,7 +120,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := deleter.Delete(taskGUID)
+				_, err := deleter.Delete(context.Background(), taskGUID)
 				Expect(err).To(MatchError(fmt.Sprintf("job with guid %s should have 1 instance, but it has: %d", taskGUID, 2)))
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
@@ -138,10 +141,10 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("deletes the docker registry image pull secret only", func() {
-				_, err := deleter.Delete(task.GUID)
+				_, err := deleter.Delete(context.Background(), task.GUID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(secretDeleter.DeleteCallCount()).To(Equal(1))
-				actualNamespace, actualSecretName := secretDeleter.DeleteArgsForCall(0)
+				_, actualNamespace, actualSecretName := secretDeleter.DeleteArgsForCall(0)
 				Expect(actualNamespace).To(Equal("my-namespace"))
 				Expect(actualSecretName).To(Equal(dockerRegistrySecretName))
 			})
@@ -152,7 +155,7 @@ var _ = Describe("TaskDeleter", func() {
 				})
 
 				It("returns the error", func() {
-					_, err := deleter.Delete(task.GUID)
+					_, err := deleter.Delete(context.Background(), task.GUID)
 					Expect(err).To(MatchError(ContainSubstring("docker-secret-delete-failure")))
 				})
 			})
@@ -164,7 +167,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := deleter.Delete(taskGUID)
+				_, err := deleter.Delete(context.Background(), taskGUID)
 				Expect(err).To(MatchError(ContainSubstring("failed to list jobs")))
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
@@ -177,7 +180,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := deleter.Delete(taskGUID)
+				_, err := deleter.Delete(context.Background(), taskGUID)
 				Expect(err).To(MatchError(ContainSubstring("failed to delete")))
 			})
 		})
@@ -185,15 +188,15 @@ var _ = Describe("TaskDeleter", func() {
 
 	Describe("DeleteStaging", func() {
 		It("daletes the job", func() {
-			Expect(deleter.DeleteStaging(taskGUID)).To(Succeed())
+			Expect(deleter.DeleteStaging(context.Background(), taskGUID)).To(Succeed())
 
 			Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
-			guid, includeCompleted := jobClient.GetByGUIDArgsForCall(0)
+			_, guid, includeCompleted := jobClient.GetByGUIDArgsForCall(0)
 			Expect(guid).To(Equal(taskGUID))
 			Expect(includeCompleted).To(Equal(true))
 
 			Expect(jobClient.DeleteCallCount()).To(Equal(1))
-			namespace, jobName := jobClient.DeleteArgsForCall(0)
+			_, namespace, jobName := jobClient.DeleteArgsForCall(0)
 			Expect(jobName).To(Equal("my-job"))
 			Expect(namespace).To(Equal("my-namespace"))
 		})
@@ -204,7 +207,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				Expect(deleter.DeleteStaging(taskGUID)).To(MatchError(fmt.Sprintf("job with guid %s should have 1 instance, but it has: %d", taskGUID, 0)))
+				Expect(deleter.DeleteStaging(context.Background(), taskGUID)).To(MatchError(eirini.ErrNotFound))
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
 			})
@@ -216,7 +219,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				Expect(deleter.DeleteStaging(taskGUID)).To(MatchError(fmt.Sprintf("job with guid %s should have 1 instance, but it has: %d", taskGUID, 2)))
+				Expect(deleter.DeleteStaging(context.Background(), taskGUID)).To(MatchError(fmt.Sprintf("job with guid %s should have 1 instance, but it has: %d", taskGUID, 2)))
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
 			})
@@ -236,9 +239,9 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("deletes the docker registry image pull secret only", func() {
-				Expect(deleter.DeleteStaging(task.GUID)).To(Succeed())
+				Expect(deleter.DeleteStaging(context.Background(), task.GUID)).To(Succeed())
 				Expect(secretDeleter.DeleteCallCount()).To(Equal(1))
-				actualNamespace, actualSecretName := secretDeleter.DeleteArgsForCall(0)
+				_, actualNamespace, actualSecretName := secretDeleter.DeleteArgsForCall(0)
 				Expect(actualNamespace).To(Equal("my-namespace"))
 				Expect(actualSecretName).To(Equal(dockerRegistrySecretName))
 			})
@@ -249,7 +252,7 @@ var _ = Describe("TaskDeleter", func() {
 				})
 
 				It("returns the error", func() {
-					Expect(deleter.DeleteStaging(task.GUID)).To(MatchError(ContainSubstring("docker-secret-delete-failure")))
+					Expect(deleter.DeleteStaging(context.Background(), task.GUID)).To(MatchError(ContainSubstring("docker-secret-delete-failure")))
 				})
 			})
 		})
@@ -260,7 +263,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				Expect(deleter.DeleteStaging(taskGUID)).To(MatchError(ContainSubstring("failed to list jobs")))
+				Expect(deleter.DeleteStaging(context.Background(), taskGUID)).To(MatchError(ContainSubstring("failed to list jobs")))
 				Expect(jobClient.GetByGUIDCallCount()).To(Equal(1))
 				Expect(jobClient.DeleteCallCount()).To(BeZero())
 			})
@@ -272,7 +275,7 @@ var _ = Describe("TaskDeleter", func() {
 			})
 
 			It("should return an error", func() {
-				Expect(deleter.DeleteStaging(taskGUID)).To(MatchError(ContainSubstring("failed to delete")))
+				Expect(deleter.DeleteStaging(context.Background(), taskGUID)).To(MatchError(ContainSubstring("failed to delete")))
 			})
 		})
 	})
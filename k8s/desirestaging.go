@@ -0,0 +1,159 @@
+package k8s
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	stagingDownloaderContainerName = "opi-task-downloader"
+	stagingExecutorContainerName   = "opi-task-executor"
+	stagingUploaderContainerName   = "opi-task-uploader"
+)
+
+// StagingDesirer runs buildpack staging as a Job. The app bits are downloaded
+// and built into a droplet by two init containers, and the droplet is then
+// uploaded to the CC, which reports the staging result back to Eirini.
+type StagingDesirer struct {
+	Logger                            lager.Logger
+	JobClient                         JobCreatingClient
+	ServiceAccountName                string
+	AllowAutomountServiceAccountToken bool
+	DownloaderImage                   string
+	ExecutorImage                     string
+	UploaderImage                     string
+	EiriniAddress                     string
+}
+
+func (d *StagingDesirer) DesireStaging(ctx context.Context, namespace string, task *opi.Task) error {
+	logger := d.Logger.Session("desire-staging", lager.Data{"staging-guid": task.GUID, "namespace": namespace})
+
+	job := d.toStagingJob(task)
+	job.Namespace = namespace
+	tracing.InjectAnnotations(ctx, job.Annotations)
+
+	if _, err := d.JobClient.Create(ctx, namespace, job); err != nil {
+		logger.Error("failed-to-create-job", err)
+
+		return errors.Wrap(err, "failed to create staging job")
+	}
+
+	return nil
+}
+
+// GetStagingAnnotations returns the annotations of the staging job, which
+// carry the trace context of the request that desired it.
+func (d *StagingDesirer) GetStagingAnnotations(ctx context.Context, stagingGUID string) (map[string]string, error) {
+	jobs, err := d.JobClient.GetByGUID(ctx, stagingGUID, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list staging jobs")
+	}
+
+	if len(jobs) == 0 {
+		return nil, errors.Wrapf(eirini.ErrNotFound, "staging job with guid %s does not exist", stagingGUID)
+	}
+
+	return jobs[0].Annotations, nil
+}
+
+func (d *StagingDesirer) toStagingJob(task *opi.Task) *batch.Job {
+	job := toJob(task, d.AllowAutomountServiceAccountToken)
+	job.GenerateName = job.Name + "-staging-"
+	job.Name = ""
+	job.Spec.Template.Spec.ServiceAccountName = d.ServiceAccountName
+	job.Labels[LabelSourceType] = StagingSourceType
+	job.Labels[LabelStagingGUID] = task.GUID
+	job.Annotations[AnnotationCompletionCallback] = task.CompletionCallback
+	job.Annotations[AnnotationStagingGUID] = task.GUID
+
+	envs := append(getEnvs(task), corev1.EnvVar{Name: eirini.EnvEiriniAddress, Value: d.EiriniAddress})
+	allowPrivilegeEscalation := false
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+	}
+
+	certsMount := corev1.VolumeMount{
+		Name:      eirini.CertsVolumeName,
+		MountPath: eirini.CertsMountPath,
+		ReadOnly:  true,
+	}
+	workspaceMount := corev1.VolumeMount{
+		Name:      eirini.RecipeWorkspaceName,
+		MountPath: eirini.RecipeWorkspaceDir,
+	}
+	outputMount := corev1.VolumeMount{
+		Name:      eirini.RecipeOutputName,
+		MountPath: eirini.RecipeOutputLocation,
+	}
+
+	job.Spec.Template.Spec.InitContainers = []corev1.Container{
+		{
+			Name:            stagingDownloaderContainerName,
+			Image:           d.DownloaderImage,
+			ImagePullPolicy: corev1.PullAlways,
+			Env:             envs,
+			SecurityContext: securityContext,
+			VolumeMounts:    []corev1.VolumeMount{certsMount, workspaceMount},
+		},
+		{
+			Name:            stagingExecutorContainerName,
+			Image:           d.ExecutorImage,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         []string{eirini.RecipePacksBuilderPath},
+			Env:             envs,
+			SecurityContext: securityContext,
+			VolumeMounts:    []corev1.VolumeMount{workspaceMount, outputMount},
+			Resources:       getTaskResources(task),
+		},
+	}
+
+	job.Spec.Template.Spec.Containers = []corev1.Container{
+		{
+			Name:            stagingUploaderContainerName,
+			Image:           d.UploaderImage,
+			ImagePullPolicy: corev1.PullAlways,
+			Env:             envs,
+			SecurityContext: securityContext,
+			VolumeMounts:    []corev1.VolumeMount{certsMount, outputMount},
+		},
+	}
+
+	job.Spec.Template.Spec.Volumes = []corev1.Volume{
+		{
+			Name: eirini.CertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: eirini.CCUploaderSecretName},
+							},
+						},
+						{
+							Secret: &corev1.SecretProjection{
+								LocalObjectReference: corev1.LocalObjectReference{Name: eirini.EiriniClientSecretName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:         eirini.RecipeWorkspaceName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			Name:         eirini.RecipeOutputName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}
+
+	return job
+}
@@ -0,0 +1,218 @@
+package k8s_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini"
+	. "code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("StagingDesirer", func() {
+	var (
+		task          *opi.Task
+		desirer       *StagingDesirer
+		fakeJobClient *k8sfakes.FakeJobCreatingClient
+		job           *batch.Job
+		jobNamespace  string
+		desireErr     error
+		ctx           context.Context
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fakeJobClient = new(k8sfakes.FakeJobCreatingClient)
+		task = &opi.Task{
+			GUID:               "staging-guid",
+			AppName:            "my-app",
+			AppGUID:            "app-guid",
+			SpaceName:          "my-space",
+			OrgName:            "my-org",
+			CompletionCallback: "example.com/call/me",
+			Env:                map[string]string{eirini.EnvDownloadURL: "example.com/download"},
+			MemoryMB:           256,
+			DiskMB:             512,
+			CPUWeight:          10,
+		}
+
+		desirer = &StagingDesirer{
+			Logger:             lagertest.NewTestLogger("staging-desirer"),
+			JobClient:          fakeJobClient,
+			ServiceAccountName: "staging-service-account",
+			DownloaderImage:    "downloader:1",
+			ExecutorImage:      "executor:1",
+			UploaderImage:      "uploader:1",
+			EiriniAddress:      "https://eirini.example.com",
+		}
+	})
+
+	JustBeforeEach(func() {
+		desireErr = desirer.DesireStaging(ctx, "staging-ns", task)
+	})
+
+	It("should succeed", func() {
+		Expect(desireErr).NotTo(HaveOccurred())
+	})
+
+	Context("the created job", func() {
+		JustBeforeEach(func() {
+			Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
+			_, jobNamespace, job = fakeJobClient.CreateArgsForCall(0)
+		})
+
+		It("should be created in the requested namespace", func() {
+			Expect(jobNamespace).To(Equal("staging-ns"))
+			Expect(job.Namespace).To(Equal("staging-ns"))
+		})
+
+		It("should have a generated staging name", func() {
+			Expect(job.Name).To(BeEmpty())
+			Expect(job.GenerateName).To(HavePrefix("my-app-my-space-"))
+			Expect(job.GenerateName).To(HaveSuffix("-staging-"))
+		})
+
+		It("should be labelled and annotated as a staging job", func() {
+			Expect(job.Labels).To(HaveKeyWithValue(LabelSourceType, "STG"))
+			Expect(job.Labels).To(HaveKeyWithValue(LabelStagingGUID, "staging-guid"))
+			Expect(job.Annotations).To(HaveKeyWithValue(AnnotationStagingGUID, "staging-guid"))
+			Expect(job.Annotations).To(HaveKeyWithValue(AnnotationCompletionCallback, "example.com/call/me"))
+			Expect(job.Annotations).NotTo(HaveKey(tracing.AnnotationTraceParent))
+		})
+
+		When("the request is traced", func() {
+			BeforeEach(func() {
+				ctx = trace.ContextWithSpanContext(ctx, tracedSpanContext())
+			})
+
+			It("should store the trace context in the job annotations", func() {
+				Expect(job.Annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"))
+			})
+		})
+
+		It("should use the configured service account", func() {
+			Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal("staging-service-account"))
+		})
+
+		It("should download and build the app in init containers", func() {
+			initContainers := job.Spec.Template.Spec.InitContainers
+			Expect(initContainers).To(HaveLen(2))
+
+			Expect(initContainers[0].Name).To(Equal("opi-task-downloader"))
+			Expect(initContainers[0].Image).To(Equal("downloader:1"))
+
+			Expect(initContainers[1].Name).To(Equal("opi-task-executor"))
+			Expect(initContainers[1].Image).To(Equal("executor:1"))
+			Expect(initContainers[1].Command).To(ConsistOf(eirini.RecipePacksBuilderPath))
+			Expect(initContainers[1].Resources.Limits.Memory().Value()).To(Equal(int64(256 * 1000 * 1000)))
+		})
+
+		It("should upload the droplet in the main container", func() {
+			containers := job.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Name).To(Equal("opi-task-uploader"))
+			Expect(containers[0].Image).To(Equal("uploader:1"))
+		})
+
+		It("should pass the staging environment to all containers", func() {
+			for _, c := range append(job.Spec.Template.Spec.InitContainers, job.Spec.Template.Spec.Containers...) {
+				Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: eirini.EnvEiriniAddress, Value: "https://eirini.example.com"}))
+				Expect(c.Env).To(ContainElement(corev1.EnvVar{Name: eirini.EnvDownloadURL, Value: "example.com/download"}))
+			}
+		})
+
+		It("should share the workspace and output volumes between the containers", func() {
+			volumes := job.Spec.Template.Spec.Volumes
+			Expect(volumes).To(HaveLen(3))
+			Expect(volumes[0].Name).To(Equal(eirini.CertsVolumeName))
+			Expect(volumes[0].Projected.Sources).To(HaveLen(2))
+			Expect(volumes[0].Projected.Sources[0].Secret.Name).To(Equal(eirini.CCUploaderSecretName))
+			Expect(volumes[0].Projected.Sources[1].Secret.Name).To(Equal(eirini.EiriniClientSecretName))
+			Expect(volumes[1].Name).To(Equal(eirini.RecipeWorkspaceName))
+			Expect(volumes[1].EmptyDir).NotTo(BeNil())
+			Expect(volumes[2].Name).To(Equal(eirini.RecipeOutputName))
+			Expect(volumes[2].EmptyDir).NotTo(BeNil())
+
+			initContainers := job.Spec.Template.Spec.InitContainers
+			Expect(initContainers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      eirini.RecipeWorkspaceName,
+				MountPath: eirini.RecipeWorkspaceDir,
+			}))
+			Expect(initContainers[1].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      eirini.RecipeOutputName,
+				MountPath: eirini.RecipeOutputLocation,
+			}))
+			Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      eirini.RecipeOutputName,
+				MountPath: eirini.RecipeOutputLocation,
+			}))
+		})
+	})
+
+	When("creating the job fails", func() {
+		BeforeEach(func() {
+			fakeJobClient.CreateReturns(nil, errors.New("boom"))
+		})
+
+		It("should return an error", func() {
+			Expect(desireErr).To(MatchError(ContainSubstring("failed to create staging job")))
+		})
+	})
+
+	Describe("GetStagingAnnotations", func() {
+		var (
+			annotations map[string]string
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			fakeJobClient.GetByGUIDReturns([]batch.Job{{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{tracing.AnnotationTraceParent: "the-trace-parent"},
+				},
+			}}, nil)
+		})
+
+		JustBeforeEach(func() {
+			annotations, getErr = desirer.GetStagingAnnotations(ctx, "staging-guid")
+		})
+
+		It("returns the annotations of the staging job", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, "the-trace-parent"))
+
+			_, guid, includeCompleted := fakeJobClient.GetByGUIDArgsForCall(0)
+			Expect(guid).To(Equal("staging-guid"))
+			Expect(includeCompleted).To(BeTrue())
+		})
+
+		When("the staging job does not exist", func() {
+			BeforeEach(func() {
+				fakeJobClient.GetByGUIDReturns(nil, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(getErr, eirini.ErrNotFound)).To(BeTrue())
+			})
+		})
+
+		When("listing the jobs fails", func() {
+			BeforeEach(func() {
+				fakeJobClient.GetByGUIDReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(getErr).To(MatchError(ContainSubstring("failed to list staging jobs")))
+			})
+		})
+	})
+})
@@ -1,36 +1,46 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
+	"io"
 
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s/utils"
 	"code.cloudfoundry.org/eirini/k8s/utils/dockerutils"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	batch "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
-	taskSourceType       = "TASK"
 	opiTaskContainerName = "opi-task"
 	parallelism          = 1
 	completions          = 1
 )
 
 //counterfeiter:generate . JobCreatingClient
+//counterfeiter:generate . TaskPodsClient
 //counterfeiter:generate . SecretsCreator
 
 type JobCreatingClient interface {
-	Create(namespace string, job *batch.Job) (*batch.Job, error)
-	GetByGUID(guid string, includeCompleted bool) ([]batch.Job, error)
-	List(includeCompleted bool) ([]batch.Job, error)
+	Create(ctx context.Context, namespace string, job *batch.Job) (*batch.Job, error)
+	GetByGUID(ctx context.Context, guid string, includeCompleted bool) ([]batch.Job, error)
+	List(ctx context.Context, includeCompleted bool) ([]batch.Job, error)
+}
+
+type TaskPodsClient interface {
+	GetByTaskGUID(ctx context.Context, guid string) ([]corev1.Pod, error)
+	GetBySourceType(ctx context.Context, sourceType string) ([]corev1.Pod, error)
+	GetLogs(ctx context.Context, namespace, name string, options *corev1.PodLogOptions) (io.ReadCloser, error)
 }
 
 type SecretsCreator interface {
-	Create(namespace string, secret *corev1.Secret) (*corev1.Secret, error)
+	Create(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error)
 }
 
 type KeyPath struct {
@@ -41,57 +51,77 @@ type KeyPath struct {
 type TaskDesirer struct {
 	logger                            lager.Logger
 	jobClient                         JobCreatingClient
+	podsClient                        TaskPodsClient
 	secretsCreator                    SecretsCreator
 	serviceAccountName                string
 	registrySecretName                string
 	allowAutomountServiceAccountToken bool
+	placementTags                     map[string]eirini.PlacementTag
+	dropletDownloaderImage            string
 }
 
 func NewTaskDesirer(
 	logger lager.Logger,
 	jobClient JobCreatingClient,
+	podsClient TaskPodsClient,
 	secretsCreator SecretsCreator,
 	serviceAccountName string,
 	registrySecretName string,
 	allowAutomountServiceAccountToken bool,
+	placementTags map[string]eirini.PlacementTag,
+	dropletDownloaderImage string,
 ) *TaskDesirer {
 	return &TaskDesirer{
 		logger:                            logger.Session("task-desirer"),
 		jobClient:                         jobClient,
+		podsClient:                        podsClient,
 		secretsCreator:                    secretsCreator,
 		serviceAccountName:                serviceAccountName,
 		registrySecretName:                registrySecretName,
 		allowAutomountServiceAccountToken: allowAutomountServiceAccountToken,
+		placementTags:                     placementTags,
+		dropletDownloaderImage:            dropletDownloaderImage,
 	}
 }
 
 func NewTaskDesirerWithEiriniInstance(
 	logger lager.Logger,
 	jobClient JobCreatingClient,
+	podsClient TaskPodsClient,
 	secretsCreator SecretsCreator,
 	serviceAccountName string,
 	registrySecretName string,
 	allowAutomountServiceAccountToken bool,
+	placementTags map[string]eirini.PlacementTag,
+	dropletDownloaderImage string,
 ) *TaskDesirer {
 	desirer := NewTaskDesirer(
 		logger,
 		jobClient,
+		podsClient,
 		secretsCreator,
 		serviceAccountName,
 		registrySecretName,
 		allowAutomountServiceAccountToken,
+		placementTags,
+		dropletDownloaderImage,
 	)
 
 	return desirer
 }
 
-func (d *TaskDesirer) Desire(namespace string, task *opi.Task, opts ...DesireOption) error {
+func (d *TaskDesirer) Desire(ctx context.Context, namespace string, task *opi.Task, opts ...DesireOption) error {
 	logger := d.logger.Session("desire", lager.Data{"guid": task.GUID, "name": task.Name, "namespace": namespace})
 
-	job := d.toTaskJob(task)
+	job, err := d.toTaskJob(task)
+	if err != nil {
+		logger.Error("failed-to-build-job", err)
+
+		return err
+	}
 
 	if imageInPrivateRegistry(task) {
-		if err := d.addImagePullSecret(namespace, task, job); err != nil {
+		if err := d.addImagePullSecret(ctx, namespace, task, job); err != nil {
 			logger.Error("failed-to-add-image-pull-secret", err)
 
 			return err
@@ -99,6 +129,7 @@ func (d *TaskDesirer) Desire(namespace string, task *opi.Task, opts ...DesireOpt
 	}
 
 	job.Namespace = namespace
+	tracing.InjectAnnotations(ctx, job.Annotations)
 
 	for _, opt := range opts {
 		err := opt(job)
@@ -109,7 +140,7 @@ func (d *TaskDesirer) Desire(namespace string, task *opi.Task, opts ...DesireOpt
 		}
 	}
 
-	_, err := d.jobClient.Create(namespace, job)
+	_, err = d.jobClient.Create(ctx, namespace, job)
 	if err != nil {
 		logger.Error("failed-to-create-job", err)
 
@@ -119,8 +150,8 @@ func (d *TaskDesirer) Desire(namespace string, task *opi.Task, opts ...DesireOpt
 	return nil
 }
 
-func (d *TaskDesirer) Get(taskGUID string) (*opi.Task, error) {
-	jobs, err := d.jobClient.GetByGUID(taskGUID, false)
+func (d *TaskDesirer) Get(ctx context.Context, taskGUID string) (*opi.Task, error) {
+	jobs, err := d.jobClient.GetByGUID(ctx, taskGUID, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get job")
 	}
@@ -129,30 +160,46 @@ func (d *TaskDesirer) Get(taskGUID string) (*opi.Task, error) {
 	case 0:
 		return nil, eirini.ErrNotFound
 	case 1:
-		return toTask(jobs[0]), nil
+		pods, err := d.podsClient.GetByTaskGUID(ctx, taskGUID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get task pods")
+		}
+
+		return toTask(jobs[0], pods), nil
 	default:
 		return nil, fmt.Errorf("multiple jobs found for task GUID %q", taskGUID)
 	}
 }
 
-func (d *TaskDesirer) List() ([]*opi.Task, error) {
-	jobs, err := d.jobClient.List(false)
+func (d *TaskDesirer) List(ctx context.Context) ([]*opi.Task, error) {
+	jobs, err := d.jobClient.List(ctx, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list jobs")
 	}
 
+	pods, err := d.podsClient.GetBySourceType(ctx, TaskSourceType)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list task pods")
+	}
+
+	podsByGUID := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		guid := pod.Labels[LabelGUID]
+		podsByGUID[guid] = append(podsByGUID[guid], pod)
+	}
+
 	tasks := make([]*opi.Task, 0, len(jobs))
 	for _, job := range jobs {
-		tasks = append(tasks, toTask(job))
+		tasks = append(tasks, toTask(job, podsByGUID[job.Labels[LabelGUID]]))
 	}
 
 	return tasks, nil
 }
 
-func (d *TaskDesirer) toTaskJob(task *opi.Task) *batch.Job {
-	job := d.toJob(task)
+func (d *TaskDesirer) toTaskJob(task *opi.Task) (*batch.Job, error) {
+	job := toJob(task, d.allowAutomountServiceAccountToken)
 	job.Spec.Template.Spec.ServiceAccountName = d.serviceAccountName
-	job.Labels[LabelSourceType] = taskSourceType
+	job.Labels[LabelSourceType] = TaskSourceType
 	job.Labels[LabelName] = task.Name
 	job.Annotations[AnnotationCompletionCallback] = task.CompletionCallback
 	job.Spec.Template.Annotations[AnnotationGUID] = task.GUID
@@ -167,6 +214,7 @@ func (d *TaskDesirer) toTaskJob(task *opi.Task) *batch.Job {
 			ImagePullPolicy: corev1.PullAlways,
 			Env:             envs,
 			Command:         task.Command,
+			Resources:       getTaskResources(task),
 		},
 	}
 
@@ -178,10 +226,42 @@ func (d *TaskDesirer) toTaskJob(task *opi.Task) *batch.Job {
 
 	job.Spec.Template.Spec.Containers = containers
 
-	return job
+	applyDroplet(&job.Spec.Template.Spec, task.Droplet, d.dropletDownloaderImage)
+
+	if err := applyPlacementTags(&job.Spec.Template.Spec, task.PlacementTags, d.placementTags); err != nil {
+		return nil, errors.Wrap(err, "failed to apply placement tags")
+	}
+
+	return job, nil
+}
+
+func getTaskResources(task *opi.Task) corev1.ResourceRequirements {
+	memory := *resource.NewScaledQuantity(task.MemoryMB, resource.Mega)
+	cpu := toCPUMillicores(task.CPUWeight)
+	ephemeralStorage := *resource.NewScaledQuantity(task.DiskMB, resource.Mega)
+
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory:           memory,
+			corev1.ResourceEphemeralStorage: ephemeralStorage,
+		},
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: memory,
+			corev1.ResourceCPU:    cpu,
+		},
+	}
+}
+
+func (d *TaskDesirer) createTaskSecret(ctx context.Context, namespace string, task *opi.Task) (*corev1.Secret, error) {
+	secret, err := toTaskSecret(task)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.secretsCreator.Create(ctx, namespace, secret)
 }
 
-func (d *TaskDesirer) createTaskSecret(namespace string, task *opi.Task) (*corev1.Secret, error) {
+func toTaskSecret(task *opi.Task) (*corev1.Secret, error) {
 	secret := &corev1.Secret{}
 
 	secret.GenerateName = dockerImagePullSecretNamePrefix(task.AppName, task.SpaceName, task.GUID)
@@ -202,7 +282,7 @@ func (d *TaskDesirer) createTaskSecret(namespace string, task *opi.Task) (*corev
 		dockerutils.DockerConfigKey: dockerConfigJSON,
 	}
 
-	return d.secretsCreator.Create(namespace, secret)
+	return secret, nil
 }
 
 func getEnvs(task *opi.Task) []corev1.EnvVar {
@@ -250,7 +330,7 @@ func getEnvs(task *opi.Task) []corev1.EnvVar {
 	return envs
 }
 
-func (d *TaskDesirer) toJob(task *opi.Task) *batch.Job {
+func toJob(task *opi.Task, allowAutomountServiceAccountToken bool) *batch.Job {
 	runAsNonRoot := true
 
 	job := &batch.Job{
@@ -269,7 +349,7 @@ func (d *TaskDesirer) toJob(task *opi.Task) *batch.Job {
 		},
 	}
 
-	if !d.allowAutomountServiceAccountToken {
+	if !allowAutomountServiceAccountToken {
 		automountServiceAccountToken := false
 		job.Spec.Template.Spec.AutomountServiceAccountToken = &automountServiceAccountToken
 	}
@@ -304,8 +384,8 @@ func (d *TaskDesirer) toJob(task *opi.Task) *batch.Job {
 	return job
 }
 
-func (d *TaskDesirer) addImagePullSecret(namespace string, task *opi.Task, job *batch.Job) error {
-	createdSecret, err := d.createTaskSecret(namespace, task)
+func (d *TaskDesirer) addImagePullSecret(ctx context.Context, namespace string, task *opi.Task, job *batch.Job) error {
+	createdSecret, err := d.createTaskSecret(ctx, namespace, task)
 	if err != nil {
 		return errors.Wrap(err, "failed to create task secret")
 	}
@@ -322,8 +402,12 @@ func imageInPrivateRegistry(task *opi.Task) bool {
 	return task.PrivateRegistry != nil && task.PrivateRegistry.Username != "" && task.PrivateRegistry.Password != ""
 }
 
-func toTask(job batch.Job) *opi.Task {
+func toTask(job batch.Job, pods []corev1.Pod) *opi.Task {
 	return &opi.Task{
-		GUID: job.Labels[LabelGUID],
+		GUID:    job.Labels[LabelGUID],
+		Name:    job.Labels[LabelName],
+		AppGUID: job.Labels[LabelAppGUID],
+		AppName: job.Annotations[AnnotationAppName],
+		Status:  GetTaskStatus(job, pods),
 	}
 }
@@ -1,6 +1,7 @@
 package k8s_test
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -8,13 +9,16 @@ import (
 	. "code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	batch "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -29,6 +33,7 @@ var _ = Describe("TaskDesirer", func() {
 		task               *opi.Task
 		desirer            *TaskDesirer
 		fakeJobClient      *k8sfakes.FakeJobCreatingClient
+		fakePodsClient     *k8sfakes.FakeTaskPodsClient
 		fakeSecretsCreator *k8sfakes.FakeSecretsCreator
 		job                *batch.Job
 		jobNamespace       string
@@ -63,6 +68,7 @@ var _ = Describe("TaskDesirer", func() {
 
 	BeforeEach(func() {
 		fakeJobClient = new(k8sfakes.FakeJobCreatingClient)
+		fakePodsClient = new(k8sfakes.FakeTaskPodsClient)
 		fakeSecretsCreator = new(k8sfakes.FakeSecretsCreator)
 		desireOpts = []DesireOption{}
 		task = &opi.Task{
@@ -89,10 +95,13 @@ var _ = Describe("TaskDesirer", func() {
 		desirer = NewTaskDesirer(
 			lagertest.NewTestLogger("desiretask"),
 			fakeJobClient,
+			fakePodsClient,
 			fakeSecretsCreator,
 			"service-account",
 			"registry-secret",
 			false,
+			nil,
+			"droplet/downloader",
 		)
 	})
 
@@ -104,14 +113,24 @@ var _ = Describe("TaskDesirer", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = desirer.Desire("app-namespace", task, desireOpts...)
+			err = desirer.Desire(context.Background(), "app-namespace", task, desireOpts...)
+		})
+
+		It("should store the trace context of traced requests in the job and pod annotations", func() {
+			ctx := trace.ContextWithSpanContext(context.Background(), tracedSpanContext())
+			Expect(desirer.Desire(ctx, "app-namespace", task)).To(Succeed())
+
+			_, _, job = fakeJobClient.CreateArgsForCall(1)
+			traceParent := "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"
+			Expect(job.Annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, traceParent))
+			Expect(job.Spec.Template.Annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, traceParent))
 		})
 
 		It("should create a job for the task with the correct attributes", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
-			jobNamespace, job = fakeJobClient.CreateArgsForCall(0)
+			_, jobNamespace, job = fakeJobClient.CreateArgsForCall(0)
 
 			assertGeneralSpec(job)
 
@@ -174,15 +193,136 @@ var _ = Describe("TaskDesirer", func() {
 			})
 		})
 
+		It("should set the container resource limits and requests", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, job = fakeJobClient.CreateArgsForCall(0)
+			resources := job.Spec.Template.Spec.Containers[0].Resources
+
+			Expect(resources.Limits.Memory()).To(Equal(resource.NewScaledQuantity(1, resource.Mega)))
+			Expect(resources.Limits.StorageEphemeral()).To(Equal(resource.NewScaledQuantity(3, resource.Mega)))
+			Expect(resources.Requests.Memory()).To(Equal(resource.NewScaledQuantity(1, resource.Mega)))
+			Expect(resources.Requests.Cpu()).To(Equal(resource.NewScaledQuantity(20, resource.Milli)))
+		})
+
+		It("should not add any init containers", func() {
+			_, _, job = fakeJobClient.CreateArgsForCall(0)
+			Expect(job.Spec.Template.Spec.InitContainers).To(BeEmpty())
+		})
+
+		When("the task runs a droplet", func() {
+			BeforeEach(func() {
+				task.Droplet = &opi.Droplet{URL: "https://cc-uploader/droplet", Hash: "droplet-hash"}
+			})
+
+			It("should download the droplet in an init container", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
+				podSpec := job.Spec.Template.Spec
+				Expect(podSpec.InitContainers).To(HaveLen(1))
+
+				downloader := podSpec.InitContainers[0]
+				Expect(downloader.Name).To(Equal(DropletDownloaderContainerName))
+				Expect(downloader.Image).To(Equal("droplet/downloader"))
+				Expect(downloader.Env).To(ContainElements(
+					corev1.EnvVar{Name: eirini.EnvDownloadURL, Value: "https://cc-uploader/droplet"},
+					corev1.EnvVar{Name: eirini.EnvDropletHash, Value: "droplet-hash"},
+				))
+				Expect(downloader.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      eirini.CertsVolumeName,
+					MountPath: eirini.CertsMountPath,
+					ReadOnly:  true,
+				}))
+			})
+
+			It("should share the droplet with the task container", func() {
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
+				podSpec := job.Spec.Template.Spec
+				Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      eirini.DropletVolumeName,
+					MountPath: eirini.DropletMountPath,
+				}))
+				Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+					Name: eirini.CertsVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: eirini.CCUploaderSecretName},
+					},
+				}))
+			})
+		})
+
+		When("the task requests placement tags", func() {
+			BeforeEach(func() {
+				desirer = NewTaskDesirer(
+					lagertest.NewTestLogger("desiretask"),
+					fakeJobClient,
+					fakePodsClient,
+					fakeSecretsCreator,
+					"service-account",
+					"registry-secret",
+					false,
+					map[string]eirini.PlacementTag{
+						"segment-a": {
+							NodeSelector: map[string]string{"segment": "a"},
+							Tolerations: []eirini.Toleration{
+								{Key: "dedicated", Operator: "Exists", Effect: "NoExecute"},
+							},
+							NodeAffinity: []eirini.NodeSelectorRequirement{
+								{Key: "zone", Operator: "NotIn", Values: []string{"z3"}},
+							},
+						},
+					},
+					"droplet/downloader",
+				)
+				task.PlacementTags = []string{"segment-a"}
+			})
+
+			It("applies the node selector, tolerations and node affinity to the pod spec", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
+				podSpec := job.Spec.Template.Spec
+
+				Expect(podSpec.NodeSelector).To(Equal(map[string]string{"segment": "a"}))
+				Expect(podSpec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				}))
+				Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+					corev1.NodeSelectorTerm{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"z3"}},
+						},
+					},
+				))
+			})
+
+			When("the placement tag is not configured", func() {
+				BeforeEach(func() {
+					task.PlacementTags = []string{"segment-b"}
+				})
+
+				It("returns an error and does not create the job", func() {
+					Expect(err).To(MatchError(ContainSubstring(`placement tag "segment-b" is not configured`)))
+					Expect(fakeJobClient.CreateCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		When("allowAutomountServiceAccountToken is true", func() {
 			BeforeEach(func() {
 				desirer = NewTaskDesirerWithEiriniInstance(
 					lagertest.NewTestLogger("desiretask"),
 					fakeJobClient,
+					fakePodsClient,
 					fakeSecretsCreator,
 					"service-account",
 					"registry-secret",
 					true,
+					nil,
+					"droplet/downloader",
 				)
 			})
 
@@ -190,7 +330,7 @@ var _ = Describe("TaskDesirer", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
-				_, job = fakeJobClient.CreateArgsForCall(0)
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
 
 				Expect(job.Spec.Template.Spec.AutomountServiceAccountToken).To(BeNil())
 			})
@@ -204,7 +344,7 @@ var _ = Describe("TaskDesirer", func() {
 
 			It("should truncate the app and space name", func() {
 				Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
-				_, job = fakeJobClient.CreateArgsForCall(0)
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
 				Expect(job.Name).To(Equal("app-with-very-long-name-space-with-a-ver-task-name"))
 			})
 		})
@@ -254,7 +394,7 @@ var _ = Describe("TaskDesirer", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
-				_, job = fakeJobClient.CreateArgsForCall(0)
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
 
 				Expect(job.Name).To(Equal(fmt.Sprintf("%s-%s", taskGUID, task.Name)))
 			})
@@ -282,7 +422,7 @@ var _ = Describe("TaskDesirer", func() {
 
 			It("creates a secret with the registry credentials", func() {
 				Expect(fakeSecretsCreator.CreateCallCount()).To(Equal(1))
-				namespace, actualSecret := fakeSecretsCreator.CreateArgsForCall(0)
+				_, namespace, actualSecret := fakeSecretsCreator.CreateArgsForCall(0)
 				Expect(namespace).To(Equal("app-namespace"))
 				Expect(actualSecret.GenerateName).To(Equal("my-app-my-space-registry-secret-"))
 				Expect(actualSecret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
@@ -297,7 +437,7 @@ var _ = Describe("TaskDesirer", func() {
 				)
 
 				Expect(fakeJobClient.CreateCallCount()).To(Equal(1))
-				_, job = fakeJobClient.CreateArgsForCall(0)
+				_, _, job = fakeJobClient.CreateArgsForCall(0)
 
 				Expect(job.Spec.Template.Spec.ImagePullSecrets).To(ConsistOf(
 					corev1.LocalObjectReference{Name: "registry-secret"},
@@ -324,7 +464,11 @@ var _ = Describe("TaskDesirer", func() {
 			job = &batch.Job{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						LabelGUID: taskGUID,
+						LabelGUID:    taskGUID,
+						LabelAppGUID: "app-guid",
+					},
+					Annotations: map[string]string{
+						AnnotationAppName: "app-name",
 					},
 				},
 			}
@@ -333,22 +477,203 @@ var _ = Describe("TaskDesirer", func() {
 		})
 
 		JustBeforeEach(func() {
-			task, err = desirer.Get(task.GUID)
+			task, err = desirer.Get(context.Background(), task.GUID)
 		})
 
 		It("succeeds", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("requests uncompleted jobs from the jobs client", func() {
+		It("requests completed jobs too from the jobs client", func() {
 			Expect(fakeJobClient.GetByGUIDCallCount()).To(Equal(1))
-			actualGUID, actualIncludeCompleted := fakeJobClient.GetByGUIDArgsForCall(0)
+			_, actualGUID, actualIncludeCompleted := fakeJobClient.GetByGUIDArgsForCall(0)
 			Expect(actualGUID).To(Equal(task.GUID))
-			Expect(actualIncludeCompleted).To(BeFalse())
+			Expect(actualIncludeCompleted).To(BeTrue())
 		})
 
 		It("returns the task with the specified task guid", func() {
 			Expect(task.GUID).To(Equal(taskGUID))
+			Expect(task.AppGUID).To(Equal("app-guid"))
+			Expect(task.AppName).To(Equal("app-name"))
+		})
+
+		It("requests the task pods", func() {
+			Expect(fakePodsClient.GetByTaskGUIDCallCount()).To(Equal(1))
+			_, guid := fakePodsClient.GetByTaskGUIDArgsForCall(0)
+			Expect(guid).To(Equal(taskGUID))
+		})
+
+		When("the task has no pods yet", func() {
+			It("is pending", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{State: opi.TaskPendingState}))
+			})
+		})
+
+		When("the task container is waiting", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}),
+				}, nil)
+			})
+
+			It("is pending", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{State: opi.TaskPendingState}))
+			})
+		})
+
+		When("the task container is running", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Unix(100, 0)}}),
+				}, nil)
+			})
+
+			It("is running", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{State: opi.TaskRunningState, StartTime: 100}))
+			})
+		})
+
+		When("the task container has succeeded", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   0,
+						Reason:     "Completed",
+						StartedAt:  metav1.Unix(100, 0),
+						FinishedAt: metav1.Unix(200, 0),
+					}}),
+				}, nil)
+			})
+
+			It("has succeeded", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{
+					State:     opi.TaskSucceededState,
+					StartTime: 100,
+					EndTime:   200,
+					ExitCode:  int32ptr(0),
+				}))
+			})
+		})
+
+		When("the task container has failed", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   3,
+						Reason:     "Error",
+						StartedAt:  metav1.Unix(100, 0),
+						FinishedAt: metav1.Unix(200, 0),
+					}}),
+				}, nil)
+			})
+
+			It("has failed", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{
+					State:         opi.TaskFailedState,
+					StartTime:     100,
+					EndTime:       200,
+					ExitCode:      int32ptr(3),
+					FailureReason: "Error",
+				}))
+			})
+		})
+
+		When("the task has been reported as completed", func() {
+			BeforeEach(func() {
+				job.Labels[LabelTaskCompleted] = TaskCompletedTrue
+				fakeJobClient.GetByGUIDReturns([]batch.Job{*job}, nil)
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						Reason:     "Error",
+						StartedAt:  metav1.Unix(100, 0),
+						FinishedAt: metav1.Unix(200, 0),
+					}}),
+				}, nil)
+			})
+
+			It("still returns the task with its final status", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.GUID).To(Equal(taskGUID))
+				Expect(task.Status).To(Equal(opi.TaskStatus{
+					State:         opi.TaskFailedState,
+					StartTime:     100,
+					EndTime:       200,
+					ExitCode:      int32ptr(1),
+					FailureReason: "Error",
+				}))
+			})
+		})
+
+		When("the task has multiple pods", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns([]corev1.Pod{
+					taskPod(10, corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Unix(100, 0)}}),
+					taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}),
+				}, nil)
+			})
+
+			It("uses the most recent one", func() {
+				Expect(task.Status.State).To(Equal(opi.TaskRunningState))
+			})
+		})
+
+		When("the pod is gone but the job has failed", func() {
+			BeforeEach(func() {
+				job.Status = batch.JobStatus{
+					StartTime: timePtr(metav1.Unix(100, 0)),
+					Failed:    1,
+					Conditions: []batch.JobCondition{{
+						Type:               batch.JobFailed,
+						Status:             corev1.ConditionTrue,
+						Reason:             "DeadlineExceeded",
+						LastTransitionTime: metav1.Unix(200, 0),
+					}},
+				}
+				fakeJobClient.GetByGUIDReturns([]batch.Job{*job}, nil)
+			})
+
+			It("uses the job status", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{
+					State:         opi.TaskFailedState,
+					StartTime:     100,
+					EndTime:       200,
+					FailureReason: "DeadlineExceeded",
+				}))
+			})
+		})
+
+		When("the pod is gone but the job has completed", func() {
+			BeforeEach(func() {
+				job.Status = batch.JobStatus{
+					StartTime: timePtr(metav1.Unix(100, 0)),
+					Succeeded: 1,
+					Conditions: []batch.JobCondition{{
+						Type:               batch.JobComplete,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.Unix(200, 0),
+					}},
+				}
+				fakeJobClient.GetByGUIDReturns([]batch.Job{*job}, nil)
+			})
+
+			It("uses the job status", func() {
+				Expect(task.Status).To(Equal(opi.TaskStatus{
+					State:     opi.TaskSucceededState,
+					StartTime: 100,
+					EndTime:   200,
+				}))
+			})
+		})
+
+		When("getting the task pods fails", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetByTaskGUIDReturns(nil, errors.New("get-pods-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(ContainSubstring("get-pods-error")))
+			})
 		})
 
 		When("getting the task fails", func() {
@@ -409,16 +734,17 @@ var _ = Describe("TaskDesirer", func() {
 		})
 
 		JustBeforeEach(func() {
-			tasks, err = desirer.List()
+			tasks, err = desirer.List(context.Background())
 		})
 
 		It("succeeds", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("excludes completed tasks", func() {
+		It("includes completed tasks", func() {
 			Expect(fakeJobClient.ListCallCount()).To(Equal(1))
-			Expect(fakeJobClient.ListArgsForCall(0)).To(BeFalse())
+			_, includeCompleted := fakeJobClient.ListArgsForCall(0)
+			Expect(includeCompleted).To(BeTrue())
 		})
 
 		It("returns all tasks", func() {
@@ -432,6 +758,79 @@ var _ = Describe("TaskDesirer", func() {
 			Expect(taskGUIDs).To(ContainElement(taskGUID))
 		})
 
+		It("lists the task pods", func() {
+			Expect(fakePodsClient.GetBySourceTypeCallCount()).To(Equal(1))
+			_, sourceType := fakePodsClient.GetBySourceTypeArgsForCall(0)
+			Expect(sourceType).To(Equal("TASK"))
+		})
+
+		When("tasks have pods", func() {
+			BeforeEach(func() {
+				otherJob := batch.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							LabelGUID: "other-task",
+						},
+					},
+				}
+				fakeJobClient.ListReturns([]batch.Job{*job, otherJob}, nil)
+
+				otherPod := taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}})
+				otherPod.Labels[LabelGUID] = "other-task"
+				fakePodsClient.GetBySourceTypeReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}),
+					otherPod,
+				}, nil)
+			})
+
+			It("matches each task with its own pods", func() {
+				states := map[string]string{}
+				for _, task := range tasks {
+					states[task.GUID] = task.Status.State
+				}
+
+				Expect(states).To(Equal(map[string]string{
+					taskGUID:     opi.TaskRunningState,
+					"other-task": opi.TaskSucceededState,
+				}))
+			})
+		})
+
+		When("a task has been reported as completed", func() {
+			BeforeEach(func() {
+				job.Labels[LabelTaskCompleted] = TaskCompletedTrue
+				fakeJobClient.ListReturns([]batch.Job{*job}, nil)
+				fakePodsClient.GetBySourceTypeReturns([]corev1.Pod{
+					taskPod(0, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   0,
+						StartedAt:  metav1.Unix(100, 0),
+						FinishedAt: metav1.Unix(200, 0),
+					}}),
+				}, nil)
+			})
+
+			It("returns it with its final status", func() {
+				Expect(tasks).To(HaveLen(1))
+				Expect(tasks[0].GUID).To(Equal(taskGUID))
+				Expect(tasks[0].Status).To(Equal(opi.TaskStatus{
+					State:     opi.TaskSucceededState,
+					StartTime: 100,
+					EndTime:   200,
+					ExitCode:  int32ptr(0),
+				}))
+			})
+		})
+
+		When("listing the task pods fails", func() {
+			BeforeEach(func() {
+				fakePodsClient.GetBySourceTypeReturns(nil, errors.New("list-pods-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(ContainSubstring("list-pods-error")))
+			})
+		})
+
 		When("listing the task fails", func() {
 			BeforeEach(func() {
 				fakeJobClient.ListReturns(nil, errors.New("list-tasks-error"))
@@ -449,3 +848,30 @@ func int32ptr(i int) *int32 {
 
 	return &u
 }
+
+func boolptr(b bool) *bool {
+	return &b
+}
+
+func timePtr(t metav1.Time) *metav1.Time {
+	return &t
+}
+
+func taskPod(createdAt int64, state corev1.ContainerState) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Unix(createdAt, 0),
+			Labels: map[string]string{
+				LabelGUID: "task-123",
+			},
+			Annotations: map[string]string{
+				AnnotationOpiTaskContainerName: "opi-task",
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "opi-task", State: state},
+			},
+		},
+	}
+}
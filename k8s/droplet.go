@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/opi"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const DropletDownloaderContainerName = "droplet-downloader"
+
+// applyDroplet adds an init container downloading the droplet from the CC
+// into a volume shared with all the containers of the pod.
+func applyDroplet(podSpec *corev1.PodSpec, droplet *opi.Droplet, downloaderImage string) {
+	if droplet == nil {
+		return
+	}
+
+	dropletMount := corev1.VolumeMount{
+		Name:      eirini.DropletVolumeName,
+		MountPath: eirini.DropletMountPath,
+	}
+	allowPrivilegeEscalation := false
+
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:            DropletDownloaderContainerName,
+		Image:           downloaderImage,
+		ImagePullPolicy: corev1.PullAlways,
+		Env: []corev1.EnvVar{
+			{Name: eirini.EnvDownloadURL, Value: droplet.URL},
+			{Name: eirini.EnvDropletHash, Value: droplet.Hash},
+			{Name: eirini.EnvDropletDestination, Value: eirini.DropletMountPath},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			dropletMount,
+			{
+				Name:      eirini.CertsVolumeName,
+				MountPath: eirini.CertsMountPath,
+				ReadOnly:  true,
+			},
+		},
+	})
+
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, dropletMount)
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes,
+		corev1.Volume{
+			Name: eirini.DropletVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		corev1.Volume{
+			Name: eirini.CertsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: eirini.CCUploaderSecretName,
+				},
+			},
+		},
+	)
+}
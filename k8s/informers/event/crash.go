@@ -21,7 +21,7 @@ const (
 //counterfeiter:generate . CrashEmitter
 
 type CrashEventGenerator interface {
-	Generate(*corev1.Pod, lager.Logger) (events.CrashEvent, bool)
+	Generate(context.Context, *corev1.Pod, lager.Logger) (events.CrashEvent, bool)
 }
 
 type CrashEmitter interface {
@@ -56,9 +56,10 @@ func (c *CrashReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 			"namespace": request.NamespacedName.Namespace,
 		})
 
+	ctx := context.Background()
 	pod := &corev1.Pod{}
 
-	err := c.client.Get(context.Background(), request.NamespacedName, pod)
+	err := c.client.Get(ctx, request.NamespacedName, pod)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("pod-not-found", lager.Data{"error": err})
@@ -71,7 +72,7 @@ func (c *CrashReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 		return reconcile.Result{}, errors.Wrap(err, "failed to get pod")
 	}
 
-	event, send := c.eventGenerator.Generate(pod, c.logger)
+	event, send := c.eventGenerator.Generate(ctx, pod, c.logger)
 	if !send {
 		logger.Debug("not-sending-event")
 
@@ -82,11 +83,13 @@ func (c *CrashReconciler) Reconcile(request reconcile.Request) (reconcile.Result
 
 	err = c.crashEmitter.Emit(event)
 	if err != nil {
+		crashEventsEmitted.WithLabelValues("error").Inc()
 		logger.Error("failed-to-emit-event", err)
 
 		return reconcile.Result{}, errors.Wrap(err, "failed to emit event")
 	}
 
+	crashEventsEmitted.WithLabelValues("success").Inc()
 	logger.Info("emitted-event")
 
 	return reconcile.Result{}, nil
@@ -1,8 +1,11 @@
 package event
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/eirini/events"
 	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/eirini/util"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/runtimeschema/cc_messages"
@@ -19,7 +22,7 @@ func NewDefaultCrashEventGenerator(eventsClient k8s.EventsClient) DefaultCrashEv
 	}
 }
 
-func (g DefaultCrashEventGenerator) Generate(pod *v1.Pod, logger lager.Logger) (events.CrashEvent, bool) {
+func (g DefaultCrashEventGenerator) Generate(ctx context.Context, pod *v1.Pod, logger lager.Logger) (events.CrashEvent, bool) {
 	logger = logger.Session("generate-crash-event",
 		lager.Data{
 			"pod-name": pod.Name,
@@ -48,7 +51,7 @@ func (g DefaultCrashEventGenerator) Generate(pod *v1.Pod, logger lager.Logger) (
 	}
 
 	if appStatus.State.Terminated != nil {
-		return g.generateReportForTerminatedPod(pod, appStatus, logger)
+		return g.generateReportForTerminatedPod(ctx, pod, appStatus, logger)
 	}
 
 	if appStatus.State.Waiting != nil && appStatus.LastTerminationState.Terminated != nil {
@@ -64,8 +67,8 @@ func (g DefaultCrashEventGenerator) Generate(pod *v1.Pod, logger lager.Logger) (
 	return events.CrashEvent{}, false
 }
 
-func (g DefaultCrashEventGenerator) generateReportForTerminatedPod(pod *v1.Pod, status *v1.ContainerStatus, logger lager.Logger) (events.CrashEvent, bool) {
-	podEvents, err := g.eventsClient.GetByPod(*pod)
+func (g DefaultCrashEventGenerator) generateReportForTerminatedPod(ctx context.Context, pod *v1.Pod, status *v1.ContainerStatus, logger lager.Logger) (events.CrashEvent, bool) {
+	podEvents, err := g.eventsClient.GetByPod(ctx, *pod)
 	if err != nil {
 		logger.Error("skipping-failed-to-get-k8s-events", err)
 
@@ -104,6 +107,7 @@ func generateReport(
 			CrashTimestamp:  crashTimestamp,
 			CrashCount:      restartCount,
 		},
+		SpanContext: tracing.SpanContextFromAnnotations(pod.Annotations),
 	}
 }
 
@@ -45,7 +45,7 @@ var _ = Describe("CrashEventGenerator", func() {
 			})
 
 			It("should generate a crashed report", func() {
-				report, returned := generator.Generate(pod, logger)
+				report, returned := generator.Generate(context.Background(), pod, logger)
 				Expect(returned).To(BeTrue())
 				Expect(report).To(Equal(events.CrashEvent{
 					ProcessGUID: "test-pod-anno",
@@ -67,12 +67,12 @@ var _ = Describe("CrashEventGenerator", func() {
 				})
 
 				It("should not generate", func() {
-					_, returned := generator.Generate(pod, logger)
+					_, returned := generator.Generate(context.Background(), pod, logger)
 					Expect(returned).To(BeFalse())
 				})
 
 				It("should provide a helpful log message", func() {
-					generator.Generate(pod, logger)
+					generator.Generate(context.Background(), pod, logger)
 
 					logs := logger.Logs()
 					Expect(logs).To(HaveLen(1))
@@ -98,7 +98,7 @@ var _ = Describe("CrashEventGenerator", func() {
 				})
 
 				It("should not emit a crashed event", func() {
-					_, returned := generator.Generate(pod, logger)
+					_, returned := generator.Generate(context.Background(), pod, logger)
 					Expect(returned).To(BeFalse())
 				})
 			})
@@ -117,7 +117,7 @@ var _ = Describe("CrashEventGenerator", func() {
 				})
 
 				It("should not emit a crashed event", func() {
-					_, returned := generator.Generate(pod, logger)
+					_, returned := generator.Generate(context.Background(), pod, logger)
 					Expect(returned).To(BeFalse())
 				})
 			})
@@ -131,12 +131,12 @@ var _ = Describe("CrashEventGenerator", func() {
 				})
 
 				It("should not emit a crashed event", func() {
-					_, returned := generator.Generate(pod, logger)
+					_, returned := generator.Generate(context.Background(), pod, logger)
 					Expect(returned).To(BeFalse())
 				})
 
 				It("should provide a helpful log message", func() {
-					generator.Generate(pod, logger)
+					generator.Generate(context.Background(), pod, logger)
 					logs := logger.Logs()
 					Expect(logs).To(HaveLen(1))
 					log := logs[0]
@@ -154,7 +154,7 @@ var _ = Describe("CrashEventGenerator", func() {
 			})
 
 			It("should not emit a crashed event", func() {
-				_, returned := generator.Generate(pod, logger)
+				_, returned := generator.Generate(context.Background(), pod, logger)
 				Expect(returned).To(BeFalse())
 			})
 		})
@@ -190,7 +190,7 @@ var _ = Describe("CrashEventGenerator", func() {
 			})
 
 			It("should not emit a crashed event", func() {
-				_, returned := generator.Generate(pod, logger)
+				_, returned := generator.Generate(context.Background(), pod, logger)
 				Expect(returned).To(BeFalse())
 			})
 		})
@@ -220,7 +220,7 @@ var _ = Describe("CrashEventGenerator", func() {
 		})
 
 		It("should return a crashed report", func() {
-			report, returned := generator.Generate(pod, logger)
+			report, returned := generator.Generate(context.Background(), pod, logger)
 			Expect(returned).To(BeTrue())
 			Expect(report).To(Equal(events.CrashEvent{
 				ProcessGUID: "test-pod-anno",
@@ -247,7 +247,7 @@ var _ = Describe("CrashEventGenerator", func() {
 			})
 
 			It("should not send any reports", func() {
-				_, returned := generator.Generate(pod, logger)
+				_, returned := generator.Generate(context.Background(), pod, logger)
 				Expect(returned).To(BeFalse())
 			})
 		})
@@ -258,12 +258,41 @@ var _ = Describe("CrashEventGenerator", func() {
 			})
 
 			It("should not send any reports", func() {
-				_, returned := generator.Generate(pod, logger)
+				_, returned := generator.Generate(context.Background(), pod, logger)
 				Expect(returned).To(BeFalse())
 			})
 		})
 	})
 
+	Context("When a pod has a sidecar listed before the opi container", func() {
+		BeforeEach(func() {
+			pod = newPod([]v1.ContainerStatus{
+				{
+					Name:  "log-shipper",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+				{
+					Name:         k8s.OPIContainerName,
+					RestartCount: 2,
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{
+							Reason:    "Error",
+							StartedAt: crashTime,
+							ExitCode:  3,
+						},
+					},
+				},
+			})
+		})
+
+		It("should report the opi container crash", func() {
+			event, returned := generator.Generate(context.Background(), pod, logger)
+			Expect(returned).To(BeTrue())
+			Expect(event.ExitStatus).To(Equal(3))
+			Expect(event.CrashCount).To(Equal(3))
+		})
+	})
+
 	Context("When a pod has no opi container statuses", func() {
 		BeforeEach(func() {
 			pod = newPod([]v1.ContainerStatus{
@@ -282,12 +311,12 @@ var _ = Describe("CrashEventGenerator", func() {
 		})
 
 		It("should not send any reports", func() {
-			_, returned := generator.Generate(pod, logger)
+			_, returned := generator.Generate(context.Background(), pod, logger)
 			Expect(returned).To(BeFalse())
 		})
 
 		It("should provide a helpful log message", func() {
-			generator.Generate(pod, logger)
+			generator.Generate(context.Background(), pod, logger)
 			logs := logger.Logs()
 			Expect(logs).To(HaveLen(1))
 			log := logs[0]
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,8 @@ var _ = Describe("Event", func() {
 		crashEvent       events.CrashEvent
 		result           reconcile.Result
 		err              error
+		emittedBefore    float64
+		failedBefore     float64
 	)
 
 	BeforeEach(func() {
@@ -67,6 +70,9 @@ var _ = Describe("Event", func() {
 			eventGenerator,
 			crashEmitter,
 		)
+
+		emittedBefore = testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("success"))
+		failedBefore = testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("error"))
 	})
 
 	JustBeforeEach(func() {
@@ -95,7 +101,7 @@ var _ = Describe("Event", func() {
 	It("sends the correct args to the event generator", func() {
 		Expect(eventGenerator.GenerateCallCount()).To(Equal(1))
 
-		inputPod, inputLogger := eventGenerator.GenerateArgsForCall(0)
+		_, inputPod, inputLogger := eventGenerator.GenerateArgsForCall(0)
 		Expect(inputPod).To(Equal(pod))
 		Expect(inputLogger).To(Equal(logger))
 	})
@@ -107,6 +113,10 @@ var _ = Describe("Event", func() {
 		Expect(actualevent.ProcessGUID).To(Equal("blahblah"))
 	})
 
+	It("counts the emitted crash event", func() {
+		Expect(testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("success"))).To(Equal(emittedBefore + 1))
+	})
+
 	When("the app does not have to be reported", func() {
 		BeforeEach(func() {
 			eventGenerator.GenerateReturns(crashEvent, false)
@@ -115,6 +125,10 @@ var _ = Describe("Event", func() {
 		It("does NOT send a crash event", func() {
 			Expect(crashEmitter.EmitCallCount()).To(Equal(0))
 		})
+
+		It("does not count any crash event", func() {
+			Expect(testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("success"))).To(Equal(emittedBefore))
+		})
 	})
 
 	When("the Pod doesn't exist", func() {
@@ -159,5 +173,10 @@ var _ = Describe("Event", func() {
 		It("returns an error", func() {
 			Expect(err).To(MatchError(ContainSubstring("emit-error")))
 		})
+
+		It("counts the failed crash event", func() {
+			Expect(testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("error"))).To(Equal(failedBefore + 1))
+			Expect(testutil.ToFloat64(event.CrashEventsEmitted.WithLabelValues("success"))).To(Equal(emittedBefore))
+		})
 	})
 })
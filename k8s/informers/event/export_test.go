@@ -0,0 +1,3 @@
+package event
+
+var CrashEventsEmitted = crashEventsEmitted
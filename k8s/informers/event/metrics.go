@@ -0,0 +1,17 @@
+package event
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var crashEventsEmitted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "eirini",
+	Subsystem: "event_reporter",
+	Name:      "crash_events_total",
+	Help:      "Number of crash events emitted to the Cloud Controller, by result.",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(crashEventsEmitted)
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"encoding/json"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -16,7 +17,7 @@ import (
 //counterfeiter:generate . StatefulSetGetter
 
 type StatefulSetGetter interface {
-	Get(namespace, name string) (*appsv1.StatefulSet, error)
+	Get(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error)
 }
 
 type PodUpdateHandler struct {
@@ -28,7 +29,7 @@ type PodUpdateHandler struct {
 func (h PodUpdateHandler) Handle(oldPod, updatedPod *corev1.Pod) {
 	loggerSession := h.Logger.Session("pod-update", lager.Data{"pod-name": updatedPod.Name, "guid": updatedPod.Annotations[k8s.AnnotationProcessGUID]})
 
-	userDefinedRoutes, err := h.getUserDefinedRoutes(updatedPod)
+	userDefinedRoutes, err := h.getUserDefinedRoutes(context.Background(), updatedPod)
 	if err != nil {
 		loggerSession.Debug("failed-to-get-user-defined-routes", lager.Data{"error": err.Error()})
 
@@ -77,8 +78,8 @@ func (h PodUpdateHandler) unregisterPodRoutes(pod *corev1.Pod, userDefinedRoutes
 	}
 }
 
-func (h PodUpdateHandler) getUserDefinedRoutes(pod *corev1.Pod) ([]cf.Route, error) {
-	owner, err := h.getOwner(pod)
+func (h PodUpdateHandler) getUserDefinedRoutes(ctx context.Context, pod *corev1.Pod) ([]cf.Route, error) {
+	owner, err := h.getOwner(ctx, pod)
 	if err != nil {
 		return []cf.Route{}, errors.Wrap(err, "failed to get owner")
 	}
@@ -86,7 +87,7 @@ func (h PodUpdateHandler) getUserDefinedRoutes(pod *corev1.Pod) ([]cf.Route, err
 	return decodeRoutes(owner.Annotations[k8s.AnnotationRegisteredRoutes])
 }
 
-func (h PodUpdateHandler) getOwner(pod *corev1.Pod) (*appsv1.StatefulSet, error) {
+func (h PodUpdateHandler) getOwner(ctx context.Context, pod *corev1.Pod) (*appsv1.StatefulSet, error) {
 	ownerReferences := pod.OwnerReferences
 
 	if len(ownerReferences) == 0 {
@@ -95,7 +96,7 @@ func (h PodUpdateHandler) getOwner(pod *corev1.Pod) (*appsv1.StatefulSet, error)
 
 	for _, owner := range ownerReferences {
 		if owner.Kind == "StatefulSet" {
-			return h.StatefulSetGetter.Get(pod.Namespace, owner.Name)
+			return h.StatefulSetGetter.Get(ctx, pod.Namespace, owner.Name)
 		}
 	}
 
@@ -0,0 +1,7 @@
+package task
+
+var (
+	CallbackAttempts = callbackAttempts
+	CallbackFailures = callbackFailures
+	TTLDeletions     = ttlDeletions
+)
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	callbackAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "eirini",
+		Subsystem: "task_reporter",
+		Name:      "completion_callbacks_total",
+		Help:      "Number of attempts to report a task completion to the Cloud Controller.",
+	})
+	callbackFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "eirini",
+		Subsystem: "task_reporter",
+		Name:      "completion_callback_failures_total",
+		Help:      "Number of failed attempts to report a task completion to the Cloud Controller.",
+	})
+	ttlDeletions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "eirini",
+		Subsystem: "task_reporter",
+		Name:      "ttl_deletions_total",
+		Help:      "Number of completed tasks deleted after their TTL expired.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(callbackAttempts, callbackFailures, ttlDeletions)
+}
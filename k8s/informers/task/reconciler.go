@@ -6,12 +6,15 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/eirini/k8s"
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/lager"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -26,16 +29,16 @@ type Reporter interface {
 }
 
 type JobsClient interface {
-	GetByGUID(guid string, includeCompleted bool) ([]batchv1.Job, error)
-	SetLabel(job *batchv1.Job, key, value string) (*batchv1.Job, error)
+	GetByGUID(ctx context.Context, guid string, includeCompleted bool) ([]batchv1.Job, error)
+	SetLabel(ctx context.Context, job *batchv1.Job, key, value string) (*batchv1.Job, error)
 }
 
 type Deleter interface {
-	Delete(guid string) (string, error)
+	Delete(ctx context.Context, guid string) (string, error)
 }
 
 type PodsClient interface {
-	SetAnnotation(pod *corev1.Pod, key, value string) (*corev1.Pod, error)
+	SetAnnotation(ctx context.Context, pod *corev1.Pod, key, value string) (*corev1.Pod, error)
 }
 
 type Reconciler struct {
@@ -74,8 +77,9 @@ func NewReconciler(
 func (r Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	logger := r.logger.Session("task-completion-reconciler", lager.Data{"namespace": request.Namespace, "pod-name": request.Name})
 
+	ctx := context.Background()
 	pod := &corev1.Pod{}
-	if err := r.runtimeClient.Get(context.Background(), request.NamespacedName, pod); err != nil {
+	if err := r.runtimeClient.Get(ctx, request.NamespacedName, pod); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error("pod does not exist", err)
 
@@ -94,7 +98,7 @@ func (r Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, erro
 	guid := pod.Labels[k8s.LabelGUID]
 	logger = logger.WithData(lager.Data{"guid": guid})
 
-	jobsForPods, err := r.jobs.GetByGUID(guid, true)
+	jobsForPods, err := r.jobs.GetByGUID(ctx, guid, true)
 	if err != nil {
 		logger.Error("failed to get related job by guid", err)
 
@@ -107,54 +111,108 @@ func (r Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, erro
 		return reconcile.Result{}, nil
 	}
 
-	if err = r.reportIfRequired(pod); err != nil {
+	acked, err := r.reportIfRequired(ctx, pod)
+	if err != nil {
 		logger.Error("completion-callback-failed", err, lager.Data{"tries": pod.Annotations[k8s.AnnotationOpiTaskCompletionReportCounter]})
 
 		return reconcile.Result{}, err
 	}
 
-	if _, err = r.jobs.SetLabel(&jobsForPods[0], k8s.LabelTaskCompleted, k8s.TaskCompletedTrue); err != nil {
+	if _, err = r.jobs.SetLabel(ctx, &jobsForPods[0], k8s.LabelTaskCompleted, k8s.TaskCompletedTrue); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to label the job as completed")
 	}
 
+	if err = r.markCallbackAcked(ctx, jobsForPods[0], acked); err != nil {
+		logger.Error("failed-to-update-task-status", err)
+
+		return reconcile.Result{}, err
+	}
+
 	if !r.taskHasExpired(logger, pod) {
 		logger.Debug("task-hasnt-expired-yet")
 
 		return reconcile.Result{RequeueAfter: time.Duration(r.ttlSeconds) * time.Second}, nil
 	}
 
-	if _, err = r.deleter.Delete(guid); err != nil {
+	if _, err = r.deleter.Delete(ctx, guid); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "failed to delete job")
 	}
 
+	ttlDeletions.Inc()
+
 	return reconcile.Result{}, nil
 }
 
-func (r *Reconciler) reportIfRequired(pod *corev1.Pod) error {
+// reportIfRequired reports the task completion to the CC unless that has
+// already happened or the retry limit has been hit. It returns whether the CC
+// has acknowledged the completion.
+func (r *Reconciler) reportIfRequired(ctx context.Context, pod *corev1.Pod) (bool, error) {
 	if pod.Annotations[k8s.AnnotationCCAckedTaskCompletion] == k8s.TaskCompletedTrue {
-		return nil
+		return true, nil
 	}
 
 	completionCounter := parseIntOrZero(pod.Annotations[k8s.AnnotationOpiTaskCompletionReportCounter])
 	if completionCounter >= r.callbackRetryLimit {
-		return nil
+		return false, nil
 	}
 
+	callbackAttempts.Inc()
+
 	if err := r.reporter.Report(pod); err != nil {
+		callbackFailures.Inc()
+
 		resultErr := multierror.Append(err)
 
-		if _, updateErr := r.pods.SetAnnotation(pod, k8s.AnnotationOpiTaskCompletionReportCounter, strconv.Itoa(completionCounter+1)); updateErr != nil {
+		if _, updateErr := r.pods.SetAnnotation(ctx, pod, k8s.AnnotationOpiTaskCompletionReportCounter, strconv.Itoa(completionCounter+1)); updateErr != nil {
 			resultErr = multierror.Append(resultErr, updateErr)
 		}
 
-		return resultErr.ErrorOrNil()
+		return false, resultErr.ErrorOrNil()
+	}
+
+	if _, updateErr := r.pods.SetAnnotation(ctx, pod, k8s.AnnotationCCAckedTaskCompletion, k8s.TaskCompletedTrue); updateErr != nil {
+		return false, errors.Wrap(updateErr, "failed to set task completion annotation")
 	}
 
-	if _, updateErr := r.pods.SetAnnotation(pod, k8s.AnnotationCCAckedTaskCompletion, k8s.TaskCompletedTrue); updateErr != nil {
-		return errors.Wrap(updateErr, "failed to set task completion annotation")
+	return true, nil
+}
+
+// markCallbackAcked records that the CC has acknowledged the task completion
+// on the Task custom resource owning the job, if any. The rest of the status
+// belongs to the eirini-controller, so only this field is patched.
+func (r *Reconciler) markCallbackAcked(ctx context.Context, job batchv1.Job, acked bool) error {
+	owner, ok := getTaskCROwner(job)
+	if !acked || !ok {
+		return nil
+	}
+
+	task := &eiriniv1.Task{}
+	if err := r.runtimeClient.Get(ctx, types.NamespacedName{Namespace: job.Namespace, Name: owner.Name}, task); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "failed to get task")
+	}
+
+	if task.Status.CompletionCallbackAcked {
+		return nil
+	}
+
+	original := task.DeepCopy()
+	task.Status.CompletionCallbackAcked = true
+
+	return errors.Wrap(r.runtimeClient.Status().Patch(ctx, task, client.MergeFrom(original)), "failed to update task status")
+}
+
+func getTaskCROwner(job batchv1.Job) (metav1.OwnerReference, bool) {
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "Task" && ref.APIVersion == eiriniv1.SchemeGroupVersion.String() {
+			return ref, true
+		}
 	}
 
-	return nil
+	return metav1.OwnerReference{}, false
 }
 
 func (r Reconciler) taskContainerHasTerminated(logger lager.Logger, pod *corev1.Pod) bool {
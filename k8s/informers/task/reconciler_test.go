@@ -9,9 +9,11 @@ import (
 	"code.cloudfoundry.org/eirini/k8s/informers/task"
 	"code.cloudfoundry.org/eirini/k8s/informers/task/taskfakes"
 	"code.cloudfoundry.org/eirini/k8s/reconciler/reconcilerfakes"
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,6 +38,8 @@ var _ = Describe("Task Completion Reconciler", func() {
 		pod           *corev1.Pod
 		job           batchv1.Job
 		ttl           int
+
+		callbacksBefore, callbackFailuresBefore, deletionsBefore float64
 	)
 
 	BeforeEach(func() {
@@ -96,6 +100,10 @@ var _ = Describe("Task Completion Reconciler", func() {
 			},
 		}
 		jobsClient.GetByGUIDReturns([]batchv1.Job{job}, nil)
+
+		callbacksBefore = testutil.ToFloat64(task.CallbackAttempts)
+		callbackFailuresBefore = testutil.ToFloat64(task.CallbackFailures)
+		deletionsBefore = testutil.ToFloat64(task.TTLDeletions)
 	})
 
 	JustBeforeEach(func() {
@@ -120,7 +128,7 @@ var _ = Describe("Task Completion Reconciler", func() {
 
 	It("fetches the job by guid", func() {
 		Expect(jobsClient.GetByGUIDCallCount()).To(Equal(1))
-		actualGUID, actualIncludeCompleted := jobsClient.GetByGUIDArgsForCall(0)
+		_, actualGUID, actualIncludeCompleted := jobsClient.GetByGUIDArgsForCall(0)
 		Expect(actualGUID).To(Equal("the-task-pod-guid"))
 		Expect(actualIncludeCompleted).To(BeTrue())
 	})
@@ -129,7 +137,7 @@ var _ = Describe("Task Completion Reconciler", func() {
 		Expect(taskReporter.ReportCallCount()).To(Equal(1))
 		Expect(taskReporter.ReportArgsForCall(0).Name).To(Equal(pod.Name))
 		Expect(podsClient.SetAnnotationCallCount()).To(Equal(1))
-		actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
+		_, actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
 		Expect(actualPod).To(Equal(pod))
 		Expect(key).To(Equal(k8s.AnnotationCCAckedTaskCompletion))
 		Expect(value).To(Equal(k8s.TaskCompletedTrue))
@@ -137,12 +145,19 @@ var _ = Describe("Task Completion Reconciler", func() {
 
 	It("deletes the task", func() {
 		Expect(taskDeleter.DeleteCallCount()).To(Equal(1))
-		Expect(taskDeleter.DeleteArgsForCall(0)).To(Equal("the-task-pod-guid"))
+		_, guid := taskDeleter.DeleteArgsForCall(0)
+		Expect(guid).To(Equal("the-task-pod-guid"))
+	})
+
+	It("counts the callback attempt and the TTL deletion", func() {
+		Expect(testutil.ToFloat64(task.CallbackAttempts)).To(Equal(callbacksBefore + 1))
+		Expect(testutil.ToFloat64(task.CallbackFailures)).To(Equal(callbackFailuresBefore))
+		Expect(testutil.ToFloat64(task.TTLDeletions)).To(Equal(deletionsBefore + 1))
 	})
 
 	It("labels the task as completed", func() {
 		Expect(jobsClient.SetLabelCallCount()).To(Equal(1))
-		_, label, value := jobsClient.SetLabelArgsForCall(0)
+		_, _, label, value := jobsClient.SetLabelArgsForCall(0)
 		Expect(label).To(Equal(k8s.LabelTaskCompleted))
 		Expect(value).To(Equal(k8s.TaskCompletedTrue))
 	})
@@ -161,6 +176,10 @@ var _ = Describe("Task Completion Reconciler", func() {
 			Expect(reconcileErr).ToNot(HaveOccurred())
 			Expect(reconcileRes.RequeueAfter).To(Equal(time.Second * time.Duration(ttl)))
 		})
+
+		It("does not count a TTL deletion", func() {
+			Expect(testutil.ToFloat64(task.TTLDeletions)).To(Equal(deletionsBefore))
+		})
 	})
 
 	When("CC has been notified and TTL has expired", func() {
@@ -172,7 +191,8 @@ var _ = Describe("Task Completion Reconciler", func() {
 		It("deletes the job", func() {
 			Expect(taskReporter.ReportCallCount()).To(Equal(0))
 			Expect(taskDeleter.DeleteCallCount()).To(Equal(1))
-			Expect(taskDeleter.DeleteArgsForCall(0)).To(Equal("the-task-pod-guid"))
+			_, guid := taskDeleter.DeleteArgsForCall(0)
+			Expect(guid).To(Equal("the-task-pod-guid"))
 			Expect(reconcileErr).ToNot(HaveOccurred())
 			Expect(reconcileRes.IsZero()).To(BeTrue())
 		})
@@ -289,13 +309,18 @@ var _ = Describe("Task Completion Reconciler", func() {
 			Expect(reconcileErr).To(MatchError(ContainSubstring("task-reporter-error")))
 		})
 
+		It("counts the failed callback attempt", func() {
+			Expect(testutil.ToFloat64(task.CallbackAttempts)).To(Equal(callbacksBefore + 1))
+			Expect(testutil.ToFloat64(task.CallbackFailures)).To(Equal(callbackFailuresBefore + 1))
+		})
+
 		It("does not set the 'cc acked' annotation on the pod", func() {
 			Expect(pod.Annotations[k8s.AnnotationCCAckedTaskCompletion]).To(BeEmpty())
 		})
 
 		It("updates the pod setting the updated call count but not reporting success", func() {
 			Expect(podsClient.SetAnnotationCallCount()).To(Equal(1))
-			actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
+			_, actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
 			Expect(actualPod).To(Equal(pod))
 			Expect(key).To(Equal(k8s.AnnotationOpiTaskCompletionReportCounter))
 			Expect(value).To(Equal("1"))
@@ -308,7 +333,7 @@ var _ = Describe("Task Completion Reconciler", func() {
 		When("it's the first time", func() {
 			It("sets the 'retry counter' annotation", func() {
 				Expect(podsClient.SetAnnotationCallCount()).To(Equal(1))
-				actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
+				_, actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
 				Expect(actualPod).To(Equal(pod))
 				Expect(key).To(Equal(k8s.AnnotationOpiTaskCompletionReportCounter))
 				Expect(value).To(Equal("1"))
@@ -326,7 +351,7 @@ var _ = Describe("Task Completion Reconciler", func() {
 
 			It("increments the reporting count", func() {
 				Expect(podsClient.SetAnnotationCallCount()).To(Equal(1))
-				actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
+				_, actualPod, key, value := podsClient.SetAnnotationArgsForCall(0)
 				Expect(actualPod).To(Equal(pod))
 				Expect(key).To(Equal(k8s.AnnotationOpiTaskCompletionReportCounter))
 				Expect(value).To(Equal("2"))
@@ -347,6 +372,10 @@ var _ = Describe("Task Completion Reconciler", func() {
 				Expect(reconcileErr).To(BeNil())
 			})
 
+			It("does not count another callback attempt", func() {
+				Expect(testutil.ToFloat64(task.CallbackAttempts)).To(Equal(callbacksBefore))
+			})
+
 			It("deletes the task", func() {
 				Expect(taskDeleter.DeleteCallCount()).To(Equal(1))
 			})
@@ -385,4 +414,106 @@ var _ = Describe("Task Completion Reconciler", func() {
 			Expect(reconcileErr).To(MatchError("failed to label the job as completed: boom"))
 		})
 	})
+
+	It("does not look for a task custom resource", func() {
+		Expect(runtimeClient.GetCallCount()).To(Equal(1))
+		Expect(runtimeClient.StatusCallCount()).To(BeZero())
+	})
+
+	When("the job is owned by a task custom resource", func() {
+		var (
+			statusWriter *reconcilerfakes.FakeStatusWriter
+			taskCR       *eiriniv1.Task
+			getTaskErr   error
+		)
+
+		BeforeEach(func() {
+			statusWriter = new(reconcilerfakes.FakeStatusWriter)
+			runtimeClient.StatusReturns(statusWriter)
+
+			job.Namespace = "space"
+			job.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "eirini.cloudfoundry.org/v1",
+				Kind:       "Task",
+				Name:       "the-task-cr",
+			}}
+			jobsClient.GetByGUIDReturns([]batchv1.Job{job}, nil)
+
+			taskCR = &eiriniv1.Task{ObjectMeta: metav1.ObjectMeta{Name: "the-task-cr", Namespace: "space"}}
+			getTaskErr = nil
+
+			getPodStub := runtimeClient.GetStub
+			runtimeClient.GetStub = func(c context.Context, nn k8stypes.NamespacedName, o runtime.Object) error {
+				if t, ok := o.(*eiriniv1.Task); ok {
+					taskCR.DeepCopyInto(t)
+
+					return getTaskErr
+				}
+
+				return getPodStub(c, nn, o)
+			}
+		})
+
+		It("fetches the task custom resource", func() {
+			Expect(runtimeClient.GetCallCount()).To(Equal(2))
+			_, nn, _ := runtimeClient.GetArgsForCall(1)
+			Expect(nn).To(Equal(k8stypes.NamespacedName{Namespace: "space", Name: "the-task-cr"}))
+		})
+
+		It("patches only the CC acknowledgement into its status", func() {
+			Expect(statusWriter.PatchCallCount()).To(Equal(1))
+			_, obj, patch, _ := statusWriter.PatchArgsForCall(0)
+			Expect(obj.(*eiriniv1.Task).Status.CompletionCallbackAcked).To(BeTrue())
+
+			data, err := patch.Data(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(MatchJSON(`{"status":{"completionCallbackAcked":true}}`))
+		})
+
+		When("the CC has not acknowledged the completion", func() {
+			BeforeEach(func() {
+				taskReporter.ReportReturns(errors.New("task-reporter-error"))
+				pod.ObjectMeta.Annotations[k8s.AnnotationOpiTaskCompletionReportCounter] = "2"
+			})
+
+			It("does not touch the status", func() {
+				Expect(statusWriter.PatchCallCount()).To(BeZero())
+			})
+		})
+
+		When("the acknowledgement has already been recorded", func() {
+			BeforeEach(func() {
+				taskCR.Status.CompletionCallbackAcked = true
+			})
+
+			It("does not patch the status again", func() {
+				Expect(statusWriter.PatchCallCount()).To(BeZero())
+			})
+		})
+
+		When("the task custom resource no longer exists", func() {
+			BeforeEach(func() {
+				getTaskErr = apierrors.NewNotFound(schema.GroupResource{}, "the-task-cr")
+			})
+
+			It("succeeds without updating anything", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(statusWriter.PatchCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the status fails", func() {
+			BeforeEach(func() {
+				statusWriter.PatchReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(reconcileErr).To(MatchError("failed to update task status: boom"))
+			})
+
+			It("does not delete the task yet", func() {
+				Expect(taskDeleter.DeleteCallCount()).To(BeZero())
+			})
+		})
+	})
 })
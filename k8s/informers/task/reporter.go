@@ -1,13 +1,16 @@
 package task
 
 import (
+	"context"
 	"net/http"
 
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/utils"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -25,13 +28,15 @@ func (r StateReporter) Report(pod *corev1.Pod) error {
 	logger.Debug("sending completion notification")
 	req := r.generateTaskCompletedRequest(logger, taskGUID, pod)
 
-	if err := utils.Post(r.Client, uri, req); err != nil {
+	ctx, span := tracing.StartLinked(context.Background(), "report-task-completion", pod.Annotations, trace.WithSpanKind(trace.SpanKindClient))
+
+	if err := utils.Post(ctx, r.Client, uri, req); err != nil {
 		logger.Error("cannot-send-task-status-response", err)
 
-		return errors.Wrap(err, "failed to complete task")
+		return tracing.End(span, errors.Wrap(err, "failed to complete task"))
 	}
 
-	return nil
+	return tracing.End(span, nil)
 }
 
 func (r StateReporter) generateTaskCompletedRequest(logger lager.Logger, guid string, pod *corev1.Pod) cf.TaskCompletedRequest {
@@ -7,10 +7,14 @@ import (
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/informers/task"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -97,6 +101,33 @@ var _ = Describe("Reporter", func() {
 		Expect(server.ReceivedRequests()).To(HaveLen(1))
 	})
 
+	When("the task was desired by a traced request", func() {
+		var exporter *tracetest.InMemoryExporter
+
+		BeforeEach(func() {
+			exporter = tracetest.NewInMemoryExporter()
+			otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+			pod.Annotations[tracing.AnnotationTraceParent] = "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"
+		})
+
+		It("links the callback back to the request", func() {
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("report-task-completion"))
+			Expect(spans[0].Links).To(HaveLen(1))
+			Expect(spans[0].Links[0].SpanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(spans[0].Links[0].SpanContext.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+		})
+
+		It("sends the trace context of the callback to the cloud controller", func() {
+			span := exporter.GetSpans()[0]
+			header := server.ReceivedRequests()[0].Header
+			Expect(header.Get("traceparent")).To(ContainSubstring(span.SpanContext.TraceID().String()))
+			Expect(header.Get("X-B3-SpanId")).To(Equal(span.SpanContext.SpanID().String()))
+		})
+	})
+
 	When("the task container failed", func() {
 		BeforeEach(func() {
 			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
@@ -2,6 +2,7 @@
 package taskfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s/informers/task"
@@ -9,12 +10,13 @@ import (
 )
 
 type FakePodsClient struct {
-	SetAnnotationStub        func(*v1.Pod, string, string) (*v1.Pod, error)
+	SetAnnotationStub        func(context.Context, *v1.Pod, string, string) (*v1.Pod, error)
 	setAnnotationMutex       sync.RWMutex
 	setAnnotationArgsForCall []struct {
-		arg1 *v1.Pod
-		arg2 string
+		arg1 context.Context
+		arg2 *v1.Pod
 		arg3 string
+		arg4 string
 	}
 	setAnnotationReturns struct {
 		result1 *v1.Pod
@@ -28,20 +30,21 @@ type FakePodsClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePodsClient) SetAnnotation(arg1 *v1.Pod, arg2 string, arg3 string) (*v1.Pod, error) {
+func (fake *FakePodsClient) SetAnnotation(arg1 context.Context, arg2 *v1.Pod, arg3 string, arg4 string) (*v1.Pod, error) {
 	fake.setAnnotationMutex.Lock()
 	ret, specificReturn := fake.setAnnotationReturnsOnCall[len(fake.setAnnotationArgsForCall)]
 	fake.setAnnotationArgsForCall = append(fake.setAnnotationArgsForCall, struct {
-		arg1 *v1.Pod
-		arg2 string
+		arg1 context.Context
+		arg2 *v1.Pod
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.SetAnnotationStub
 	fakeReturns := fake.setAnnotationReturns
-	fake.recordInvocation("SetAnnotation", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("SetAnnotation", []interface{}{arg1, arg2, arg3, arg4})
 	fake.setAnnotationMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,17 +58,17 @@ func (fake *FakePodsClient) SetAnnotationCallCount() int {
 	return len(fake.setAnnotationArgsForCall)
 }
 
-func (fake *FakePodsClient) SetAnnotationCalls(stub func(*v1.Pod, string, string) (*v1.Pod, error)) {
+func (fake *FakePodsClient) SetAnnotationCalls(stub func(context.Context, *v1.Pod, string, string) (*v1.Pod, error)) {
 	fake.setAnnotationMutex.Lock()
 	defer fake.setAnnotationMutex.Unlock()
 	fake.SetAnnotationStub = stub
 }
 
-func (fake *FakePodsClient) SetAnnotationArgsForCall(i int) (*v1.Pod, string, string) {
+func (fake *FakePodsClient) SetAnnotationArgsForCall(i int) (context.Context, *v1.Pod, string, string) {
 	fake.setAnnotationMutex.RLock()
 	defer fake.setAnnotationMutex.RUnlock()
 	argsForCall := fake.setAnnotationArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakePodsClient) SetAnnotationReturns(result1 *v1.Pod, result2 error) {
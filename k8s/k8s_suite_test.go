@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -21,3 +22,17 @@ func expectedValFrom(fieldPath string) *v1.EnvVarSource {
 		},
 	}
 }
+
+func tracedSpanContext() trace.SpanContext {
+	traceID, err := trace.TraceIDFromHex("463ac35c9f6413ad48485a3953bb6124")
+	Expect(err).NotTo(HaveOccurred())
+
+	spanID, err := trace.SpanIDFromHex("a2fb4a1d1a96d312")
+	Expect(err).NotTo(HaveOccurred())
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
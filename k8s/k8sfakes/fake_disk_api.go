@@ -2,15 +2,19 @@
 package k8sfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
+	v1 "k8s.io/api/core/v1"
 )
 
 type FakeDiskAPI struct {
-	GetPodMetricsStub        func() (map[string]float64, error)
+	GetPodMetricsStub        func(context.Context, []v1.Pod) (map[string]float64, error)
 	getPodMetricsMutex       sync.RWMutex
 	getPodMetricsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1.Pod
 	}
 	getPodMetricsReturns struct {
 		result1 map[string]float64
@@ -24,17 +28,24 @@ type FakeDiskAPI struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeDiskAPI) GetPodMetrics() (map[string]float64, error) {
+func (fake *FakeDiskAPI) GetPodMetrics(arg1 context.Context, arg2 []v1.Pod) (map[string]float64, error) {
+	var arg2Copy []v1.Pod
+	if arg2 != nil {
+		arg2Copy = make([]v1.Pod, len(arg2))
+		copy(arg2Copy, arg2)
+	}
 	fake.getPodMetricsMutex.Lock()
 	ret, specificReturn := fake.getPodMetricsReturnsOnCall[len(fake.getPodMetricsArgsForCall)]
 	fake.getPodMetricsArgsForCall = append(fake.getPodMetricsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+		arg2 []v1.Pod
+	}{arg1, arg2Copy})
 	stub := fake.GetPodMetricsStub
 	fakeReturns := fake.getPodMetricsReturns
-	fake.recordInvocation("GetPodMetrics", []interface{}{})
+	fake.recordInvocation("GetPodMetrics", []interface{}{arg1, arg2Copy})
 	fake.getPodMetricsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -48,12 +59,19 @@ func (fake *FakeDiskAPI) GetPodMetricsCallCount() int {
 	return len(fake.getPodMetricsArgsForCall)
 }
 
-func (fake *FakeDiskAPI) GetPodMetricsCalls(stub func() (map[string]float64, error)) {
+func (fake *FakeDiskAPI) GetPodMetricsCalls(stub func(context.Context, []v1.Pod) (map[string]float64, error)) {
 	fake.getPodMetricsMutex.Lock()
 	defer fake.getPodMetricsMutex.Unlock()
 	fake.GetPodMetricsStub = stub
 }
 
+func (fake *FakeDiskAPI) GetPodMetricsArgsForCall(i int) (context.Context, []v1.Pod) {
+	fake.getPodMetricsMutex.RLock()
+	defer fake.getPodMetricsMutex.RUnlock()
+	argsForCall := fake.getPodMetricsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeDiskAPI) GetPodMetricsReturns(result1 map[string]float64, result2 error) {
 	fake.getPodMetricsMutex.Lock()
 	defer fake.getPodMetricsMutex.Unlock()
@@ -2,6 +2,7 @@
 package k8sfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -9,11 +10,12 @@ import (
 )
 
 type FakeJobCreatingClient struct {
-	CreateStub        func(string, *v1.Job) (*v1.Job, error)
+	CreateStub        func(context.Context, string, *v1.Job) (*v1.Job, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 string
-		arg2 *v1.Job
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.Job
 	}
 	createReturns struct {
 		result1 *v1.Job
@@ -23,11 +25,12 @@ type FakeJobCreatingClient struct {
 		result1 *v1.Job
 		result2 error
 	}
-	GetByGUIDStub        func(string, bool) ([]v1.Job, error)
+	GetByGUIDStub        func(context.Context, string, bool) ([]v1.Job, error)
 	getByGUIDMutex       sync.RWMutex
 	getByGUIDArgsForCall []struct {
-		arg1 string
-		arg2 bool
+		arg1 context.Context
+		arg2 string
+		arg3 bool
 	}
 	getByGUIDReturns struct {
 		result1 []v1.Job
@@ -37,10 +40,11 @@ type FakeJobCreatingClient struct {
 		result1 []v1.Job
 		result2 error
 	}
-	ListStub        func(bool) ([]v1.Job, error)
+	ListStub        func(context.Context, bool) ([]v1.Job, error)
 	listMutex       sync.RWMutex
 	listArgsForCall []struct {
-		arg1 bool
+		arg1 context.Context
+		arg2 bool
 	}
 	listReturns struct {
 		result1 []v1.Job
@@ -54,19 +58,20 @@ type FakeJobCreatingClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeJobCreatingClient) Create(arg1 string, arg2 *v1.Job) (*v1.Job, error) {
+func (fake *FakeJobCreatingClient) Create(arg1 context.Context, arg2 string, arg3 *v1.Job) (*v1.Job, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 string
-		arg2 *v1.Job
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.Job
+	}{arg1, arg2, arg3})
 	stub := fake.CreateStub
 	fakeReturns := fake.createReturns
-	fake.recordInvocation("Create", []interface{}{arg1, arg2})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3})
 	fake.createMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -80,17 +85,17 @@ func (fake *FakeJobCreatingClient) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeJobCreatingClient) CreateCalls(stub func(string, *v1.Job) (*v1.Job, error)) {
+func (fake *FakeJobCreatingClient) CreateCalls(stub func(context.Context, string, *v1.Job) (*v1.Job, error)) {
 	fake.createMutex.Lock()
 	defer fake.createMutex.Unlock()
 	fake.CreateStub = stub
 }
 
-func (fake *FakeJobCreatingClient) CreateArgsForCall(i int) (string, *v1.Job) {
+func (fake *FakeJobCreatingClient) CreateArgsForCall(i int) (context.Context, string, *v1.Job) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	argsForCall := fake.createArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeJobCreatingClient) CreateReturns(result1 *v1.Job, result2 error) {
@@ -119,19 +124,20 @@ func (fake *FakeJobCreatingClient) CreateReturnsOnCall(i int, result1 *v1.Job, r
 	}{result1, result2}
 }
 
-func (fake *FakeJobCreatingClient) GetByGUID(arg1 string, arg2 bool) ([]v1.Job, error) {
+func (fake *FakeJobCreatingClient) GetByGUID(arg1 context.Context, arg2 string, arg3 bool) ([]v1.Job, error) {
 	fake.getByGUIDMutex.Lock()
 	ret, specificReturn := fake.getByGUIDReturnsOnCall[len(fake.getByGUIDArgsForCall)]
 	fake.getByGUIDArgsForCall = append(fake.getByGUIDArgsForCall, struct {
-		arg1 string
-		arg2 bool
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.GetByGUIDStub
 	fakeReturns := fake.getByGUIDReturns
-	fake.recordInvocation("GetByGUID", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetByGUID", []interface{}{arg1, arg2, arg3})
 	fake.getByGUIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -145,17 +151,17 @@ func (fake *FakeJobCreatingClient) GetByGUIDCallCount() int {
 	return len(fake.getByGUIDArgsForCall)
 }
 
-func (fake *FakeJobCreatingClient) GetByGUIDCalls(stub func(string, bool) ([]v1.Job, error)) {
+func (fake *FakeJobCreatingClient) GetByGUIDCalls(stub func(context.Context, string, bool) ([]v1.Job, error)) {
 	fake.getByGUIDMutex.Lock()
 	defer fake.getByGUIDMutex.Unlock()
 	fake.GetByGUIDStub = stub
 }
 
-func (fake *FakeJobCreatingClient) GetByGUIDArgsForCall(i int) (string, bool) {
+func (fake *FakeJobCreatingClient) GetByGUIDArgsForCall(i int) (context.Context, string, bool) {
 	fake.getByGUIDMutex.RLock()
 	defer fake.getByGUIDMutex.RUnlock()
 	argsForCall := fake.getByGUIDArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeJobCreatingClient) GetByGUIDReturns(result1 []v1.Job, result2 error) {
@@ -184,18 +190,19 @@ func (fake *FakeJobCreatingClient) GetByGUIDReturnsOnCall(i int, result1 []v1.Jo
 	}{result1, result2}
 }
 
-func (fake *FakeJobCreatingClient) List(arg1 bool) ([]v1.Job, error) {
+func (fake *FakeJobCreatingClient) List(arg1 context.Context, arg2 bool) ([]v1.Job, error) {
 	fake.listMutex.Lock()
 	ret, specificReturn := fake.listReturnsOnCall[len(fake.listArgsForCall)]
 	fake.listArgsForCall = append(fake.listArgsForCall, struct {
-		arg1 bool
-	}{arg1})
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
 	stub := fake.ListStub
 	fakeReturns := fake.listReturns
-	fake.recordInvocation("List", []interface{}{arg1})
+	fake.recordInvocation("List", []interface{}{arg1, arg2})
 	fake.listMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -209,17 +216,17 @@ func (fake *FakeJobCreatingClient) ListCallCount() int {
 	return len(fake.listArgsForCall)
 }
 
-func (fake *FakeJobCreatingClient) ListCalls(stub func(bool) ([]v1.Job, error)) {
+func (fake *FakeJobCreatingClient) ListCalls(stub func(context.Context, bool) ([]v1.Job, error)) {
 	fake.listMutex.Lock()
 	defer fake.listMutex.Unlock()
 	fake.ListStub = stub
 }
 
-func (fake *FakeJobCreatingClient) ListArgsForCall(i int) bool {
+func (fake *FakeJobCreatingClient) ListArgsForCall(i int) (context.Context, bool) {
 	fake.listMutex.RLock()
 	defer fake.listMutex.RUnlock()
 	argsForCall := fake.listArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeJobCreatingClient) ListReturns(result1 []v1.Job, result2 error) {
@@ -2,6 +2,7 @@
 package k8sfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -9,11 +10,12 @@ import (
 )
 
 type FakeJobDeletingClient struct {
-	DeleteStub        func(string, string) error
+	DeleteStub        func(context.Context, string, string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	deleteReturns struct {
 		result1 error
@@ -21,11 +23,12 @@ type FakeJobDeletingClient struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetByGUIDStub        func(string, bool) ([]v1.Job, error)
+	GetByGUIDStub        func(context.Context, string, bool) ([]v1.Job, error)
 	getByGUIDMutex       sync.RWMutex
 	getByGUIDArgsForCall []struct {
-		arg1 string
-		arg2 bool
+		arg1 context.Context
+		arg2 string
+		arg3 bool
 	}
 	getByGUIDReturns struct {
 		result1 []v1.Job
@@ -39,19 +42,20 @@ type FakeJobDeletingClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeJobDeletingClient) Delete(arg1 string, arg2 string) error {
+func (fake *FakeJobDeletingClient) Delete(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -65,17 +69,17 @@ func (fake *FakeJobDeletingClient) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeJobDeletingClient) DeleteCalls(stub func(string, string) error) {
+func (fake *FakeJobDeletingClient) DeleteCalls(stub func(context.Context, string, string) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeJobDeletingClient) DeleteArgsForCall(i int) (string, string) {
+func (fake *FakeJobDeletingClient) DeleteArgsForCall(i int) (context.Context, string, string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeJobDeletingClient) DeleteReturns(result1 error) {
@@ -101,19 +105,20 @@ func (fake *FakeJobDeletingClient) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJobDeletingClient) GetByGUID(arg1 string, arg2 bool) ([]v1.Job, error) {
+func (fake *FakeJobDeletingClient) GetByGUID(arg1 context.Context, arg2 string, arg3 bool) ([]v1.Job, error) {
 	fake.getByGUIDMutex.Lock()
 	ret, specificReturn := fake.getByGUIDReturnsOnCall[len(fake.getByGUIDArgsForCall)]
 	fake.getByGUIDArgsForCall = append(fake.getByGUIDArgsForCall, struct {
-		arg1 string
-		arg2 bool
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.GetByGUIDStub
 	fakeReturns := fake.getByGUIDReturns
-	fake.recordInvocation("GetByGUID", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetByGUID", []interface{}{arg1, arg2, arg3})
 	fake.getByGUIDMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -127,17 +132,17 @@ func (fake *FakeJobDeletingClient) GetByGUIDCallCount() int {
 	return len(fake.getByGUIDArgsForCall)
 }
 
-func (fake *FakeJobDeletingClient) GetByGUIDCalls(stub func(string, bool) ([]v1.Job, error)) {
+func (fake *FakeJobDeletingClient) GetByGUIDCalls(stub func(context.Context, string, bool) ([]v1.Job, error)) {
 	fake.getByGUIDMutex.Lock()
 	defer fake.getByGUIDMutex.Unlock()
 	fake.GetByGUIDStub = stub
 }
 
-func (fake *FakeJobDeletingClient) GetByGUIDArgsForCall(i int) (string, bool) {
+func (fake *FakeJobDeletingClient) GetByGUIDArgsForCall(i int) (context.Context, string, bool) {
 	fake.getByGUIDMutex.RLock()
 	defer fake.getByGUIDMutex.RUnlock()
 	argsForCall := fake.getByGUIDArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeJobDeletingClient) GetByGUIDReturns(result1 []v1.Job, result2 error) {
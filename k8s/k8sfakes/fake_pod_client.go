@@ -2,6 +2,8 @@
 package k8sfakes
 
 import (
+	"context"
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -10,11 +12,12 @@ import (
 )
 
 type FakePodClient struct {
-	DeleteStub        func(string, string) error
+	DeleteStub        func(context.Context, string, string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	deleteReturns struct {
 		result1 error
@@ -22,9 +25,10 @@ type FakePodClient struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetAllStub        func() ([]v1.Pod, error)
+	GetAllStub        func(context.Context) ([]v1.Pod, error)
 	getAllMutex       sync.RWMutex
 	getAllArgsForCall []struct {
+		arg1 context.Context
 	}
 	getAllReturns struct {
 		result1 []v1.Pod
@@ -34,10 +38,11 @@ type FakePodClient struct {
 		result1 []v1.Pod
 		result2 error
 	}
-	GetByLRPIdentifierStub        func(opi.LRPIdentifier) ([]v1.Pod, error)
+	GetByLRPIdentifierStub        func(context.Context, opi.LRPIdentifier) ([]v1.Pod, error)
 	getByLRPIdentifierMutex       sync.RWMutex
 	getByLRPIdentifierArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	getByLRPIdentifierReturns struct {
 		result1 []v1.Pod
@@ -47,23 +52,40 @@ type FakePodClient struct {
 		result1 []v1.Pod
 		result2 error
 	}
+	GetLogsStub        func(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *v1.PodLogOptions
+	}
+	getLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePodClient) Delete(arg1 string, arg2 string) error {
+func (fake *FakePodClient) Delete(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -77,17 +99,17 @@ func (fake *FakePodClient) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakePodClient) DeleteCalls(stub func(string, string) error) {
+func (fake *FakePodClient) DeleteCalls(stub func(context.Context, string, string) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakePodClient) DeleteArgsForCall(i int) (string, string) {
+func (fake *FakePodClient) DeleteArgsForCall(i int) (context.Context, string, string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakePodClient) DeleteReturns(result1 error) {
@@ -113,17 +135,18 @@ func (fake *FakePodClient) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakePodClient) GetAll() ([]v1.Pod, error) {
+func (fake *FakePodClient) GetAll(arg1 context.Context) ([]v1.Pod, error) {
 	fake.getAllMutex.Lock()
 	ret, specificReturn := fake.getAllReturnsOnCall[len(fake.getAllArgsForCall)]
 	fake.getAllArgsForCall = append(fake.getAllArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.GetAllStub
 	fakeReturns := fake.getAllReturns
-	fake.recordInvocation("GetAll", []interface{}{})
+	fake.recordInvocation("GetAll", []interface{}{arg1})
 	fake.getAllMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -137,12 +160,19 @@ func (fake *FakePodClient) GetAllCallCount() int {
 	return len(fake.getAllArgsForCall)
 }
 
-func (fake *FakePodClient) GetAllCalls(stub func() ([]v1.Pod, error)) {
+func (fake *FakePodClient) GetAllCalls(stub func(context.Context) ([]v1.Pod, error)) {
 	fake.getAllMutex.Lock()
 	defer fake.getAllMutex.Unlock()
 	fake.GetAllStub = stub
 }
 
+func (fake *FakePodClient) GetAllArgsForCall(i int) context.Context {
+	fake.getAllMutex.RLock()
+	defer fake.getAllMutex.RUnlock()
+	argsForCall := fake.getAllArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakePodClient) GetAllReturns(result1 []v1.Pod, result2 error) {
 	fake.getAllMutex.Lock()
 	defer fake.getAllMutex.Unlock()
@@ -169,18 +199,19 @@ func (fake *FakePodClient) GetAllReturnsOnCall(i int, result1 []v1.Pod, result2
 	}{result1, result2}
 }
 
-func (fake *FakePodClient) GetByLRPIdentifier(arg1 opi.LRPIdentifier) ([]v1.Pod, error) {
+func (fake *FakePodClient) GetByLRPIdentifier(arg1 context.Context, arg2 opi.LRPIdentifier) ([]v1.Pod, error) {
 	fake.getByLRPIdentifierMutex.Lock()
 	ret, specificReturn := fake.getByLRPIdentifierReturnsOnCall[len(fake.getByLRPIdentifierArgsForCall)]
 	fake.getByLRPIdentifierArgsForCall = append(fake.getByLRPIdentifierArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.GetByLRPIdentifierStub
 	fakeReturns := fake.getByLRPIdentifierReturns
-	fake.recordInvocation("GetByLRPIdentifier", []interface{}{arg1})
+	fake.recordInvocation("GetByLRPIdentifier", []interface{}{arg1, arg2})
 	fake.getByLRPIdentifierMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -194,17 +225,17 @@ func (fake *FakePodClient) GetByLRPIdentifierCallCount() int {
 	return len(fake.getByLRPIdentifierArgsForCall)
 }
 
-func (fake *FakePodClient) GetByLRPIdentifierCalls(stub func(opi.LRPIdentifier) ([]v1.Pod, error)) {
+func (fake *FakePodClient) GetByLRPIdentifierCalls(stub func(context.Context, opi.LRPIdentifier) ([]v1.Pod, error)) {
 	fake.getByLRPIdentifierMutex.Lock()
 	defer fake.getByLRPIdentifierMutex.Unlock()
 	fake.GetByLRPIdentifierStub = stub
 }
 
-func (fake *FakePodClient) GetByLRPIdentifierArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakePodClient) GetByLRPIdentifierArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.getByLRPIdentifierMutex.RLock()
 	defer fake.getByLRPIdentifierMutex.RUnlock()
 	argsForCall := fake.getByLRPIdentifierArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakePodClient) GetByLRPIdentifierReturns(result1 []v1.Pod, result2 error) {
@@ -233,6 +264,73 @@ func (fake *FakePodClient) GetByLRPIdentifierReturnsOnCall(i int, result1 []v1.P
 	}{result1, result2}
 }
 
+func (fake *FakePodClient) GetLogs(arg1 context.Context, arg2 string, arg3 string, arg4 *v1.PodLogOptions) (io.ReadCloser, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *v1.PodLogOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePodClient) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *FakePodClient) GetLogsCalls(stub func(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *FakePodClient) GetLogsArgsForCall(i int) (context.Context, string, string, *v1.PodLogOptions) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePodClient) GetLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePodClient) GetLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePodClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -242,6 +340,8 @@ func (fake *FakePodClient) Invocations() map[string][][]interface{} {
 	defer fake.getAllMutex.RUnlock()
 	fake.getByLRPIdentifierMutex.RLock()
 	defer fake.getByLRPIdentifierMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
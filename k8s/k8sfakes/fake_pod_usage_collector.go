@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package k8sfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+type FakePodUsageCollector struct {
+	CollectPodUsageStub        func(context.Context, []v1.Pod) map[string]k8s.PodUsage
+	collectPodUsageMutex       sync.RWMutex
+	collectPodUsageArgsForCall []struct {
+		arg1 context.Context
+		arg2 []v1.Pod
+	}
+	collectPodUsageReturns struct {
+		result1 map[string]k8s.PodUsage
+	}
+	collectPodUsageReturnsOnCall map[int]struct {
+		result1 map[string]k8s.PodUsage
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsage(arg1 context.Context, arg2 []v1.Pod) map[string]k8s.PodUsage {
+	var arg2Copy []v1.Pod
+	if arg2 != nil {
+		arg2Copy = make([]v1.Pod, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.collectPodUsageMutex.Lock()
+	ret, specificReturn := fake.collectPodUsageReturnsOnCall[len(fake.collectPodUsageArgsForCall)]
+	fake.collectPodUsageArgsForCall = append(fake.collectPodUsageArgsForCall, struct {
+		arg1 context.Context
+		arg2 []v1.Pod
+	}{arg1, arg2Copy})
+	stub := fake.CollectPodUsageStub
+	fakeReturns := fake.collectPodUsageReturns
+	fake.recordInvocation("CollectPodUsage", []interface{}{arg1, arg2Copy})
+	fake.collectPodUsageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsageCallCount() int {
+	fake.collectPodUsageMutex.RLock()
+	defer fake.collectPodUsageMutex.RUnlock()
+	return len(fake.collectPodUsageArgsForCall)
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsageCalls(stub func(context.Context, []v1.Pod) map[string]k8s.PodUsage) {
+	fake.collectPodUsageMutex.Lock()
+	defer fake.collectPodUsageMutex.Unlock()
+	fake.CollectPodUsageStub = stub
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsageArgsForCall(i int) (context.Context, []v1.Pod) {
+	fake.collectPodUsageMutex.RLock()
+	defer fake.collectPodUsageMutex.RUnlock()
+	argsForCall := fake.collectPodUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsageReturns(result1 map[string]k8s.PodUsage) {
+	fake.collectPodUsageMutex.Lock()
+	defer fake.collectPodUsageMutex.Unlock()
+	fake.CollectPodUsageStub = nil
+	fake.collectPodUsageReturns = struct {
+		result1 map[string]k8s.PodUsage
+	}{result1}
+}
+
+func (fake *FakePodUsageCollector) CollectPodUsageReturnsOnCall(i int, result1 map[string]k8s.PodUsage) {
+	fake.collectPodUsageMutex.Lock()
+	defer fake.collectPodUsageMutex.Unlock()
+	fake.CollectPodUsageStub = nil
+	if fake.collectPodUsageReturnsOnCall == nil {
+		fake.collectPodUsageReturnsOnCall = make(map[int]struct {
+			result1 map[string]k8s.PodUsage
+		})
+	}
+	fake.collectPodUsageReturnsOnCall[i] = struct {
+		result1 map[string]k8s.PodUsage
+	}{result1}
+}
+
+func (fake *FakePodUsageCollector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.collectPodUsageMutex.RLock()
+	defer fake.collectPodUsageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePodUsageCollector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ k8s.PodUsageCollector = new(FakePodUsageCollector)
@@ -2,6 +2,7 @@
 package k8sfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -10,11 +11,12 @@ import (
 )
 
 type FakeStatefulSetClient struct {
-	CreateStub        func(string, *v1.StatefulSet) (*v1.StatefulSet, error)
+	CreateStub        func(context.Context, string, *v1.StatefulSet) (*v1.StatefulSet, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 string
-		arg2 *v1.StatefulSet
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.StatefulSet
 	}
 	createReturns struct {
 		result1 *v1.StatefulSet
@@ -24,11 +26,12 @@ type FakeStatefulSetClient struct {
 		result1 *v1.StatefulSet
 		result2 error
 	}
-	DeleteStub        func(string, string) error
+	DeleteStub        func(context.Context, string, string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	deleteReturns struct {
 		result1 error
@@ -36,10 +39,11 @@ type FakeStatefulSetClient struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetByLRPIdentifierStub        func(opi.LRPIdentifier) ([]v1.StatefulSet, error)
+	GetByLRPIdentifierStub        func(context.Context, opi.LRPIdentifier) ([]v1.StatefulSet, error)
 	getByLRPIdentifierMutex       sync.RWMutex
 	getByLRPIdentifierArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	getByLRPIdentifierReturns struct {
 		result1 []v1.StatefulSet
@@ -49,10 +53,11 @@ type FakeStatefulSetClient struct {
 		result1 []v1.StatefulSet
 		result2 error
 	}
-	GetBySourceTypeStub        func(string) ([]v1.StatefulSet, error)
+	GetBySourceTypeStub        func(context.Context, string) ([]v1.StatefulSet, error)
 	getBySourceTypeMutex       sync.RWMutex
 	getBySourceTypeArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	getBySourceTypeReturns struct {
 		result1 []v1.StatefulSet
@@ -62,11 +67,12 @@ type FakeStatefulSetClient struct {
 		result1 []v1.StatefulSet
 		result2 error
 	}
-	UpdateStub        func(string, *v1.StatefulSet) (*v1.StatefulSet, error)
+	UpdateStub        func(context.Context, string, *v1.StatefulSet) (*v1.StatefulSet, error)
 	updateMutex       sync.RWMutex
 	updateArgsForCall []struct {
-		arg1 string
-		arg2 *v1.StatefulSet
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.StatefulSet
 	}
 	updateReturns struct {
 		result1 *v1.StatefulSet
@@ -80,19 +86,20 @@ type FakeStatefulSetClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStatefulSetClient) Create(arg1 string, arg2 *v1.StatefulSet) (*v1.StatefulSet, error) {
+func (fake *FakeStatefulSetClient) Create(arg1 context.Context, arg2 string, arg3 *v1.StatefulSet) (*v1.StatefulSet, error) {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 string
-		arg2 *v1.StatefulSet
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.StatefulSet
+	}{arg1, arg2, arg3})
 	stub := fake.CreateStub
 	fakeReturns := fake.createReturns
-	fake.recordInvocation("Create", []interface{}{arg1, arg2})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3})
 	fake.createMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -106,17 +113,17 @@ func (fake *FakeStatefulSetClient) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeStatefulSetClient) CreateCalls(stub func(string, *v1.StatefulSet) (*v1.StatefulSet, error)) {
+func (fake *FakeStatefulSetClient) CreateCalls(stub func(context.Context, string, *v1.StatefulSet) (*v1.StatefulSet, error)) {
 	fake.createMutex.Lock()
 	defer fake.createMutex.Unlock()
 	fake.CreateStub = stub
 }
 
-func (fake *FakeStatefulSetClient) CreateArgsForCall(i int) (string, *v1.StatefulSet) {
+func (fake *FakeStatefulSetClient) CreateArgsForCall(i int) (context.Context, string, *v1.StatefulSet) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	argsForCall := fake.createArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeStatefulSetClient) CreateReturns(result1 *v1.StatefulSet, result2 error) {
@@ -145,19 +152,20 @@ func (fake *FakeStatefulSetClient) CreateReturnsOnCall(i int, result1 *v1.Statef
 	}{result1, result2}
 }
 
-func (fake *FakeStatefulSetClient) Delete(arg1 string, arg2 string) error {
+func (fake *FakeStatefulSetClient) Delete(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -171,17 +179,17 @@ func (fake *FakeStatefulSetClient) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeStatefulSetClient) DeleteCalls(stub func(string, string) error) {
+func (fake *FakeStatefulSetClient) DeleteCalls(stub func(context.Context, string, string) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
-func (fake *FakeStatefulSetClient) DeleteArgsForCall(i int) (string, string) {
+func (fake *FakeStatefulSetClient) DeleteArgsForCall(i int) (context.Context, string, string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	argsForCall := fake.deleteArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeStatefulSetClient) DeleteReturns(result1 error) {
@@ -207,18 +215,19 @@ func (fake *FakeStatefulSetClient) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStatefulSetClient) GetByLRPIdentifier(arg1 opi.LRPIdentifier) ([]v1.StatefulSet, error) {
+func (fake *FakeStatefulSetClient) GetByLRPIdentifier(arg1 context.Context, arg2 opi.LRPIdentifier) ([]v1.StatefulSet, error) {
 	fake.getByLRPIdentifierMutex.Lock()
 	ret, specificReturn := fake.getByLRPIdentifierReturnsOnCall[len(fake.getByLRPIdentifierArgsForCall)]
 	fake.getByLRPIdentifierArgsForCall = append(fake.getByLRPIdentifierArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.GetByLRPIdentifierStub
 	fakeReturns := fake.getByLRPIdentifierReturns
-	fake.recordInvocation("GetByLRPIdentifier", []interface{}{arg1})
+	fake.recordInvocation("GetByLRPIdentifier", []interface{}{arg1, arg2})
 	fake.getByLRPIdentifierMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -232,17 +241,17 @@ func (fake *FakeStatefulSetClient) GetByLRPIdentifierCallCount() int {
 	return len(fake.getByLRPIdentifierArgsForCall)
 }
 
-func (fake *FakeStatefulSetClient) GetByLRPIdentifierCalls(stub func(opi.LRPIdentifier) ([]v1.StatefulSet, error)) {
+func (fake *FakeStatefulSetClient) GetByLRPIdentifierCalls(stub func(context.Context, opi.LRPIdentifier) ([]v1.StatefulSet, error)) {
 	fake.getByLRPIdentifierMutex.Lock()
 	defer fake.getByLRPIdentifierMutex.Unlock()
 	fake.GetByLRPIdentifierStub = stub
 }
 
-func (fake *FakeStatefulSetClient) GetByLRPIdentifierArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakeStatefulSetClient) GetByLRPIdentifierArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.getByLRPIdentifierMutex.RLock()
 	defer fake.getByLRPIdentifierMutex.RUnlock()
 	argsForCall := fake.getByLRPIdentifierArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStatefulSetClient) GetByLRPIdentifierReturns(result1 []v1.StatefulSet, result2 error) {
@@ -271,18 +280,19 @@ func (fake *FakeStatefulSetClient) GetByLRPIdentifierReturnsOnCall(i int, result
 	}{result1, result2}
 }
 
-func (fake *FakeStatefulSetClient) GetBySourceType(arg1 string) ([]v1.StatefulSet, error) {
+func (fake *FakeStatefulSetClient) GetBySourceType(arg1 context.Context, arg2 string) ([]v1.StatefulSet, error) {
 	fake.getBySourceTypeMutex.Lock()
 	ret, specificReturn := fake.getBySourceTypeReturnsOnCall[len(fake.getBySourceTypeArgsForCall)]
 	fake.getBySourceTypeArgsForCall = append(fake.getBySourceTypeArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.GetBySourceTypeStub
 	fakeReturns := fake.getBySourceTypeReturns
-	fake.recordInvocation("GetBySourceType", []interface{}{arg1})
+	fake.recordInvocation("GetBySourceType", []interface{}{arg1, arg2})
 	fake.getBySourceTypeMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -296,17 +306,17 @@ func (fake *FakeStatefulSetClient) GetBySourceTypeCallCount() int {
 	return len(fake.getBySourceTypeArgsForCall)
 }
 
-func (fake *FakeStatefulSetClient) GetBySourceTypeCalls(stub func(string) ([]v1.StatefulSet, error)) {
+func (fake *FakeStatefulSetClient) GetBySourceTypeCalls(stub func(context.Context, string) ([]v1.StatefulSet, error)) {
 	fake.getBySourceTypeMutex.Lock()
 	defer fake.getBySourceTypeMutex.Unlock()
 	fake.GetBySourceTypeStub = stub
 }
 
-func (fake *FakeStatefulSetClient) GetBySourceTypeArgsForCall(i int) string {
+func (fake *FakeStatefulSetClient) GetBySourceTypeArgsForCall(i int) (context.Context, string) {
 	fake.getBySourceTypeMutex.RLock()
 	defer fake.getBySourceTypeMutex.RUnlock()
 	argsForCall := fake.getBySourceTypeArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeStatefulSetClient) GetBySourceTypeReturns(result1 []v1.StatefulSet, result2 error) {
@@ -335,19 +345,20 @@ func (fake *FakeStatefulSetClient) GetBySourceTypeReturnsOnCall(i int, result1 [
 	}{result1, result2}
 }
 
-func (fake *FakeStatefulSetClient) Update(arg1 string, arg2 *v1.StatefulSet) (*v1.StatefulSet, error) {
+func (fake *FakeStatefulSetClient) Update(arg1 context.Context, arg2 string, arg3 *v1.StatefulSet) (*v1.StatefulSet, error) {
 	fake.updateMutex.Lock()
 	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
 	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
-		arg1 string
-		arg2 *v1.StatefulSet
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 *v1.StatefulSet
+	}{arg1, arg2, arg3})
 	stub := fake.UpdateStub
 	fakeReturns := fake.updateReturns
-	fake.recordInvocation("Update", []interface{}{arg1, arg2})
+	fake.recordInvocation("Update", []interface{}{arg1, arg2, arg3})
 	fake.updateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -361,17 +372,17 @@ func (fake *FakeStatefulSetClient) UpdateCallCount() int {
 	return len(fake.updateArgsForCall)
 }
 
-func (fake *FakeStatefulSetClient) UpdateCalls(stub func(string, *v1.StatefulSet) (*v1.StatefulSet, error)) {
+func (fake *FakeStatefulSetClient) UpdateCalls(stub func(context.Context, string, *v1.StatefulSet) (*v1.StatefulSet, error)) {
 	fake.updateMutex.Lock()
 	defer fake.updateMutex.Unlock()
 	fake.UpdateStub = stub
 }
 
-func (fake *FakeStatefulSetClient) UpdateArgsForCall(i int) (string, *v1.StatefulSet) {
+func (fake *FakeStatefulSetClient) UpdateArgsForCall(i int) (context.Context, string, *v1.StatefulSet) {
 	fake.updateMutex.RLock()
 	defer fake.updateMutex.RUnlock()
 	argsForCall := fake.updateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeStatefulSetClient) UpdateReturns(result1 *v1.StatefulSet, result2 error) {
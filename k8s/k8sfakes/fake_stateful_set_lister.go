@@ -0,0 +1,202 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package k8sfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/opi"
+	v1 "k8s.io/api/apps/v1"
+)
+
+type FakeStatefulSetLister struct {
+	GetByLRPIdentifierStub        func(context.Context, opi.LRPIdentifier) ([]v1.StatefulSet, error)
+	getByLRPIdentifierMutex       sync.RWMutex
+	getByLRPIdentifierArgsForCall []struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}
+	getByLRPIdentifierReturns struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}
+	getByLRPIdentifierReturnsOnCall map[int]struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}
+	GetBySourceTypeStub        func(context.Context, string) ([]v1.StatefulSet, error)
+	getBySourceTypeMutex       sync.RWMutex
+	getBySourceTypeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getBySourceTypeReturns struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}
+	getBySourceTypeReturnsOnCall map[int]struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifier(arg1 context.Context, arg2 opi.LRPIdentifier) ([]v1.StatefulSet, error) {
+	fake.getByLRPIdentifierMutex.Lock()
+	ret, specificReturn := fake.getByLRPIdentifierReturnsOnCall[len(fake.getByLRPIdentifierArgsForCall)]
+	fake.getByLRPIdentifierArgsForCall = append(fake.getByLRPIdentifierArgsForCall, struct {
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
+	stub := fake.GetByLRPIdentifierStub
+	fakeReturns := fake.getByLRPIdentifierReturns
+	fake.recordInvocation("GetByLRPIdentifier", []interface{}{arg1, arg2})
+	fake.getByLRPIdentifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifierCallCount() int {
+	fake.getByLRPIdentifierMutex.RLock()
+	defer fake.getByLRPIdentifierMutex.RUnlock()
+	return len(fake.getByLRPIdentifierArgsForCall)
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifierCalls(stub func(context.Context, opi.LRPIdentifier) ([]v1.StatefulSet, error)) {
+	fake.getByLRPIdentifierMutex.Lock()
+	defer fake.getByLRPIdentifierMutex.Unlock()
+	fake.GetByLRPIdentifierStub = stub
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifierArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
+	fake.getByLRPIdentifierMutex.RLock()
+	defer fake.getByLRPIdentifierMutex.RUnlock()
+	argsForCall := fake.getByLRPIdentifierArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifierReturns(result1 []v1.StatefulSet, result2 error) {
+	fake.getByLRPIdentifierMutex.Lock()
+	defer fake.getByLRPIdentifierMutex.Unlock()
+	fake.GetByLRPIdentifierStub = nil
+	fake.getByLRPIdentifierReturns = struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatefulSetLister) GetByLRPIdentifierReturnsOnCall(i int, result1 []v1.StatefulSet, result2 error) {
+	fake.getByLRPIdentifierMutex.Lock()
+	defer fake.getByLRPIdentifierMutex.Unlock()
+	fake.GetByLRPIdentifierStub = nil
+	if fake.getByLRPIdentifierReturnsOnCall == nil {
+		fake.getByLRPIdentifierReturnsOnCall = make(map[int]struct {
+			result1 []v1.StatefulSet
+			result2 error
+		})
+	}
+	fake.getByLRPIdentifierReturnsOnCall[i] = struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceType(arg1 context.Context, arg2 string) ([]v1.StatefulSet, error) {
+	fake.getBySourceTypeMutex.Lock()
+	ret, specificReturn := fake.getBySourceTypeReturnsOnCall[len(fake.getBySourceTypeArgsForCall)]
+	fake.getBySourceTypeArgsForCall = append(fake.getBySourceTypeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetBySourceTypeStub
+	fakeReturns := fake.getBySourceTypeReturns
+	fake.recordInvocation("GetBySourceType", []interface{}{arg1, arg2})
+	fake.getBySourceTypeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceTypeCallCount() int {
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	return len(fake.getBySourceTypeArgsForCall)
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceTypeCalls(stub func(context.Context, string) ([]v1.StatefulSet, error)) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = stub
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceTypeArgsForCall(i int) (context.Context, string) {
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	argsForCall := fake.getBySourceTypeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceTypeReturns(result1 []v1.StatefulSet, result2 error) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = nil
+	fake.getBySourceTypeReturns = struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatefulSetLister) GetBySourceTypeReturnsOnCall(i int, result1 []v1.StatefulSet, result2 error) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = nil
+	if fake.getBySourceTypeReturnsOnCall == nil {
+		fake.getBySourceTypeReturnsOnCall = make(map[int]struct {
+			result1 []v1.StatefulSet
+			result2 error
+		})
+	}
+	fake.getBySourceTypeReturnsOnCall[i] = struct {
+		result1 []v1.StatefulSet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStatefulSetLister) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getByLRPIdentifierMutex.RLock()
+	defer fake.getByLRPIdentifierMutex.RUnlock()
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStatefulSetLister) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ k8s.StatefulSetLister = new(FakeStatefulSetLister)
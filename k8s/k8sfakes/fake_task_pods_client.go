@@ -0,0 +1,287 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package k8sfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	v1 "k8s.io/api/core/v1"
+)
+
+type FakeTaskPodsClient struct {
+	GetBySourceTypeStub        func(context.Context, string) ([]v1.Pod, error)
+	getBySourceTypeMutex       sync.RWMutex
+	getBySourceTypeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getBySourceTypeReturns struct {
+		result1 []v1.Pod
+		result2 error
+	}
+	getBySourceTypeReturnsOnCall map[int]struct {
+		result1 []v1.Pod
+		result2 error
+	}
+	GetByTaskGUIDStub        func(context.Context, string) ([]v1.Pod, error)
+	getByTaskGUIDMutex       sync.RWMutex
+	getByTaskGUIDArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getByTaskGUIDReturns struct {
+		result1 []v1.Pod
+		result2 error
+	}
+	getByTaskGUIDReturnsOnCall map[int]struct {
+		result1 []v1.Pod
+		result2 error
+	}
+	GetLogsStub        func(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error)
+	getLogsMutex       sync.RWMutex
+	getLogsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *v1.PodLogOptions
+	}
+	getLogsReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getLogsReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceType(arg1 context.Context, arg2 string) ([]v1.Pod, error) {
+	fake.getBySourceTypeMutex.Lock()
+	ret, specificReturn := fake.getBySourceTypeReturnsOnCall[len(fake.getBySourceTypeArgsForCall)]
+	fake.getBySourceTypeArgsForCall = append(fake.getBySourceTypeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetBySourceTypeStub
+	fakeReturns := fake.getBySourceTypeReturns
+	fake.recordInvocation("GetBySourceType", []interface{}{arg1, arg2})
+	fake.getBySourceTypeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceTypeCallCount() int {
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	return len(fake.getBySourceTypeArgsForCall)
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceTypeCalls(stub func(context.Context, string) ([]v1.Pod, error)) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = stub
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceTypeArgsForCall(i int) (context.Context, string) {
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	argsForCall := fake.getBySourceTypeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceTypeReturns(result1 []v1.Pod, result2 error) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = nil
+	fake.getBySourceTypeReturns = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) GetBySourceTypeReturnsOnCall(i int, result1 []v1.Pod, result2 error) {
+	fake.getBySourceTypeMutex.Lock()
+	defer fake.getBySourceTypeMutex.Unlock()
+	fake.GetBySourceTypeStub = nil
+	if fake.getBySourceTypeReturnsOnCall == nil {
+		fake.getBySourceTypeReturnsOnCall = make(map[int]struct {
+			result1 []v1.Pod
+			result2 error
+		})
+	}
+	fake.getBySourceTypeReturnsOnCall[i] = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUID(arg1 context.Context, arg2 string) ([]v1.Pod, error) {
+	fake.getByTaskGUIDMutex.Lock()
+	ret, specificReturn := fake.getByTaskGUIDReturnsOnCall[len(fake.getByTaskGUIDArgsForCall)]
+	fake.getByTaskGUIDArgsForCall = append(fake.getByTaskGUIDArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetByTaskGUIDStub
+	fakeReturns := fake.getByTaskGUIDReturns
+	fake.recordInvocation("GetByTaskGUID", []interface{}{arg1, arg2})
+	fake.getByTaskGUIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUIDCallCount() int {
+	fake.getByTaskGUIDMutex.RLock()
+	defer fake.getByTaskGUIDMutex.RUnlock()
+	return len(fake.getByTaskGUIDArgsForCall)
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUIDCalls(stub func(context.Context, string) ([]v1.Pod, error)) {
+	fake.getByTaskGUIDMutex.Lock()
+	defer fake.getByTaskGUIDMutex.Unlock()
+	fake.GetByTaskGUIDStub = stub
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUIDArgsForCall(i int) (context.Context, string) {
+	fake.getByTaskGUIDMutex.RLock()
+	defer fake.getByTaskGUIDMutex.RUnlock()
+	argsForCall := fake.getByTaskGUIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUIDReturns(result1 []v1.Pod, result2 error) {
+	fake.getByTaskGUIDMutex.Lock()
+	defer fake.getByTaskGUIDMutex.Unlock()
+	fake.GetByTaskGUIDStub = nil
+	fake.getByTaskGUIDReturns = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) GetByTaskGUIDReturnsOnCall(i int, result1 []v1.Pod, result2 error) {
+	fake.getByTaskGUIDMutex.Lock()
+	defer fake.getByTaskGUIDMutex.Unlock()
+	fake.GetByTaskGUIDStub = nil
+	if fake.getByTaskGUIDReturnsOnCall == nil {
+		fake.getByTaskGUIDReturnsOnCall = make(map[int]struct {
+			result1 []v1.Pod
+			result2 error
+		})
+	}
+	fake.getByTaskGUIDReturnsOnCall[i] = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) GetLogs(arg1 context.Context, arg2 string, arg3 string, arg4 *v1.PodLogOptions) (io.ReadCloser, error) {
+	fake.getLogsMutex.Lock()
+	ret, specificReturn := fake.getLogsReturnsOnCall[len(fake.getLogsArgsForCall)]
+	fake.getLogsArgsForCall = append(fake.getLogsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 *v1.PodLogOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetLogsStub
+	fakeReturns := fake.getLogsReturns
+	fake.recordInvocation("GetLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getLogsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskPodsClient) GetLogsCallCount() int {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	return len(fake.getLogsArgsForCall)
+}
+
+func (fake *FakeTaskPodsClient) GetLogsCalls(stub func(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error)) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = stub
+}
+
+func (fake *FakeTaskPodsClient) GetLogsArgsForCall(i int) (context.Context, string, string, *v1.PodLogOptions) {
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	argsForCall := fake.getLogsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskPodsClient) GetLogsReturns(result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	fake.getLogsReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) GetLogsReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getLogsMutex.Lock()
+	defer fake.getLogsMutex.Unlock()
+	fake.GetLogsStub = nil
+	if fake.getLogsReturnsOnCall == nil {
+		fake.getLogsReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getLogsReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskPodsClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBySourceTypeMutex.RLock()
+	defer fake.getBySourceTypeMutex.RUnlock()
+	fake.getByTaskGUIDMutex.RLock()
+	defer fake.getByTaskGUIDMutex.RUnlock()
+	fake.getLogsMutex.RLock()
+	defer fake.getLogsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeTaskPodsClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ k8s.TaskPodsClient = new(FakeTaskPodsClient)
@@ -18,7 +18,7 @@ func NewClient(kubeClient rest.Interface) Client {
 	}
 }
 
-func (c Client) StatsSummary(nodename string) (StatsSummary, error) {
+func (c Client) StatsSummary(ctx context.Context, nodename string) (StatsSummary, error) {
 	var summary StatsSummary
 
 	result := c.kubeClient.
@@ -26,7 +26,7 @@ func (c Client) StatsSummary(nodename string) (StatsSummary, error) {
 		Resource("nodes").
 		Name(nodename).
 		SubResource("proxy", "stats", "summary").
-		Do(context.Background())
+		Do(ctx)
 
 	body, err := result.Raw()
 	if err != nil {
@@ -3,47 +3,44 @@ package kubelet
 import (
 	"context"
 
+	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/lager"
-	"github.com/pkg/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type DiskMetricsClient struct {
-	nodeClient    NodeAPI
 	kubeletClient API
 	logger        lager.Logger
 }
 
-func NewDiskMetricsClient(nodeClient NodeAPI, kubeletClient API, logger lager.Logger) DiskMetricsClient {
+func NewDiskMetricsClient(kubeletClient API, logger lager.Logger) DiskMetricsClient {
 	return DiskMetricsClient{
-		nodeClient:    nodeClient,
 		kubeletClient: kubeletClient,
 		logger:        logger,
 	}
 }
 
-func (d DiskMetricsClient) GetPodMetrics() (map[string]float64, error) {
+// GetPodMetrics asks the kubelets of the nodes the pods are scheduled on for
+// the disk usage of their pods, keyed by pod name. Pods that have not been
+// scheduled yet have no usage.
+func (d DiskMetricsClient) GetPodMetrics(ctx context.Context, pods []corev1.Pod) (map[string]float64, error) {
 	metrics := map[string]float64{}
-	pods := []PodStats{}
+	podStats := []PodStats{}
 
-	nodes, err := d.nodeClient.List(context.Background(), metav1.ListOptions{})
-	if err != nil {
-		return metrics, errors.Wrap(err, "failed to list nodes")
-	}
-
-	for _, n := range nodes.Items {
-		statsSummary, err := d.kubeletClient.StatsSummary(n.Name)
+	for _, nodeName := range nodeNames(pods) {
+		statsSummary, err := d.kubeletClient.StatsSummary(ctx, nodeName)
 		if err != nil {
-			d.logger.Error("failed-to-get-stats-summary", err, lager.Data{"node-name": n.Name})
+			d.logger.Error("failed-to-get-stats-summary", err, lager.Data{"node-name": nodeName})
 		}
 
-		pods = append(pods, statsSummary.Pods...)
+		podStats = append(podStats, statsSummary.Pods...)
 	}
 
-	for _, p := range pods {
+	for _, p := range podStats {
 		if len(p.Containers) != 0 {
-			logsBytes := getUsedBytes(p.Containers[0].Logs)
-			rootfsBytes := getUsedBytes(p.Containers[0].Rootfs)
+			container := getOPIContainerStats(p.Containers)
+			logsBytes := getUsedBytes(container.Logs)
+			rootfsBytes := getUsedBytes(container.Rootfs)
 			metrics[p.PodRef.Name] = logsBytes + rootfsBytes
 		}
 	}
@@ -51,6 +48,33 @@ func (d DiskMetricsClient) GetPodMetrics() (map[string]float64, error) {
 	return metrics, nil
 }
 
+func nodeNames(pods []corev1.Pod) []string {
+	names := []string{}
+	seen := map[string]bool{}
+
+	for _, pod := range pods {
+		name := pod.Spec.NodeName
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func getOPIContainerStats(containers []ContainerStats) ContainerStats {
+	for _, c := range containers {
+		if c.Name == k8s.OPIContainerName {
+			return c
+		}
+	}
+
+	return containers[0]
+}
+
 func getUsedBytes(stats *FsStats) float64 {
 	if stats == nil || stats.UsedBytes == nil {
 		return 0
@@ -1,8 +1,10 @@
 package kubelet_test
 
 import (
+	"context"
 	"errors"
 
+	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/kubelet"
 	"code.cloudfoundry.org/eirini/k8s/kubelet/kubeletfakes"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -15,11 +17,18 @@ import (
 var _ = Describe("Stats", func() {
 	var (
 		diskMetricsClient kubelet.DiskMetricsClient
-		nodeClient        *kubeletfakes.FakeNodeAPI
 		kubeletClient     *kubeletfakes.FakeAPI
 		logger            *lagertest.TestLogger
+		ctx               context.Context
 	)
 
+	podOnNode := func(name, nodeName string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
 	createStatsSummary := func(podName string, namespace string, rootfsBytes, logsBytes uint64) kubelet.StatsSummary {
 		return kubelet.StatsSummary{
 			Pods: []kubelet.PodStats{
@@ -44,64 +53,82 @@ var _ = Describe("Stats", func() {
 	}
 
 	BeforeEach(func() {
-		nodeClient = new(kubeletfakes.FakeNodeAPI)
 		kubeletClient = new(kubeletfakes.FakeAPI)
 		logger = lagertest.NewTestLogger("statstest")
+		ctx = context.Background()
 
-		diskMetricsClient = kubelet.NewDiskMetricsClient(nodeClient, kubeletClient, logger)
+		diskMetricsClient = kubelet.NewDiskMetricsClient(kubeletClient, logger)
 	})
 
-	It("should return the disk metrics for all pods on all nodes", func() {
-		nodeClient.ListReturns(&corev1.NodeList{
-			Items: []corev1.Node{
-				{ObjectMeta: metav1.ObjectMeta{
-					Name: "node1",
-				}},
-				{ObjectMeta: metav1.ObjectMeta{
-					Name: "node2",
-				}},
-			},
-		}, nil)
+	It("should return the disk metrics for the pods on their nodes", func() {
 		kubeletClient.StatsSummaryReturnsOnCall(0, createStatsSummary("pod-1", "ns-1", 300, 700), nil)
 		kubeletClient.StatsSummaryReturnsOnCall(1, createStatsSummary("pod-2", "ns-2", 200, 256), nil)
 
-		metrics, err := diskMetricsClient.GetPodMetrics()
+		metrics, err := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{
+			podOnNode("pod-1", "node1"),
+			podOnNode("pod-2", "node2"),
+		})
 		Expect(err).ToNot(HaveOccurred())
-		Expect(nodeClient.ListCallCount()).To(Equal(1))
 		Expect(kubeletClient.StatsSummaryCallCount()).To(Equal(2))
-		Expect(kubeletClient.StatsSummaryArgsForCall(0)).To(Equal("node1"))
-		Expect(kubeletClient.StatsSummaryArgsForCall(1)).To(Equal("node2"))
+		actualCtx, nodeName := kubeletClient.StatsSummaryArgsForCall(0)
+		Expect(actualCtx).To(Equal(ctx))
+		Expect(nodeName).To(Equal("node1"))
+		_, nodeName = kubeletClient.StatsSummaryArgsForCall(1)
+		Expect(nodeName).To(Equal("node2"))
 		Expect(metrics).To(HaveKeyWithValue("pod-1", float64(1000)))
 		Expect(metrics).To(HaveKeyWithValue("pod-2", float64(456)))
 	})
 
-	When("the node client return an error", func() {
-		It("should return an error", func() {
-			nodeClient.ListReturns(&corev1.NodeList{}, errors.New("oopsie"))
-			_, err := diskMetricsClient.GetPodMetrics()
-			Expect(err).To(HaveOccurred())
-			Expect(err).To(MatchError(ContainSubstring("oopsie")))
+	When("several pods run on the same node", func() {
+		It("should query the node once", func() {
+			_, err := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{
+				podOnNode("pod-1", "node1"),
+				podOnNode("pod-2", "node1"),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeletClient.StatsSummaryCallCount()).To(Equal(1))
+		})
+	})
+
+	When("a pod has not been scheduled yet", func() {
+		It("should not query any node for it", func() {
+			metrics, err := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{podOnNode("pod-1", "")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeletClient.StatsSummaryCallCount()).To(BeZero())
+			Expect(metrics).To(BeEmpty())
+		})
+	})
+
+	When("the pod has sidecar containers", func() {
+		It("should report the disk usage of the app container", func() {
+			sidecarBytes := uint64(5000)
+			stats := createStatsSummary("pod-1", "ns-1", 300, 700)
+			stats.Pods[0].Containers[0].Name = k8s.OPIContainerName
+			stats.Pods[0].Containers = append([]kubelet.ContainerStats{
+				{
+					Name:   "sidecar",
+					Rootfs: &kubelet.FsStats{UsedBytes: &sidecarBytes},
+				},
+			}, stats.Pods[0].Containers...)
+			kubeletClient.StatsSummaryReturns(stats, nil)
+
+			metrics, err := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{podOnNode("pod-1", "node1")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics).To(HaveKeyWithValue("pod-1", float64(1000)))
 		})
 	})
 
 	When("there are no containers in the pod stats", func() {
 		It("the pod should be ignored", func() {
-			nodeClient.ListReturns(&corev1.NodeList{
-				Items: []corev1.Node{
-					{ObjectMeta: metav1.ObjectMeta{
-						Name: "node1",
-					}},
-					{ObjectMeta: metav1.ObjectMeta{
-						Name: "node2",
-					}},
-				},
-			}, nil)
 			stats := createStatsSummary("pod-1", "ns-1", 300, 700)
 			stats.Pods[0].Containers = nil
 			kubeletClient.StatsSummaryReturnsOnCall(0, stats, nil)
 			kubeletClient.StatsSummaryReturnsOnCall(1, createStatsSummary("pod-2", "ns-1", 200, 256), nil)
 
-			metrics, _ := diskMetricsClient.GetPodMetrics()
+			metrics, _ := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{
+				podOnNode("pod-1", "node1"),
+				podOnNode("pod-2", "node2"),
+			})
 			Expect(metrics).To(HaveLen(1))
 			Expect(metrics).To(HaveKeyWithValue("pod-2", float64(456)))
 		})
@@ -109,16 +136,9 @@ var _ = Describe("Stats", func() {
 
 	When("the kubeletClient returns an error for a node", func() {
 		It("should ignore that node", func() {
-			nodeClient.ListReturns(&corev1.NodeList{
-				Items: []corev1.Node{
-					{ObjectMeta: metav1.ObjectMeta{
-						Name: "node1",
-					}},
-				},
-			}, nil)
 			kubeletClient.StatsSummaryReturnsOnCall(0, kubelet.StatsSummary{}, errors.New("oopsie"))
 
-			metrics, _ := diskMetricsClient.GetPodMetrics()
+			metrics, _ := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{podOnNode("pod-1", "node1")})
 			Expect(metrics).To(BeEmpty())
 			logs := logger.Logs()
 			Expect(logs).To(HaveLen(1))
@@ -129,20 +149,12 @@ var _ = Describe("Stats", func() {
 
 	When("the disk metrics for a pod are missing", func() {
 		It("should report the used bytes as zero", func() {
-			nodeClient.ListReturns(&corev1.NodeList{
-				Items: []corev1.Node{
-					{ObjectMeta: metav1.ObjectMeta{
-						Name: "node1",
-					}},
-				},
-			}, nil)
-
 			stats := createStatsSummary("pod-1", "ns-1", 300, 700)
 			stats.Pods[0].Containers[0].Rootfs = nil
 			stats.Pods[0].Containers[0].Logs.UsedBytes = nil
 			kubeletClient.StatsSummaryReturnsOnCall(0, stats, nil)
 
-			metrics, _ := diskMetricsClient.GetPodMetrics()
+			metrics, _ := diskMetricsClient.GetPodMetrics(ctx, []corev1.Pod{podOnNode("pod-1", "node1")})
 			Expect(metrics).To(HaveLen(1))
 			Expect(metrics).To(HaveKeyWithValue("pod-1", float64(0)))
 		})
@@ -0,0 +1,205 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/util"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Log lines longer than maxLogLineBytes are split into several lines, each
+// prefixed with the index of its instance.
+const maxLogLineBytes = 64 * 1024
+
+// GetLogs streams the logs of the opi container of the LRP instances. When
+// no instance is selected the logs of all instances are interleaved line by
+// line, each line prefixed with the index of its instance.
+func (m *StatefulSetDesirer) GetLogs(ctx context.Context, identifier opi.LRPIdentifier, opts opi.LogOptions) (io.ReadCloser, error) {
+	logger := m.Logger.Session("get-logs", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
+	if _, err := m.getLRP(ctx, logger, identifier); err != nil {
+		return nil, err
+	}
+
+	pods, err := m.Pods.GetByLRPIdentifier(ctx, identifier)
+	if err != nil {
+		logger.Error("failed-to-list-pods", err)
+
+		return nil, errors.Wrap(err, "failed to list pods")
+	}
+
+	podsByIndex := map[int]corev1.Pod{}
+
+	for _, pod := range pods {
+		index, err := util.ParseAppIndex(pod.Name)
+		if err != nil {
+			logger.Error("failed-to-parse-app-index", err)
+
+			return nil, errors.Wrap(err, "failed to parse pod index")
+		}
+
+		if opts.Index == nil || *opts.Index == index {
+			podsByIndex[index] = pod
+		}
+	}
+
+	if len(podsByIndex) == 0 {
+		return nil, errors.Wrap(eirini.ErrNotFound, "no instances to stream logs from")
+	}
+
+	streams := map[int]io.ReadCloser{}
+
+	for index, pod := range podsByIndex {
+		stream, err := m.Pods.GetLogs(ctx, pod.Namespace, pod.Name, podLogOptions(OPIContainerName, opts))
+		if err != nil {
+			logger.Error("failed-to-stream-logs", err, lager.Data{"pod": pod.Name})
+			closeAll(streams)
+
+			return nil, err
+		}
+
+		streams[index] = stream
+	}
+
+	if opts.Index != nil {
+		return streams[*opts.Index], nil
+	}
+
+	return interleaveLogs(logger, streams), nil
+}
+
+// GetLogs streams the logs of the task container. When the job has been
+// retried, the logs of the most recent pod are streamed.
+func (d *TaskDesirer) GetLogs(ctx context.Context, taskGUID string, opts opi.LogOptions) (io.ReadCloser, error) {
+	logger := d.logger.Session("get-logs", lager.Data{"guid": taskGUID})
+
+	jobs, err := d.jobClient.GetByGUID(ctx, taskGUID, true)
+	if err != nil {
+		logger.Error("failed-to-get-job", err)
+
+		return nil, errors.Wrap(err, "failed to get job")
+	}
+
+	if len(jobs) == 0 {
+		return nil, errors.Wrapf(eirini.ErrNotFound, "task %s", taskGUID)
+	}
+
+	pods, err := d.podsClient.GetByTaskGUID(ctx, taskGUID)
+	if err != nil {
+		logger.Error("failed-to-list-pods", err)
+
+		return nil, errors.Wrap(err, "failed to list pods")
+	}
+
+	if len(pods) == 0 {
+		return nil, errors.Wrapf(eirini.ErrNotFound, "task %s has not been scheduled yet", taskGUID)
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	stream, err := d.podsClient.GetLogs(ctx, pods[0].Namespace, pods[0].Name, podLogOptions(opiTaskContainerName, opts))
+	if err != nil {
+		logger.Error("failed-to-stream-logs", err, lager.Data{"pod": pods[0].Name})
+
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func podLogOptions(container string, opts opi.LogOptions) *corev1.PodLogOptions {
+	options := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    opts.Follow,
+	}
+
+	if opts.SinceTime != nil {
+		sinceTime := metav1.NewTime(*opts.SinceTime)
+		options.SinceTime = &sinceTime
+	}
+
+	return options
+}
+
+type interleavedLogs struct {
+	*io.PipeReader
+	streams map[int]io.ReadCloser
+}
+
+func (l interleavedLogs) Close() error {
+	closeAll(l.streams)
+
+	return l.PipeReader.Close()
+}
+
+// interleaveLogs writes a marker line for an instance whose stream fails, so
+// that the client can tell it apart from an instance that stopped logging.
+func interleaveLogs(logger lager.Logger, streams map[int]io.ReadCloser) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	var (
+		mutex sync.Mutex
+		wg    sync.WaitGroup
+	)
+
+	for index, stream := range streams {
+		wg.Add(1)
+
+		go func(index int, stream io.Reader) {
+			defer wg.Done()
+
+			reader := bufio.NewReaderSize(stream, maxLogLineBytes)
+
+			for {
+				line, _, err := reader.ReadLine()
+				if errors.Is(err, io.EOF) {
+					return
+				}
+
+				if err != nil {
+					logger.Error("failed-to-read-logs", err, lager.Data{"index": index})
+					_ = writeLine(&mutex, writer, index, []byte("log stream failed: "+err.Error()))
+
+					return
+				}
+
+				if err := writeLine(&mutex, writer, index, line); err != nil {
+					return
+				}
+			}
+		}(index, stream)
+	}
+
+	go func() {
+		wg.Wait()
+		writer.Close()
+	}()
+
+	return interleavedLogs{PipeReader: reader, streams: streams}
+}
+
+func writeLine(mutex *sync.Mutex, writer io.Writer, index int, line []byte) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	_, err := fmt.Fprintf(writer, "[%d] %s\n", index, line)
+
+	return err
+}
+
+func closeAll(streams map[int]io.ReadCloser) {
+	for _, stream := range streams {
+		stream.Close()
+	}
+}
@@ -0,0 +1,318 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+
+	return nil
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func logStream(content string) *closeRecorder {
+	return &closeRecorder{Reader: strings.NewReader(content)}
+}
+
+func podNamed(name string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "the-namespace"}}
+}
+
+var _ = Describe("Logs", func() {
+	var (
+		ctx     context.Context
+		opts    opi.LogOptions
+		logs    io.ReadCloser
+		logsErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		opts = opi.LogOptions{}
+	})
+
+	Describe("StatefulSetDesirer", func() {
+		var (
+			podsClient        *k8sfakes.FakePodClient
+			statefulSetClient *k8sfakes.FakeStatefulSetClient
+			desirer           *k8s.StatefulSetDesirer
+			identifier        opi.LRPIdentifier
+			streams           map[string]*closeRecorder
+		)
+
+		BeforeEach(func() {
+			podsClient = new(k8sfakes.FakePodClient)
+			statefulSetClient = new(k8sfakes.FakeStatefulSetClient)
+			mapper := new(k8sfakes.FakeLRPMapper)
+			mapper.Returns(&opi.LRP{}, nil)
+
+			desirer = &k8s.StatefulSetDesirer{
+				Pods:                   podsClient,
+				StatefulSets:           statefulSetClient,
+				StatefulSetToLRPMapper: mapper.Spy,
+				Logger:                 lagertest.NewTestLogger("logs"),
+			}
+
+			identifier = opi.LRPIdentifier{GUID: "guid", Version: "version"}
+			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{}}, nil)
+			podsClient.GetByLRPIdentifierReturns([]corev1.Pod{podNamed("app-0"), podNamed("app-1")}, nil)
+
+			streams = map[string]*closeRecorder{
+				"app-0": logStream("zero\n"),
+				"app-1": logStream("one\nuno\n"),
+			}
+			podsClient.GetLogsStub = func(_ context.Context, _, name string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+				return streams[name], nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			logs, logsErr = desirer.GetLogs(ctx, identifier, opts)
+		})
+
+		It("interleaves the logs of all instances, prefixed with their index", func() {
+			Expect(logsErr).NotTo(HaveOccurred())
+
+			content, err := ioutil.ReadAll(logs)
+			Expect(err).NotTo(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			Expect(lines).To(ConsistOf("[0] zero", "[1] one", "[1] uno"))
+			Expect(strings.Index(string(content), "[1] one")).To(BeNumerically("<", strings.Index(string(content), "[1] uno")))
+		})
+
+		When("the stream of an instance fails", func() {
+			BeforeEach(func() {
+				streams["app-1"] = &closeRecorder{Reader: io.MultiReader(
+					strings.NewReader("one\n"),
+					failingReader{err: errors.New("connection reset")},
+				)}
+			})
+
+			It("writes a marker line for that instance and keeps the others", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+
+				content, err := ioutil.ReadAll(logs)
+				Expect(err).NotTo(HaveOccurred())
+
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				Expect(lines).To(ConsistOf("[0] zero", "[1] one", "[1] log stream failed: connection reset"))
+			})
+		})
+
+		When("an instance logs a line longer than the limit", func() {
+			var longLine string
+
+			BeforeEach(func() {
+				longLine = strings.Repeat("x", 150*1024)
+				streams["app-1"] = logStream("one\n" + longLine + "\nuno\n")
+			})
+
+			It("splits it into prefixed lines and keeps streaming", func() {
+				content, err := ioutil.ReadAll(logs)
+				Expect(err).NotTo(HaveOccurred())
+
+				instanceLines := []string{}
+				for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+					if strings.HasPrefix(line, "[1] ") {
+						instanceLines = append(instanceLines, strings.TrimPrefix(line, "[1] "))
+					}
+				}
+
+				Expect(len(instanceLines)).To(BeNumerically(">", 3))
+				Expect(instanceLines[0]).To(Equal("one"))
+				Expect(strings.Join(instanceLines[1:len(instanceLines)-1], "")).To(Equal(longLine))
+				Expect(instanceLines[len(instanceLines)-1]).To(Equal("uno"))
+			})
+		})
+
+		It("closes the streams of all instances", func() {
+			Expect(logs.Close()).To(Succeed())
+			Expect(streams["app-0"].closed).To(BeTrue())
+			Expect(streams["app-1"].closed).To(BeTrue())
+		})
+
+		It("streams the opi container in the pod namespace", func() {
+			Expect(podsClient.GetLogsCallCount()).To(Equal(2))
+			_, namespace, _, options := podsClient.GetLogsArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(options.Container).To(Equal(k8s.OPIContainerName))
+			Expect(options.Follow).To(BeFalse())
+			Expect(options.SinceTime).To(BeNil())
+		})
+
+		When("an instance is selected", func() {
+			BeforeEach(func() {
+				index := 1
+				since := time.Unix(1600000000, 0)
+				opts = opi.LogOptions{Index: &index, Follow: true, SinceTime: &since}
+			})
+
+			It("streams the logs of that instance only", func() {
+				Expect(podsClient.GetLogsCallCount()).To(Equal(1))
+				_, _, name, _ := podsClient.GetLogsArgsForCall(0)
+				Expect(name).To(Equal("app-1"))
+
+				content, err := ioutil.ReadAll(logs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("one\nuno\n"))
+			})
+
+			It("passes the log options", func() {
+				_, _, _, options := podsClient.GetLogsArgsForCall(0)
+				Expect(options.Follow).To(BeTrue())
+				Expect(options.SinceTime.Time).To(Equal(time.Unix(1600000000, 0)))
+			})
+		})
+
+		When("the selected instance does not exist", func() {
+			BeforeEach(func() {
+				index := 5
+				opts = opi.LogOptions{Index: &index}
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(logsErr, eirini.ErrNotFound)).To(BeTrue())
+			})
+		})
+
+		When("the LRP does not exist", func() {
+			BeforeEach(func() {
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(logsErr, eirini.ErrNotFound)).To(BeTrue())
+				Expect(podsClient.GetLogsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("streaming the logs of an instance fails", func() {
+			BeforeEach(func() {
+				podsClient.GetLogsStub = func(_ context.Context, _, name string, _ *corev1.PodLogOptions) (io.ReadCloser, error) {
+					if podsClient.GetLogsCallCount() == 2 {
+						return nil, errors.New("boom")
+					}
+
+					return streams[name], nil
+				}
+			})
+
+			It("returns the error", func() {
+				Expect(logsErr).To(MatchError(ContainSubstring("boom")))
+			})
+
+			It("closes the streams already opened", func() {
+				_, _, name, _ := podsClient.GetLogsArgsForCall(0)
+				Expect(streams[name].closed).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("TaskDesirer", func() {
+		var (
+			jobClient  *k8sfakes.FakeJobCreatingClient
+			podsClient *k8sfakes.FakeTaskPodsClient
+			desirer    *k8s.TaskDesirer
+		)
+
+		BeforeEach(func() {
+			jobClient = new(k8sfakes.FakeJobCreatingClient)
+			podsClient = new(k8sfakes.FakeTaskPodsClient)
+			desirer = k8s.NewTaskDesirer(lagertest.NewTestLogger("logs"), jobClient, podsClient, nil, "", "", false, nil, "")
+
+			jobClient.GetByGUIDReturns([]batch.Job{{}}, nil)
+
+			oldPod := podNamed("task-old")
+			oldPod.CreationTimestamp = metav1.NewTime(time.Unix(100, 0))
+			newPod := podNamed("task-new")
+			newPod.CreationTimestamp = metav1.NewTime(time.Unix(200, 0))
+			podsClient.GetByTaskGUIDReturns([]corev1.Pod{oldPod, newPod}, nil)
+			podsClient.GetLogsReturns(logStream("hello\n"), nil)
+		})
+
+		JustBeforeEach(func() {
+			logs, logsErr = desirer.GetLogs(ctx, "task-guid", opts)
+		})
+
+		It("streams the logs of the task container", func() {
+			Expect(logsErr).NotTo(HaveOccurred())
+
+			content, err := ioutil.ReadAll(logs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("hello\n"))
+
+			_, guid, includeCompleted := jobClient.GetByGUIDArgsForCall(0)
+			Expect(guid).To(Equal("task-guid"))
+			Expect(includeCompleted).To(BeTrue())
+		})
+
+		It("streams the most recent pod", func() {
+			Expect(podsClient.GetLogsCallCount()).To(Equal(1))
+			_, namespace, name, options := podsClient.GetLogsArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(name).To(Equal("task-new"))
+			Expect(options.Container).To(Equal("opi-task"))
+		})
+
+		When("the task does not exist", func() {
+			BeforeEach(func() {
+				jobClient.GetByGUIDReturns([]batch.Job{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(logsErr, eirini.ErrNotFound)).To(BeTrue())
+			})
+		})
+
+		When("the task has no pods yet", func() {
+			BeforeEach(func() {
+				podsClient.GetByTaskGUIDReturns([]corev1.Pod{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(logsErr, eirini.ErrNotFound)).To(BeTrue())
+			})
+		})
+
+		When("listing the pods fails", func() {
+			BeforeEach(func() {
+				podsClient.GetByTaskGUIDReturns(nil, errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(logsErr).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+	})
+})
@@ -2,13 +2,40 @@ package k8s
 
 import (
 	"encoding/json"
+	"strconv"
 
+	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// managedAnnotations are set by eirini itself. All other annotations of a
+// statefulset are user defined.
+var managedAnnotations = map[string]bool{
+	AnnotationSpaceName:            true,
+	AnnotationSpaceGUID:            true,
+	AnnotationOriginalRequest:      true,
+	AnnotationRegisteredRoutes:     true,
+	AnnotationAppID:                true,
+	AnnotationVersion:              true,
+	AnnotationLastUpdated:          true,
+	AnnotationProcessGUID:          true,
+	AnnotationAppName:              true,
+	AnnotationOrgName:              true,
+	AnnotationOrgGUID:              true,
+	AnnotationEgressRules:          true,
+	AnnotationHealthCheck:          true,
+	AnnotationPlacementTags:        true,
+	AnnotationRestartGeneration:    true,
+	tracing.AnnotationTraceParent:  true,
+	tracing.AnnotationTraceState:   true,
+	corev1.SeccompPodAnnotationKey: true,
+}
+
 func StatefulSetToLRP(s appsv1.StatefulSet) (*opi.LRP, error) {
 	stRoutes := s.Annotations[AnnotationRegisteredRoutes]
 
@@ -20,7 +47,7 @@ func StatefulSetToLRP(s appsv1.StatefulSet) (*opi.LRP, error) {
 	}
 
 	ports := []int32{}
-	container := s.Spec.Template.Spec.Containers[0]
+	container := getOPIContainer(s.Spec.Template.Spec.Containers)
 
 	for _, port := range container.Ports {
 		ports = append(ports, port.ContainerPort)
@@ -31,29 +58,205 @@ func StatefulSetToLRP(s appsv1.StatefulSet) (*opi.LRP, error) {
 	volMounts := []opi.VolumeMount{}
 
 	for _, vol := range container.VolumeMounts {
+		if vol.Name == eirini.DropletVolumeName {
+			continue
+		}
+
 		volMounts = append(volMounts, opi.VolumeMount{
 			ClaimName: vol.Name,
 			MountPath: vol.MountPath,
 		})
 	}
 
+	var egressRules []opi.EgressRule
+	if rules, ok := s.Annotations[AnnotationEgressRules]; ok {
+		if err := json.Unmarshal([]byte(rules), &egressRules); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal egress rules")
+		}
+	}
+
+	health, err := getHealthCheck(s.Annotations, container)
+	if err != nil {
+		return nil, err
+	}
+
+	var placementTags []string
+	if tags, ok := s.Annotations[AnnotationPlacementTags]; ok {
+		if err := json.Unmarshal([]byte(tags), &placementTags); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal placement tags")
+		}
+	}
+
+	var restartGeneration int64
+	if generation, ok := s.Annotations[AnnotationRestartGeneration]; ok {
+		restartGeneration, err = strconv.ParseInt(generation, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse restart generation")
+		}
+	}
+
 	return &opi.LRP{
 		LRPIdentifier: opi.LRPIdentifier{
 			GUID:    s.Labels[LabelGUID],
 			Version: s.Annotations[AnnotationVersion],
 		},
-		AppName:          s.Annotations[AnnotationAppName],
-		SpaceName:        s.Annotations[AnnotationSpaceName],
-		Image:            container.Image,
-		Command:          container.Command,
-		RunningInstances: int(s.Status.ReadyReplicas),
-		TargetInstances:  int(*s.Spec.Replicas),
-		Ports:            ports,
-		LastUpdated:      s.Annotations[AnnotationLastUpdated],
-		AppURIs:          uris,
-		AppGUID:          s.Annotations[AnnotationAppID],
-		MemoryMB:         memory,
-		DiskMB:           disk,
-		VolumeMounts:     volMounts,
+		ProcessType:            s.Labels[LabelProcessType],
+		AppName:                s.Annotations[AnnotationAppName],
+		AppGUID:                s.Annotations[AnnotationAppID],
+		OrgName:                s.Annotations[AnnotationOrgName],
+		OrgGUID:                s.Annotations[AnnotationOrgGUID],
+		SpaceName:              s.Annotations[AnnotationSpaceName],
+		SpaceGUID:              s.Annotations[AnnotationSpaceGUID],
+		Image:                  container.Image,
+		Command:                container.Command,
+		Env:                    getEnv(container.Env),
+		Health:                 health,
+		RunningInstances:       int(s.Status.ReadyReplicas),
+		TargetInstances:        int(*s.Spec.Replicas),
+		Ports:                  ports,
+		MemoryMB:               memory,
+		DiskMB:                 disk,
+		RunsAsRoot:             !runsAsNonRoot(s.Spec.Template.Spec.SecurityContext),
+		CPUWeight:              uint8(toCPUPercentage(container.Resources.Requests.Cpu().MilliValue())),
+		VolumeMounts:           volMounts,
+		LRP:                    s.Annotations[AnnotationOriginalRequest],
+		LastUpdated:            s.Annotations[AnnotationLastUpdated],
+		AppURIs:                uris,
+		UserDefinedAnnotations: getUserDefinedAnnotations(s.Annotations),
+		PlacementTags:          placementTags,
+		RestartGeneration:      restartGeneration,
+		EgressRules:            egressRules,
+		Sidecars:               getSidecars(s.Spec.Template.Spec.Containers),
+		Droplet:                getDroplet(s.Spec.Template.Spec.InitContainers),
 	}, nil
 }
+
+// getHealthCheck reads the health check from its annotation. Statefulsets
+// created before the annotation was introduced fall back to the liveness
+// probe, which cannot tell "process" and "none" checks apart.
+func getHealthCheck(annotations map[string]string, container corev1.Container) (opi.Healtcheck, error) {
+	health := opi.Healtcheck{}
+
+	if healthCheck, ok := annotations[AnnotationHealthCheck]; ok {
+		err := json.Unmarshal([]byte(healthCheck), &health)
+
+		return health, errors.Wrap(err, "failed to unmarshal health check")
+	}
+
+	probe := container.LivenessProbe
+	if probe == nil {
+		return health, nil
+	}
+
+	health.TimeoutMs = uint(probe.InitialDelaySeconds) * 1000 //nolint:gomnd
+
+	switch {
+	case probe.HTTPGet != nil:
+		health.Type = "http"
+		health.Port = probe.HTTPGet.Port.IntVal
+		health.Endpoint = probe.HTTPGet.Path
+	case probe.TCPSocket != nil:
+		health.Type = "port"
+		health.Port = probe.TCPSocket.Port.IntVal
+	}
+
+	return health, nil
+}
+
+func getEnv(envVars []corev1.EnvVar) map[string]string {
+	env := map[string]string{}
+
+	for _, envVar := range envVars {
+		// values from the downward API are added by eirini for every app
+		if envVar.ValueFrom != nil {
+			continue
+		}
+
+		env[envVar.Name] = envVar.Value
+	}
+
+	if len(env) == 0 {
+		return nil
+	}
+
+	return env
+}
+
+func getUserDefinedAnnotations(annotations map[string]string) map[string]string {
+	userDefined := map[string]string{}
+
+	for k, v := range annotations {
+		if !managedAnnotations[k] {
+			userDefined[k] = v
+		}
+	}
+
+	if len(userDefined) == 0 {
+		return nil
+	}
+
+	return userDefined
+}
+
+func runsAsNonRoot(securityContext *corev1.PodSecurityContext) bool {
+	return securityContext != nil && securityContext.RunAsNonRoot != nil && *securityContext.RunAsNonRoot
+}
+
+func getDroplet(initContainers []corev1.Container) *opi.Droplet {
+	for _, container := range initContainers {
+		if container.Name != DropletDownloaderContainerName {
+			continue
+		}
+
+		droplet := &opi.Droplet{}
+
+		for _, envVar := range container.Env {
+			switch envVar.Name {
+			case eirini.EnvDownloadURL:
+				droplet.URL = envVar.Value
+			case eirini.EnvDropletHash:
+				droplet.Hash = envVar.Value
+			}
+		}
+
+		return droplet
+	}
+
+	return nil
+}
+
+// getOPIContainer returns the container running the app process. It falls
+// back to the first container for pods that were created without a name.
+func getOPIContainer(containers []corev1.Container) corev1.Container {
+	return containers[getOPIContainerIndex(containers)]
+}
+
+func getOPIContainerIndex(containers []corev1.Container) int {
+	for i, container := range containers {
+		if container.Name == OPIContainerName {
+			return i
+		}
+	}
+
+	return 0
+}
+
+func getSidecars(containers []corev1.Container) []opi.Sidecar {
+	var sidecars []opi.Sidecar
+
+	opiContainerIndex := getOPIContainerIndex(containers)
+
+	for i, container := range containers {
+		if i == opiContainerIndex {
+			continue
+		}
+
+		sidecars = append(sidecars, opi.Sidecar{
+			Name:     container.Name,
+			Command:  container.Command,
+			MemoryMB: container.Resources.Requests.Memory().ScaledValue(resource.Mega),
+		})
+	}
+
+	return sidecars
+}
@@ -1,14 +1,24 @@
 package k8s_test
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"code.cloudfoundry.org/eirini"
 	. "code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var _ = Describe("Mapper", func() {
@@ -20,25 +30,50 @@ var _ = Describe("Mapper", func() {
 				Name:      "baldur",
 				Namespace: "baldur-ns",
 				Labels: map[string]string{
-					LabelGUID: "Bald-guid",
+					LabelGUID:        "Bald-guid",
+					LabelProcessType: "web",
 				},
 				Annotations: map[string]string{
 					AnnotationProcessGUID:      "Baldur-guid",
+					AnnotationOrgName:          "org-foo",
+					AnnotationOrgGUID:          "org-guid",
+					AnnotationSpaceGUID:        "space-guid",
+					AnnotationOriginalRequest:  "original request",
+					AnnotationHealthCheck:      `{"type":"http","port":8080,"endpoint":"/health","timeout_ms":3000}`,
+					AnnotationPlacementTags:    `["segment-a"]`,
+					"prometheus.io/scrape":     "true",
 					AnnotationLastUpdated:      "last-updated-some-time-ago",
 					AnnotationRegisteredRoutes: `[{"hostname":"my.example.route","port":8080}]`,
 					AnnotationAppID:            "guid_1234",
 					AnnotationVersion:          "version_1234",
 					AnnotationAppName:          "Baldur",
 					AnnotationSpaceName:        "space-foo",
+					AnnotationEgressRules:      `[{"protocol":"tcp","destinations":["10.0.0.0/8"],"ports":[80]}]`,
 				},
 			},
 			Spec: appsv1.StatefulSetSpec{
 				Replicas: int32ptr(3),
 				Template: corev1.PodTemplateSpec{
 					Spec: corev1.PodSpec{
+						SecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: boolptr(true)},
+						InitContainers: []corev1.Container{
+							{
+								Name: DropletDownloaderContainerName,
+								Env: []corev1.EnvVar{
+									{Name: eirini.EnvDownloadURL, Value: "https://cc/droplet"},
+									{Name: eirini.EnvDropletHash, Value: "droplet-hash"},
+								},
+							},
+						},
 						Containers: []corev1.Container{
 							{
 								Image: "busybox",
+								Env: []corev1.EnvVar{
+									{Name: "FOO", Value: "foo"},
+									{Name: eirini.EnvPodName, ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+									}},
+								},
 								Command: []string{
 									"/bin/sh",
 									"-c",
@@ -55,6 +90,7 @@ var _ = Describe("Mapper", func() {
 								Resources: corev1.ResourceRequirements{
 									Requests: corev1.ResourceList{
 										corev1.ResourceMemory: *resource.NewScaledQuantity(1024, resource.Mega),
+										corev1.ResourceCPU:    *resource.NewScaledQuantity(150, resource.Milli),
 									},
 									Limits: corev1.ResourceList{
 										corev1.ResourceEphemeralStorage: *resource.NewScaledQuantity(2048, resource.Mega),
@@ -65,6 +101,20 @@ var _ = Describe("Mapper", func() {
 										Name:      "some-claim",
 										MountPath: "/some/path",
 									},
+									{
+										Name:      eirini.DropletVolumeName,
+										MountPath: eirini.DropletMountPath,
+									},
+								},
+							},
+							{
+								Name:    "logger",
+								Image:   "busybox",
+								Command: []string{"/bin/logger"},
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{
+										corev1.ResourceMemory: *resource.NewScaledQuantity(32, resource.Mega),
+									},
 								},
 							},
 						},
@@ -137,6 +187,121 @@ var _ = Describe("Mapper", func() {
 		}))
 	})
 
+	It("should set the correct LRP egress rules", func() {
+		Expect(lrp.EgressRules).To(Equal([]opi.EgressRule{
+			{Protocol: "tcp", Destinations: []string{"10.0.0.0/8"}, Ports: []int32{80}},
+		}))
+	})
+
+	It("should set the correct LRP sidecars", func() {
+		Expect(lrp.Sidecars).To(Equal([]opi.Sidecar{
+			{Name: "logger", Command: []string{"/bin/logger"}, MemoryMB: 32},
+		}))
+	})
+
+	It("should set the correct LRP process type", func() {
+		Expect(lrp.ProcessType).To(Equal("web"))
+	})
+
+	It("should set the correct LRP org and space", func() {
+		Expect(lrp.OrgName).To(Equal("org-foo"))
+		Expect(lrp.OrgGUID).To(Equal("org-guid"))
+		Expect(lrp.SpaceGUID).To(Equal("space-guid"))
+	})
+
+	It("should set the correct LRP original request", func() {
+		Expect(lrp.LRP).To(Equal("original request"))
+	})
+
+	It("should set the LRP env, without the values added by eirini", func() {
+		Expect(lrp.Env).To(Equal(map[string]string{"FOO": "foo"}))
+	})
+
+	It("should set the correct LRP health check", func() {
+		Expect(lrp.Health).To(Equal(opi.Healtcheck{Type: "http", Port: 8080, Endpoint: "/health", TimeoutMs: 3000}))
+	})
+
+	It("should set the correct LRP CPU weight", func() {
+		Expect(lrp.CPUWeight).To(Equal(uint8(15)))
+	})
+
+	It("should not run the LRP as root", func() {
+		Expect(lrp.RunsAsRoot).To(BeFalse())
+	})
+
+	It("should set the user defined annotations only", func() {
+		Expect(lrp.UserDefinedAnnotations).To(Equal(map[string]string{"prometheus.io/scrape": "true"}))
+	})
+
+	It("should set the correct LRP placement tags", func() {
+		Expect(lrp.PlacementTags).To(ConsistOf("segment-a"))
+	})
+
+	It("should set the correct LRP droplet", func() {
+		Expect(lrp.Droplet).To(Equal(&opi.Droplet{URL: "https://cc/droplet", Hash: "droplet-hash"}))
+	})
+
+	When("the statefulset has no health check annotation", func() {
+		It("should read the health check from the liveness probe", func() {
+			statefulset := appsv1.StatefulSet{
+				ObjectMeta: meta.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationRegisteredRoutes: `[]`,
+					},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: int32ptr(1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: OPIContainerName,
+									LivenessProbe: &corev1.Probe{
+										Handler: corev1.Handler{
+											TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+										},
+										InitialDelaySeconds: 2,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			lrp, err := StatefulSetToLRP(statefulset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrp.Health).To(Equal(opi.Healtcheck{Type: "port", Port: 8080, TimeoutMs: 2000}))
+			Expect(lrp.RunsAsRoot).To(BeTrue())
+		})
+	})
+
+	When("the app container is not the first container", func() {
+		It("should map the app container", func() {
+			statefulset := appsv1.StatefulSet{
+				ObjectMeta: meta.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationRegisteredRoutes: `[]`,
+					},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: int32ptr(1),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "logger", Image: "busybox", Command: []string{"/bin/logger"}},
+								{Name: OPIContainerName, Image: "busybox", Command: []string{"/bin/app"}},
+							},
+						},
+					},
+				},
+			}
+			lrp, err := StatefulSetToLRP(statefulset)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lrp.Command).To(Equal([]string{"/bin/app"}))
+			Expect(lrp.Sidecars).To(ConsistOf(opi.Sidecar{Name: "logger", Command: []string{"/bin/logger"}}))
+		})
+	})
+
 	When("route marshalling fails", func() {
 		It("should return the error", func() {
 			statefulset := appsv1.StatefulSet{
@@ -151,3 +316,200 @@ var _ = Describe("Mapper", func() {
 		})
 	})
 })
+
+var _ = Describe("StatefulSet round trip", func() {
+	It("reads back every LRP it desires", func() {
+		var failure string
+
+		roundTrips := func(l generatedLRP) bool {
+			expected := l.LRP
+			actual, err := desireAndGet(expected)
+			if err != nil {
+				failure = err.Error()
+
+				return false
+			}
+
+			matcher := Equal(normalizeMappedLRP(expected))
+			if ok, _ := matcher.Match(actual); !ok {
+				failure = matcher.FailureMessage(actual)
+
+				return false
+			}
+
+			return true
+		}
+
+		config := &quick.Config{
+			MaxCount: 200,
+			Rand:     rand.New(rand.NewSource(GinkgoRandomSeed())), //nolint:gosec
+		}
+
+		Expect(quick.Check(roundTrips, config)).To(Succeed(), func() string { return failure })
+	})
+})
+
+// desireAndGet desires the LRP against fake clients and reads it back
+// through the real statefulset mapper.
+func desireAndGet(lrp *opi.LRP) (*opi.LRP, error) {
+	statefulSetClient := new(k8sfakes.FakeStatefulSetClient)
+	secretsClient := new(k8sfakes.FakeSecretsCreatorDeleter)
+
+	desirer := &StatefulSetDesirer{
+		Pods:                   new(k8sfakes.FakePodClient),
+		Secrets:                secretsClient,
+		StatefulSets:           statefulSetClient,
+		PodDisruptionBudgets:   new(k8sfakes.FakePodDisruptionBudgetClient),
+		NetworkPolicies:        new(k8sfakes.FakeNetworkPolicyClient),
+		EventsClient:           new(k8sfakes.FakeEventsClient),
+		StatefulSetToLRPMapper: StatefulSetToLRP,
+		RegistrySecretName:     "registry-secret",
+		LivenessProbeCreator:   CreateLivenessProbe,
+		ReadinessProbeCreator:  CreateReadinessProbe,
+		Logger:                 lagertest.NewTestLogger("round-trip"),
+		DropletDownloaderImage: "downloader",
+		PlacementTags: map[string]eirini.PlacementTag{
+			"segment-a": {NodeSelector: map[string]string{"segment-a": "true"}},
+			"segment-b": {Tolerations: []eirini.Toleration{{Key: "segment-b", Operator: "Exists", Effect: "NoSchedule"}}},
+		},
+	}
+
+	if err := desirer.Desire(context.Background(), "the-namespace", lrp); err != nil {
+		return nil, err
+	}
+
+	_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+	statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{*statefulSet}, nil)
+
+	if secretsClient.CreateCallCount() > 0 {
+		_, _, secret := secretsClient.CreateArgsForCall(0)
+		secretsClient.GetReturns(secret, nil)
+	}
+
+	return desirer.Get(context.Background(), lrp.LRPIdentifier)
+}
+
+// normalizeMappedLRP returns the LRP as the mapper is expected to read it:
+// running instances come from the status and empty lists are not nil.
+func normalizeMappedLRP(lrp *opi.LRP) *opi.LRP {
+	normalized := *lrp
+	normalized.RunningInstances = 0
+
+	if normalized.Ports == nil {
+		normalized.Ports = []int32{}
+	}
+
+	if normalized.VolumeMounts == nil {
+		normalized.VolumeMounts = []opi.VolumeMount{}
+	}
+
+	return &normalized
+}
+
+type generatedLRP struct {
+	*opi.LRP
+}
+
+func (generatedLRP) Generate(r *rand.Rand, size int) reflect.Value {
+	lrp := &opi.LRP{
+		LRPIdentifier: opi.LRPIdentifier{
+			GUID:    randomName(r),
+			Version: randomName(r),
+		},
+		ProcessType:       randomName(r),
+		AppName:           randomName(r),
+		AppGUID:           randomName(r),
+		OrgName:           randomName(r),
+		OrgGUID:           randomName(r),
+		SpaceName:         randomName(r),
+		SpaceGUID:         randomName(r),
+		Image:             randomName(r) + "/" + randomName(r),
+		TargetInstances:   r.Intn(10),
+		MemoryMB:          1 + r.Int63n(8192),
+		DiskMB:            1 + r.Int63n(8192),
+		RunsAsRoot:        r.Intn(2) == 0,
+		CPUWeight:         uint8(r.Intn(256)),
+		LRP:               randomName(r),
+		LastUpdated:       randomName(r),
+		RestartGeneration: r.Int63n(10),
+		Health: opi.Healtcheck{
+			Type:      []string{"", "none", "process", "port", "http"}[r.Intn(5)],
+			Port:      int32(1 + r.Intn(65535)),
+			Endpoint:  "/" + randomName(r),
+			TimeoutMs: uint(r.Intn(120000)),
+		},
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.Command = append(lrp.Command, randomName(r))
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.Ports = append(lrp.Ports, int32(1+r.Intn(65535)))
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.AppURIs = append(lrp.AppURIs, opi.Route{Hostname: randomName(r), Port: int32(1 + r.Intn(65535))})
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.VolumeMounts = append(lrp.VolumeMounts, opi.VolumeMount{
+			ClaimName: fmt.Sprintf("claim-%d", i),
+			MountPath: "/" + randomName(r),
+		})
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.Sidecars = append(lrp.Sidecars, opi.Sidecar{
+			Name:     fmt.Sprintf("sidecar-%d", i),
+			Command:  []string{randomName(r)},
+			MemoryMB: 1 + r.Int63n(512),
+		})
+	}
+
+	for i := r.Intn(3); i > 0; i-- {
+		lrp.EgressRules = append(lrp.EgressRules, opi.EgressRule{
+			Protocol:     "tcp",
+			Destinations: []string{fmt.Sprintf("10.0.0.%d", r.Intn(256))},
+			Ports:        []int32{int32(1 + r.Intn(65535))},
+		})
+	}
+
+	if r.Intn(2) == 0 {
+		lrp.Env = map[string]string{}
+		for i := 1 + r.Intn(3); i > 0; i-- {
+			lrp.Env[fmt.Sprintf("ENV_%d", i)] = randomName(r)
+		}
+	}
+
+	if r.Intn(2) == 0 {
+		lrp.UserDefinedAnnotations = map[string]string{"example.com/" + randomName(r): randomName(r)}
+	}
+
+	if r.Intn(2) == 0 {
+		lrp.PlacementTags = []string{"segment-a", "segment-b"}[:1+r.Intn(2)]
+	}
+
+	if r.Intn(2) == 0 {
+		lrp.PrivateRegistry = &opi.PrivateRegistry{
+			Server:   randomName(r) + ".io",
+			Username: randomName(r),
+			Password: randomName(r),
+		}
+	}
+
+	if r.Intn(2) == 0 {
+		lrp.Droplet = &opi.Droplet{URL: "https://cc/" + randomName(r), Hash: randomName(r)}
+	}
+
+	return reflect.ValueOf(generatedLRP{LRP: lrp})
+}
+
+func randomName(r *rand.Rand) string {
+	b := make([]byte, 1+r.Intn(10))
+	for i := range b {
+		b[i] = "abcdefghijklmnopqrstuvwxyz0123456789"[r.Intn(36)]
+	}
+
+	return string(b)
+}
@@ -9,12 +9,14 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv1beta1 "k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1"
 )
 
 //counterfeiter:generate . MetricsCollector
+//counterfeiter:generate . PodUsageCollector
 //counterfeiter:generate . DiskAPI
 //counterfeiter:generate . Emitter
 //counterfeiter:generate -o k8sfakes/fake_pod_metrics_interface.go k8s.io/metrics/pkg/client/clientset/versioned/typed/metrics/v1beta1.PodMetricsInterface
@@ -23,8 +25,24 @@ type MetricsCollector interface {
 	Collect() ([]metrics.Message, error)
 }
 
+// PodUsageCollector gets the resource usage of the given pods, keyed by pod
+// name.
+type PodUsageCollector interface {
+	CollectPodUsage(ctx context.Context, pods []apiv1.Pod) map[string]PodUsage
+}
+
+// PodUsage is the resource usage of the opi container of a pod, along with
+// its limits.
+type PodUsage struct {
+	CPU         float64
+	Memory      float64
+	MemoryQuota float64
+	Disk        float64
+	DiskQuota   float64
+}
+
 type DiskAPI interface {
-	GetPodMetrics() (map[string]float64, error)
+	GetPodMetrics(ctx context.Context, pods []apiv1.Pod) (map[string]float64, error)
 }
 
 type Emitter interface {
@@ -64,55 +82,96 @@ func NewMetricsCollector(metricsClient metricsv1beta1.PodMetricsInterface,
 }
 
 func (c *metricsCollector) Collect() ([]metrics.Message, error) {
-	pods, err := c.podClient.GetAll()
+	ctx := context.Background()
+
+	pods, err := c.podClient.GetAll(ctx)
 	if err != nil {
 		return []metrics.Message{}, errors.Wrap(err, "failed to list pods")
 	}
 
-	return c.collectMetrics(pods), nil
+	return c.collectMetrics(ctx, pods), nil
 }
 
-func (c *metricsCollector) collectMetrics(pods []apiv1.Pod) []metrics.Message {
-	logger := c.logger.Session("collect")
-
-	diskMetrics, err := c.diskClient.GetPodMetrics()
-	if err != nil {
-		logger.Error("failed-to-get-disk-metrics", err, lager.Data{})
+// NewPodUsageCollector gathers the usage of pods the same way the metrics
+// collector does.
+func NewPodUsageCollector(metricsClient metricsv1beta1.PodMetricsInterface,
+	diskClient DiskAPI,
+	logger lager.Logger) PodUsageCollector {
+	return &metricsCollector{
+		metricsClient: metricsClient,
+		diskClient:    diskClient,
+		logger:        logger,
 	}
+}
 
-	messages := []metrics.Message{}
+func (c *metricsCollector) collectMetrics(ctx context.Context, pods []apiv1.Pod) []metrics.Message {
+	logger := c.logger.Session("collect")
 
-	podMetrics, err := c.getPodMetrics()
+	podMetrics, err := c.listPodMetrics(ctx)
 	if err != nil {
 		logger.Error("failed-to-get-metrics-from-kubernetes", err, lager.Data{})
 	}
 
+	usage := c.podUsage(ctx, logger, pods, podMetrics)
+	messages := []metrics.Message{}
+
 	for _, pod := range pods {
 		indexID, err := util.ParseAppIndex(pod.Name)
 		if err != nil {
 			continue
 		}
 
+		podUsage := usage[pod.Name]
+
+		messages = append(messages, metrics.Message{
+			AppID:       pod.Labels[LabelGUID],
+			IndexID:     strconv.Itoa(indexID),
+			CPU:         podUsage.CPU,
+			Memory:      podUsage.Memory,
+			MemoryQuota: podUsage.MemoryQuota,
+			Disk:        podUsage.Disk,
+			DiskQuota:   podUsage.DiskQuota,
+		})
+	}
+
+	return messages
+}
+
+// CollectPodUsage only gets the metrics of the given pods, rather than
+// listing the metrics of the whole namespace.
+func (c *metricsCollector) CollectPodUsage(ctx context.Context, pods []apiv1.Pod) map[string]PodUsage {
+	logger := c.logger.Session("collect-pod-usage")
+
+	return c.podUsage(ctx, logger, pods, c.getPodMetrics(ctx, logger, pods))
+}
+
+// podUsage returns zero usage for pods whose metrics cannot be gathered, as
+// the metrics server and the kubelets may lag behind new pods.
+func (c *metricsCollector) podUsage(ctx context.Context, logger lager.Logger, pods []apiv1.Pod, podMetrics map[string]v1beta1.PodMetrics) map[string]PodUsage {
+	diskMetrics, err := c.diskClient.GetPodMetrics(ctx, pods)
+	if err != nil {
+		logger.Error("failed-to-get-disk-metrics", err, lager.Data{})
+	}
+
+	usage := make(map[string]PodUsage, len(pods))
+
+	for _, pod := range pods {
 		cpuPercentage, memoryValue := parseMetrics(podMetrics[pod.Name])
 
-		appContainer := pod.Spec.Containers[0]
+		appContainer := getOPIContainer(pod.Spec.Containers)
 		memoryLimit := appContainer.Resources.Limits.Memory()
 		diskLimit := appContainer.Resources.Limits.StorageEphemeral()
 
-		diskUsage := diskMetrics[pod.Name]
-
-		messages = append(messages, metrics.Message{
-			AppID:       pod.Labels[LabelGUID],
-			IndexID:     strconv.Itoa(indexID),
+		usage[pod.Name] = PodUsage{
 			CPU:         cpuPercentage,
 			Memory:      memoryValue,
 			MemoryQuota: float64(memoryLimit.Value()),
-			Disk:        diskUsage,
+			Disk:        diskMetrics[pod.Name],
 			DiskQuota:   float64(diskLimit.Value()),
-		})
+		}
 	}
 
-	return messages
+	return usage
 }
 
 func parseMetrics(metric v1beta1.PodMetrics) (cpu float64, memory float64) {
@@ -121,6 +180,15 @@ func parseMetrics(metric v1beta1.PodMetrics) (cpu float64, memory float64) {
 	}
 
 	container := metric.Containers[0]
+
+	for _, c := range metric.Containers {
+		if c.Name == OPIContainerName {
+			container = c
+
+			break
+		}
+	}
+
 	usage := container.Usage
 	res := usage[apiv1.ResourceCPU]
 	cpu = toCPUPercentage(res.MilliValue())
@@ -130,8 +198,8 @@ func parseMetrics(metric v1beta1.PodMetrics) (cpu float64, memory float64) {
 	return
 }
 
-func (c *metricsCollector) getPodMetrics() (map[string]v1beta1.PodMetrics, error) {
-	metricsList, err := c.metricsClient.List(context.Background(), metav1.ListOptions{})
+func (c *metricsCollector) listPodMetrics(ctx context.Context) (map[string]v1beta1.PodMetrics, error) {
+	metricsList, err := c.metricsClient.List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list metrics")
 	}
@@ -144,3 +212,24 @@ func (c *metricsCollector) getPodMetrics() (map[string]v1beta1.PodMetrics, error
 
 	return metricsMap, nil
 }
+
+func (c *metricsCollector) getPodMetrics(ctx context.Context, logger lager.Logger, pods []apiv1.Pod) map[string]v1beta1.PodMetrics {
+	metricsMap := make(map[string]v1beta1.PodMetrics, len(pods))
+
+	for _, pod := range pods {
+		podMetrics, err := c.metricsClient.Get(ctx, pod.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-metrics-from-kubernetes", err, lager.Data{"pod": pod.Name})
+
+			continue
+		}
+
+		metricsMap[pod.Name] = *podMetrics
+	}
+
+	return metricsMap
+}
@@ -1,6 +1,8 @@
 package k8s_test
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini/metrics"
@@ -10,8 +12,10 @@ import (
 	"github.com/onsi/gomega/gbytes"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	metricsv1beta1api "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 )
@@ -82,6 +86,55 @@ var _ = Describe("Metrics", func() {
 			})
 		})
 
+		When("pods have sidecar containers", func() {
+			It("should report the metrics of the app container", func() {
+				podMetrics := createMetrics(podName1)
+				podMetrics.Containers[0].Name = k8s.OPIContainerName
+				podMetrics.Containers = append([]metricsv1beta1api.ContainerMetrics{
+					{
+						Name: "sidecar",
+						Usage: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("1Ki"),
+						},
+					},
+				}, podMetrics.Containers...)
+				podMetricsClient.ListReturns(&metricsv1beta1api.PodMetricsList{
+					Items: []metricsv1beta1api.PodMetrics{podMetrics},
+				}, nil)
+
+				pod := createPod(podName1)
+				pod.Spec.Containers[0].Name = k8s.OPIContainerName
+				pod.Spec.Containers = append([]v1.Container{
+					{
+						Name: "sidecar",
+						Resources: v1.ResourceRequirements{
+							Limits: v1.ResourceList{
+								v1.ResourceMemory: *resource.NewScaledQuantity(1, resource.Kilo),
+							},
+						},
+					},
+				}, pod.Spec.Containers...)
+				podClient.GetAllReturns([]v1.Pod{*pod}, nil)
+
+				diskClient.GetPodMetricsReturns(map[string]float64{podName1: 50}, nil)
+
+				collected, err := collector.Collect()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(collected).To(ConsistOf(
+					metrics.Message{
+						AppID:       podName1,
+						IndexID:     "9000",
+						CPU:         420.5,
+						Memory:      430080,
+						MemoryQuota: 800000,
+						Disk:        50,
+						DiskQuota:   10000000,
+					},
+				))
+			})
+		})
+
 		When("there are no pods", func() {
 			It("should return empty list", func() {
 				podClient.GetAllReturns([]v1.Pod{}, nil)
@@ -262,6 +315,103 @@ var _ = Describe("Metrics", func() {
 	})
 })
 
+var _ = Describe("PodUsageCollector", func() {
+	var (
+		podMetricsClient *k8sfakes.FakePodMetricsInterface
+		diskClient       *k8sfakes.FakeDiskAPI
+		logger           *lagertest.TestLogger
+		collector        k8s.PodUsageCollector
+		pods             []v1.Pod
+		usage            map[string]k8s.PodUsage
+		ctx              context.Context
+	)
+
+	BeforeEach(func() {
+		podMetricsClient = new(k8sfakes.FakePodMetricsInterface)
+		podMetricsClient.GetStub = func(_ context.Context, name string, _ metav1.GetOptions) (*metricsv1beta1api.PodMetrics, error) {
+			if name != "app-0" {
+				return nil, k8serrors.NewNotFound(schema.GroupResource{}, name)
+			}
+
+			podMetrics := createMetrics(name)
+
+			return &podMetrics, nil
+		}
+
+		diskClient = new(k8sfakes.FakeDiskAPI)
+		diskClient.GetPodMetricsReturns(map[string]float64{"app-0": 50, "other-app-0": 88}, nil)
+
+		logger = lagertest.NewTestLogger("pod-usage-test")
+		collector = k8s.NewPodUsageCollector(podMetricsClient, diskClient, logger)
+		pods = []v1.Pod{*createPod("app-0"), *createPod("app-1")}
+		ctx = context.Background()
+	})
+
+	JustBeforeEach(func() {
+		usage = collector.CollectPodUsage(ctx, pods)
+	})
+
+	It("gets the disk usage of the given pods", func() {
+		Expect(diskClient.GetPodMetricsCallCount()).To(Equal(1))
+		actualCtx, actualPods := diskClient.GetPodMetricsArgsForCall(0)
+		Expect(actualCtx).To(Equal(ctx))
+		Expect(actualPods).To(Equal(pods))
+	})
+
+	It("gets the metrics of the given pods only", func() {
+		Expect(podMetricsClient.ListCallCount()).To(BeZero())
+		Expect(podMetricsClient.GetCallCount()).To(Equal(2))
+
+		names := []string{}
+		for i := 0; i < podMetricsClient.GetCallCount(); i++ {
+			actualCtx, name, _ := podMetricsClient.GetArgsForCall(i)
+			Expect(actualCtx).To(Equal(ctx))
+			names = append(names, name)
+		}
+		Expect(names).To(ConsistOf("app-0", "app-1"))
+	})
+
+	It("does not log pods without metrics yet", func() {
+		Expect(logger.LogMessages()).To(BeEmpty())
+	})
+
+	It("returns the usage of the given pods only", func() {
+		Expect(usage).To(Equal(map[string]k8s.PodUsage{
+			"app-0": {
+				CPU:         420.5,
+				Memory:      430080,
+				MemoryQuota: 800000,
+				Disk:        50,
+				DiskQuota:   10000000,
+			},
+			"app-1": {
+				MemoryQuota: 800000,
+				DiskQuota:   10000000,
+			},
+		}))
+	})
+
+	When("the metrics cannot be gathered", func() {
+		BeforeEach(func() {
+			podMetricsClient.GetStub = nil
+			podMetricsClient.GetReturns(nil, errors.New("oopsie"))
+			diskClient.GetPodMetricsReturns(nil, errors.New("whoopsie"))
+		})
+
+		It("logs the errors", func() {
+			Expect(logger).To(gbytes.Say("oopsie"))
+			Expect(logger).To(gbytes.Say("whoopsie"))
+		})
+
+		It("returns only the quotas", func() {
+			Expect(usage).To(HaveKeyWithValue("app-0", k8s.PodUsage{
+				MemoryQuota: 800000,
+				DiskQuota:   10000000,
+			}))
+		})
+	})
+})
+
 var _ = Describe("ForwardMetricsToEmitter", func() {
 	It("should forward the messages when collector returns them", func() {
 		emitter := new(k8sfakes.FakeEmitter)
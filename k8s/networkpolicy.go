@@ -0,0 +1,226 @@
+package k8s
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+	"strings"
+
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	EgressProtocolTCP  = "tcp"
+	EgressProtocolUDP  = "udp"
+	EgressProtocolICMP = "icmp"
+	EgressProtocolAll  = "all"
+
+	// Network policies cannot express port ranges, so ranges are expanded
+	// into single ports. Bigger ranges would make the policy too large and
+	// are rejected, unless they cover all ports of the protocol.
+	maxExpandedPortRange = 1000
+	maxPort              = 65535
+)
+
+func toNetworkPolicy(logger lager.Logger, name string, lrp *opi.LRP, selector *metav1.LabelSelector) (*networkingv1.NetworkPolicy, error) {
+	if len(lrp.EgressRules) == 0 {
+		return nil, nil
+	}
+
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+
+	for _, rule := range lrp.EgressRules {
+		if strings.ToLower(rule.Protocol) == EgressProtocolICMP {
+			logger.Info("skipping-icmp-egress-rule", lager.Data{"destinations": rule.Destinations})
+
+			continue
+		}
+
+		egressRule, err := toNetworkPolicyEgressRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		egressRules = append(egressRules, egressRule)
+	}
+
+	// Security groups are allow lists, so when only ICMP rules are left the
+	// policy has no egress rules and denies all egress.
+	if len(egressRules) == 0 {
+		logger.Info("icmp-egress-rules-not-expressible-denying-all-egress")
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				LabelGUID:       lrp.GUID,
+				LabelVersion:    lrp.Version,
+				LabelSourceType: AppSourceType,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *selector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egressRules,
+		},
+	}, nil
+}
+
+func toNetworkPolicyEgressRule(rule opi.EgressRule) (networkingv1.NetworkPolicyEgressRule, error) {
+	peers := []networkingv1.NetworkPolicyPeer{}
+
+	for _, destination := range rule.Destinations {
+		cidrs, err := destinationToCIDRs(destination)
+		if err != nil {
+			return networkingv1.NetworkPolicyEgressRule{}, err
+		}
+
+		for _, cidr := range cidrs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+
+	ports, err := toNetworkPolicyPorts(rule)
+	if err != nil {
+		return networkingv1.NetworkPolicyEgressRule{}, err
+	}
+
+	return networkingv1.NetworkPolicyEgressRule{
+		To:    peers,
+		Ports: ports,
+	}, nil
+}
+
+func toNetworkPolicyPorts(rule opi.EgressRule) ([]networkingv1.NetworkPolicyPort, error) {
+	var protocol corev1.Protocol
+
+	switch strings.ToLower(rule.Protocol) {
+	case EgressProtocolAll:
+		return nil, nil
+	case EgressProtocolTCP:
+		protocol = corev1.ProtocolTCP
+	case EgressProtocolUDP:
+		protocol = corev1.ProtocolUDP
+	default:
+		return nil, fmt.Errorf("unsupported egress rule protocol %q", rule.Protocol)
+	}
+
+	allPorts := []networkingv1.NetworkPolicyPort{{Protocol: &protocol}}
+
+	if len(rule.Ports) == 0 && rule.PortRange == nil {
+		return allPorts, nil
+	}
+
+	ports := []networkingv1.NetworkPolicyPort{}
+	for _, port := range rule.Ports {
+		ports = append(ports, toNetworkPolicyPort(protocol, port))
+	}
+
+	if rule.PortRange != nil {
+		start, end := rule.PortRange.Start, rule.PortRange.End
+		if start > end {
+			return nil, fmt.Errorf("invalid egress rule port range %d-%d", start, end)
+		}
+
+		if start <= 1 && end >= maxPort {
+			return allPorts, nil
+		}
+
+		if end-start >= maxExpandedPortRange {
+			return nil, fmt.Errorf("egress rule port range %d-%d is too big, at most %d ports are supported", start, end, maxExpandedPortRange)
+		}
+
+		for port := start; port <= end; port++ {
+			ports = append(ports, toNetworkPolicyPort(protocol, port))
+		}
+	}
+
+	return ports, nil
+}
+
+func toNetworkPolicyPort(protocol corev1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+	p := protocol
+	portNumber := intstr.FromInt(int(port))
+
+	return networkingv1.NetworkPolicyPort{
+		Protocol: &p,
+		Port:     &portNumber,
+	}
+}
+
+func destinationToCIDRs(destination string) ([]string, error) {
+	if strings.Contains(destination, "-") {
+		return ipRangeToCIDRs(destination)
+	}
+
+	if strings.Contains(destination, "/") {
+		_, ipNet, err := net.ParseCIDR(destination)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid egress rule destination %q", destination)
+		}
+
+		return []string{ipNet.String()}, nil
+	}
+
+	ip := net.ParseIP(destination)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid egress rule destination %q", destination)
+	}
+
+	if ip.To4() != nil {
+		return []string{ip.String() + "/32"}, nil
+	}
+
+	return []string{ip.String() + "/128"}, nil
+}
+
+// ipRangeToCIDRs splits an IPv4 range such as "10.0.0.0-10.0.0.255" into the
+// minimal list of CIDR blocks covering it.
+func ipRangeToCIDRs(ipRange string) ([]string, error) {
+	bounds := strings.SplitN(ipRange, "-", 2) //nolint:gomnd
+
+	startIP := net.ParseIP(strings.TrimSpace(bounds[0])).To4()
+	endIP := net.ParseIP(strings.TrimSpace(bounds[1])).To4()
+
+	if startIP == nil || endIP == nil {
+		return nil, fmt.Errorf("invalid egress rule destination %q", ipRange)
+	}
+
+	start := uint64(binary.BigEndian.Uint32(startIP))
+	end := uint64(binary.BigEndian.Uint32(endIP))
+
+	if start > end {
+		return nil, fmt.Errorf("invalid egress rule destination %q", ipRange)
+	}
+
+	cidrs := []string{}
+
+	for start <= end {
+		hostBits := 32
+		if start != 0 {
+			hostBits = bits.TrailingZeros64(start)
+		}
+
+		for hostBits > 0 && start+(uint64(1)<<hostBits)-1 > end {
+			hostBits--
+		}
+
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, uint32(start))
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, 32-hostBits))
+
+		start += uint64(1) << hostBits
+	}
+
+	return cidrs, nil
+}
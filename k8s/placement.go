@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/eirini"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func applyPlacementTags(podSpec *corev1.PodSpec, tags []string, placementTags map[string]eirini.PlacementTag) error {
+	for _, tag := range tags {
+		placement, ok := placementTags[tag]
+		if !ok {
+			return fmt.Errorf("placement tag %q is not configured", tag)
+		}
+
+		if err := applyNodeSelector(podSpec, tag, placement.NodeSelector); err != nil {
+			return err
+		}
+
+		applyTolerations(podSpec, placement.Tolerations)
+		applyNodeAffinity(podSpec, placement.NodeAffinity)
+	}
+
+	return nil
+}
+
+func applyNodeSelector(podSpec *corev1.PodSpec, tag string, nodeSelector map[string]string) error {
+	if len(nodeSelector) == 0 {
+		return nil
+	}
+
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+
+	for k, v := range nodeSelector {
+		if existing, ok := podSpec.NodeSelector[k]; ok && existing != v {
+			return fmt.Errorf("placement tag %q conflicts with node selector %s=%s", tag, k, existing)
+		}
+
+		podSpec.NodeSelector[k] = v
+	}
+
+	return nil
+}
+
+func applyTolerations(podSpec *corev1.PodSpec, tolerations []eirini.Toleration) {
+	for _, t := range tolerations {
+		podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+			Key:      t.Key,
+			Operator: corev1.TolerationOperator(t.Operator),
+			Value:    t.Value,
+			Effect:   corev1.TaintEffect(t.Effect),
+		})
+	}
+}
+
+func applyNodeAffinity(podSpec *corev1.PodSpec, requirements []eirini.NodeSelectorRequirement) {
+	if len(requirements) == 0 {
+		return
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{}},
+		}
+	}
+
+	// All requirements go into a single term, so that they are ANDed together
+	term := &nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
+	for _, r := range requirements {
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      r.Key,
+			Operator: corev1.NodeSelectorOperator(r.Operator),
+			Values:   r.Values,
+		})
+	}
+}
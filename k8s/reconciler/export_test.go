@@ -0,0 +1,3 @@
+package reconciler
+
+var ReconcileResults = reconcileResults
@@ -13,6 +13,7 @@ import (
 	"github.com/jinzhu/copier"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,13 +28,13 @@ import (
 //counterfeiter:generate . StatefulSetGetter
 
 type LRPDesirer interface {
-	Desire(namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) error
-	Get(identifier opi.LRPIdentifier) (*opi.LRP, error)
-	Update(lrp *opi.LRP) error
+	Desire(ctx context.Context, namespace string, lrp *opi.LRP, opts ...k8s.DesireOption) error
+	Get(ctx context.Context, identifier opi.LRPIdentifier) (*opi.LRP, error)
+	UpdateSpec(ctx context.Context, lrp *opi.LRP) error
 }
 
 type StatefulSetGetter interface {
-	Get(namespace, name string) (*appsv1.StatefulSet, error)
+	Get(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error)
 }
 
 func NewLRP(logger lager.Logger, lrps client.Client, desirer LRPDesirer, statefulsetGetter StatefulSetGetter, scheme *runtime.Scheme) *LRP {
@@ -55,6 +56,14 @@ type LRP struct {
 }
 
 func (r *LRP) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconcile(request)
+	observeReconcile("lrp", result, err)
+
+	return result, err
+}
+
+func (r *LRP) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.Background()
 	logger := r.logger.Session("reconcile-lrp",
 		lager.Data{
 			"name":      request.NamespacedName.Name,
@@ -62,7 +71,7 @@ func (r *LRP) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 		})
 
 	lrp := &eiriniv1.LRP{}
-	if err := r.lrps.Get(context.Background(), request.NamespacedName, lrp); err != nil {
+	if err := r.lrps.Get(ctx, request.NamespacedName, lrp); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error("lrp-not-found", err)
 
@@ -74,7 +83,7 @@ func (r *LRP) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to get lrp")
 	}
 
-	err := r.do(lrp)
+	err := r.do(ctx, lrp)
 	if err != nil {
 		logger.Error("failed-to-reconcile", err)
 	}
@@ -82,8 +91,8 @@ func (r *LRP) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	return reconcile.Result{}, err
 }
 
-func (r *LRP) do(lrp *eiriniv1.LRP) error {
-	_, err := r.desirer.Get(opi.LRPIdentifier{
+func (r *LRP) do(ctx context.Context, lrp *eiriniv1.LRP) error {
+	_, err := r.desirer.Get(ctx, opi.LRPIdentifier{
 		GUID:    lrp.Spec.GUID,
 		Version: lrp.Spec.Version,
 	})
@@ -93,7 +102,7 @@ func (r *LRP) do(lrp *eiriniv1.LRP) error {
 			return errors.Wrap(parseErr, "failed to parse the crd spec to the lrp model")
 		}
 
-		return errors.Wrap(r.desirer.Desire(lrp.Namespace, appLRP, r.setOwnerFn(lrp)), "failed to desire lrp")
+		return errors.Wrap(r.desirer.Desire(ctx, lrp.Namespace, appLRP, r.setOwnerFn(lrp)), "failed to desire lrp")
 	}
 
 	if err != nil {
@@ -107,29 +116,37 @@ func (r *LRP) do(lrp *eiriniv1.LRP) error {
 
 	var errs *multierror.Error
 
-	err = r.updateStatus(lrp, appLRP)
+	err = r.updateStatus(ctx, lrp, appLRP)
 	errs = multierror.Append(errs, errors.Wrap(err, "failed to update lrp status"))
 
-	err = r.desirer.Update(appLRP)
+	err = r.desirer.UpdateSpec(ctx, appLRP)
 	errs = multierror.Append(errs, errors.Wrap(err, "failed to update app"))
 
 	return errs.ErrorOrNil()
 }
 
-func (r *LRP) updateStatus(lrp *eiriniv1.LRP, appLRP *opi.LRP) error {
+func (r *LRP) updateStatus(ctx context.Context, lrp *eiriniv1.LRP, appLRP *opi.LRP) error {
 	statefulSetName, err := utils.GetStatefulsetName(appLRP)
 	if err != nil {
 		return err
 	}
 
-	st, err := r.statefulsetGetter.Get(lrp.Namespace, statefulSetName)
+	st, err := r.statefulsetGetter.Get(ctx, lrp.Namespace, statefulSetName)
 	if err != nil {
 		return errors.Wrap(err, "failed to get stateful set")
 	}
 
-	lrp.Status.Replicas = st.Status.ReadyReplicas
+	pods := &corev1.PodList{}
+	if err := r.lrps.List(ctx, pods,
+		client.InNamespace(lrp.Namespace),
+		client.MatchingLabels{k8s.LabelGUID: lrp.Spec.GUID, k8s.LabelVersion: lrp.Spec.Version},
+	); err != nil {
+		return errors.Wrap(err, "failed to list lrp pods")
+	}
+
+	lrp.Status = getLRPStatus(lrp, st, pods.Items)
 
-	return r.lrps.Status().Update(context.Background(), lrp)
+	return r.lrps.Status().Update(ctx, lrp)
 }
 
 func (r *LRP) setOwnerFn(lrp *eiriniv1.LRP) func(interface{}) error {
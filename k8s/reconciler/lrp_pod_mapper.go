@@ -0,0 +1,60 @@
+package reconciler
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/lager"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// PodToLRP maps app pods to the LRP owning their statefulset, so that the LRP
+// status is refreshed whenever one of its instances changes.
+type PodToLRP struct {
+	logger       lager.Logger
+	statefulSets client.Client
+}
+
+func NewPodToLRP(logger lager.Logger, statefulSets client.Client) *PodToLRP {
+	return &PodToLRP{
+		logger:       logger,
+		statefulSets: statefulSets,
+	}
+}
+
+func (m *PodToLRP) Map(obj handler.MapObject) []reconcile.Request {
+	if obj.Meta.GetLabels()[k8s.LabelSourceType] != k8s.AppSourceType {
+		return nil
+	}
+
+	logger := m.logger.Session("map-pod-to-lrp", lager.Data{"namespace": obj.Meta.GetNamespace(), "name": obj.Meta.GetName()})
+
+	statefulSetRef, err := getOwner(obj.Meta, statefulSetKind)
+	if err != nil {
+		logger.Debug("pod-without-statefulset-owner")
+
+		return nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err = m.statefulSets.Get(context.Background(), types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: statefulSetRef.Name}, statefulSet); err != nil {
+		logger.Error("failed-to-get-stateful-set", err)
+
+		return nil
+	}
+
+	lrpRef, err := getOwner(statefulSet, lrpKind)
+	if err != nil {
+		logger.Debug("statefulset-without-lrp-owner", lager.Data{"statefulset-name": statefulSet.Name})
+
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Namespace: statefulSet.Namespace, Name: lrpRef.Name},
+	}}
+}
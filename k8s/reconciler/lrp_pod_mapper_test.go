@@ -0,0 +1,116 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/reconciler"
+	"code.cloudfoundry.org/eirini/k8s/reconciler/reconcilerfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("PodToLRP", func() {
+	var (
+		controllerClient *reconcilerfakes.FakeClient
+		mapper           *reconciler.PodToLRP
+		pod              *corev1.Pod
+		statefulSet      *appsv1.StatefulSet
+		requests         []reconcile.Request
+	)
+
+	BeforeEach(func() {
+		controllerClient = new(reconcilerfakes.FakeClient)
+		mapper = reconciler.NewPodToLRP(lagertest.NewTestLogger("pod-to-lrp"), controllerClient)
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "app-0",
+				Namespace:       "space",
+				Labels:          map[string]string{k8s.LabelSourceType: k8s.AppSourceType},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "app"}},
+			},
+		}
+
+		statefulSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "app",
+				Namespace:       "space",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "LRP", Name: "the-lrp"}},
+			},
+		}
+
+		controllerClient.GetStub = func(ctx context.Context, nn types.NamespacedName, obj runtime.Object) error {
+			statefulSet.DeepCopyInto(obj.(*appsv1.StatefulSet))
+
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		requests = mapper.Map(handler.MapObject{Meta: pod, Object: pod})
+	})
+
+	It("gets the statefulset owning the pod", func() {
+		Expect(controllerClient.GetCallCount()).To(Equal(1))
+		_, nn, _ := controllerClient.GetArgsForCall(0)
+		Expect(nn).To(Equal(types.NamespacedName{Namespace: "space", Name: "app"}))
+	})
+
+	It("maps the pod to the LRP owning its statefulset", func() {
+		Expect(requests).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "space", Name: "the-lrp"},
+		}))
+	})
+
+	When("the pod is not an app pod", func() {
+		BeforeEach(func() {
+			pod.Labels[k8s.LabelSourceType] = k8s.TaskSourceType
+		})
+
+		It("maps it to nothing", func() {
+			Expect(requests).To(BeEmpty())
+			Expect(controllerClient.GetCallCount()).To(BeZero())
+		})
+	})
+
+	When("the pod is not owned by a statefulset", func() {
+		BeforeEach(func() {
+			pod.OwnerReferences = nil
+		})
+
+		It("maps it to nothing", func() {
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	When("the statefulset is not owned by an LRP", func() {
+		BeforeEach(func() {
+			statefulSet.OwnerReferences = nil
+		})
+
+		It("maps it to nothing", func() {
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	When("getting the statefulset fails", func() {
+		BeforeEach(func() {
+			controllerClient.GetStub = nil
+			controllerClient.GetReturns(errors.New("boom"))
+		})
+
+		It("maps it to nothing", func() {
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
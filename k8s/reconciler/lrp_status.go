@@ -0,0 +1,167 @@
+package reconciler
+
+import (
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/utils"
+	"code.cloudfoundry.org/eirini/opi"
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/eirini/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
+func getLRPStatus(lrp *eiriniv1.LRP, statefulSet *appsv1.StatefulSet, pods []corev1.Pod) eiriniv1.LRPStatus {
+	status := eiriniv1.LRPStatus{
+		Replicas:           statefulSet.Status.ReadyReplicas,
+		ObservedGeneration: lrp.Generation,
+		Conditions:         append([]metav1.Condition{}, lrp.Status.Conditions...),
+		Instances:          getLRPInstances(pods),
+	}
+
+	desired := int32(lrp.Spec.Instances)
+	ready := statefulSet.Status.ReadyReplicas
+	progressing := isRollingOut(statefulSet, desired)
+	crashed := countCrashedInstances(status.Instances)
+	crashLooping := countCrashLoopingPods(pods)
+
+	setCondition(&status, eiriniv1.LRPReady, ready >= desired,
+		"AllInstancesReady", "InstancesNotReady",
+		fmt.Sprintf("%d/%d instances are ready", ready, desired))
+
+	setCondition(&status, eiriniv1.LRPProgressing, progressing,
+		"RolloutInProgress", "RolloutComplete",
+		fmt.Sprintf("%d/%d instances are up to date", statefulSet.Status.UpdatedReplicas, desired))
+
+	degradedReason, degradedMessage := "AsExpected", "all instances are available"
+
+	switch {
+	case crashed > 0:
+		degradedReason, degradedMessage = "InstancesCrashed", fmt.Sprintf("%d instances have crashed", crashed)
+	case !progressing && ready < desired:
+		degradedReason, degradedMessage = "InstancesUnavailable", fmt.Sprintf("%d instances are unavailable", desired-ready)
+	}
+
+	setCondition(&status, eiriniv1.LRPDegraded, degradedReason != "AsExpected",
+		degradedReason, degradedReason, degradedMessage)
+
+	setCondition(&status, eiriniv1.LRPCrashLooping, crashLooping > 0,
+		crashLoopBackOffReason, "NoCrashLoop",
+		fmt.Sprintf("%d instances are crash looping", crashLooping))
+
+	return status
+}
+
+func setCondition(status *eiriniv1.LRPStatus, conditionType string, isTrue bool, trueReason, falseReason, message string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             falseReason,
+		Message:            message,
+		ObservedGeneration: status.ObservedGeneration,
+	}
+
+	if isTrue {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = trueReason
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	// SetStatusCondition only refreshes the observed generation of new conditions
+	meta.FindStatusCondition(status.Conditions, conditionType).ObservedGeneration = status.ObservedGeneration
+}
+
+func isRollingOut(statefulSet *appsv1.StatefulSet, desired int32) bool {
+	return statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		statefulSet.Status.CurrentRevision != statefulSet.Status.UpdateRevision ||
+		statefulSet.Status.UpdatedReplicas < desired ||
+		statefulSet.Status.Replicas != desired
+}
+
+func getLRPInstances(pods []corev1.Pod) []eiriniv1.LRPInstance {
+	instances := []eiriniv1.LRPInstance{}
+
+	for _, pod := range pods {
+		index, err := util.ParseAppIndex(pod.Name)
+		if err != nil {
+			continue
+		}
+
+		instance := eiriniv1.LRPInstance{
+			Index:   index,
+			PodName: pod.Name,
+			State:   utils.GetPodState(pod),
+			Since:   pod.Status.StartTime,
+		}
+
+		if containerStatus, ok := getOPIContainerStatus(pod); ok {
+			instance.CrashCount = containerStatus.RestartCount
+			instance.LastCrashReason = getLastCrashReason(containerStatus)
+		}
+
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Index < instances[j].Index
+	})
+
+	return instances
+}
+
+func getOPIContainerStatus(pod corev1.Pod) (corev1.ContainerStatus, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == k8s.OPIContainerName {
+			return status, true
+		}
+	}
+
+	return corev1.ContainerStatus{}, false
+}
+
+func getLastCrashReason(status corev1.ContainerStatus) string {
+	if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		return terminated.Reason
+	}
+
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		return terminated.Reason
+	}
+
+	return ""
+}
+
+func countCrashedInstances(instances []eiriniv1.LRPInstance) int {
+	count := 0
+
+	for _, instance := range instances {
+		if instance.State == opi.CrashedState {
+			count++
+		}
+	}
+
+	return count
+}
+
+func countCrashLoopingPods(pods []corev1.Pod) int {
+	count := 0
+
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+				count++
+
+				break
+			}
+		}
+	}
+
+	return count
+}
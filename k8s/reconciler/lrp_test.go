@@ -12,13 +12,18 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -32,6 +37,8 @@ var _ = Describe("reconciler.LRP", func() {
 		scheme            *runtime.Scheme
 		lrpreconciler     *reconciler.LRP
 		resultErr         error
+		successesBefore   float64
+		errorsBefore      float64
 	)
 
 	BeforeEach(func() {
@@ -54,12 +61,20 @@ var _ = Describe("reconciler.LRP", func() {
 			lrp.Spec.AppRoutes = []eiriniv1.Route{
 				{Hostname: "foo.io", Port: 8080}, {Hostname: "bar.io", Port: 9090},
 			}
+			lrp.Spec.PlacementTags = []string{"segment-a"}
+			lrp.Spec.Sidecars = []eiriniv1.Sidecar{
+				{Name: "logger", Command: []string{"/bin/logger"}, MemoryMB: 32},
+			}
+			lrp.Spec.RestartGeneration = 3
 
 			return nil
 		}
 		controllerClient.StatusReturns(statusClient)
 		statefulsetGetter.GetReturns(&appsv1.StatefulSet{}, nil)
 		desirer.GetReturns(nil, eirini.ErrNotFound)
+
+		successesBefore = testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "success"))
+		errorsBefore = testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "error"))
 	})
 
 	JustBeforeEach(func() {
@@ -74,10 +89,10 @@ var _ = Describe("reconciler.LRP", func() {
 	It("creates a statefulset for each CRD", func() {
 		Expect(resultErr).NotTo(HaveOccurred())
 
-		Expect(desirer.UpdateCallCount()).To(Equal(0))
+		Expect(desirer.UpdateSpecCallCount()).To(Equal(0))
 		Expect(desirer.DesireCallCount()).To(Equal(1))
 
-		ns, lrp, _ := desirer.DesireArgsForCall(0)
+		_, ns, lrp, _ := desirer.DesireArgsForCall(0)
 		Expect(ns).To(Equal("some-ns"))
 		Expect(lrp.GUID).To(Equal("the-lrp-guid"))
 		Expect(lrp.Version).To(Equal("the-lrp-version"))
@@ -87,13 +102,22 @@ var _ = Describe("reconciler.LRP", func() {
 			opi.Route{Hostname: "foo.io", Port: 8080},
 			opi.Route{Hostname: "bar.io", Port: 9090},
 		))
+		Expect(lrp.PlacementTags).To(ConsistOf("segment-a"))
+		Expect(lrp.Sidecars).To(ConsistOf(
+			opi.Sidecar{Name: "logger", Command: []string{"/bin/logger"}, MemoryMB: 32},
+		))
+	})
+
+	It("counts the successful reconcile", func() {
+		Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "success"))).To(Equal(successesBefore + 1))
+		Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "error"))).To(Equal(errorsBefore))
 	})
 
 	It("sets an owner reference in the statefulset", func() {
 		Expect(resultErr).NotTo(HaveOccurred())
 
 		Expect(desirer.DesireCallCount()).To(Equal(1))
-		_, _, setOwnerFns := desirer.DesireArgsForCall(0)
+		_, _, _, setOwnerFns := desirer.DesireArgsForCall(0)
 		Expect(setOwnerFns).To(HaveLen(1))
 		setOwnerFn := setOwnerFns[0]
 
@@ -112,14 +136,22 @@ var _ = Describe("reconciler.LRP", func() {
 		It("updates it", func() {
 			Expect(resultErr).NotTo(HaveOccurred())
 
-			Expect(desirer.UpdateCallCount()).To(Equal(1))
-			lrp := desirer.UpdateArgsForCall(0)
+			Expect(desirer.UpdateSpecCallCount()).To(Equal(1))
+			_, lrp := desirer.UpdateSpecArgsForCall(0)
 			Expect(lrp.TargetInstances).To(Equal(10))
 			Expect(lrp.AppURIs).To(ConsistOf(
 				opi.Route{Hostname: "foo.io", Port: 8080},
 				opi.Route{Hostname: "bar.io", Port: 9090},
 			))
 		})
+
+		It("passes the full spec to the desirer", func() {
+			_, lrp := desirer.UpdateSpecArgsForCall(0)
+			Expect(lrp.Command).To(ConsistOf("ls", "-la"))
+			Expect(lrp.PlacementTags).To(ConsistOf("segment-a"))
+			Expect(lrp.Sidecars).To(ConsistOf(opi.Sidecar{Name: "logger", Command: []string{"/bin/logger"}, MemoryMB: 32}))
+			Expect(lrp.RestartGeneration).To(Equal(int64(3)))
+		})
 	})
 
 	When("an app instance becomes unready", func() {
@@ -139,9 +171,178 @@ var _ = Describe("reconciler.LRP", func() {
 			Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 			_, obj, _ := statusWriter.UpdateArgsForCall(0)
 			lrp := obj.(*eiriniv1.LRP)
-			Expect(lrp.Status).To(Equal(eiriniv1.LRPStatus{
-				Replicas: 9,
-			}))
+			Expect(lrp.Status.Replicas).To(Equal(int32(9)))
+			Expect(meta.IsStatusConditionFalse(lrp.Status.Conditions, eiriniv1.LRPReady)).To(BeTrue())
+		})
+
+		It("lists the LRP pods in the CR's namespace", func() {
+			Expect(controllerClient.ListCallCount()).To(Equal(1))
+			_, _, opts := controllerClient.ListArgsForCall(0)
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			Expect(listOpts.Namespace).To(Equal("some-ns"))
+			Expect(listOpts.LabelSelector.String()).To(SatisfyAll(
+				ContainSubstring("cloudfoundry.org/guid=the-lrp-guid"),
+				ContainSubstring("cloudfoundry.org/version=the-lrp-version"),
+			))
+		})
+
+		When("all instances are ready and up to date", func() {
+			BeforeEach(func() {
+				statefulsetGetter.GetReturns(&appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{
+					Replicas:        10,
+					ReadyReplicas:   10,
+					UpdatedReplicas: 10,
+				}}, nil)
+			})
+
+			It("is ready and neither progressing nor degraded", func() {
+				_, obj, _ := statusWriter.UpdateArgsForCall(0)
+				conditions := obj.(*eiriniv1.LRP).Status.Conditions
+				Expect(meta.IsStatusConditionTrue(conditions, eiriniv1.LRPReady)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(conditions, eiriniv1.LRPProgressing)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(conditions, eiriniv1.LRPDegraded)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(conditions, eiriniv1.LRPCrashLooping)).To(BeTrue())
+			})
+		})
+
+		When("the statefulset is being rolled out", func() {
+			BeforeEach(func() {
+				statefulsetGetter.GetReturns(&appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{
+					Replicas:        10,
+					ReadyReplicas:   9,
+					UpdatedReplicas: 4,
+					CurrentRevision: "rev-1",
+					UpdateRevision:  "rev-2",
+				}}, nil)
+			})
+
+			It("is progressing but not degraded", func() {
+				_, obj, _ := statusWriter.UpdateArgsForCall(0)
+				conditions := obj.(*eiriniv1.LRP).Status.Conditions
+				Expect(meta.IsStatusConditionTrue(conditions, eiriniv1.LRPProgressing)).To(BeTrue())
+				Expect(meta.IsStatusConditionFalse(conditions, eiriniv1.LRPDegraded)).To(BeTrue())
+			})
+		})
+
+		When("instances are crashing", func() {
+			BeforeEach(func() {
+				statefulsetGetter.GetReturns(&appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{
+					Replicas:        10,
+					ReadyReplicas:   9,
+					UpdatedReplicas: 10,
+				}}, nil)
+
+				controllerClient.ListStub = func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+					startTime := v1.Unix(100, 0)
+					list.(*corev1.PodList).Items = []corev1.Pod{
+						{
+							ObjectMeta: v1.ObjectMeta{Name: "some-lrp-1"},
+							Status: corev1.PodStatus{
+								Phase: corev1.PodRunning,
+								ContainerStatuses: []corev1.ContainerStatus{{
+									Name:         "opi",
+									RestartCount: 3,
+									State: corev1.ContainerState{
+										Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+									},
+									LastTerminationState: corev1.ContainerState{
+										Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"},
+									},
+								}},
+							},
+						},
+						{
+							ObjectMeta: v1.ObjectMeta{Name: "some-lrp-0"},
+							Status: corev1.PodStatus{
+								Phase:     corev1.PodRunning,
+								StartTime: &startTime,
+								ContainerStatuses: []corev1.ContainerStatus{{
+									Name:  "opi",
+									Ready: true,
+									State: corev1.ContainerState{
+										Running: &corev1.ContainerStateRunning{},
+									},
+								}},
+							},
+						},
+					}
+
+					return nil
+				}
+			})
+
+			It("reports each instance ordered by index", func() {
+				_, obj, _ := statusWriter.UpdateArgsForCall(0)
+				startTime := v1.Unix(100, 0)
+				Expect(obj.(*eiriniv1.LRP).Status.Instances).To(Equal([]eiriniv1.LRPInstance{
+					{
+						Index:   0,
+						PodName: "some-lrp-0",
+						State:   opi.RunningState,
+						Since:   &startTime,
+					},
+					{
+						Index:           1,
+						PodName:         "some-lrp-1",
+						State:           opi.CrashedState,
+						CrashCount:      3,
+						LastCrashReason: "OOMKilled",
+					},
+				}))
+			})
+
+			It("is degraded and crash looping", func() {
+				_, obj, _ := statusWriter.UpdateArgsForCall(0)
+				conditions := obj.(*eiriniv1.LRP).Status.Conditions
+				Expect(meta.FindStatusCondition(conditions, eiriniv1.LRPDegraded)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Status": Equal(v1.ConditionTrue),
+					"Reason": Equal("InstancesCrashed"),
+				})))
+				Expect(meta.IsStatusConditionTrue(conditions, eiriniv1.LRPCrashLooping)).To(BeTrue())
+			})
+		})
+
+		When("the LRP already has conditions", func() {
+			var transitionTime v1.Time
+
+			BeforeEach(func() {
+				transitionTime = v1.Unix(42, 0)
+				getStub := controllerClient.GetStub
+				controllerClient.GetStub = func(c context.Context, nn types.NamespacedName, o runtime.Object) error {
+					Expect(getStub(c, nn, o)).To(Succeed())
+					lrp := o.(*eiriniv1.LRP)
+					lrp.Generation = 7
+					lrp.Status.Conditions = []v1.Condition{{
+						Type:               eiriniv1.LRPReady,
+						Status:             v1.ConditionFalse,
+						Reason:             "InstancesNotReady",
+						LastTransitionTime: transitionTime,
+					}}
+
+					return nil
+				}
+			})
+
+			It("keeps the transition time of unchanged conditions", func() {
+				_, obj, _ := statusWriter.UpdateArgsForCall(0)
+				lrp := obj.(*eiriniv1.LRP)
+				Expect(lrp.Status.ObservedGeneration).To(Equal(int64(7)))
+				ready := meta.FindStatusCondition(lrp.Status.Conditions, eiriniv1.LRPReady)
+				Expect(ready.LastTransitionTime).To(Equal(transitionTime))
+				Expect(ready.ObservedGeneration).To(Equal(int64(7)))
+			})
+		})
+
+		When("listing the pods fails", func() {
+			BeforeEach(func() {
+				controllerClient.ListReturns(errors.New("boom"))
+			})
+
+			It("does not update the status", func() {
+				Expect(resultErr).To(MatchError(ContainSubstring("failed to list lrp pods")))
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
 		})
 
 		When("statefulset getter fails to get the statefulset", func() {
@@ -153,7 +354,7 @@ var _ = Describe("reconciler.LRP", func() {
 				Expect(resultErr).To(MatchError(ContainSubstring("boom")))
 
 				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
-				Expect(desirer.UpdateCallCount()).To(Equal(1))
+				Expect(desirer.UpdateSpecCallCount()).To(Equal(1))
 			})
 		})
 
@@ -166,7 +367,7 @@ var _ = Describe("reconciler.LRP", func() {
 				Expect(resultErr).To(MatchError(ContainSubstring("bom")))
 
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
-				Expect(desirer.UpdateCallCount()).To(Equal(1))
+				Expect(desirer.UpdateSpecCallCount()).To(Equal(1))
 			})
 		})
 	})
@@ -213,12 +414,17 @@ var _ = Describe("reconciler.LRP", func() {
 		It("returns an error", func() {
 			Expect(resultErr).To(MatchError("failed to desire lrp: boom"))
 		})
+
+		It("counts the failed reconcile", func() {
+			Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "error"))).To(Equal(errorsBefore + 1))
+			Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("lrp", "success"))).To(Equal(successesBefore))
+		})
 	})
 
 	When("the lrp desirer fails to update the app", func() {
 		BeforeEach(func() {
 			desirer.GetReturns(nil, nil)
-			desirer.UpdateReturns(errors.New("boom"))
+			desirer.UpdateSpecReturns(errors.New("boom"))
 		})
 
 		It("returns an error", func() {
@@ -0,0 +1,37 @@
+package reconciler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	resultSuccess = "success"
+	resultRequeue = "requeue"
+	resultError   = "error"
+)
+
+var reconcileResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "eirini",
+	Subsystem: "controller",
+	Name:      "reconciles_total",
+	Help:      "Number of reconciliations, by reconciler and result.",
+}, []string{"reconciler", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileResults)
+}
+
+func observeReconcile(reconciler string, result reconcile.Result, err error) {
+	outcome := resultSuccess
+
+	switch {
+	case err != nil:
+		outcome = resultError
+	case result.Requeue || result.RequeueAfter > 0:
+		outcome = resultRequeue
+	}
+
+	reconcileResults.WithLabelValues(reconciler, outcome).Inc()
+}
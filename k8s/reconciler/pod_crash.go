@@ -29,15 +29,15 @@ const (
 //counterfeiter:generate . CrashEventGenerator
 
 type CrashEventGenerator interface {
-	Generate(*corev1.Pod, lager.Logger) (events.CrashEvent, bool)
+	Generate(context.Context, *corev1.Pod, lager.Logger) (events.CrashEvent, bool)
 }
 
 //counterfeiter:generate . EventsClient
 
 type EventsClient interface {
-	Create(namespace string, event *corev1.Event) (*corev1.Event, error)
-	Update(namespace string, event *corev1.Event) (*corev1.Event, error)
-	GetByInstanceAndReason(namespace string, ownerRef metav1.OwnerReference, instanceIndex int, reason string) (*corev1.Event, error)
+	Create(ctx context.Context, namespace string, event *corev1.Event) (*corev1.Event, error)
+	Update(ctx context.Context, namespace string, event *corev1.Event) (*corev1.Event, error)
+	GetByInstanceAndReason(ctx context.Context, namespace string, ownerRef metav1.OwnerReference, instanceIndex int, reason string) (*corev1.Event, error)
 }
 
 type PodCrash struct {
@@ -61,10 +61,18 @@ func NewPodCrash(
 }
 
 func (r PodCrash) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconcile(request)
+	observeReconcile("pod_crash", result, err)
+
+	return result, err
+}
+
+func (r PodCrash) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.Background()
 	logger := r.logger.Session("crash-event-reconciler", lager.Data{"namespace": request.Namespace, "name": request.Name})
 
 	pod := &corev1.Pod{}
-	if err := r.pods.Get(context.Background(), request.NamespacedName, pod); err != nil {
+	if err := r.pods.Get(ctx, request.NamespacedName, pod); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error("pod does not exist", err)
 
@@ -76,33 +84,33 @@ func (r PodCrash) Reconcile(request reconcile.Request) (reconcile.Result, error)
 		return reconcile.Result{}, errors.Wrap(err, "failed to get pod")
 	}
 
-	crashEvent, shouldCreate := r.crashEventGenerator.Generate(pod, logger)
+	crashEvent, shouldCreate := r.crashEventGenerator.Generate(ctx, pod, logger)
 	if !shouldCreate {
 		return reconcile.Result{}, nil
 	}
 
-	statefulSetRef, err := r.getOwner(pod, statefulSetKind)
+	statefulSetRef, err := getOwner(pod, statefulSetKind)
 	if err != nil {
 		logger.Debug("pod-without-statefulset-owner")
 
 		return reconcile.Result{}, nil
 	}
 
-	statefulSet, err := r.statefulSetGetter.Get(pod.Namespace, statefulSetRef.Name)
+	statefulSet, err := r.statefulSetGetter.Get(ctx, pod.Namespace, statefulSetRef.Name)
 	if err != nil {
 		logger.Error("failed-to-get-stateful-set", err)
 
 		return reconcile.Result{}, errors.Wrap(err, "failed to get stateful set")
 	}
 
-	lrpRef, err := r.getOwner(statefulSet, lrpKind)
+	lrpRef, err := getOwner(statefulSet, lrpKind)
 	if err != nil {
 		logger.Debug("statefulset-without-lrp-owner", lager.Data{"statefulset-name": statefulSet.Name})
 
 		return reconcile.Result{}, nil
 	}
 
-	kubeEvent, err := r.eventsClient.GetByInstanceAndReason(request.Namespace, lrpRef, crashEvent.Index, failureReason(crashEvent))
+	kubeEvent, err := r.eventsClient.GetByInstanceAndReason(ctx, request.Namespace, lrpRef, crashEvent.Index, failureReason(crashEvent))
 	if err != nil {
 		logger.Error("failed-to-get-existing-event", err)
 
@@ -110,17 +118,17 @@ func (r PodCrash) Reconcile(request reconcile.Request) (reconcile.Result, error)
 	}
 
 	if kubeEvent != nil {
-		return r.updateEvent(logger, kubeEvent, crashEvent, request.Namespace)
+		return r.updateEvent(ctx, logger, kubeEvent, crashEvent, request.Namespace)
 	}
 
-	return r.createEvent(logger, lrpRef, crashEvent, request.Namespace)
+	return r.createEvent(ctx, logger, lrpRef, crashEvent, request.Namespace)
 }
 
-func (r PodCrash) createEvent(logger lager.Logger, ownerRef metav1.OwnerReference, crashEvent events.CrashEvent, namespace string) (reconcile.Result, error) {
+func (r PodCrash) createEvent(ctx context.Context, logger lager.Logger, ownerRef metav1.OwnerReference, crashEvent events.CrashEvent, namespace string) (reconcile.Result, error) {
 	var err error
 
 	event := r.makeEvent(crashEvent, namespace, ownerRef)
-	if event, err = r.eventsClient.Create(namespace, event); err != nil {
+	if event, err = r.eventsClient.Create(ctx, namespace, event); err != nil {
 		logger.Error("failed-to-create-event", err)
 
 		return reconcile.Result{}, errors.Wrap(err, "failed to create event")
@@ -131,11 +139,11 @@ func (r PodCrash) createEvent(logger lager.Logger, ownerRef metav1.OwnerReferenc
 	return reconcile.Result{}, nil
 }
 
-func (r PodCrash) updateEvent(logger lager.Logger, kubeEvent *corev1.Event, crashEvent events.CrashEvent, namespace string) (reconcile.Result, error) {
+func (r PodCrash) updateEvent(ctx context.Context, logger lager.Logger, kubeEvent *corev1.Event, crashEvent events.CrashEvent, namespace string) (reconcile.Result, error) {
 	kubeEvent.Count++
 	kubeEvent.LastTimestamp = metav1.NewTime(time.Unix(crashEvent.CrashTimestamp, 0))
 
-	if _, err := r.eventsClient.Update(namespace, kubeEvent); err != nil {
+	if _, err := r.eventsClient.Update(ctx, namespace, kubeEvent); err != nil {
 		logger.Error("failed-to-update-event", err)
 
 		return reconcile.Result{}, errors.Wrap(err, "failed to update event")
@@ -182,7 +190,7 @@ func (r PodCrash) makeEvent(crashEvent events.CrashEvent, namespace string, invo
 	}
 }
 
-func (r PodCrash) getOwner(obj metav1.Object, kind string) (metav1.OwnerReference, error) {
+func getOwner(obj metav1.Object, kind string) (metav1.OwnerReference, error) {
 	for _, ref := range obj.GetOwnerReferences() {
 		if ref.Kind == kind {
 			return ref, nil
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -104,7 +105,7 @@ var _ = Describe("K8s/Reconciler/AppCrash", func() {
 		Expect(nsName).To(Equal(types.NamespacedName{Namespace: "some-ns", Name: "app-instance"}))
 
 		Expect(crashEventGenerator.GenerateCallCount()).To(Equal(1))
-		pod, _ := crashEventGenerator.GenerateArgsForCall(0)
+		_, pod, _ := crashEventGenerator.GenerateArgsForCall(0)
 		Expect(pod.Namespace).To(Equal("some-ns"))
 		Expect(pod.Name).To(Equal("app-instance"))
 	})
@@ -143,7 +144,7 @@ var _ = Describe("K8s/Reconciler/AppCrash", func() {
 
 		It("creates a k8s event", func() {
 			Expect(eventsClient.CreateCallCount()).To(Equal(1))
-			namespace, event := eventsClient.CreateArgsForCall(0)
+			_, namespace, event := eventsClient.CreateArgsForCall(0)
 			Expect(namespace).To(Equal("some-ns"))
 			Expect(event.GenerateName).To(Equal("instance-name-"))
 			Expect(event.Labels).To(HaveKeyWithValue("cloudfoundry.org/instance_index", "3"))
@@ -215,13 +216,20 @@ var _ = Describe("K8s/Reconciler/AppCrash", func() {
 		})
 
 		When("creating the event errors", func() {
+			var errorsBefore float64
+
 			BeforeEach(func() {
 				eventsClient.CreateReturns(nil, errors.New("boom"))
+				errorsBefore = testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("pod_crash", "error"))
 			})
 
 			It("requeues the request", func() {
 				Expect(resultErr).To(MatchError(ContainSubstring("failed to create event")))
 			})
+
+			It("counts the failed reconcile", func() {
+				Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("pod_crash", "error"))).To(Equal(errorsBefore + 1))
+			})
 		})
 	})
 
@@ -281,7 +289,7 @@ var _ = Describe("K8s/Reconciler/AppCrash", func() {
 			})
 
 			Expect(eventsClient.GetByInstanceAndReasonCallCount()).To(Equal(2))
-			namespace, ownerRef, instanceIndex, reason := eventsClient.GetByInstanceAndReasonArgsForCall(0)
+			_, namespace, ownerRef, instanceIndex, reason := eventsClient.GetByInstanceAndReasonArgsForCall(0)
 			Expect(namespace).To(Equal("some-ns"))
 			Expect(ownerRef.Kind).To(Equal("LRP"))
 			Expect(ownerRef.Name).To(Equal("parent-lrp"))
@@ -289,7 +297,7 @@ var _ = Describe("K8s/Reconciler/AppCrash", func() {
 			Expect(reason).To(Equal("Container: Error"))
 
 			Expect(eventsClient.UpdateCallCount()).To(Equal(1))
-			ns, event := eventsClient.UpdateArgsForCall(0)
+			_, ns, event := eventsClient.UpdateArgsForCall(0)
 
 			Expect(ns).To(Equal("some-ns"))
 			Expect(event.Reason).To(Equal("Container: Error"))
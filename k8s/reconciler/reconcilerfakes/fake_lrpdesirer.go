@@ -2,6 +2,7 @@
 package reconcilerfakes
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -10,12 +11,13 @@ import (
 )
 
 type FakeLRPDesirer struct {
-	DesireStub        func(string, *opi.LRP, ...k8s.DesireOption) error
+	DesireStub        func(context.Context, string, *opi.LRP, ...k8s.DesireOption) error
 	desireMutex       sync.RWMutex
 	desireArgsForCall []struct {
-		arg1 string
-		arg2 *opi.LRP
-		arg3 []k8s.DesireOption
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.LRP
+		arg4 []k8s.DesireOption
 	}
 	desireReturns struct {
 		result1 error
@@ -23,10 +25,11 @@ type FakeLRPDesirer struct {
 	desireReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetStub        func(opi.LRPIdentifier) (*opi.LRP, error)
+	GetStub        func(context.Context, opi.LRPIdentifier) (*opi.LRP, error)
 	getMutex       sync.RWMutex
 	getArgsForCall []struct {
-		arg1 opi.LRPIdentifier
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
 	}
 	getReturns struct {
 		result1 *opi.LRP
@@ -36,35 +39,37 @@ type FakeLRPDesirer struct {
 		result1 *opi.LRP
 		result2 error
 	}
-	UpdateStub        func(*opi.LRP) error
-	updateMutex       sync.RWMutex
-	updateArgsForCall []struct {
-		arg1 *opi.LRP
+	UpdateSpecStub        func(context.Context, *opi.LRP) error
+	updateSpecMutex       sync.RWMutex
+	updateSpecArgsForCall []struct {
+		arg1 context.Context
+		arg2 *opi.LRP
 	}
-	updateReturns struct {
+	updateSpecReturns struct {
 		result1 error
 	}
-	updateReturnsOnCall map[int]struct {
+	updateSpecReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeLRPDesirer) Desire(arg1 string, arg2 *opi.LRP, arg3 ...k8s.DesireOption) error {
+func (fake *FakeLRPDesirer) Desire(arg1 context.Context, arg2 string, arg3 *opi.LRP, arg4 ...k8s.DesireOption) error {
 	fake.desireMutex.Lock()
 	ret, specificReturn := fake.desireReturnsOnCall[len(fake.desireArgsForCall)]
 	fake.desireArgsForCall = append(fake.desireArgsForCall, struct {
-		arg1 string
-		arg2 *opi.LRP
-		arg3 []k8s.DesireOption
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 string
+		arg3 *opi.LRP
+		arg4 []k8s.DesireOption
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.DesireStub
 	fakeReturns := fake.desireReturns
-	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("Desire", []interface{}{arg1, arg2, arg3, arg4})
 	fake.desireMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3...)
+		return stub(arg1, arg2, arg3, arg4...)
 	}
 	if specificReturn {
 		return ret.result1
@@ -78,17 +83,17 @@ func (fake *FakeLRPDesirer) DesireCallCount() int {
 	return len(fake.desireArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) DesireCalls(stub func(string, *opi.LRP, ...k8s.DesireOption) error) {
+func (fake *FakeLRPDesirer) DesireCalls(stub func(context.Context, string, *opi.LRP, ...k8s.DesireOption) error) {
 	fake.desireMutex.Lock()
 	defer fake.desireMutex.Unlock()
 	fake.DesireStub = stub
 }
 
-func (fake *FakeLRPDesirer) DesireArgsForCall(i int) (string, *opi.LRP, []k8s.DesireOption) {
+func (fake *FakeLRPDesirer) DesireArgsForCall(i int) (context.Context, string, *opi.LRP, []k8s.DesireOption) {
 	fake.desireMutex.RLock()
 	defer fake.desireMutex.RUnlock()
 	argsForCall := fake.desireArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeLRPDesirer) DesireReturns(result1 error) {
@@ -114,18 +119,19 @@ func (fake *FakeLRPDesirer) DesireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeLRPDesirer) Get(arg1 opi.LRPIdentifier) (*opi.LRP, error) {
+func (fake *FakeLRPDesirer) Get(arg1 context.Context, arg2 opi.LRPIdentifier) (*opi.LRP, error) {
 	fake.getMutex.Lock()
 	ret, specificReturn := fake.getReturnsOnCall[len(fake.getArgsForCall)]
 	fake.getArgsForCall = append(fake.getArgsForCall, struct {
-		arg1 opi.LRPIdentifier
-	}{arg1})
+		arg1 context.Context
+		arg2 opi.LRPIdentifier
+	}{arg1, arg2})
 	stub := fake.GetStub
 	fakeReturns := fake.getReturns
-	fake.recordInvocation("Get", []interface{}{arg1})
+	fake.recordInvocation("Get", []interface{}{arg1, arg2})
 	fake.getMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -139,17 +145,17 @@ func (fake *FakeLRPDesirer) GetCallCount() int {
 	return len(fake.getArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) GetCalls(stub func(opi.LRPIdentifier) (*opi.LRP, error)) {
+func (fake *FakeLRPDesirer) GetCalls(stub func(context.Context, opi.LRPIdentifier) (*opi.LRP, error)) {
 	fake.getMutex.Lock()
 	defer fake.getMutex.Unlock()
 	fake.GetStub = stub
 }
 
-func (fake *FakeLRPDesirer) GetArgsForCall(i int) opi.LRPIdentifier {
+func (fake *FakeLRPDesirer) GetArgsForCall(i int) (context.Context, opi.LRPIdentifier) {
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
 	argsForCall := fake.getArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeLRPDesirer) GetReturns(result1 *opi.LRP, result2 error) {
@@ -178,18 +184,19 @@ func (fake *FakeLRPDesirer) GetReturnsOnCall(i int, result1 *opi.LRP, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeLRPDesirer) Update(arg1 *opi.LRP) error {
-	fake.updateMutex.Lock()
-	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
-	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
-		arg1 *opi.LRP
-	}{arg1})
-	stub := fake.UpdateStub
-	fakeReturns := fake.updateReturns
-	fake.recordInvocation("Update", []interface{}{arg1})
-	fake.updateMutex.Unlock()
+func (fake *FakeLRPDesirer) UpdateSpec(arg1 context.Context, arg2 *opi.LRP) error {
+	fake.updateSpecMutex.Lock()
+	ret, specificReturn := fake.updateSpecReturnsOnCall[len(fake.updateSpecArgsForCall)]
+	fake.updateSpecArgsForCall = append(fake.updateSpecArgsForCall, struct {
+		arg1 context.Context
+		arg2 *opi.LRP
+	}{arg1, arg2})
+	stub := fake.UpdateSpecStub
+	fakeReturns := fake.updateSpecReturns
+	fake.recordInvocation("UpdateSpec", []interface{}{arg1, arg2})
+	fake.updateSpecMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -197,44 +204,44 @@ func (fake *FakeLRPDesirer) Update(arg1 *opi.LRP) error {
 	return fakeReturns.result1
 }
 
-func (fake *FakeLRPDesirer) UpdateCallCount() int {
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
-	return len(fake.updateArgsForCall)
+func (fake *FakeLRPDesirer) UpdateSpecCallCount() int {
+	fake.updateSpecMutex.RLock()
+	defer fake.updateSpecMutex.RUnlock()
+	return len(fake.updateSpecArgsForCall)
 }
 
-func (fake *FakeLRPDesirer) UpdateCalls(stub func(*opi.LRP) error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = stub
+func (fake *FakeLRPDesirer) UpdateSpecCalls(stub func(context.Context, *opi.LRP) error) {
+	fake.updateSpecMutex.Lock()
+	defer fake.updateSpecMutex.Unlock()
+	fake.UpdateSpecStub = stub
 }
 
-func (fake *FakeLRPDesirer) UpdateArgsForCall(i int) *opi.LRP {
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
-	argsForCall := fake.updateArgsForCall[i]
-	return argsForCall.arg1
+func (fake *FakeLRPDesirer) UpdateSpecArgsForCall(i int) (context.Context, *opi.LRP) {
+	fake.updateSpecMutex.RLock()
+	defer fake.updateSpecMutex.RUnlock()
+	argsForCall := fake.updateSpecArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeLRPDesirer) UpdateReturns(result1 error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = nil
-	fake.updateReturns = struct {
+func (fake *FakeLRPDesirer) UpdateSpecReturns(result1 error) {
+	fake.updateSpecMutex.Lock()
+	defer fake.updateSpecMutex.Unlock()
+	fake.UpdateSpecStub = nil
+	fake.updateSpecReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeLRPDesirer) UpdateReturnsOnCall(i int, result1 error) {
-	fake.updateMutex.Lock()
-	defer fake.updateMutex.Unlock()
-	fake.UpdateStub = nil
-	if fake.updateReturnsOnCall == nil {
-		fake.updateReturnsOnCall = make(map[int]struct {
+func (fake *FakeLRPDesirer) UpdateSpecReturnsOnCall(i int, result1 error) {
+	fake.updateSpecMutex.Lock()
+	defer fake.updateSpecMutex.Unlock()
+	fake.UpdateSpecStub = nil
+	if fake.updateSpecReturnsOnCall == nil {
+		fake.updateSpecReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.updateReturnsOnCall[i] = struct {
+	fake.updateSpecReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -246,8 +253,8 @@ func (fake *FakeLRPDesirer) Invocations() map[string][][]interface{} {
 	defer fake.desireMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
-	fake.updateMutex.RLock()
-	defer fake.updateMutex.RUnlock()
+	fake.updateSpecMutex.RLock()
+	defer fake.updateSpecMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
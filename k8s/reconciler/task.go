@@ -9,6 +9,9 @@ import (
 	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
 	"code.cloudfoundry.org/lager"
 	exterrors "github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,15 +39,23 @@ func NewTask(logger lager.Logger, client client.Client, taskDesirer TaskDesirer,
 }
 
 type TaskDesirer interface {
-	Desire(namespace string, task *opi.Task, opts ...k8s.DesireOption) error
+	Desire(ctx context.Context, namespace string, task *opi.Task, opts ...k8s.DesireOption) error
 }
 
 func (t *Task) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	result, err := t.reconcile(request)
+	observeReconcile("task", result, err)
+
+	return result, err
+}
+
+func (t *Task) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	ctx := context.Background()
 	task := &eiriniv1.Task{}
 	logger := t.logger.Session("reconcile-task", lager.Data{"request": request})
 	logger.Debug("start")
 
-	err := t.client.Get(context.Background(), request.NamespacedName, task)
+	err := t.client.Get(ctx, request.NamespacedName, task)
 	if errors.IsNotFound(err) {
 		logger.Error("no-such-task", err)
 
@@ -57,24 +68,69 @@ func (t *Task) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 		return reconcile.Result{}, fmt.Errorf("could not fetch task: %w", err)
 	}
 
-	err = t.taskDesirer.Desire(task.Namespace, toOpiTask(task), t.setOwnerFn(task))
+	err = t.taskDesirer.Desire(ctx, task.Namespace, toOpiTask(task), t.setOwnerFn(task))
 	if errors.IsAlreadyExists(err) {
 		logger.Info("task-already-exists")
-
-		return reconcile.Result{}, nil
-	}
-
-	if err != nil {
+	} else if err != nil {
 		logger.Error("desire-task-failed", err)
 
 		return reconcile.Result{}, exterrors.Wrap(err, "failed to desire task")
+	} else {
+		logger.Debug("task-desired-successfully")
 	}
 
-	logger.Debug("task-desired-successfully")
+	if err = t.updateStatus(ctx, task); err != nil {
+		logger.Error("update-task-status-failed", err)
+
+		return reconcile.Result{}, exterrors.Wrap(err, "failed to update task status")
+	}
 
 	return reconcile.Result{}, nil
 }
 
+// updateStatus records the progress and outcome of the task. The completion
+// callback acknowledgement belongs to the task-reporter, so it is left out of
+// the patch.
+func (t *Task) updateStatus(ctx context.Context, task *eiriniv1.Task) error {
+	jobs := &batchv1.JobList{}
+	if err := t.client.List(ctx, jobs,
+		client.InNamespace(task.Namespace),
+		client.MatchingLabels{k8s.LabelGUID: task.Spec.GUID},
+	); err != nil {
+		return exterrors.Wrap(err, "failed to list task jobs")
+	}
+
+	status := task.Status
+
+	switch len(jobs.Items) {
+	case 0:
+		// the job has either not been created yet or has already expired
+		if status.Phase == "" {
+			status.Phase = eiriniv1.TaskPending
+		}
+	default:
+		pods := &corev1.PodList{}
+		if err := t.client.List(ctx, pods,
+			client.InNamespace(task.Namespace),
+			client.MatchingLabels{k8s.LabelGUID: task.Spec.GUID, k8s.LabelSourceType: k8s.TaskSourceType},
+		); err != nil {
+			return exterrors.Wrap(err, "failed to list task pods")
+		}
+
+		status = k8s.ToTaskCRStatus(k8s.GetTaskStatus(jobs.Items[0], pods.Items))
+		status.CompletionCallbackAcked = task.Status.CompletionCallbackAcked
+	}
+
+	if equality.Semantic.DeepEqual(status, task.Status) {
+		return nil
+	}
+
+	original := task.DeepCopy()
+	task.Status = status
+
+	return t.client.Status().Patch(ctx, task, client.MergeFrom(original))
+}
+
 func (t *Task) setOwnerFn(task *eiriniv1.Task) func(interface{}) error {
 	return func(resource interface{}) error {
 		obj := resource.(metav1.Object)
@@ -103,6 +159,7 @@ func toOpiTask(task *eiriniv1.Task) *opi.Task {
 		MemoryMB:           task.Spec.MemoryMB,
 		DiskMB:             task.Spec.DiskMB,
 		CPUWeight:          task.Spec.CPUWeight,
+		PlacementTags:      task.Spec.PlacementTags,
 	}
 
 	if task.Spec.PrivateRegistry != nil {
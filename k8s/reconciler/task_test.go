@@ -12,12 +12,15 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -29,6 +32,7 @@ var _ = Describe("Task", func() {
 		controllerClient *reconcilerfakes.FakeClient
 		namespacedName   types.NamespacedName
 		taskDesirer      *reconcilerfakes.FakeTaskDesirer
+		statusWriter     *reconcilerfakes.FakeStatusWriter
 		scheme           *runtime.Scheme
 	)
 
@@ -39,6 +43,8 @@ var _ = Describe("Task", func() {
 			Name:      "my-name",
 		}
 		taskDesirer = new(reconcilerfakes.FakeTaskDesirer)
+		statusWriter = new(reconcilerfakes.FakeStatusWriter)
+		controllerClient.StatusReturns(statusWriter)
 
 		scheme = eiriniv1scheme.Scheme
 		logger := lagertest.NewTestLogger("task-reconciler")
@@ -77,6 +83,7 @@ var _ = Describe("Task", func() {
 				task.Spec.MemoryMB = 1234
 				task.Spec.DiskMB = 4312
 				task.Spec.CPUWeight = 14
+				task.Spec.PlacementTags = []string{"segment-a"}
 
 				return nil
 			}
@@ -96,7 +103,7 @@ var _ = Describe("Task", func() {
 
 			By("invoking the task desirer", func() {
 				Expect(taskDesirer.DesireCallCount()).To(Equal(1))
-				namespace, opiTask, _ := taskDesirer.DesireArgsForCall(0)
+				_, namespace, opiTask, _ := taskDesirer.DesireArgsForCall(0)
 				Expect(namespace).To(Equal("my-namespace"))
 				Expect(opiTask.GUID).To(Equal("my-task-guid"))
 				Expect(opiTask.Name).To(Equal("my-task-name"))
@@ -118,11 +125,12 @@ var _ = Describe("Task", func() {
 				Expect(opiTask.MemoryMB).To(BeNumerically("==", 1234))
 				Expect(opiTask.DiskMB).To(BeNumerically("==", 4312))
 				Expect(opiTask.CPUWeight).To(BeNumerically("==", 14))
+				Expect(opiTask.PlacementTags).To(ConsistOf("segment-a"))
 			})
 
 			By("sets an owner reference in the statefulset", func() {
 				Expect(taskDesirer.DesireCallCount()).To(Equal(1))
-				_, _, setOwnerFns := taskDesirer.DesireArgsForCall(0)
+				_, _, _, setOwnerFns := taskDesirer.DesireArgsForCall(0)
 				Expect(setOwnerFns).To(HaveLen(1))
 				setOwnerFn := setOwnerFns[0]
 
@@ -147,13 +155,20 @@ var _ = Describe("Task", func() {
 	})
 
 	When("getting the task returns another error", func() {
+		var errorsBefore float64
+
 		BeforeEach(func() {
 			controllerClient.GetReturns(fmt.Errorf("some problem"))
+			errorsBefore = testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("task", "error"))
 		})
 
 		It("returns an error", func() {
 			Expect(reconcileErr).To(MatchError(ContainSubstring("some problem")))
 		})
+
+		It("counts the failed reconcile", func() {
+			Expect(testutil.ToFloat64(reconciler.ReconcileResults.WithLabelValues("task", "error"))).To(Equal(errorsBefore + 1))
+		})
 	})
 
 	When("desiring the task returns an error", func() {
@@ -176,4 +191,166 @@ var _ = Describe("Task", func() {
 			Expect(reconcileErr).ToNot(HaveOccurred())
 		})
 	})
+
+	Describe("updating the task status", func() {
+		var (
+			jobs []batchv1.Job
+			pods []corev1.Pod
+		)
+
+		BeforeEach(func() {
+			jobs = []batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "the-job"}}}
+			pods = []corev1.Pod{}
+
+			controllerClient.GetStub = func(ctx context.Context, namespacedName types.NamespacedName, obj runtime.Object) error {
+				task, ok := obj.(*eiriniv1.Task)
+				Expect(ok).To(BeTrue())
+
+				task.Name = namespacedName.Name
+				task.Namespace = namespacedName.Namespace
+				task.Spec.GUID = "my-task-guid"
+
+				return nil
+			}
+
+			controllerClient.ListStub = func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+				switch l := list.(type) {
+				case *batchv1.JobList:
+					l.Items = jobs
+				case *corev1.PodList:
+					l.Items = pods
+				default:
+					Fail(fmt.Sprintf("unexpected list type %T", list))
+				}
+
+				return nil
+			}
+		})
+
+		It("looks up the task job and pods in the CR's namespace", func() {
+			Expect(controllerClient.ListCallCount()).To(Equal(2))
+
+			listOpts := &client.ListOptions{}
+			_, _, opts := controllerClient.ListArgsForCall(0)
+			listOpts.ApplyOptions(opts)
+			Expect(listOpts.Namespace).To(Equal("my-namespace"))
+			Expect(listOpts.LabelSelector.String()).To(Equal("cloudfoundry.org/guid=my-task-guid"))
+
+			listOpts = &client.ListOptions{}
+			_, _, opts = controllerClient.ListArgsForCall(1)
+			listOpts.ApplyOptions(opts)
+			Expect(listOpts.Namespace).To(Equal("my-namespace"))
+			Expect(listOpts.LabelSelector.String()).To(ContainSubstring("cloudfoundry.org/source_type=TASK"))
+		})
+
+		When("the task has not started yet", func() {
+			It("sets the phase to pending", func() {
+				Expect(statusWriter.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := statusWriter.PatchArgsForCall(0)
+				task := obj.(*eiriniv1.Task)
+				Expect(task.Status).To(Equal(eiriniv1.TaskStatus{Phase: eiriniv1.TaskPending}))
+			})
+		})
+
+		When("the task has failed", func() {
+			BeforeEach(func() {
+				pods = []corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"cloudfoundry.org/opi-task-container-name": "opi-task"},
+					},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name: "opi-task",
+							State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+								ExitCode:   2,
+								Reason:     "Error",
+								StartedAt:  metav1.Unix(100, 0),
+								FinishedAt: metav1.Unix(200, 0),
+							}},
+						}},
+					},
+				}}
+			})
+
+			It("records the outcome in the status", func() {
+				Expect(statusWriter.PatchCallCount()).To(Equal(1))
+				_, obj, _, _ := statusWriter.PatchArgsForCall(0)
+				task := obj.(*eiriniv1.Task)
+
+				exitCode := int32(2)
+				startTime := metav1.Unix(100, 0)
+				completionTime := metav1.Unix(200, 0)
+				Expect(task.Status).To(Equal(eiriniv1.TaskStatus{
+					Phase:          eiriniv1.TaskFailed,
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+					ExitCode:       &exitCode,
+					FailureReason:  "Error",
+				}))
+			})
+		})
+
+		When("the CC has acknowledged the completion", func() {
+			BeforeEach(func() {
+				getStub := controllerClient.GetStub
+				controllerClient.GetStub = func(ctx context.Context, namespacedName types.NamespacedName, obj runtime.Object) error {
+					Expect(getStub(ctx, namespacedName, obj)).To(Succeed())
+					obj.(*eiriniv1.Task).Status = eiriniv1.TaskStatus{CompletionCallbackAcked: true}
+
+					return nil
+				}
+			})
+
+			It("leaves the acknowledgement to the task-reporter", func() {
+				Expect(statusWriter.PatchCallCount()).To(Equal(1))
+				_, obj, patch, _ := statusWriter.PatchArgsForCall(0)
+				Expect(obj.(*eiriniv1.Task).Status.CompletionCallbackAcked).To(BeTrue())
+
+				data, err := patch.Data(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).NotTo(ContainSubstring("completionCallbackAcked"))
+			})
+		})
+
+		When("the job has expired", func() {
+			BeforeEach(func() {
+				jobs = []batchv1.Job{}
+				getStub := controllerClient.GetStub
+				controllerClient.GetStub = func(ctx context.Context, namespacedName types.NamespacedName, obj runtime.Object) error {
+					Expect(getStub(ctx, namespacedName, obj)).To(Succeed())
+					obj.(*eiriniv1.Task).Status = eiriniv1.TaskStatus{
+						Phase:                   eiriniv1.TaskSucceeded,
+						CompletionCallbackAcked: true,
+					}
+
+					return nil
+				}
+			})
+
+			It("keeps the last known status", func() {
+				Expect(statusWriter.PatchCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the jobs fails", func() {
+			BeforeEach(func() {
+				controllerClient.ListReturns(fmt.Errorf("boom"))
+				controllerClient.ListStub = nil
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError(ContainSubstring("failed to list task jobs")))
+			})
+		})
+
+		When("updating the status fails", func() {
+			BeforeEach(func() {
+				statusWriter.PatchReturns(fmt.Errorf("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError(ContainSubstring("failed to update task status")))
+			})
+		})
+	})
 })
@@ -0,0 +1,198 @@
+package k8s
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/eirini/k8s/utils"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RedactedValue replaces the data of rendered secrets.
+const RedactedValue = "<redacted>"
+
+// RenderedLRP holds the objects Desire would create for an LRP.
+type RenderedLRP struct {
+	StatefulSet         *appsv1.StatefulSet
+	PodDisruptionBudget *v1beta1.PodDisruptionBudget
+	NetworkPolicy       *networkingv1.NetworkPolicy
+	Secrets             []*corev1.Secret
+}
+
+// Objects returns the rendered objects in the order they are created in.
+func (r *RenderedLRP) Objects() []runtime.Object {
+	objects := []runtime.Object{}
+	for _, secret := range r.Secrets {
+		objects = append(objects, secret)
+	}
+
+	objects = append(objects, r.StatefulSet)
+
+	if r.PodDisruptionBudget != nil {
+		objects = append(objects, r.PodDisruptionBudget)
+	}
+
+	if r.NetworkPolicy != nil {
+		objects = append(objects, r.NetworkPolicy)
+	}
+
+	return objects
+}
+
+// RenderedTask holds the objects Desire would create for a task.
+type RenderedTask struct {
+	Job     *batch.Job
+	Secrets []*corev1.Secret
+}
+
+// Objects returns the rendered objects in the order they are created in.
+func (r *RenderedTask) Objects() []runtime.Object {
+	objects := []runtime.Object{}
+	for _, secret := range r.Secrets {
+		objects = append(objects, secret)
+	}
+
+	return append(objects, r.Job)
+}
+
+// Render builds the objects Desire would create for the LRP, with the options
+// applied, without talking to Kubernetes. Secret data is redacted.
+func (m *StatefulSetDesirer) Render(namespace string, lrp *opi.LRP, opts ...DesireOption) (*RenderedLRP, error) {
+	statefulSetName, err := utils.GetStatefulsetName(lrp)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &RenderedLRP{}
+
+	if lrp.PrivateRegistry != nil {
+		secret, err := m.generateRegistryCredsSecret(statefulSetName, lrp)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate private registry secret for statefulset")
+		}
+
+		rendered.Secrets = append(rendered.Secrets, renderSecret(namespace, secret))
+	}
+
+	st, err := m.toStatefulSet(statefulSetName, lrp)
+	if err != nil {
+		return nil, err
+	}
+
+	st.Namespace = namespace
+	st.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"}
+
+	if err := applyOpts(st, opts...); err != nil {
+		return nil, err
+	}
+
+	rendered.StatefulSet = st
+
+	if lrp.TargetInstances > 1 {
+		pdb := m.toPodDisruptionBudget(statefulSetName, lrp)
+		pdb.Namespace = namespace
+		pdb.TypeMeta = metav1.TypeMeta{APIVersion: "policy/v1beta1", Kind: "PodDisruptionBudget"}
+		rendered.PodDisruptionBudget = pdb
+	}
+
+	if len(lrp.EgressRules) > 0 {
+		networkPolicy, err := toNetworkPolicy(m.Logger, statefulSetName, lrp, m.labelSelector(lrp))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert egress rules to network policy")
+		}
+
+		if networkPolicy != nil {
+			networkPolicy.Namespace = namespace
+			networkPolicy.TypeMeta = metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"}
+			rendered.NetworkPolicy = networkPolicy
+		}
+	}
+
+	return rendered, nil
+}
+
+// Render builds the objects Desire would create for the task, with the
+// options applied, without talking to Kubernetes. Secret data is redacted and
+// the generated name of the image pull secret stands in for its final name.
+func (d *TaskDesirer) Render(namespace string, task *opi.Task, opts ...DesireOption) (*RenderedTask, error) {
+	logger := d.logger.Session("render", lager.Data{"guid": task.GUID, "name": task.Name, "namespace": namespace})
+
+	job, err := d.toTaskJob(task)
+	if err != nil {
+		logger.Error("failed-to-build-job", err)
+
+		return nil, err
+	}
+
+	rendered := &RenderedTask{}
+
+	if imageInPrivateRegistry(task) {
+		secret, err := toTaskSecret(task)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create task secret")
+		}
+
+		spec := &job.Spec.Template.Spec
+		spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{
+			Name: secret.GenerateName,
+		})
+
+		rendered.Secrets = append(rendered.Secrets, renderSecret(namespace, secret))
+	}
+
+	job.Namespace = namespace
+	job.TypeMeta = metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"}
+
+	for _, opt := range opts {
+		if err := opt(job); err != nil {
+			return nil, errors.Wrap(err, "failed to apply options")
+		}
+	}
+
+	rendered.Job = job
+
+	return rendered, nil
+}
+
+// ToList wraps rendered objects in a v1 List, which can be printed or
+// applied with kubectl.
+func ToList(objects []runtime.Object) (*corev1.List, error) {
+	list := &corev1.List{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "List"},
+		Items:    []runtime.RawExtension{},
+	}
+
+	for _, object := range objects {
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal rendered object")
+		}
+
+		list.Items = append(list.Items, runtime.RawExtension{Raw: raw})
+	}
+
+	return list, nil
+}
+
+func renderSecret(namespace string, secret *corev1.Secret) *corev1.Secret {
+	secret.Namespace = namespace
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+
+	for key := range secret.StringData {
+		secret.StringData[key] = RedactedValue
+	}
+
+	for key := range secret.Data {
+		secret.Data[key] = []byte(RedactedValue)
+	}
+
+	return secret
+}
@@ -0,0 +1,231 @@
+package k8s_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
+	"code.cloudfoundry.org/eirini/k8s/utils/dockerutils"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Render", func() {
+	Describe("StatefulSetDesirer", func() {
+		var (
+			statefulSetClient *k8sfakes.FakeStatefulSetClient
+			secretsClient     *k8sfakes.FakeSecretsCreatorDeleter
+			pdbClient         *k8sfakes.FakePodDisruptionBudgetClient
+			desirer           *k8s.StatefulSetDesirer
+			desireOpt         *k8sfakes.FakeDesireOption
+			lrp               *opi.LRP
+			rendered          *k8s.RenderedLRP
+			renderErr         error
+		)
+
+		BeforeEach(func() {
+			statefulSetClient = new(k8sfakes.FakeStatefulSetClient)
+			secretsClient = new(k8sfakes.FakeSecretsCreatorDeleter)
+			pdbClient = new(k8sfakes.FakePodDisruptionBudgetClient)
+			livenessProbeCreator := new(k8sfakes.FakeProbeCreator)
+			readinessProbeCreator := new(k8sfakes.FakeProbeCreator)
+
+			desirer = &k8s.StatefulSetDesirer{
+				Secrets:                   secretsClient,
+				StatefulSets:              statefulSetClient,
+				PodDisruptionBudgets:      pdbClient,
+				RegistrySecretName:        registrySecretName,
+				LivenessProbeCreator:      livenessProbeCreator.Spy,
+				ReadinessProbeCreator:     readinessProbeCreator.Spy,
+				Logger:                    lagertest.NewTestLogger("render"),
+				ApplicationServiceAccount: "eirini",
+			}
+
+			desireOpt = new(k8sfakes.FakeDesireOption)
+			lrp = createLRP("Baldur", []opi.Route{{Hostname: "my.example.route", Port: 1000}})
+		})
+
+		JustBeforeEach(func() {
+			rendered, renderErr = desirer.Render("the-namespace", lrp, desireOpt.Spy)
+		})
+
+		It("renders the statefulset", func() {
+			Expect(renderErr).NotTo(HaveOccurred())
+			Expect(rendered.StatefulSet.Kind).To(Equal("StatefulSet"))
+			Expect(rendered.StatefulSet.APIVersion).To(Equal("apps/v1"))
+			Expect(rendered.StatefulSet.Namespace).To(Equal("the-namespace"))
+			Expect(rendered.StatefulSet.Labels).To(HaveKeyWithValue(k8s.LabelGUID, "guid_1234"))
+		})
+
+		It("applies the options to the statefulset", func() {
+			Expect(desireOpt.CallCount()).To(Equal(1))
+			Expect(desireOpt.ArgsForCall(0)).To(Equal(rendered.StatefulSet))
+		})
+
+		It("does not render a pod disruption budget for a single instance", func() {
+			Expect(rendered.PodDisruptionBudget).To(BeNil())
+		})
+
+		It("does not create anything", func() {
+			Expect(statefulSetClient.CreateCallCount()).To(Equal(0))
+			Expect(secretsClient.CreateCallCount()).To(Equal(0))
+			Expect(pdbClient.CreateCallCount()).To(Equal(0))
+		})
+
+		When("the LRP has more than one instance", func() {
+			BeforeEach(func() {
+				lrp.TargetInstances = 2
+			})
+
+			It("renders a pod disruption budget", func() {
+				Expect(rendered.PodDisruptionBudget).NotTo(BeNil())
+				Expect(rendered.PodDisruptionBudget.Kind).To(Equal("PodDisruptionBudget"))
+				Expect(rendered.PodDisruptionBudget.Name).To(Equal(rendered.StatefulSet.Name))
+				Expect(rendered.PodDisruptionBudget.Namespace).To(Equal("the-namespace"))
+			})
+		})
+
+		When("the image is in a private registry", func() {
+			BeforeEach(func() {
+				lrp.PrivateRegistry = &opi.PrivateRegistry{
+					Server:   "registry.example.com",
+					Username: "user",
+					Password: "password",
+				}
+			})
+
+			It("renders the registry secret with its data redacted", func() {
+				Expect(rendered.Secrets).To(HaveLen(1))
+				Expect(rendered.Secrets[0].Kind).To(Equal("Secret"))
+				Expect(rendered.Secrets[0].Namespace).To(Equal("the-namespace"))
+				Expect(rendered.Secrets[0].StringData).To(Equal(map[string]string{dockerutils.DockerConfigKey: k8s.RedactedValue}))
+			})
+
+			It("references the secret from the statefulset", func() {
+				Expect(rendered.StatefulSet.Spec.Template.Spec.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: rendered.Secrets[0].Name}))
+			})
+
+			It("lists the secret before the statefulset", func() {
+				objects := rendered.Objects()
+				Expect(objects).To(HaveLen(2))
+				Expect(objects[0]).To(BeAssignableToTypeOf(&corev1.Secret{}))
+				Expect(objects[1]).To(BeAssignableToTypeOf(&appsv1.StatefulSet{}))
+			})
+		})
+
+		When("an option fails", func() {
+			BeforeEach(func() {
+				desireOpt.Returns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(renderErr).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+	})
+
+	Describe("TaskDesirer", func() {
+		var (
+			jobClient      *k8sfakes.FakeJobCreatingClient
+			secretsCreator *k8sfakes.FakeSecretsCreator
+			desirer        *k8s.TaskDesirer
+			desireOpt      *k8sfakes.FakeDesireOption
+			task           *opi.Task
+			rendered       *k8s.RenderedTask
+			renderErr      error
+		)
+
+		BeforeEach(func() {
+			jobClient = new(k8sfakes.FakeJobCreatingClient)
+			secretsCreator = new(k8sfakes.FakeSecretsCreator)
+			desirer = k8s.NewTaskDesirer(
+				lagertest.NewTestLogger("render"),
+				jobClient,
+				nil,
+				secretsCreator,
+				"service-account",
+				"registry-secret",
+				false,
+				nil,
+				"droplet/downloader",
+			)
+
+			desireOpt = new(k8sfakes.FakeDesireOption)
+			task = &opi.Task{
+				GUID:      "task-guid",
+				Name:      "task-name",
+				AppName:   "my-app",
+				SpaceName: "my-space",
+				Image:     "docker.png",
+			}
+		})
+
+		JustBeforeEach(func() {
+			rendered, renderErr = desirer.Render("the-namespace", task, desireOpt.Spy)
+		})
+
+		It("renders the job", func() {
+			Expect(renderErr).NotTo(HaveOccurred())
+			Expect(rendered.Job.Kind).To(Equal("Job"))
+			Expect(rendered.Job.APIVersion).To(Equal("batch/v1"))
+			Expect(rendered.Job.Namespace).To(Equal("the-namespace"))
+			Expect(rendered.Job.Labels).To(HaveKeyWithValue(k8s.LabelGUID, "task-guid"))
+		})
+
+		It("applies the options to the job", func() {
+			Expect(desireOpt.CallCount()).To(Equal(1))
+			Expect(desireOpt.ArgsForCall(0)).To(Equal(rendered.Job))
+		})
+
+		It("does not create anything", func() {
+			Expect(jobClient.CreateCallCount()).To(Equal(0))
+			Expect(secretsCreator.CreateCallCount()).To(Equal(0))
+		})
+
+		When("the image is in a private registry", func() {
+			BeforeEach(func() {
+				task.PrivateRegistry = &opi.PrivateRegistry{
+					Server:   "registry.example.com",
+					Username: "user",
+					Password: "password",
+				}
+			})
+
+			It("renders the registry secret with its data redacted", func() {
+				Expect(rendered.Secrets).To(HaveLen(1))
+				Expect(rendered.Secrets[0].Namespace).To(Equal("the-namespace"))
+				Expect(rendered.Secrets[0].StringData).To(Equal(map[string]string{dockerutils.DockerConfigKey: k8s.RedactedValue}))
+			})
+
+			It("references the secret by its generated name", func() {
+				Expect(rendered.Job.Spec.Template.Spec.ImagePullSecrets).To(ContainElement(corev1.LocalObjectReference{Name: rendered.Secrets[0].GenerateName}))
+			})
+		})
+	})
+
+	Describe("ToList", func() {
+		It("wraps the objects in a list", func() {
+			job := &batch.Job{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+				ObjectMeta: metav1.ObjectMeta{Name: "the-job"},
+			}
+
+			list, err := k8s.ToList([]runtime.Object{job})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Kind).To(Equal("List"))
+			Expect(list.Items).To(HaveLen(1))
+
+			var decoded batch.Job
+			Expect(json.Unmarshal(list.Items[0].Raw, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(*job))
+		})
+	})
+})
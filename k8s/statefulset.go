@@ -1,19 +1,26 @@
 package k8s
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s/utils"
 	"code.cloudfoundry.org/eirini/k8s/utils/dockerutils"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/eirini/util"
 	"code.cloudfoundry.org/lager"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/api/policy/v1beta1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -37,14 +44,21 @@ const (
 	AnnotationLastUpdated                    = "cloudfoundry.org/last_updated"
 	AnnotationProcessGUID                    = "cloudfoundry.org/process_guid"
 	AnnotationRegisteredRoutes               = "cloudfoundry.org/routes"
+	AnnotationEgressRules                    = "cloudfoundry.org/egress_rules"
+	AnnotationHealthCheck                    = "cloudfoundry.org/health_check"
+	AnnotationPlacementTags                  = "cloudfoundry.org/placement_tags"
 	AnnotationOriginalRequest                = "cloudfoundry.org/original_request"
 	AnnotationCompletionCallback             = "cloudfoundry.org/completion_callback"
 	AnnotationOpiTaskContainerName           = "cloudfoundry.org/opi-task-container-name"
 	AnnotationOpiTaskCompletionReportCounter = "cloudfoundry.org/task_completion_report_counter"
 	AnnotationCCAckedTaskCompletion          = "cloudfoundry.org/cc_acked_task_completion"
 	AnnotationGUID                           = "cloudfoundry.org/guid"
+	AnnotationRestartedAt                    = "cloudfoundry.org/restarted_at"
+	AnnotationRestartGeneration              = "cloudfoundry.org/restart_generation"
 
-	AppSourceType = "APP"
+	AppSourceType     = "APP"
+	TaskSourceType    = "TASK"
+	StagingSourceType = "STG"
 
 	AnnotationStagingGUID = "cloudfoundry.org/staging_guid"
 
@@ -72,7 +86,9 @@ const (
 
 //counterfeiter:generate . PodClient
 //counterfeiter:generate . PodDisruptionBudgetClient
+//counterfeiter:generate . NetworkPolicyClient
 //counterfeiter:generate . StatefulSetClient
+//counterfeiter:generate . StatefulSetLister
 //counterfeiter:generate . SecretsCreatorDeleter
 //counterfeiter:generate . EventsClient
 //counterfeiter:generate . LRPMapper
@@ -81,31 +97,47 @@ const (
 //counterfeiter:generate . StatefulSetClient
 
 type PodClient interface {
-	GetAll() ([]corev1.Pod, error)
-	GetByLRPIdentifier(opi.LRPIdentifier) ([]corev1.Pod, error)
-	Delete(namespace, name string) error
+	GetAll(ctx context.Context) ([]corev1.Pod, error)
+	GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]corev1.Pod, error)
+	GetLogs(ctx context.Context, namespace, name string, options *corev1.PodLogOptions) (io.ReadCloser, error)
+	Delete(ctx context.Context, namespace, name string) error
 }
 
 type PodDisruptionBudgetClient interface {
-	Create(namespace string, podDisruptionBudget *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error)
-	Delete(namespace string, name string) error
+	Create(ctx context.Context, namespace string, podDisruptionBudget *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error)
+	Delete(ctx context.Context, namespace string, name string) error
+}
+
+type NetworkPolicyClient interface {
+	Create(ctx context.Context, namespace string, networkPolicy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error)
+	Update(ctx context.Context, namespace string, networkPolicy *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error)
+	Delete(ctx context.Context, namespace string, name string) error
 }
 
 type StatefulSetClient interface {
-	Create(namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
-	Update(namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
-	Delete(namespace string, name string) error
-	GetBySourceType(sourceType string) ([]appsv1.StatefulSet, error)
-	GetByLRPIdentifier(id opi.LRPIdentifier) ([]appsv1.StatefulSet, error)
+	Create(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
+	Update(ctx context.Context, namespace string, statefulSet *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
+	Delete(ctx context.Context, namespace string, name string) error
+	GetBySourceType(ctx context.Context, sourceType string) ([]appsv1.StatefulSet, error)
+	GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]appsv1.StatefulSet, error)
+}
+
+// StatefulSetLister lists statefulsets, possibly from a cache that lags
+// behind the API server.
+type StatefulSetLister interface {
+	GetBySourceType(ctx context.Context, sourceType string) ([]appsv1.StatefulSet, error)
+	GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]appsv1.StatefulSet, error)
 }
 
 type SecretsCreatorDeleter interface {
-	Create(namespace string, secret *corev1.Secret) (*corev1.Secret, error)
-	Delete(namespace string, name string) error
+	Get(ctx context.Context, namespace, name string) (*corev1.Secret, error)
+	Create(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error)
+	Update(ctx context.Context, namespace string, secret *corev1.Secret) (*corev1.Secret, error)
+	Delete(ctx context.Context, namespace string, name string) error
 }
 
 type EventsClient interface {
-	GetByPod(pod corev1.Pod) ([]corev1.Event, error)
+	GetByPod(ctx context.Context, pod corev1.Pod) ([]corev1.Event, error)
 }
 
 type LRPMapper func(s appsv1.StatefulSet) (*opi.LRP, error)
@@ -115,6 +147,7 @@ type StatefulSetDesirer struct {
 	Secrets                           SecretsCreatorDeleter
 	StatefulSets                      StatefulSetClient
 	PodDisruptionBudgets              PodDisruptionBudgetClient
+	NetworkPolicies                   NetworkPolicyClient
 	EventsClient                      EventsClient
 	StatefulSetToLRPMapper            LRPMapper
 	RegistrySecretName                string
@@ -123,13 +156,22 @@ type StatefulSetDesirer struct {
 	Logger                            lager.Logger
 	ApplicationServiceAccount         string
 	AllowAutomountServiceAccountToken bool
+	PlacementTags                     map[string]eirini.PlacementTag
+	DropletDownloaderImage            string
+	// PodUsage is optional; without it instance stats have no usage.
+	PodUsage PodUsageCollector
+	// StatefulSetLister is optional; when set, List, Get, GetInstances,
+	// GetInstanceStats and GetLogs read statefulsets from it. Operations
+	// that update the statefulsets they read always use StatefulSets, so
+	// that they never act on stale statefulsets.
+	StatefulSetLister StatefulSetLister
 }
 
 type ProbeCreator func(lrp *opi.LRP) *corev1.Probe
 
 type DesireOption func(resource interface{}) error
 
-func (m *StatefulSetDesirer) Desire(namespace string, lrp *opi.LRP, opts ...DesireOption) error {
+func (m *StatefulSetDesirer) Desire(ctx context.Context, namespace string, lrp *opi.LRP, opts ...DesireOption) error {
 	logger := m.Logger.Session("desire", lager.Data{"guid": lrp.GUID, "version": lrp.Version, "namespace": namespace})
 
 	statefulSetName, err := utils.GetStatefulsetName(lrp)
@@ -138,7 +180,7 @@ func (m *StatefulSetDesirer) Desire(namespace string, lrp *opi.LRP, opts ...Desi
 	}
 
 	if lrp.PrivateRegistry != nil {
-		err = m.createRegistryCredsSecret(namespace, statefulSetName, lrp)
+		err = m.createRegistryCredsSecret(ctx, namespace, statefulSetName, lrp)
 		if err != nil {
 			return err
 		}
@@ -150,13 +192,25 @@ func (m *StatefulSetDesirer) Desire(namespace string, lrp *opi.LRP, opts ...Desi
 	}
 
 	st.Namespace = namespace
+	tracing.InjectAnnotations(ctx, st.Annotations)
 
 	err = applyOpts(st, opts...)
 	if err != nil {
 		return err
 	}
 
-	if _, err := m.StatefulSets.Create(namespace, st); err != nil {
+	// The network policy is in place before the statefulset, so that the
+	// app never runs with unrestricted egress, even if Desire is retried
+	// after the statefulset has been created.
+	if len(lrp.EgressRules) > 0 {
+		if err := m.syncNetworkPolicy(ctx, logger, namespace, statefulSetName, lrp); err != nil {
+			logger.Error("failed-to-create-network-policy", err)
+
+			return err
+		}
+	}
+
+	if _, err := m.StatefulSets.Create(ctx, namespace, st); err != nil {
 		var statusErr *k8serrors.StatusError
 		if errors.As(err, &statusErr) && statusErr.Status().Reason == metav1.StatusReasonAlreadyExists {
 			logger.Debug("statefulset-already-exists", lager.Data{"error": err.Error()})
@@ -167,7 +221,7 @@ func (m *StatefulSetDesirer) Desire(namespace string, lrp *opi.LRP, opts ...Desi
 		return errors.Wrap(err, "failed to create statefulset")
 	}
 
-	if err := m.createPodDisruptionBudget(namespace, statefulSetName, lrp); err != nil {
+	if err := m.createPodDisruptionBudget(ctx, namespace, statefulSetName, lrp); err != nil {
 		logger.Error("failed-to-create-pod-disruption-budget", err)
 
 		return errors.Wrap(err, "failed to create pod disruption budget")
@@ -176,10 +230,10 @@ func (m *StatefulSetDesirer) Desire(namespace string, lrp *opi.LRP, opts ...Desi
 	return nil
 }
 
-func (m *StatefulSetDesirer) List() ([]*opi.LRP, error) {
+func (m *StatefulSetDesirer) List(ctx context.Context) ([]*opi.LRP, error) {
 	logger := m.Logger.Session("list")
 
-	statefulsets, err := m.StatefulSets.GetBySourceType(AppSourceType)
+	statefulsets, err := m.statefulSetLister().GetBySourceType(ctx, AppSourceType)
 	if err != nil {
 		logger.Error("failed-to-list-statefulsets", err)
 
@@ -196,17 +250,17 @@ func (m *StatefulSetDesirer) List() ([]*opi.LRP, error) {
 	return lrps, nil
 }
 
-func (m *StatefulSetDesirer) Stop(identifier opi.LRPIdentifier) error {
+func (m *StatefulSetDesirer) Stop(ctx context.Context, identifier opi.LRPIdentifier) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return m.stop(identifier)
+		return m.stop(ctx, identifier)
 	})
 
 	return errors.Wrap(err, "failed to delete statefulset")
 }
 
-func (m *StatefulSetDesirer) stop(identifier opi.LRPIdentifier) error {
+func (m *StatefulSetDesirer) stop(ctx context.Context, identifier opi.LRPIdentifier) error {
 	logger := m.Logger.Session("stop", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
-	statefulSet, err := m.getStatefulSet(identifier)
+	statefulSet, err := m.getStatefulSet(ctx, identifier)
 
 	if errors.Is(err, eirini.ErrNotFound) {
 		logger.Debug("statefulset-does-not-exist")
@@ -220,21 +274,28 @@ func (m *StatefulSetDesirer) stop(identifier opi.LRPIdentifier) error {
 		return err
 	}
 
-	err = m.PodDisruptionBudgets.Delete(statefulSet.Namespace, statefulSet.Name)
+	err = m.PodDisruptionBudgets.Delete(ctx, statefulSet.Namespace, statefulSet.Name)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		logger.Error("failed-to-delete-disruption-budget", err)
 
 		return errors.Wrap(err, "failed to delete pod disruption budget")
 	}
 
-	err = m.deletePrivateRegistrySecret(statefulSet)
+	err = m.NetworkPolicies.Delete(ctx, statefulSet.Namespace, statefulSet.Name)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error("failed-to-delete-network-policy", err)
+
+		return errors.Wrap(err, "failed to delete network policy")
+	}
+
+	err = m.deletePrivateRegistrySecret(ctx, statefulSet)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		logger.Error("failed-to-delete-private-registry-secret", err)
 
 		return err
 	}
 
-	if err := m.StatefulSets.Delete(statefulSet.Namespace, statefulSet.Name); err != nil {
+	if err := m.StatefulSets.Delete(ctx, statefulSet.Namespace, statefulSet.Name); err != nil {
 		logger.Error("failed-to-delete-statefulset", err)
 
 		return errors.Wrap(err, "failed to delete statefulset")
@@ -243,19 +304,19 @@ func (m *StatefulSetDesirer) stop(identifier opi.LRPIdentifier) error {
 	return nil
 }
 
-func (m *StatefulSetDesirer) deletePrivateRegistrySecret(statefulSet *appsv1.StatefulSet) error {
+func (m *StatefulSetDesirer) deletePrivateRegistrySecret(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
 	for _, secret := range statefulSet.Spec.Template.Spec.ImagePullSecrets {
 		if secret.Name == m.privateRegistrySecretName(statefulSet.Name) {
-			return m.Secrets.Delete(statefulSet.Namespace, secret.Name)
+			return m.Secrets.Delete(ctx, statefulSet.Namespace, secret.Name)
 		}
 	}
 
 	return nil
 }
 
-func (m *StatefulSetDesirer) StopInstance(identifier opi.LRPIdentifier, index uint) error {
+func (m *StatefulSetDesirer) StopInstance(ctx context.Context, identifier opi.LRPIdentifier, index uint) error {
 	logger := m.Logger.Session("stopInstance", lager.Data{"guid": identifier.GUID, "version": identifier.Version, "index": index})
-	statefulset, err := m.getStatefulSet(identifier)
+	statefulset, err := m.getStatefulSet(ctx, identifier)
 
 	if errors.Is(err, eirini.ErrNotFound) {
 		logger.Debug("statefulset-does-not-exist")
@@ -273,7 +334,7 @@ func (m *StatefulSetDesirer) StopInstance(identifier opi.LRPIdentifier, index ui
 		return eirini.ErrInvalidInstanceIndex
 	}
 
-	err = m.Pods.Delete(statefulset.Namespace, fmt.Sprintf("%s-%d", statefulset.Name, index))
+	err = m.Pods.Delete(ctx, statefulset.Namespace, fmt.Sprintf("%s-%d", statefulset.Name, index))
 	if k8serrors.IsNotFound(err) {
 		logger.Debug("pod-does-not-exist")
 
@@ -283,18 +344,18 @@ func (m *StatefulSetDesirer) StopInstance(identifier opi.LRPIdentifier, index ui
 	return errors.Wrap(err, "failed to delete pod")
 }
 
-func (m *StatefulSetDesirer) Update(lrp *opi.LRP) error {
+func (m *StatefulSetDesirer) Update(ctx context.Context, lrp *opi.LRP) error {
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		return m.update(lrp)
+		return m.update(ctx, lrp)
 	})
 
 	return errors.Wrap(err, "failed to update statefulset")
 }
 
-func (m *StatefulSetDesirer) update(lrp *opi.LRP) error {
+func (m *StatefulSetDesirer) update(ctx context.Context, lrp *opi.LRP) error {
 	logger := m.Logger.Session("update", lager.Data{"guid": lrp.GUID, "version": lrp.Version})
 
-	statefulSet, err := m.getStatefulSet(opi.LRPIdentifier{GUID: lrp.GUID, Version: lrp.Version})
+	statefulSet, err := m.getStatefulSet(ctx, opi.LRPIdentifier{GUID: lrp.GUID, Version: lrp.Version})
 	if err != nil {
 		logger.Error("failed-to-get-statefulset", err)
 
@@ -303,6 +364,7 @@ func (m *StatefulSetDesirer) update(lrp *opi.LRP) error {
 
 	updatedStatefulSet, err := m.getUpdatedStatefulSetObj(statefulSet,
 		lrp.AppURIs,
+		lrp.EgressRules,
 		lrp.TargetInstances,
 		lrp.LastUpdated,
 		lrp.Image,
@@ -313,28 +375,120 @@ func (m *StatefulSetDesirer) update(lrp *opi.LRP) error {
 		return err
 	}
 
-	_, err = m.StatefulSets.Update(updatedStatefulSet.Namespace, updatedStatefulSet)
+	_, err = m.StatefulSets.Update(ctx, updatedStatefulSet.Namespace, updatedStatefulSet)
 	if err != nil {
 		logger.Error("failed-to-update-statefulset", err, lager.Data{"namespace": statefulSet.Namespace})
 
 		return errors.Wrap(err, "failed to update statefulset")
 	}
 
-	return m.handlePodDisruptionBudget(logger,
+	err = m.handlePodDisruptionBudget(ctx, logger,
 		statefulSet.Namespace,
 		statefulSet.Name,
 		lrp,
 	)
+	if err != nil {
+		return err
+	}
+
+	return m.syncNetworkPolicy(ctx, logger, statefulSet.Namespace, statefulSet.Name, lrp)
+}
+
+// UpdateSpec reconciles the statefulset of an LRP against its full spec.
+// Unlike Update, which only applies the fields the cloud controller is able
+// to change, the whole pod template is regenerated, so changing e.g. the
+// environment, resources or health check triggers a rolling update.
+func (m *StatefulSetDesirer) UpdateSpec(ctx context.Context, lrp *opi.LRP) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return m.updateSpec(ctx, lrp)
+	})
+
+	return errors.Wrap(err, "failed to update statefulset spec")
+}
+
+func (m *StatefulSetDesirer) updateSpec(ctx context.Context, lrp *opi.LRP) error {
+	logger := m.Logger.Session("update-spec", lager.Data{"guid": lrp.GUID, "version": lrp.Version})
+
+	statefulSet, err := m.getStatefulSet(ctx, opi.LRPIdentifier{GUID: lrp.GUID, Version: lrp.Version})
+	if err != nil {
+		logger.Error("failed-to-get-statefulset", err)
+
+		return err
+	}
+
+	updatedStatefulSet, err := m.getUpdatedStatefulSetSpecObj(statefulSet, lrp)
+	if err != nil {
+		logger.Error("failed-to-get-updated-statefulset", err)
+
+		return err
+	}
+
+	if lrp.PrivateRegistry != nil {
+		if err = m.createOrUpdateRegistryCredsSecret(ctx, statefulSet.Namespace, statefulSet.Name, lrp); err != nil {
+			logger.Error("failed-to-sync-private-registry-secret", err, lager.Data{"namespace": statefulSet.Namespace})
+
+			return err
+		}
+	}
+
+	if _, err = m.StatefulSets.Update(ctx, updatedStatefulSet.Namespace, updatedStatefulSet); err != nil {
+		logger.Error("failed-to-update-statefulset", err, lager.Data{"namespace": statefulSet.Namespace})
+
+		return errors.Wrap(err, "failed to update statefulset")
+	}
+
+	if err = m.handlePodDisruptionBudget(ctx, logger, statefulSet.Namespace, statefulSet.Name, lrp); err != nil {
+		return err
+	}
+
+	return m.syncNetworkPolicy(ctx, logger, statefulSet.Namespace, statefulSet.Name, lrp)
+}
+
+// Restart replaces the instances of an LRP one by one by stamping the time of
+// the restart on the pod template. The statefulset controller deletes the
+// instances in reverse index order and waits for each replacement to be
+// running and ready before moving on, so at most one instance is down at a
+// time, which is what the pod disruption budget allows.
+func (m *StatefulSetDesirer) Restart(ctx context.Context, identifier opi.LRPIdentifier) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return m.restart(ctx, identifier)
+	})
+
+	return errors.Wrap(err, "failed to restart statefulset")
+}
+
+func (m *StatefulSetDesirer) restart(ctx context.Context, identifier opi.LRPIdentifier) error {
+	logger := m.Logger.Session("restart", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
+
+	statefulSet, err := m.getStatefulSet(ctx, identifier)
+	if err != nil {
+		logger.Error("failed-to-get-statefulset", err)
+
+		return err
+	}
+
+	restartedSts := statefulSet.DeepCopy()
+	restartedSts.Spec.Template.Annotations = mergeStringMaps(restartedSts.Spec.Template.Annotations, map[string]string{
+		AnnotationRestartedAt: time.Now().UTC().Format(time.RFC3339Nano),
+	})
+
+	if _, err = m.StatefulSets.Update(ctx, restartedSts.Namespace, restartedSts); err != nil {
+		logger.Error("failed-to-update-statefulset", err, lager.Data{"namespace": statefulSet.Namespace})
+
+		return errors.Wrap(err, "failed to update statefulset")
+	}
+
+	return nil
 }
 
-func (m *StatefulSetDesirer) Get(identifier opi.LRPIdentifier) (*opi.LRP, error) {
+func (m *StatefulSetDesirer) Get(ctx context.Context, identifier opi.LRPIdentifier) (*opi.LRP, error) {
 	logger := m.Logger.Session("get", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
 
-	return m.getLRP(logger, identifier)
+	return m.getLRP(ctx, logger, identifier)
 }
 
-func (m *StatefulSetDesirer) getLRP(logger lager.Logger, identifier opi.LRPIdentifier) (*opi.LRP, error) {
-	statefulset, err := m.getStatefulSet(identifier)
+func (m *StatefulSetDesirer) getLRP(ctx context.Context, logger lager.Logger, identifier opi.LRPIdentifier) (*opi.LRP, error) {
+	statefulset, err := findStatefulSet(ctx, m.statefulSetLister(), identifier)
 	if err != nil {
 		logger.Error("failed-to-get-statefulset", err)
 
@@ -348,11 +502,83 @@ func (m *StatefulSetDesirer) getLRP(logger lager.Logger, identifier opi.LRPIdent
 		return nil, err
 	}
 
+	lrp.PrivateRegistry, err = m.getPrivateRegistry(ctx, logger, statefulset)
+	if err != nil {
+		logger.Error("failed-to-get-private-registry", err)
+
+		return nil, err
+	}
+
 	return lrp, nil
 }
 
-func (m *StatefulSetDesirer) getStatefulSet(identifier opi.LRPIdentifier) (*appsv1.StatefulSet, error) {
-	statefulSets, err := m.StatefulSets.GetByLRPIdentifier(identifier)
+// getPrivateRegistry reads the private registry credentials back from the
+// image pull secret eirini creates for the statefulset, if there is one. A
+// secret that has gone missing leaves the LRP without registry credentials
+// rather than making it unreadable.
+func (m *StatefulSetDesirer) getPrivateRegistry(ctx context.Context, logger lager.Logger, statefulSet *appsv1.StatefulSet) (*opi.PrivateRegistry, error) {
+	secretName := m.privateRegistrySecretName(statefulSet.Name)
+
+	if !hasImagePullSecret(statefulSet, secretName) {
+		return nil, nil
+	}
+
+	secret, err := m.Secrets.Get(ctx, statefulSet.Namespace, secretName)
+	if k8serrors.IsNotFound(err) {
+		logger.Info("private-registry-secret-not-found", lager.Data{"secret-name": secretName})
+
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get private registry secret")
+	}
+
+	configJSON, ok := secret.Data[dockerutils.DockerConfigKey]
+	if !ok {
+		configJSON = []byte(secret.StringData[dockerutils.DockerConfigKey])
+	}
+
+	config, err := dockerutils.ParseConfig(configJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private registry secret")
+	}
+
+	for server, auth := range config.Auths {
+		return &opi.PrivateRegistry{
+			Server:   server,
+			Username: auth.User,
+			Password: auth.Password,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func hasImagePullSecret(statefulSet *appsv1.StatefulSet, name string) bool {
+	for _, secret := range statefulSet.Spec.Template.Spec.ImagePullSecrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *StatefulSetDesirer) statefulSetLister() StatefulSetLister {
+	if m.StatefulSetLister != nil {
+		return m.StatefulSetLister
+	}
+
+	return m.StatefulSets
+}
+
+func (m *StatefulSetDesirer) getStatefulSet(ctx context.Context, identifier opi.LRPIdentifier) (*appsv1.StatefulSet, error) {
+	return findStatefulSet(ctx, m.StatefulSets, identifier)
+}
+
+func findStatefulSet(ctx context.Context, lister StatefulSetLister, identifier opi.LRPIdentifier) (*appsv1.StatefulSet, error) {
+	statefulSets, err := lister.GetByLRPIdentifier(ctx, identifier)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list statefulsets")
 	}
@@ -367,27 +593,73 @@ func (m *StatefulSetDesirer) getStatefulSet(identifier opi.LRPIdentifier) (*apps
 	}
 }
 
-func (m *StatefulSetDesirer) GetInstances(identifier opi.LRPIdentifier) ([]*opi.Instance, error) {
+func (m *StatefulSetDesirer) GetInstances(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.Instance, error) {
 	logger := m.Logger.Session("get-instance", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
-	if _, err := m.getLRP(logger, identifier); errors.Is(err, eirini.ErrNotFound) {
+
+	instances, _, err := m.getInstances(ctx, logger, identifier)
+
+	return instances, err
+}
+
+// GetInstanceStats joins the instances of the LRP with the resource usage of
+// their pods.
+func (m *StatefulSetDesirer) GetInstanceStats(ctx context.Context, identifier opi.LRPIdentifier) ([]*opi.InstanceStats, error) {
+	logger := m.Logger.Session("get-instance-stats", lager.Data{"guid": identifier.GUID, "version": identifier.Version})
+
+	instances, pods, err := m.getInstances(ctx, logger, identifier)
+	if err != nil {
 		return nil, err
 	}
 
-	pods, err := m.Pods.GetByLRPIdentifier(identifier)
+	usage := map[string]PodUsage{}
+	if m.PodUsage != nil {
+		usage = m.PodUsage.CollectPodUsage(ctx, pods)
+	}
+
+	stats := make([]*opi.InstanceStats, 0, len(instances))
+
+	for i, instance := range instances {
+		pod := pods[i]
+
+		stats = append(stats, &opi.InstanceStats{
+			Instance:   *instance,
+			Host:       pod.Status.HostIP,
+			InternalIP: pod.Status.PodIP,
+			Ports:      getPortMappings(pod),
+			Uptime:     getUptime(pod),
+			Usage:      opi.InstanceUsage(usage[pod.Name]),
+		})
+	}
+
+	return stats, nil
+}
+
+// getInstances returns the instances of the LRP along with the pods they
+// run in, at the same indices.
+func (m *StatefulSetDesirer) getInstances(ctx context.Context, logger lager.Logger, identifier opi.LRPIdentifier) ([]*opi.Instance, []corev1.Pod, error) {
+	statefulSet, err := findStatefulSet(ctx, m.statefulSetLister(), identifier)
+	if errors.Is(err, eirini.ErrNotFound) {
+		logger.Error("failed-to-get-statefulset", err)
+
+		return nil, nil, err
+	}
+
+	pods, err := m.Pods.GetByLRPIdentifier(ctx, identifier)
 	if err != nil {
 		logger.Error("failed-to-list-pods", err)
 
-		return nil, errors.Wrap(err, "failed to list pods")
+		return nil, nil, errors.Wrap(err, "failed to list pods")
 	}
 
 	instances := []*opi.Instance{}
+	instancePods := []corev1.Pod{}
 
 	for _, pod := range pods {
-		events, err := m.EventsClient.GetByPod(pod)
+		events, err := m.EventsClient.GetByPod(ctx, pod)
 		if err != nil {
 			logger.Error("failed-to-get-events", err)
 
-			return nil, errors.Wrapf(err, "failed to get events for pod %s", pod.Name)
+			return nil, nil, errors.Wrapf(err, "failed to get events for pod %s", pod.Name)
 		}
 
 		if IsStopped(events) {
@@ -398,7 +670,7 @@ func (m *StatefulSetDesirer) GetInstances(identifier opi.LRPIdentifier) ([]*opi.
 		if err != nil {
 			logger.Error("failed-to-parse-app-index", err)
 
-			return nil, errors.Wrap(err, "failed to parse pod index")
+			return nil, nil, errors.Wrap(err, "failed to parse pod index")
 		}
 
 		since := int64(0)
@@ -418,25 +690,56 @@ func (m *StatefulSetDesirer) GetInstances(identifier opi.LRPIdentifier) ([]*opi.
 			Index:          index,
 			State:          state,
 			PlacementError: placementError,
+			Outdated:       isOutdated(statefulSet, pod),
 		}
 		instances = append(instances, &instance)
+		instancePods = append(instancePods, pod)
 	}
 
-	return instances, nil
+	return instances, instancePods, nil
 }
 
-func (m *StatefulSetDesirer) createPodDisruptionBudget(namespace, statefulSetName string, lrp *opi.LRP) error {
-	if lrp.TargetInstances > 1 {
-		minAvailable := intstr.FromInt(PdbMinAvailableInstances)
-		_, err := m.PodDisruptionBudgets.Create(namespace, &v1beta1.PodDisruptionBudget{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: statefulSetName,
-			},
-			Spec: v1beta1.PodDisruptionBudgetSpec{
-				MinAvailable: &minAvailable,
-				Selector:     m.labelSelector(lrp),
-			},
+func getPortMappings(pod corev1.Pod) []opi.PortMapping {
+	mappings := []opi.PortMapping{}
+	if len(pod.Spec.Containers) == 0 {
+		return mappings
+	}
+
+	for _, port := range getOPIContainer(pod.Spec.Containers).Ports {
+		mappings = append(mappings, opi.PortMapping{
+			ContainerPort: uint32(port.ContainerPort),
+			HostPort:      uint32(port.HostPort),
 		})
+	}
+
+	return mappings
+}
+
+// getUptime counts from when the opi container last started, so that it is
+// reset when the app crashes and is restarted in the same pod.
+func getUptime(pod corev1.Pod) int64 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == OPIContainerName && status.State.Running != nil {
+			return int64(time.Since(status.State.Running.StartedAt.Time).Seconds())
+		}
+	}
+
+	return 0
+}
+
+// isOutdated tells whether the pod runs an older revision of the pod
+// template than the one a rolling restart or update is rolling out.
+func isOutdated(statefulSet *appsv1.StatefulSet, pod corev1.Pod) bool {
+	if statefulSet == nil || statefulSet.Status.UpdateRevision == "" {
+		return false
+	}
+
+	return pod.Labels[appsv1.ControllerRevisionHashLabelKey] != statefulSet.Status.UpdateRevision
+}
+
+func (m *StatefulSetDesirer) createPodDisruptionBudget(ctx context.Context, namespace, statefulSetName string, lrp *opi.LRP) error {
+	if lrp.TargetInstances > 1 {
+		_, err := m.PodDisruptionBudgets.Create(ctx, namespace, m.toPodDisruptionBudget(statefulSetName, lrp))
 
 		return errors.Wrap(err, "failed to create pod distruption budget")
 	}
@@ -444,6 +747,20 @@ func (m *StatefulSetDesirer) createPodDisruptionBudget(namespace, statefulSetNam
 	return nil
 }
 
+func (m *StatefulSetDesirer) toPodDisruptionBudget(statefulSetName string, lrp *opi.LRP) *v1beta1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt(PdbMinAvailableInstances)
+
+	return &v1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: statefulSetName,
+		},
+		Spec: v1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     m.labelSelector(lrp),
+		},
+	}
+}
+
 func hasInsufficientMemory(events []corev1.Event) bool {
 	if len(events) == 0 {
 		return false
@@ -610,6 +927,13 @@ func (m *StatefulSetDesirer) toStatefulSet(statefulSetName string, lrp *opi.LRP)
 		},
 	}
 
+	statefulSet.Spec.Template.Spec.Containers = append(
+		statefulSet.Spec.Template.Spec.Containers,
+		getSidecarContainers(lrp, envs)...,
+	)
+
+	applyDroplet(&statefulSet.Spec.Template.Spec, lrp.Droplet, m.DropletDownloaderImage)
+
 	automountServiceAccountToken := false
 
 	if !m.AllowAutomountServiceAccountToken {
@@ -634,6 +958,10 @@ func (m *StatefulSetDesirer) toStatefulSet(statefulSetName string, lrp *opi.LRP)
 		},
 	}
 
+	if err := applyPlacementTags(&statefulSet.Spec.Template.Spec, lrp.PlacementTags, m.PlacementTags); err != nil {
+		return nil, errors.Wrap(err, "failed to apply placement tags")
+	}
+
 	labels := map[string]string{
 		LabelOrgGUID:     lrp.OrgGUID,
 		LabelOrgName:     lrp.OrgName,
@@ -668,6 +996,35 @@ func (m *StatefulSetDesirer) toStatefulSet(statefulSetName string, lrp *opi.LRP)
 		AnnotationOrgGUID:          lrp.OrgGUID,
 	}
 
+	if len(lrp.EgressRules) > 0 {
+		rules, err := json.Marshal(lrp.EgressRules)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal egress rules")
+		}
+
+		annotations[AnnotationEgressRules] = string(rules)
+	}
+
+	healthCheck, err := json.Marshal(lrp.Health)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal health check")
+	}
+
+	annotations[AnnotationHealthCheck] = string(healthCheck)
+
+	if len(lrp.PlacementTags) > 0 {
+		tags, err := json.Marshal(lrp.PlacementTags)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal placement tags")
+		}
+
+		annotations[AnnotationPlacementTags] = string(tags)
+	}
+
+	if lrp.RestartGeneration > 0 {
+		annotations[AnnotationRestartGeneration] = strconv.FormatInt(lrp.RestartGeneration, 10)
+	}
+
 	for k, v := range lrp.UserDefinedAnnotations {
 		annotations[k] = v
 	}
@@ -691,6 +1048,10 @@ func toLabelSelectorRequirements(selector *metav1.LabelSelector) []metav1.LabelS
 		})
 	}
 
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].Key < reqs[j].Key
+	})
+
 	return reqs
 }
 
@@ -739,9 +1100,9 @@ func (m *StatefulSetDesirer) getGetSecurityContext(lrp *opi.LRP) *corev1.PodSecu
 	}
 }
 
-func (m *StatefulSetDesirer) handlePodDisruptionBudget(logger lager.Logger, namespace, name string, lrp *opi.LRP) error {
+func (m *StatefulSetDesirer) handlePodDisruptionBudget(ctx context.Context, logger lager.Logger, namespace, name string, lrp *opi.LRP) error {
 	if lrp.TargetInstances <= 1 {
-		err := m.PodDisruptionBudgets.Delete(namespace, name)
+		err := m.PodDisruptionBudgets.Delete(ctx, namespace, name)
 		if err != nil && !k8serrors.IsNotFound(err) {
 			logger.Error("failed-to-delete-disruption-budget", err, lager.Data{"namespace": namespace})
 
@@ -751,7 +1112,7 @@ func (m *StatefulSetDesirer) handlePodDisruptionBudget(logger lager.Logger, name
 		return nil
 	}
 
-	err := m.createPodDisruptionBudget(namespace, name, lrp)
+	err := m.createPodDisruptionBudget(ctx, namespace, name, lrp)
 
 	if err != nil && !k8serrors.IsAlreadyExists(err) {
 		logger.Error("failed-to-create-disruption-budget", err, lager.Data{"namespace": namespace})
@@ -762,7 +1123,59 @@ func (m *StatefulSetDesirer) handlePodDisruptionBudget(logger lager.Logger, name
 	return nil
 }
 
-func (m *StatefulSetDesirer) getUpdatedStatefulSetObj(sts *appsv1.StatefulSet, routes []opi.Route, instances int, lastUpdated, image string) (*appsv1.StatefulSet, error) {
+func getSidecarContainers(lrp *opi.LRP, envs []corev1.EnvVar) []corev1.Container {
+	containers := []corev1.Container{}
+	usedNames := map[string]bool{}
+
+	for i, sidecar := range lrp.Sidecars {
+		memory := *resource.NewScaledQuantity(sidecar.MemoryMB, resource.Mega)
+		allowPrivilegeEscalation := false
+
+		containers = append(containers, corev1.Container{
+			Name:            sidecarContainerName(sidecar.Name, i, usedNames),
+			Image:           lrp.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+			},
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: memory,
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: memory,
+				},
+			},
+		})
+	}
+
+	return containers
+}
+
+// sidecarContainerName prefixes the names of the containers eirini adds
+// itself, so that a sidecar cannot clash with them. Names clashing with an
+// earlier sidecar get the sidecar index as suffix.
+func sidecarContainerName(name string, index int, usedNames map[string]bool) string {
+	containerName := utils.SanitizeLabelName(name, fmt.Sprintf("sidecar-%d", index))
+
+	switch containerName {
+	case OPIContainerName, DropletDownloaderContainerName:
+		containerName = "sidecar-" + containerName
+	}
+
+	uniqueName := containerName
+	for suffix := index; usedNames[uniqueName]; suffix++ {
+		uniqueName = utils.SuffixLabelName(containerName, fmt.Sprintf("-%d", suffix))
+	}
+
+	usedNames[uniqueName] = true
+
+	return uniqueName
+}
+
+func (m *StatefulSetDesirer) getUpdatedStatefulSetObj(sts *appsv1.StatefulSet, routes []opi.Route, egressRules []opi.EgressRule, instances int, lastUpdated, image string) (*appsv1.StatefulSet, error) {
 	updatedSts := sts.DeepCopy()
 
 	uris, err := json.Marshal(routes)
@@ -775,10 +1188,25 @@ func (m *StatefulSetDesirer) getUpdatedStatefulSetObj(sts *appsv1.StatefulSet, r
 	updatedSts.Annotations[AnnotationLastUpdated] = lastUpdated
 	updatedSts.Annotations[AnnotationRegisteredRoutes] = string(uris)
 
+	if len(egressRules) > 0 {
+		rules, err := json.Marshal(egressRules)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal egress rules")
+		}
+
+		updatedSts.Annotations[AnnotationEgressRules] = string(rules)
+	} else {
+		delete(updatedSts.Annotations, AnnotationEgressRules)
+	}
+
 	if image != "" {
-		for i, container := range updatedSts.Spec.Template.Spec.Containers {
-			if container.Name == OPIContainerName {
-				updatedSts.Spec.Template.Spec.Containers[i].Image = image
+		containers := updatedSts.Spec.Template.Spec.Containers
+		oldImage := getOPIContainer(containers).Image
+
+		// sidecars share the image of the app container, so they are updated alongside it
+		for i, container := range containers {
+			if container.Name == OPIContainerName || container.Image == oldImage {
+				containers[i].Image = image
 			}
 		}
 	}
@@ -786,17 +1214,111 @@ func (m *StatefulSetDesirer) getUpdatedStatefulSetObj(sts *appsv1.StatefulSet, r
 	return updatedSts, nil
 }
 
-func (m *StatefulSetDesirer) createRegistryCredsSecret(namespace, statefulSetName string, lrp *opi.LRP) error {
+func (m *StatefulSetDesirer) getUpdatedStatefulSetSpecObj(sts *appsv1.StatefulSet, lrp *opi.LRP) (*appsv1.StatefulSet, error) {
+	desiredSts, err := m.toStatefulSet(sts.Name, lrp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate desired statefulset")
+	}
+
+	updatedSts := sts.DeepCopy()
+	updatedSts.Labels = mergeStringMaps(updatedSts.Labels, desiredSts.Labels)
+	updatedSts.Annotations = mergeStringMaps(updatedSts.Annotations, desiredSts.Annotations)
+
+	if len(lrp.EgressRules) == 0 {
+		delete(updatedSts.Annotations, AnnotationEgressRules)
+	}
+
+	// the selector and the pod management policy are immutable, so only
+	// the replicas and the pod template are taken from the desired state
+	updatedSts.Spec.Replicas = desiredSts.Spec.Replicas
+	updatedSts.Spec.Template = desiredSts.Spec.Template
+	updatedSts.Spec.Template.Annotations = templateAnnotations(sts.Spec.Template.Annotations, desiredSts.Spec.Template.Annotations)
+
+	return updatedSts, nil
+}
+
+// templateAnnotations keeps the values of annotations which are applied
+// without restarting the instances, so that changing them does not cause
+// a rolling update. The trace context is only set when the statefulset is
+// created and is kept as is, and so is the time of the last restart.
+func templateAnnotations(current, desired map[string]string) map[string]string {
+	annotations := mergeStringMaps(nil, desired)
+
+	for _, key := range []string{AnnotationRegisteredRoutes, AnnotationLastUpdated, AnnotationEgressRules, AnnotationRestartedAt, tracing.AnnotationTraceParent, tracing.AnnotationTraceState} {
+		if value, ok := current[key]; ok {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+
+	return annotations
+}
+
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	merged := map[string]string{}
+
+	for k, v := range base {
+		merged[k] = v
+	}
+
+	for k, v := range overrides {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func (m *StatefulSetDesirer) syncNetworkPolicy(ctx context.Context, logger lager.Logger, namespace, name string, lrp *opi.LRP) error {
+	networkPolicy, err := toNetworkPolicy(logger, name, lrp, m.labelSelector(lrp))
+	if err != nil {
+		return errors.Wrap(err, "failed to convert egress rules to network policy")
+	}
+
+	if networkPolicy == nil {
+		err = m.NetworkPolicies.Delete(ctx, namespace, name)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error("failed-to-delete-network-policy", err, lager.Data{"namespace": namespace})
+
+			return errors.Wrap(err, "failed to delete network policy")
+		}
+
+		return nil
+	}
+
+	_, err = m.NetworkPolicies.Create(ctx, namespace, networkPolicy)
+	if k8serrors.IsAlreadyExists(err) {
+		_, err = m.NetworkPolicies.Update(ctx, namespace, networkPolicy)
+	}
+
+	return errors.Wrap(err, "failed to create or update network policy")
+}
+
+func (m *StatefulSetDesirer) createRegistryCredsSecret(ctx context.Context, namespace, statefulSetName string, lrp *opi.LRP) error {
 	secret, err := m.generateRegistryCredsSecret(statefulSetName, lrp)
 	if err != nil {
 		return errors.Wrap(err, "failed to generate private registry secret for statefulset")
 	}
 
-	_, err = m.Secrets.Create(namespace, secret)
+	_, err = m.Secrets.Create(ctx, namespace, secret)
 
 	return errors.Wrap(err, "failed to create private registry secret for statefulset")
 }
 
+func (m *StatefulSetDesirer) createOrUpdateRegistryCredsSecret(ctx context.Context, namespace, statefulSetName string, lrp *opi.LRP) error {
+	secret, err := m.generateRegistryCredsSecret(statefulSetName, lrp)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate private registry secret for statefulset")
+	}
+
+	_, err = m.Secrets.Create(ctx, namespace, secret)
+	if k8serrors.IsAlreadyExists(err) {
+		_, err = m.Secrets.Update(ctx, namespace, secret)
+	}
+
+	return errors.Wrap(err, "failed to create or update private registry secret for statefulset")
+}
+
 func applyOpts(statefulset *appsv1.StatefulSet, opts ...DesireOption) error {
 	for _, opt := range opts {
 		if err := opt(statefulset); err != nil {
@@ -1,24 +1,30 @@
 package k8s_test
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/k8s/k8sfakes"
 	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gstruct"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +49,7 @@ var _ = Describe("Statefulset Desirer", func() {
 		logger                *lagertest.TestLogger
 		mapper                *k8sfakes.FakeLRPMapper
 		pdbClient             *k8sfakes.FakePodDisruptionBudgetClient
+		networkPolicyClient   *k8sfakes.FakeNetworkPolicyClient
 	)
 
 	BeforeEach(func() {
@@ -54,7 +61,9 @@ var _ = Describe("Statefulset Desirer", func() {
 		livenessProbeCreator = new(k8sfakes.FakeProbeCreator)
 		readinessProbeCreator = new(k8sfakes.FakeProbeCreator)
 		mapper = new(k8sfakes.FakeLRPMapper)
+		mapper.Returns(&opi.LRP{}, nil)
 		pdbClient = new(k8sfakes.FakePodDisruptionBudgetClient)
+		networkPolicyClient = new(k8sfakes.FakeNetworkPolicyClient)
 
 		logger = lagertest.NewTestLogger("handler-test")
 		statefulSetDesirer = &k8s.StatefulSetDesirer{
@@ -62,6 +71,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			Secrets:                   secretsClient,
 			StatefulSets:              statefulSetClient,
 			PodDisruptionBudgets:      pdbClient,
+			NetworkPolicies:           networkPolicyClient,
 			RegistrySecretName:        registrySecretName,
 			LivenessProbeCreator:      livenessProbeCreator.Spy,
 			ReadinessProbeCreator:     readinessProbeCreator.Spy,
@@ -77,6 +87,8 @@ var _ = Describe("Statefulset Desirer", func() {
 			lrp                        *opi.LRP
 			desireErr                  error
 			desireOptOne, desireOptTwo *k8sfakes.FakeDesireOption
+			ctx                        context.Context
+			cancel                     context.CancelFunc
 		)
 
 		BeforeEach(func() {
@@ -88,7 +100,12 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		JustBeforeEach(func() {
-			desireErr = statefulSetDesirer.Desire("the-namespace", lrp, desireOptOne.Spy, desireOptTwo.Spy)
+			ctx, cancel = context.WithCancel(context.Background())
+			desireErr = statefulSetDesirer.Desire(ctx, "the-namespace", lrp, desireOptOne.Spy, desireOptTwo.Spy)
+		})
+
+		AfterEach(func() {
+			cancel()
 		})
 
 		It("should succeed", func() {
@@ -99,6 +116,31 @@ var _ = Describe("Statefulset Desirer", func() {
 			Expect(statefulSetClient.CreateCallCount()).To(Equal(1))
 		})
 
+		It("should pass the context to the statefulset client", func() {
+			createCtx, _, _ := statefulSetClient.CreateArgsForCall(0)
+			Expect(createCtx).To(BeIdenticalTo(ctx))
+		})
+
+		It("should not store a trace context when the request is not traced", func() {
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			Expect(statefulSet.Annotations).NotTo(HaveKey(tracing.AnnotationTraceParent))
+		})
+
+		When("the request is traced", func() {
+			JustBeforeEach(func() {
+				statefulSetClient.CreateReturns(nil, nil)
+				ctx = trace.ContextWithSpanContext(context.Background(), tracedSpanContext())
+				Expect(statefulSetDesirer.Desire(ctx, "the-namespace", lrp)).To(Succeed())
+			})
+
+			It("should store the trace context in the statefulset and pod template annotations", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(1)
+				traceParent := "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"
+				Expect(statefulSet.Annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, traceParent))
+				Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue(tracing.AnnotationTraceParent, traceParent))
+			})
+		})
+
 		It("should create a healthcheck probe", func() {
 			Expect(livenessProbeCreator.CallCount()).To(Equal(1))
 		})
@@ -111,14 +153,14 @@ var _ = Describe("Statefulset Desirer", func() {
 			Expect(desireOptOne.CallCount()).To(Equal(1))
 			Expect(desireOptTwo.CallCount()).To(Equal(1))
 
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(desireOptOne.ArgsForCall(0)).To(Equal(statefulSet))
 			Expect(desireOptTwo.ArgsForCall(0)).To(Equal(statefulSet))
 		})
 
 		DescribeTable("Statefulset Annotations",
 			func(annotationName, expectedValue string) {
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
 			},
 			Entry("ProcessGUID", k8s.AnnotationProcessGUID, "guid_1234-version_1234"),
@@ -135,7 +177,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 		DescribeTable("Statefulset Template Annotations",
 			func(annotationName, expectedValue string) {
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue(annotationName, expectedValue))
 			},
 			Entry("ProcessGUID", k8s.AnnotationProcessGUID, "guid_1234-version_1234"),
@@ -151,86 +193,86 @@ var _ = Describe("Statefulset Desirer", func() {
 		)
 
 		It("should provide last updated to the statefulset annotation", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Annotations).To(HaveKeyWithValue(k8s.AnnotationLastUpdated, lrp.LastUpdated))
 		})
 
 		It("should set seccomp pod annotation", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Annotations[corev1.SeccompPodAnnotationKey]).To(Equal(corev1.SeccompProfileRuntimeDefault))
 		})
 
 		It("should set name for the stateful set", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Name).To(Equal("baldur-space-foo-34f869d015"))
 		})
 
 		It("should set namespace for the stateful set", func() {
-			namespace, _ := statefulSetClient.CreateArgsForCall(0)
+			_, namespace, _ := statefulSetClient.CreateArgsForCall(0)
 			Expect(namespace).To(Equal("the-namespace"))
 		})
 
 		It("should set podManagementPolicy to parallel", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(string(statefulSet.Spec.PodManagementPolicy)).To(Equal("Parallel"))
 		})
 
 		It("should set podImagePullSecret", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Spec.ImagePullSecrets).To(HaveLen(1))
 			secret := statefulSet.Spec.Template.Spec.ImagePullSecrets[0]
 			Expect(secret.Name).To(Equal("secret-name"))
 		})
 
 		It("should deny privilegeEscalation", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(*statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.AllowPrivilegeEscalation).To(Equal(false))
 		})
 
 		It("should not automount service account token", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			f := false
 			Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(&f))
 		})
 
 		It("should set imagePullPolicy to Always", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(string(statefulSet.Spec.Template.Spec.Containers[0].ImagePullPolicy)).To(Equal("Always"))
 		})
 
 		It("should set app_guid as a label", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 
 			Expect(statefulSet.Labels).To(HaveKeyWithValue(k8s.LabelAppGUID, "premium_app_guid_1234"))
 			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(k8s.LabelAppGUID, "premium_app_guid_1234"))
 		})
 
 		It("should set process_type as a label", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Labels).To(HaveKeyWithValue(k8s.LabelProcessType, "worker"))
 			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(k8s.LabelProcessType, "worker"))
 		})
 
 		It("should set guid as a label", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Labels).To(HaveKeyWithValue(k8s.LabelGUID, "guid_1234"))
 			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(k8s.LabelGUID, "guid_1234"))
 		})
 
 		It("should set version as a label", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Labels).To(HaveKeyWithValue(k8s.LabelVersion, "version_1234"))
 			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(k8s.LabelVersion, "version_1234"))
 		})
 
 		It("should set source_type as a label", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Labels).To(HaveKeyWithValue(k8s.LabelSourceType, "APP"))
 			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(k8s.LabelSourceType, "APP"))
 		})
 
 		It("should set cf space and org information as labels", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			haveSpaceAndOrgInformation := SatisfyAll(
 				HaveKeyWithValue(k8s.LabelOrgGUID, "org-guid"),
 				HaveKeyWithValue(k8s.LabelOrgName, "org-foo"),
@@ -242,22 +284,22 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		It("should set guid as a label selector", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Selector.MatchLabels).To(HaveKeyWithValue(k8s.LabelGUID, "guid_1234"))
 		})
 
 		It("should set version as a label selector", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Selector.MatchLabels).To(HaveKeyWithValue(k8s.LabelVersion, "version_1234"))
 		})
 
 		It("should set source_type as a label selector", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Selector.MatchLabels).To(HaveKeyWithValue(k8s.LabelSourceType, "APP"))
 		})
 
 		It("should set disk limit", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 
 			expectedLimit := resource.NewScaledQuantity(2048, resource.Mega)
 			actualLimit := statefulSet.Spec.Template.Spec.Containers[0].Resources.Limits.StorageEphemeral()
@@ -265,12 +307,12 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		It("should set user defined annotations", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Annotations["prometheus.io/scrape"]).To(Equal("secret-value"))
 		})
 
 		It("should run it with non-root user", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(PointTo(Equal(true)))
 		})
 
@@ -279,7 +321,7 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		It("should set soft inter-pod anti-affinity", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			podAntiAffinity := statefulSet.Spec.Template.Spec.Affinity.PodAntiAffinity
 			Expect(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
 			Expect(podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
@@ -307,12 +349,12 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		It("should set application service account", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("eirini"))
 		})
 
 		It("should set the container environment variables", func() {
-			_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+			_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
 			container := statefulSet.Spec.Template.Spec.Containers[0]
 			Expect(container.Env).To(ContainElements(
@@ -323,13 +365,125 @@ var _ = Describe("Statefulset Desirer", func() {
 			))
 		})
 
+		When("the app has sidecars", func() {
+			BeforeEach(func() {
+				lrp.Sidecars = []opi.Sidecar{
+					{Name: "Log_Shipper", Command: []string{"/bin/ship-logs"}, MemoryMB: 32},
+					{Name: "not a valid name", Command: []string{"/bin/other"}, MemoryMB: 16},
+				}
+			})
+
+			It("should add a container for each sidecar", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				containers := statefulSet.Spec.Template.Spec.Containers
+				Expect(containers).To(HaveLen(3))
+				Expect(containers[0].Name).To(Equal(k8s.OPIContainerName))
+				Expect(containers[1].Name).To(Equal("log-shipper"))
+				Expect(containers[1].Command).To(Equal([]string{"/bin/ship-logs"}))
+				Expect(containers[2].Name).To(Equal("sidecar-1"))
+				Expect(containers[2].Command).To(Equal([]string{"/bin/other"}))
+			})
+
+			It("should run the sidecars with the app image and environment", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				containers := statefulSet.Spec.Template.Spec.Containers
+				Expect(containers[1].Image).To(Equal(containers[0].Image))
+				Expect(containers[1].Env).To(Equal(containers[0].Env))
+				Expect(*containers[1].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+			})
+
+			When("a sidecar is named like a container eirini adds", func() {
+				BeforeEach(func() {
+					lrp.Sidecars = []opi.Sidecar{{Name: "opi"}, {Name: "droplet-downloader"}}
+				})
+
+				It("should prefix its name", func() {
+					_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+					containers := statefulSet.Spec.Template.Spec.Containers
+					Expect(containers[1].Name).To(Equal("sidecar-opi"))
+					Expect(containers[2].Name).To(Equal("sidecar-droplet-downloader"))
+				})
+			})
+
+			When("a sidecar name is not a valid container name", func() {
+				BeforeEach(func() {
+					lrp.Sidecars = []opi.Sidecar{{Name: "my.sidecar"}}
+				})
+
+				It("should replace the dots", func() {
+					_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+					containers := statefulSet.Spec.Template.Spec.Containers
+					Expect(containers[1].Name).To(Equal("my-sidecar"))
+				})
+			})
+
+			When("sidecar names clash", func() {
+				BeforeEach(func() {
+					lrp.Sidecars = []opi.Sidecar{
+						{Name: "Web"},
+						{Name: "web"},
+						{Name: strings.Repeat("a", 70)},
+						{Name: strings.Repeat("a", 80)},
+					}
+				})
+
+				It("should make the container names unique", func() {
+					_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+					containers := statefulSet.Spec.Template.Spec.Containers
+					Expect(containers[1].Name).To(Equal("web"))
+					Expect(containers[2].Name).To(Equal("web-1"))
+					Expect(containers[3].Name).To(Equal(strings.Repeat("a", 63)))
+					Expect(containers[4].Name).To(Equal(strings.Repeat("a", 61) + "-3"))
+				})
+			})
+
+			It("should set the sidecar memory limits", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				sidecar := statefulSet.Spec.Template.Spec.Containers[1]
+				expectedMemory := resource.NewScaledQuantity(32, resource.Mega)
+				Expect(sidecar.Resources.Requests.Memory()).To(Equal(expectedMemory))
+				Expect(sidecar.Resources.Limits.Memory()).To(Equal(expectedMemory))
+			})
+		})
+
+		When("the app runs a droplet", func() {
+			BeforeEach(func() {
+				statefulSetDesirer.DropletDownloaderImage = "droplet/downloader"
+				lrp.Droplet = &opi.Droplet{URL: "https://cc-uploader/droplet", Hash: "droplet-hash"}
+				lrp.Sidecars = []opi.Sidecar{{Name: "log-shipper"}}
+			})
+
+			It("should download the droplet in an init container", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				initContainers := statefulSet.Spec.Template.Spec.InitContainers
+				Expect(initContainers).To(HaveLen(1))
+				Expect(initContainers[0].Name).To(Equal(k8s.DropletDownloaderContainerName))
+				Expect(initContainers[0].Image).To(Equal("droplet/downloader"))
+				Expect(initContainers[0].Env).To(ContainElement(
+					corev1.EnvVar{Name: eirini.EnvDownloadURL, Value: "https://cc-uploader/droplet"},
+				))
+			})
+
+			It("should mount the droplet in the app and sidecar containers", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				dropletMount := corev1.VolumeMount{Name: eirini.DropletVolumeName, MountPath: eirini.DropletMountPath}
+				for _, container := range statefulSet.Spec.Template.Spec.Containers {
+					Expect(container.VolumeMounts).To(ContainElement(dropletMount))
+				}
+				Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name:         eirini.DropletVolumeName,
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}))
+			})
+		})
+
 		When("automounting service account token is allowed", func() {
 			BeforeEach(func() {
 				statefulSetDesirer.AllowAutomountServiceAccountToken = true
 			})
 
 			It("does not set automountServiceAccountToken", func() {
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(BeNil())
 			})
 		})
@@ -340,7 +494,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("does not set privileged context", func() {
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Spec.Template.Spec.SecurityContext).To(BeNil())
 			})
 		})
@@ -351,7 +505,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should use the guid as a name", func() {
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Name).To(Equal("guid_1234-34f869d015"))
 			})
 		})
@@ -364,8 +518,8 @@ var _ = Describe("Statefulset Desirer", func() {
 			It("should create a pod disruption budget for it", func() {
 				Expect(pdbClient.CreateCallCount()).To(Equal(1))
 
-				pdbNamespace, pdb := pdbClient.CreateArgsForCall(0)
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, pdbNamespace, pdb := pdbClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(pdbNamespace).To(Equal("the-namespace"))
 				Expect(pdb.Name).To(Equal(statefulSet.Name))
 				Expect(pdb.Spec.MinAvailable).To(PointTo(Equal(intstr.FromInt(1))))
@@ -416,7 +570,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			It("should create a private repo secret containing the private repo credentials", func() {
 				Expect(secretsClient.CreateCallCount()).To(Equal(1))
-				secretNamespace, actualSecret := secretsClient.CreateArgsForCall(0)
+				_, secretNamespace, actualSecret := secretsClient.CreateArgsForCall(0)
 				Expect(secretNamespace).To(Equal("the-namespace"))
 				Expect(actualSecret.Name).To(Equal("baldur-space-foo-34f869d015-registry-credentials"))
 				Expect(actualSecret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
@@ -433,12 +587,247 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			It("should add the private repo secret to podImagePullSecret", func() {
 				Expect(statefulSetClient.CreateCallCount()).To(Equal(1))
-				_, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
 				Expect(statefulSet.Spec.Template.Spec.ImagePullSecrets).To(HaveLen(2))
 				secret := statefulSet.Spec.Template.Spec.ImagePullSecrets[1]
 				Expect(secret.Name).To(Equal("baldur-space-foo-34f869d015-registry-credentials"))
 			})
 		})
+
+		It("should not create a network policy", func() {
+			Expect(networkPolicyClient.CreateCallCount()).To(BeZero())
+		})
+
+		When("the app has egress rules", func() {
+			BeforeEach(func() {
+				lrp.EgressRules = []opi.EgressRule{
+					{Protocol: "tcp", Destinations: []string{"10.0.0.0/8", "1.2.3.4"}, Ports: []int32{80, 443}},
+					{Protocol: "udp", Destinations: []string{"0.0.0.0-9.255.255.255"}, PortRange: &opi.PortRange{Start: 53, End: 54}},
+					{Protocol: "all", Destinations: []string{"192.168.0.0-192.168.1.255"}},
+					{Protocol: "icmp", Destinations: []string{"0.0.0.0/0"}},
+				}
+			})
+
+			It("should store the egress rules in an annotation", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				Expect(statefulSet.Annotations).To(HaveKey(k8s.AnnotationEgressRules))
+			})
+
+			It("should create a network policy selecting the lrp pods", func() {
+				Expect(networkPolicyClient.CreateCallCount()).To(Equal(1))
+				_, npNamespace, networkPolicy := networkPolicyClient.CreateArgsForCall(0)
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+
+				Expect(npNamespace).To(Equal("the-namespace"))
+				Expect(networkPolicy.Name).To(Equal(statefulSet.Name))
+				Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				Expect(networkPolicy.Spec.PodSelector.MatchLabels).To(SatisfyAll(
+					HaveKeyWithValue(k8s.LabelGUID, "guid_1234"),
+					HaveKeyWithValue(k8s.LabelVersion, "version_1234"),
+				))
+			})
+
+			It("should translate the egress rules", func() {
+				_, _, networkPolicy := networkPolicyClient.CreateArgsForCall(0)
+				tcp, udp := corev1.ProtocolTCP, corev1.ProtocolUDP
+				port := func(p int) *intstr.IntOrString {
+					port := intstr.FromInt(p)
+
+					return &port
+				}
+				ipBlock := func(cidr string) networkingv1.NetworkPolicyPeer {
+					return networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}
+				}
+
+				Expect(networkPolicy.Spec.Egress).To(Equal([]networkingv1.NetworkPolicyEgressRule{
+					{
+						To:    []networkingv1.NetworkPolicyPeer{ipBlock("10.0.0.0/8"), ipBlock("1.2.3.4/32")},
+						Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: port(80)}, {Protocol: &tcp, Port: port(443)}},
+					},
+					{
+						To:    []networkingv1.NetworkPolicyPeer{ipBlock("0.0.0.0/5"), ipBlock("8.0.0.0/7")},
+						Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: port(53)}, {Protocol: &udp, Port: port(54)}},
+					},
+					{
+						To: []networkingv1.NetworkPolicyPeer{ipBlock("192.168.0.0/23")},
+					},
+				}))
+			})
+
+			When("the statefulset is created", func() {
+				var policiesBeforeStatefulSet int
+
+				BeforeEach(func() {
+					statefulSetClient.CreateStub = func(context.Context, string, *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+						policiesBeforeStatefulSet = networkPolicyClient.CreateCallCount()
+
+						return nil, nil
+					}
+				})
+
+				It("should have created the network policy already", func() {
+					Expect(policiesBeforeStatefulSet).To(Equal(1))
+				})
+			})
+
+			When("a port range covers all ports", func() {
+				BeforeEach(func() {
+					lrp.EgressRules = []opi.EgressRule{
+						{Protocol: "tcp", Destinations: []string{"10.0.0.0/8"}, PortRange: &opi.PortRange{Start: 1, End: 65535}},
+					}
+				})
+
+				It("allows all ports of the protocol", func() {
+					_, _, networkPolicy := networkPolicyClient.CreateArgsForCall(0)
+					tcp := corev1.ProtocolTCP
+					Expect(networkPolicy.Spec.Egress[0].Ports).To(Equal([]networkingv1.NetworkPolicyPort{{Protocol: &tcp}}))
+				})
+			})
+
+			When("a port range is too big to be expanded", func() {
+				BeforeEach(func() {
+					lrp.EgressRules = []opi.EgressRule{
+						{Protocol: "tcp", Destinations: []string{"10.0.0.0/8"}, PortRange: &opi.PortRange{Start: 1024, End: 65535}},
+					}
+				})
+
+				It("should fail", func() {
+					Expect(desireErr).To(MatchError(ContainSubstring("egress rule port range 1024-65535 is too big")))
+				})
+
+				It("should not create the statefulset", func() {
+					Expect(statefulSetClient.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			When("all egress rules are ICMP", func() {
+				BeforeEach(func() {
+					lrp.EgressRules = []opi.EgressRule{
+						{Protocol: "icmp", Destinations: []string{"0.0.0.0/0"}},
+					}
+				})
+
+				It("should create a network policy denying all egress", func() {
+					Expect(desireErr).NotTo(HaveOccurred())
+					Expect(networkPolicyClient.CreateCallCount()).To(Equal(1))
+					_, _, networkPolicy := networkPolicyClient.CreateArgsForCall(0)
+					Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+					Expect(networkPolicy.Spec.Egress).To(BeEmpty())
+				})
+
+				It("should log that ICMP cannot be expressed", func() {
+					Expect(logger.LogMessages()).To(ContainElement(ContainSubstring("icmp-egress-rules-not-expressible")))
+				})
+			})
+
+			When("the statefulset already exists", func() {
+				BeforeEach(func() {
+					statefulSetClient.CreateReturns(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "baldur"))
+				})
+
+				It("should still create the network policy", func() {
+					Expect(desireErr).NotTo(HaveOccurred())
+					Expect(networkPolicyClient.CreateCallCount()).To(Equal(1))
+				})
+			})
+
+			When("a destination is invalid", func() {
+				BeforeEach(func() {
+					lrp.EgressRules = []opi.EgressRule{
+						{Protocol: "tcp", Destinations: []string{"10.0.0.10-10.0.0.1"}},
+					}
+				})
+
+				It("should fail", func() {
+					Expect(desireErr).To(MatchError(ContainSubstring(`invalid egress rule destination "10.0.0.10-10.0.0.1"`)))
+				})
+			})
+
+			When("the protocol is not supported", func() {
+				BeforeEach(func() {
+					lrp.EgressRules = []opi.EgressRule{
+						{Protocol: "sctp", Destinations: []string{"10.0.0.1"}},
+					}
+				})
+
+				It("should fail", func() {
+					Expect(desireErr).To(MatchError(ContainSubstring(`unsupported egress rule protocol "sctp"`)))
+				})
+			})
+
+			When("creating the network policy fails", func() {
+				BeforeEach(func() {
+					networkPolicyClient.CreateReturns(nil, errors.New("boom"))
+				})
+
+				It("should propagate the error", func() {
+					Expect(desireErr).To(MatchError(ContainSubstring("boom")))
+				})
+
+				It("should not create the statefulset", func() {
+					Expect(statefulSetClient.CreateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the app requests placement tags", func() {
+			BeforeEach(func() {
+				statefulSetDesirer.PlacementTags = map[string]eirini.PlacementTag{
+					"segment-a": {
+						NodeSelector: map[string]string{"segment": "a"},
+						Tolerations: []eirini.Toleration{
+							{Key: "dedicated", Operator: "Equal", Value: "a", Effect: "NoSchedule"},
+						},
+						NodeAffinity: []eirini.NodeSelectorRequirement{
+							{Key: "zone", Operator: "In", Values: []string{"z1", "z2"}},
+						},
+					},
+				}
+				lrp.PlacementTags = []string{"segment-a"}
+			})
+
+			It("should set the node selector", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"segment": "a"}))
+			})
+
+			It("should set the tolerations", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "a",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			})
+
+			It("should set the required node affinity", func() {
+				_, _, statefulSet := statefulSetClient.CreateArgsForCall(0)
+				affinity := statefulSet.Spec.Template.Spec.Affinity
+				Expect(affinity.PodAntiAffinity).NotTo(BeNil())
+				Expect(affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+					corev1.NodeSelectorTerm{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"z1", "z2"}},
+						},
+					},
+				))
+			})
+
+			When("the placement tag is not configured", func() {
+				BeforeEach(func() {
+					lrp.PlacementTags = []string{"segment-b"}
+				})
+
+				It("should fail", func() {
+					Expect(desireErr).To(MatchError(ContainSubstring(`placement tag "segment-b" is not configured`)))
+				})
+
+				It("should not create the statefulset", func() {
+					Expect(statefulSetClient.CreateCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("Get", func() {
@@ -454,7 +843,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			}
 
 			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{st}, nil)
-			lrp, _ := statefulSetDesirer.Get(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+			lrp, _ := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
 			Expect(mapper.CallCount()).To(Equal(1))
 			Expect(lrp.AppName).To(Equal("baldur-app"))
 		})
@@ -465,7 +854,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := statefulSetDesirer.Get(opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
+				_, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
 				Expect(err).To(MatchError(ContainSubstring("not found")))
 			})
 		})
@@ -476,7 +865,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := statefulSetDesirer.Get(opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
+				_, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
 				Expect(err).To(MatchError(ContainSubstring("failed to list statefulsets")))
 			})
 		})
@@ -487,10 +876,77 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should return an error", func() {
-				_, err := statefulSetDesirer.Get(opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
+				_, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "idontknow", Version: "42"})
 				Expect(err).To(MatchError(ContainSubstring("multiple statefulsets found for LRP identifier")))
 			})
 		})
+
+		When("the statefulset pulls its image from a private registry", func() {
+			BeforeEach(func() {
+				st := appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "baldur",
+						Namespace: "the-namespace",
+					},
+				}
+				st.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
+					{Name: "baldur-registry-credentials"},
+				}
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{st}, nil)
+
+				secretsClient.GetReturns(&corev1.Secret{
+					StringData: map[string]string{
+						".dockerconfigjson": `{"auths":{"host":{"username":"user","password":"password"}}}`,
+					},
+				}, nil)
+			})
+
+			It("reads the registry credentials back from the secret", func() {
+				lrp, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(secretsClient.GetCallCount()).To(Equal(1))
+				_, namespace, name := secretsClient.GetArgsForCall(0)
+				Expect(namespace).To(Equal("the-namespace"))
+				Expect(name).To(Equal("baldur-registry-credentials"))
+
+				Expect(lrp.PrivateRegistry).To(Equal(&opi.PrivateRegistry{
+					Server:   "host",
+					Username: "user",
+					Password: "password",
+				}))
+			})
+
+			When("the secret does not exist", func() {
+				BeforeEach(func() {
+					secretsClient.GetReturns(nil, k8serrors.NewNotFound(schema.GroupResource{}, "baldur-registry-credentials"))
+				})
+
+				It("returns the LRP without registry credentials", func() {
+					lrp, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(lrp.AppName).To(Equal("baldur-app"))
+					Expect(lrp.PrivateRegistry).To(BeNil())
+				})
+
+				It("logs that the secret is missing", func() {
+					_, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(logger.LogMessages()).To(ContainElement(ContainSubstring("private-registry-secret-not-found")))
+				})
+			})
+
+			When("getting the secret fails", func() {
+				BeforeEach(func() {
+					secretsClient.GetReturns(nil, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					_, err := statefulSetDesirer.Get(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+					Expect(err).To(MatchError(ContainSubstring("failed to get private registry secret")))
+				})
+			})
+		})
 	})
 
 	Describe("Update", func() {
@@ -544,7 +1000,7 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = statefulSetDesirer.Update(updatedLRP)
+			err = statefulSetDesirer.Update(context.Background(), updatedLRP)
 		})
 
 		It("succeeds", func() {
@@ -554,7 +1010,7 @@ var _ = Describe("Statefulset Desirer", func() {
 		It("updates the statefulset", func() {
 			Expect(statefulSetClient.UpdateCallCount()).To(Equal(1))
 
-			namespace, st := statefulSetClient.UpdateArgsForCall(0)
+			_, namespace, st := statefulSetClient.UpdateArgsForCall(0)
 			Expect(namespace).To(Equal("the-namespace"))
 			Expect(st.GetAnnotations()).To(HaveKeyWithValue(k8s.AnnotationLastUpdated, "now"))
 			Expect(st.GetAnnotations()).To(HaveKeyWithValue(k8s.AnnotationRegisteredRoutes, `[{"hostname":"new-route.io","port":6666}]`))
@@ -576,11 +1032,45 @@ var _ = Describe("Statefulset Desirer", func() {
 			It("doesn't reset the image", func() {
 				Expect(statefulSetClient.UpdateCallCount()).To(Equal(1))
 
-				_, st := statefulSetClient.UpdateArgsForCall(0)
+				_, _, st := statefulSetClient.UpdateArgsForCall(0)
 				Expect(st.Spec.Template.Spec.Containers[1].Image).To(Equal("old/image"))
 			})
 		})
 
+		When("the statefulset has sidecars", func() {
+			BeforeEach(func() {
+				st := []appsv1.StatefulSet{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "baldur",
+							Namespace:   "the-namespace",
+							Annotations: map[string]string{},
+						},
+						Spec: appsv1.StatefulSetSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{Name: k8s.OPIContainerName, Image: "old/image"},
+										{Name: "log-shipper", Image: "old/image"},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				statefulSetClient.GetByLRPIdentifierReturns(st, nil)
+			})
+
+			It("updates the sidecar image too", func() {
+				Expect(statefulSetClient.UpdateCallCount()).To(Equal(1))
+
+				_, _, st := statefulSetClient.UpdateArgsForCall(0)
+				Expect(st.Spec.Template.Spec.Containers[0].Image).To(Equal("new/image"))
+				Expect(st.Spec.Template.Spec.Containers[1].Image).To(Equal("new/image"))
+			})
+		})
+
 		When("lrp is scaled down to 1 instance", func() {
 			BeforeEach(func() {
 				updatedLRP.TargetInstances = 1
@@ -588,7 +1078,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			It("should delete the pod disruption budget for the lrp", func() {
 				Expect(pdbClient.DeleteCallCount()).To(Equal(1))
-				pdbNamespace, pdbName := pdbClient.DeleteArgsForCall(0)
+				_, pdbNamespace, pdbName := pdbClient.DeleteArgsForCall(0)
 				Expect(pdbNamespace).To(Equal("the-namespace"))
 				Expect(pdbName).To(Equal("baldur"))
 			})
@@ -624,7 +1114,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			It("should create a pod disruption budget for the lrp in the same namespace", func() {
 				Expect(pdbClient.CreateCallCount()).To(Equal(1))
-				namespace, pdb := pdbClient.CreateArgsForCall(0)
+				_, namespace, pdb := pdbClient.CreateArgsForCall(0)
 
 				Expect(pdb.Name).To(Equal("baldur"))
 				Expect(namespace).To(Equal("the-namespace"))
@@ -654,6 +1144,69 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 		})
 
+		It("should delete the network policy, as the lrp has no egress rules", func() {
+			Expect(networkPolicyClient.DeleteCallCount()).To(Equal(1))
+			_, npNamespace, npName := networkPolicyClient.DeleteArgsForCall(0)
+			Expect(npNamespace).To(Equal("the-namespace"))
+			Expect(npName).To(Equal("baldur"))
+		})
+
+		When("the network policy does not exist", func() {
+			BeforeEach(func() {
+				networkPolicyClient.DeleteReturns(k8serrors.NewNotFound(schema.GroupResource{}, "baldur"))
+			})
+
+			It("should ignore the error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the lrp has egress rules", func() {
+			BeforeEach(func() {
+				updatedLRP.EgressRules = []opi.EgressRule{
+					{Protocol: "tcp", Destinations: []string{"10.0.0.1"}, Ports: []int32{8080}},
+				}
+			})
+
+			It("should store the egress rules in an annotation", func() {
+				_, _, st := statefulSetClient.UpdateArgsForCall(0)
+				Expect(st.GetAnnotations()).To(HaveKeyWithValue(k8s.AnnotationEgressRules, `[{"protocol":"tcp","destinations":["10.0.0.1"],"ports":[8080]}]`))
+			})
+
+			It("should create the network policy", func() {
+				Expect(networkPolicyClient.CreateCallCount()).To(Equal(1))
+				_, npNamespace, networkPolicy := networkPolicyClient.CreateArgsForCall(0)
+				Expect(npNamespace).To(Equal("the-namespace"))
+				Expect(networkPolicy.Name).To(Equal("baldur"))
+				Expect(networkPolicy.Spec.Egress).To(HaveLen(1))
+			})
+
+			When("the network policy already exists", func() {
+				BeforeEach(func() {
+					networkPolicyClient.CreateReturns(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "baldur"))
+				})
+
+				It("should update it", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(networkPolicyClient.UpdateCallCount()).To(Equal(1))
+					_, npNamespace, networkPolicy := networkPolicyClient.UpdateArgsForCall(0)
+					Expect(npNamespace).To(Equal("the-namespace"))
+					Expect(networkPolicy.Name).To(Equal("baldur"))
+				})
+			})
+
+			When("updating the network policy fails", func() {
+				BeforeEach(func() {
+					networkPolicyClient.CreateReturns(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "baldur"))
+					networkPolicyClient.UpdateReturns(nil, errors.New("boom"))
+				})
+
+				It("should propagate the error", func() {
+					Expect(err).To(MatchError(ContainSubstring("boom")))
+				})
+			})
+		})
+
 		When("update fails", func() {
 			BeforeEach(func() {
 				statefulSetClient.UpdateReturns(nil, errors.New("boom"))
@@ -712,25 +1265,25 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			statefulSetClient.GetBySourceTypeReturns(st, nil)
 
-			Expect(statefulSetDesirer.List()).To(HaveLen(3))
+			Expect(statefulSetDesirer.List(context.Background())).To(HaveLen(3))
 			Expect(mapper.CallCount()).To(Equal(3))
 		})
 
 		It("lists all statefulSets with APP source_type", func() {
 			statefulSetClient.GetBySourceTypeReturns([]appsv1.StatefulSet{}, nil)
-			_, err := statefulSetDesirer.List()
+			_, err := statefulSetDesirer.List(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(statefulSetClient.GetBySourceTypeCallCount()).To(Equal(1))
 
-			sourceType := statefulSetClient.GetBySourceTypeArgsForCall(0)
+			_, sourceType := statefulSetClient.GetBySourceTypeArgsForCall(0)
 			Expect(sourceType).To(Equal("APP"))
 		})
 
 		When("no statefulSets exist", func() {
 			It("returns an empy list of LRPs", func() {
 				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
-				Expect(statefulSetDesirer.List()).To(BeEmpty())
+				Expect(statefulSetDesirer.List(context.Background())).To(BeEmpty())
 				Expect(mapper.CallCount()).To(Equal(0))
 			})
 		})
@@ -738,48 +1291,269 @@ var _ = Describe("Statefulset Desirer", func() {
 		When("listing statefulsets fails", func() {
 			It("should return a meaningful error", func() {
 				statefulSetClient.GetBySourceTypeReturns(nil, errors.New("who is this?"))
-				_, err := statefulSetDesirer.List()
+				_, err := statefulSetDesirer.List(context.Background())
 				Expect(err).To(MatchError(ContainSubstring("failed to list statefulsets")))
 			})
 		})
 	})
 
-	Describe("Stop", func() {
-		var statefulSets []appsv1.StatefulSet
+	Describe("UpdateSpec", func() {
+		var (
+			originalLRP, updatedLRP *opi.LRP
+			existingStatefulSet     *appsv1.StatefulSet
+			err                     error
+		)
 
 		BeforeEach(func() {
-			statefulSets = []appsv1.StatefulSet{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "baldur",
-						Namespace: "the-namespace",
-					},
-				},
-			}
-			statefulSetClient.GetByLRPIdentifierReturns(statefulSets, nil)
-			pdbClient.DeleteReturns(k8serrors.NewNotFound(schema.GroupResource{
-				Group:    "policy/v1beta1",
-				Resource: "PodDisruptionBudet",
-			},
-				"foo"))
-		})
+			livenessProbeCreator.Returns(&corev1.Probe{})
+			readinessProbeCreator.Returns(&corev1.Probe{})
+
+			originalLRP = createLRP("Baldur", []opi.Route{{Hostname: "my.example.route", Port: 1000}})
+			originalLRP.Env = map[string]string{"FOO": "foo"}
+			Expect(statefulSetDesirer.Desire(context.Background(), "the-namespace", originalLRP)).To(Succeed())
+
+			_, _, existingStatefulSet = statefulSetClient.CreateArgsForCall(0)
+			existingStatefulSet.Namespace = "the-namespace"
+			existingStatefulSet.ResourceVersion = "42"
+			existingStatefulSet.OwnerReferences = []metav1.OwnerReference{{Name: "the-lrp"}}
+			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{*existingStatefulSet}, nil)
+
+			updatedLRP = createLRP("Baldur", []opi.Route{{Hostname: "my.example.route", Port: 1000}})
+			updatedLRP.Env = map[string]string{"FOO": "foo"}
+			updatedLRP.LastUpdated = originalLRP.LastUpdated
+		})
+
+		JustBeforeEach(func() {
+			err = statefulSetDesirer.UpdateSpec(context.Background(), updatedLRP)
+		})
+
+		getUpdatedStatefulSet := func() *appsv1.StatefulSet {
+			Expect(statefulSetClient.UpdateCallCount()).To(Equal(1))
+			_, namespace, st := statefulSetClient.UpdateArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+
+			return st
+		}
+
+		It("succeeds", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps the metadata of the existing statefulset", func() {
+			st := getUpdatedStatefulSet()
+			Expect(st.Name).To(Equal(existingStatefulSet.Name))
+			Expect(st.ResourceVersion).To(Equal("42"))
+			Expect(st.OwnerReferences).To(Equal(existingStatefulSet.OwnerReferences))
+		})
+
+		When("nothing has changed", func() {
+			It("does not change the pod template", func() {
+				Expect(getUpdatedStatefulSet().Spec.Template).To(Equal(existingStatefulSet.Spec.Template))
+			})
+		})
+
+		When("the process config changes", func() {
+			BeforeEach(func() {
+				updatedLRP.Env = map[string]string{"FOO": "bar", "BAZ": "baz"}
+				updatedLRP.MemoryMB = 512
+				updatedLRP.Command = []string{"/bin/new-command"}
+				updatedLRP.Ports = []int32{8080}
+				updatedLRP.Health = opi.Healtcheck{Type: "http", Port: 8080, Endpoint: "/health"}
+				updatedLRP.TargetInstances = 3
+			})
+
+			It("regenerates the pod template from the lrp", func() {
+				st := getUpdatedStatefulSet()
+				Expect(*st.Spec.Replicas).To(Equal(int32(3)))
+
+				container := st.Spec.Template.Spec.Containers[0]
+				Expect(container.Env).To(ContainElements(
+					corev1.EnvVar{Name: "BAZ", Value: "baz"},
+					corev1.EnvVar{Name: "FOO", Value: "bar"},
+				))
+				Expect(container.Command).To(ConsistOf("/bin/new-command"))
+				Expect(container.Ports).To(ConsistOf(corev1.ContainerPort{ContainerPort: 8080}))
+				Expect(container.Resources.Limits.Memory().String()).To(Equal("512M"))
+			})
+
+			It("regenerates the probes from the lrp", func() {
+				Expect(livenessProbeCreator.CallCount()).To(Equal(2))
+				Expect(livenessProbeCreator.ArgsForCall(1).Health).To(Equal(updatedLRP.Health))
+				Expect(readinessProbeCreator.CallCount()).To(Equal(2))
+			})
+		})
+
+		When("only the routes and the last updated timestamp change", func() {
+			BeforeEach(func() {
+				updatedLRP.AppURIs = []opi.Route{{Hostname: "new.example.route", Port: 2000}}
+				updatedLRP.LastUpdated = "now"
+			})
+
+			It("updates the statefulset annotations", func() {
+				st := getUpdatedStatefulSet()
+				Expect(st.Annotations).To(HaveKeyWithValue(k8s.AnnotationRegisteredRoutes, `[{"hostname":"new.example.route","port":2000}]`))
+				Expect(st.Annotations).To(HaveKeyWithValue(k8s.AnnotationLastUpdated, "now"))
+			})
+
+			It("does not change the pod template, to avoid restarting the instances", func() {
+				Expect(getUpdatedStatefulSet().Spec.Template).To(Equal(existingStatefulSet.Spec.Template))
+			})
+		})
+
+		When("the statefulset was desired by a traced request", func() {
+			BeforeEach(func() {
+				existingStatefulSet.Spec.Template.Annotations[tracing.AnnotationTraceParent] = "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"
+			})
+
+			It("keeps the trace context, to avoid restarting the instances", func() {
+				Expect(getUpdatedStatefulSet().Spec.Template).To(Equal(existingStatefulSet.Spec.Template))
+			})
+		})
+
+		When("the instances have been restarted", func() {
+			BeforeEach(func() {
+				existingStatefulSet.Spec.Template.Annotations[k8s.AnnotationRestartedAt] = "2020-09-13T12:26:40Z"
+			})
+
+			It("keeps the restart timestamp, to avoid restarting the instances again", func() {
+				Expect(getUpdatedStatefulSet().Spec.Template).To(Equal(existingStatefulSet.Spec.Template))
+			})
+		})
+
+		When("the restart generation changes", func() {
+			BeforeEach(func() {
+				updatedLRP.RestartGeneration = 2
+			})
+
+			It("stamps it on the pod template, to roll the instances", func() {
+				Expect(getUpdatedStatefulSet().Spec.Template.Annotations).To(HaveKeyWithValue(k8s.AnnotationRestartGeneration, "2"))
+			})
+		})
+
+		When("the lrp uses a private registry", func() {
+			BeforeEach(func() {
+				updatedLRP.PrivateRegistry = &opi.PrivateRegistry{
+					Server:   "host",
+					Username: "user",
+					Password: "password",
+				}
+			})
+
+			It("creates the registry credentials secret", func() {
+				Expect(secretsClient.CreateCallCount()).To(Equal(1))
+				_, namespace, secret := secretsClient.CreateArgsForCall(0)
+				Expect(namespace).To(Equal("the-namespace"))
+				Expect(secret.Name).To(HavePrefix(existingStatefulSet.Name))
+			})
+
+			When("the secret already exists", func() {
+				BeforeEach(func() {
+					secretsClient.CreateReturns(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "secret"))
+				})
+
+				It("updates it", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(secretsClient.UpdateCallCount()).To(Equal(1))
+				})
+			})
+
+			When("syncing the secret fails", func() {
+				BeforeEach(func() {
+					secretsClient.CreateReturns(nil, errors.New("boom"))
+				})
+
+				It("returns an error without updating the statefulset", func() {
+					Expect(err).To(MatchError(ContainSubstring("boom")))
+					Expect(statefulSetClient.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the lrp is scaled up", func() {
+			BeforeEach(func() {
+				updatedLRP.TargetInstances = 2
+			})
+
+			It("creates a pod disruption budget", func() {
+				Expect(pdbClient.CreateCallCount()).To(Equal(1))
+			})
+		})
+
+		When("the statefulset cannot be found", func() {
+			BeforeEach(func() {
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to update statefulset spec")))
+			})
+		})
+
+		When("the update fails", func() {
+			BeforeEach(func() {
+				statefulSetClient.UpdateReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		var statefulSets []appsv1.StatefulSet
+
+		BeforeEach(func() {
+			statefulSets = []appsv1.StatefulSet{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "baldur",
+						Namespace: "the-namespace",
+					},
+				},
+			}
+			statefulSetClient.GetByLRPIdentifierReturns(statefulSets, nil)
+			pdbClient.DeleteReturns(k8serrors.NewNotFound(schema.GroupResource{
+				Group:    "policy/v1beta1",
+				Resource: "PodDisruptionBudet",
+			},
+				"foo"))
+		})
 
 		It("deletes the statefulSet", func() {
-			Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+			Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
 			Expect(statefulSetClient.DeleteCallCount()).To(Equal(1))
-			namespace, name := statefulSetClient.DeleteArgsForCall(0)
+			_, namespace, name := statefulSetClient.DeleteArgsForCall(0)
 			Expect(namespace).To(Equal("the-namespace"))
 			Expect(name).To(Equal("baldur"))
 		})
 
 		It("should delete any corresponding pod disruption budgets", func() {
-			Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+			Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
 			Expect(pdbClient.DeleteCallCount()).To(Equal(1))
-			namespace, pdbName := pdbClient.DeleteArgsForCall(0)
+			_, namespace, pdbName := pdbClient.DeleteArgsForCall(0)
 			Expect(namespace).To(Equal("the-namespace"))
 			Expect(pdbName).To(Equal("baldur"))
 		})
 
+		It("should delete the corresponding network policy", func() {
+			Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+			Expect(networkPolicyClient.DeleteCallCount()).To(Equal(1))
+			_, namespace, npName := networkPolicyClient.DeleteArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(npName).To(Equal("baldur"))
+		})
+
+		When("network policy deletion fails", func() {
+			BeforeEach(func() {
+				networkPolicyClient.DeleteReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(MatchError(ContainSubstring("boom")))
+			})
+		})
+
 		When("the stateful set runs an image from a private registry", func() {
 			BeforeEach(func() {
 				statefulSets[0].Spec = appsv1.StatefulSetSpec{
@@ -794,9 +1568,9 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("deletes the secret holding the creds of the private registry", func() {
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
 				Expect(secretsClient.DeleteCallCount()).To(Equal(1))
-				secretNs, secretName := secretsClient.DeleteArgsForCall(0)
+				_, secretNs, secretName := secretsClient.DeleteArgsForCall(0)
 				Expect(secretName).To(Equal("baldur-registry-credentials"))
 				Expect(secretNs).To(Equal("the-namespace"))
 			})
@@ -807,7 +1581,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				})
 
 				It("returns the error", func() {
-					Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(MatchError(ContainSubstring("boom")))
+					Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(MatchError(ContainSubstring("boom")))
 				})
 			})
 
@@ -820,7 +1594,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				})
 
 				It("succeeds", func() {
-					Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+					Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
 				})
 			})
 		})
@@ -831,7 +1605,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should return a meaningful error", func() {
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).
 					To(MatchError(ContainSubstring("failed to delete statefulset")))
 			})
 		})
@@ -841,7 +1615,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{}}, nil)
 				statefulSetClient.DeleteReturnsOnCall(0, k8serrors.NewConflict(schema.GroupResource{}, "foo", errors.New("boom")))
 				statefulSetClient.DeleteReturnsOnCall(1, nil)
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(Succeed())
 				Expect(statefulSetClient.DeleteCallCount()).To(Equal(2))
 			})
 		})
@@ -850,7 +1624,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			It("returns an error", func() {
 				pdbClient.DeleteReturns(errors.New("boom"))
 
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(MatchError(ContainSubstring("boom")))
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})).To(MatchError(ContainSubstring("boom")))
 			})
 		})
 
@@ -860,7 +1634,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("should return a meaningful error", func() {
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{})).
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{})).
 					To(MatchError(ContainSubstring("failed to list statefulsets")))
 			})
 		})
@@ -871,11 +1645,11 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("succeeds", func() {
-				Expect(statefulSetDesirer.Stop(opi.LRPIdentifier{})).To(Succeed())
+				Expect(statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{})).To(Succeed())
 			})
 
 			It("logs useful information", func() {
-				_ = statefulSetDesirer.Stop(opi.LRPIdentifier{GUID: "missing_guid", Version: "some_version"})
+				_ = statefulSetDesirer.Stop(context.Background(), opi.LRPIdentifier{GUID: "missing_guid", Version: "some_version"})
 				Expect(logger).To(gbytes.Say("statefulset-does-not-exist.*missing_guid.*some_version"))
 			})
 		})
@@ -899,12 +1673,12 @@ var _ = Describe("Statefulset Desirer", func() {
 		})
 
 		It("deletes a pod instance", func() {
-			Expect(statefulSetDesirer.StopInstance(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 0)).
+			Expect(statefulSetDesirer.StopInstance(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 0)).
 				To(Succeed())
 
 			Expect(podsClient.DeleteCallCount()).To(Equal(1))
 
-			namespace, name := podsClient.DeleteArgsForCall(0)
+			_, namespace, name := podsClient.DeleteArgsForCall(0)
 			Expect(namespace).To(Equal("the-namespace"))
 			Expect(name).To(Equal("baldur-space-foo-34f869d015-0"))
 		})
@@ -912,7 +1686,7 @@ var _ = Describe("Statefulset Desirer", func() {
 		When("there's an internal K8s error", func() {
 			It("should return an error", func() {
 				statefulSetClient.GetByLRPIdentifierReturns(nil, errors.New("boom"))
-				Expect(statefulSetDesirer.StopInstance(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 1)).
+				Expect(statefulSetDesirer.StopInstance(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 1)).
 					To(MatchError(ContainSubstring("failed to list statefulsets")))
 			})
 		})
@@ -920,14 +1694,14 @@ var _ = Describe("Statefulset Desirer", func() {
 		When("the statefulset does not exist", func() {
 			It("succeeds", func() {
 				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
-				Expect(statefulSetDesirer.StopInstance(opi.LRPIdentifier{GUID: "some", Version: "thing"}, 1)).To(Succeed())
+				Expect(statefulSetDesirer.StopInstance(context.Background(), opi.LRPIdentifier{GUID: "some", Version: "thing"}, 1)).To(Succeed())
 			})
 		})
 
 		When("the instance index is invalid", func() {
 			It("returns an error", func() {
 				podsClient.DeleteReturns(errors.New("boom"))
-				Expect(statefulSetDesirer.StopInstance(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 42)).
+				Expect(statefulSetDesirer.StopInstance(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 42)).
 					To(MatchError(eirini.ErrInvalidInstanceIndex))
 			})
 		})
@@ -938,12 +1712,91 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 
 			It("succeeds", func() {
-				Expect(statefulSetDesirer.StopInstance(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 1)).
+				Expect(statefulSetDesirer.StopInstance(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}, 1)).
 					To(Succeed())
 			})
 		})
 	})
 
+	Describe("Restart", func() {
+		var (
+			statefulSet *appsv1.StatefulSet
+			err         error
+		)
+
+		BeforeEach(func() {
+			statefulSet = &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baldur-space-foo-34f869d015",
+					Namespace: "the-namespace",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: int32ptr(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{k8s.AnnotationLastUpdated: "yesterday"},
+						},
+					},
+				},
+			}
+
+			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{*statefulSet}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err = statefulSetDesirer.Restart(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+		})
+
+		It("stamps the restart time on the pod template", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statefulSetClient.UpdateCallCount()).To(Equal(1))
+
+			_, namespace, updated := statefulSetClient.UpdateArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(updated.Spec.Template.Annotations).To(HaveKeyWithValue(k8s.AnnotationLastUpdated, "yesterday"))
+
+			restartedAt, parseErr := time.Parse(time.RFC3339Nano, updated.Spec.Template.Annotations[k8s.AnnotationRestartedAt])
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(restartedAt).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("does not change the number of instances", func() {
+			_, _, updated := statefulSetClient.UpdateArgsForCall(0)
+			Expect(*updated.Spec.Replicas).To(Equal(int32(2)))
+		})
+
+		When("the statefulset does not exist", func() {
+			BeforeEach(func() {
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.Is(err, eirini.ErrNotFound)).To(BeTrue())
+			})
+		})
+
+		When("the update conflicts", func() {
+			BeforeEach(func() {
+				statefulSetClient.UpdateReturnsOnCall(0, nil, k8serrors.NewConflict(schema.GroupResource{}, "foo", errors.New("boom")))
+			})
+
+			It("retries", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(statefulSetClient.UpdateCallCount()).To(Equal(2))
+			})
+		})
+
+		When("the update fails", func() {
+			BeforeEach(func() {
+				statefulSetClient.UpdateReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("failed to restart statefulset")))
+			})
+		})
+	})
+
 	Describe("GetInstances", func() {
 		BeforeEach(func() {
 			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{}}, nil)
@@ -958,11 +1811,12 @@ var _ = Describe("Statefulset Desirer", func() {
 			podsClient.GetByLRPIdentifierReturns(pods, nil)
 			eventsClient.GetByPodReturns([]corev1.Event{}, nil)
 
-			_, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+			_, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(podsClient.GetByLRPIdentifierCallCount()).To(Equal(1))
-			Expect(podsClient.GetByLRPIdentifierArgsForCall(0)).To(Equal(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}))
+			_, identifier := podsClient.GetByLRPIdentifierArgsForCall(0)
+			Expect(identifier).To(Equal(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}))
 		})
 
 		It("should return the correct number of instances", func() {
@@ -973,7 +1827,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			}
 			podsClient.GetByLRPIdentifierReturns(pods, nil)
 			eventsClient.GetByPodReturns([]corev1.Event{}, nil)
-			instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+			instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(instances).To(HaveLen(3))
 		})
@@ -1000,7 +1854,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 			podsClient.GetByLRPIdentifierReturns(pods, nil)
 			eventsClient.GetByPodReturns([]corev1.Event{}, nil)
-			instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+			instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(instances).To(HaveLen(1))
@@ -1014,7 +1868,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			It("should return a meaningful error", func() {
 				podsClient.GetByLRPIdentifierReturns(nil, errors.New("boom"))
 
-				_, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+				_, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 				Expect(err).To(MatchError(ContainSubstring("failed to list pods")))
 			})
 		})
@@ -1023,7 +1877,7 @@ var _ = Describe("Statefulset Desirer", func() {
 			It("should return an error", func() {
 				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
 
-				_, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{GUID: "does-not", Version: "exist"})
+				_, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{GUID: "does-not", Version: "exist"})
 				Expect(err).To(Equal(eirini.ErrNotFound))
 			})
 		})
@@ -1037,7 +1891,7 @@ var _ = Describe("Statefulset Desirer", func() {
 
 				eventsClient.GetByPodReturns(nil, errors.New("I am error"))
 
-				_, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+				_, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
 				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("failed to get events for pod %s", "odin-0"))))
 			})
 		})
@@ -1050,7 +1904,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				podsClient.GetByLRPIdentifierReturns(pods, nil)
 				eventsClient.GetByPodReturns([]corev1.Event{}, nil)
 
-				instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+				instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(instances).To(HaveLen(1))
 				Expect(instances[0].Since).To(Equal(int64(0)))
@@ -1076,7 +1930,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				})
 
 				It("returns insufficient memory response", func() {
-					instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+					instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(instances).To(HaveLen(1))
 					Expect(instances[0].PlacementError).To(Equal(opi.InsufficientMemoryError))
@@ -1094,7 +1948,7 @@ var _ = Describe("Statefulset Desirer", func() {
 				})
 
 				It("returns insufficient memory response", func() {
-					instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+					instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(instances).To(HaveLen(1))
 					Expect(instances[0].PlacementError).To(Equal(opi.InsufficientMemoryError))
@@ -1102,6 +1956,28 @@ var _ = Describe("Statefulset Desirer", func() {
 			})
 		})
 
+		When("a rolling restart is in progress", func() {
+			BeforeEach(func() {
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{
+					Status: appsv1.StatefulSetStatus{CurrentRevision: "rev-1", UpdateRevision: "rev-2"},
+				}}, nil)
+
+				podsClient.GetByLRPIdentifierReturns([]corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Name: "odin-0", Labels: map[string]string{appsv1.ControllerRevisionHashLabelKey: "rev-1"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "odin-1", Labels: map[string]string{appsv1.ControllerRevisionHashLabelKey: "rev-2"}}},
+				}, nil)
+				eventsClient.GetByPodReturns([]corev1.Event{}, nil)
+			})
+
+			It("reports the instances yet to be replaced as outdated", func() {
+				instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(instances).To(HaveLen(2))
+				Expect(instances[0].Outdated).To(BeTrue())
+				Expect(instances[1].Outdated).To(BeFalse())
+			})
+		})
+
 		When("the StatefulSet was deleted/stopped", func() {
 			It("should return a default value", func() {
 				event1 := corev1.Event{
@@ -1130,12 +2006,223 @@ var _ = Describe("Statefulset Desirer", func() {
 				}
 				podsClient.GetByLRPIdentifierReturns(pods, nil)
 
-				instances, err := statefulSetDesirer.GetInstances(opi.LRPIdentifier{})
+				instances, err := statefulSetDesirer.GetInstances(context.Background(), opi.LRPIdentifier{})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(instances).To(HaveLen(0))
 			})
 		})
 	})
+
+	Describe("reading through a StatefulSetLister", func() {
+		var (
+			lister     *k8sfakes.FakeStatefulSetLister
+			identifier opi.LRPIdentifier
+		)
+
+		BeforeEach(func() {
+			identifier = opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"}
+
+			st := appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "baldur",
+					Namespace:   "the-namespace",
+					Annotations: map[string]string{},
+				},
+				Spec: appsv1.StatefulSetSpec{Replicas: int32ptr(2)},
+			}
+			st.Spec.Template.Annotations = map[string]string{}
+			lister = new(k8sfakes.FakeStatefulSetLister)
+			lister.GetBySourceTypeReturns([]appsv1.StatefulSet{st}, nil)
+			lister.GetByLRPIdentifierReturns([]appsv1.StatefulSet{st}, nil)
+			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{st}, nil)
+
+			statefulSetDesirer.StatefulSetLister = lister
+		})
+
+		It("lists the LRPs from the lister", func() {
+			_, err := statefulSetDesirer.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.GetBySourceTypeCallCount()).To(Equal(1))
+			Expect(statefulSetClient.GetBySourceTypeCallCount()).To(BeZero())
+		})
+
+		It("gets the LRP from the lister", func() {
+			_, err := statefulSetDesirer.Get(context.Background(), identifier)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+
+		It("gets the instances from the lister", func() {
+			_, err := statefulSetDesirer.GetInstances(context.Background(), identifier)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lister.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+
+		It("reads the statefulset it stops from the API server", func() {
+			Expect(statefulSetDesirer.Stop(context.Background(), identifier)).To(Succeed())
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(lister.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+
+		It("reads the statefulset of the instance it stops from the API server", func() {
+			Expect(statefulSetDesirer.StopInstance(context.Background(), identifier, 1)).To(Succeed())
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(lister.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+
+		It("reads the statefulset it updates from the API server", func() {
+			Expect(statefulSetDesirer.Update(context.Background(), &opi.LRP{
+				LRPIdentifier:   identifier,
+				TargetInstances: 3,
+			})).To(Succeed())
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(lister.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+
+		It("reads the statefulset it restarts from the API server", func() {
+			Expect(statefulSetDesirer.Restart(context.Background(), identifier)).To(Succeed())
+			Expect(statefulSetClient.GetByLRPIdentifierCallCount()).To(Equal(1))
+			Expect(lister.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("GetInstanceStats", func() {
+		var (
+			podUsage  *k8sfakes.FakePodUsageCollector
+			pods      []corev1.Pod
+			stats     []*opi.InstanceStats
+			statsErr  error
+			startedAt metav1.Time
+		)
+
+		BeforeEach(func() {
+			statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{}}, nil)
+			eventsClient.GetByPodReturns([]corev1.Event{}, nil)
+
+			startedAt = metav1.NewTime(time.Now().Add(-time.Hour))
+			pods = []corev1.Pod{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "odin-0"},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "sidecar", Ports: []corev1.ContainerPort{{ContainerPort: 9999}}},
+							{Name: k8s.OPIContainerName, Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 2222, HostPort: 32222}}},
+						},
+					},
+					Status: corev1.PodStatus{
+						Phase:  corev1.PodRunning,
+						HostIP: "10.0.0.1",
+						PodIP:  "172.16.0.1",
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								Name:  k8s.OPIContainerName,
+								State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}},
+								Ready: true,
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "odin-1"},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: k8s.OPIContainerName}}},
+					Status:     corev1.PodStatus{Phase: corev1.PodPending},
+				},
+			}
+			podsClient.GetByLRPIdentifierReturns(pods, nil)
+
+			podUsage = new(k8sfakes.FakePodUsageCollector)
+			podUsage.CollectPodUsageReturns(map[string]k8s.PodUsage{
+				"odin-0": {CPU: 12.5, Memory: 1024, MemoryQuota: 2048, Disk: 4096, DiskQuota: 8192},
+			})
+			statefulSetDesirer.PodUsage = podUsage
+		})
+
+		JustBeforeEach(func() {
+			stats, statsErr = statefulSetDesirer.GetInstanceStats(context.Background(), opi.LRPIdentifier{GUID: "guid_1234", Version: "version_1234"})
+		})
+
+		It("collects the usage of the instance pods", func() {
+			Expect(statsErr).NotTo(HaveOccurred())
+			Expect(podUsage.CollectPodUsageCallCount()).To(Equal(1))
+			_, actualPods := podUsage.CollectPodUsageArgsForCall(0)
+			Expect(actualPods).To(Equal(pods))
+		})
+
+		It("returns the stats of each instance", func() {
+			Expect(stats).To(HaveLen(2))
+
+			Expect(stats[0].Index).To(Equal(0))
+			Expect(stats[0].State).To(Equal(opi.RunningState))
+			Expect(stats[0].Host).To(Equal("10.0.0.1"))
+			Expect(stats[0].InternalIP).To(Equal("172.16.0.1"))
+			Expect(stats[0].Ports).To(Equal([]opi.PortMapping{
+				{ContainerPort: 8080},
+				{ContainerPort: 2222, HostPort: 32222},
+			}))
+			Expect(stats[0].Uptime).To(BeNumerically("~", 3600, 5))
+			Expect(stats[0].Usage).To(Equal(opi.InstanceUsage{CPU: 12.5, Memory: 1024, MemoryQuota: 2048, Disk: 4096, DiskQuota: 8192}))
+
+			Expect(stats[1].Index).To(Equal(1))
+			Expect(stats[1].Ports).To(BeEmpty())
+			Expect(stats[1].Uptime).To(BeZero())
+			Expect(stats[1].Usage).To(BeZero())
+		})
+
+		When("there is no pod usage collector", func() {
+			BeforeEach(func() {
+				statefulSetDesirer.PodUsage = nil
+			})
+
+			It("returns the stats without usage", func() {
+				Expect(statsErr).NotTo(HaveOccurred())
+				Expect(stats).To(HaveLen(2))
+				Expect(stats[0].Host).To(Equal("10.0.0.1"))
+				Expect(stats[0].Usage).To(BeZero())
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				statefulSetClient.GetByLRPIdentifierReturns([]appsv1.StatefulSet{}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(statsErr).To(Equal(eirini.ErrNotFound))
+				Expect(podUsage.CollectPodUsageCallCount()).To(BeZero())
+			})
+		})
+
+		When("listing the pods fails", func() {
+			BeforeEach(func() {
+				podsClient.GetByLRPIdentifierReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(statsErr).To(MatchError(ContainSubstring("failed to list pods")))
+			})
+		})
+
+		When("an instance is stopped", func() {
+			BeforeEach(func() {
+				eventsClient.GetByPodStub = func(_ context.Context, pod corev1.Pod) ([]corev1.Event, error) {
+					if pod.Name == "odin-0" {
+						return []corev1.Event{{Reason: "Killing"}}, nil
+					}
+
+					return []corev1.Event{}, nil
+				}
+			})
+
+			It("skips it", func() {
+				Expect(stats).To(HaveLen(1))
+				Expect(stats[0].Index).To(Equal(1))
+				_, actualPods := podUsage.CollectPodUsageArgsForCall(0)
+				Expect(actualPods).To(Equal(pods[1:]))
+			})
+		})
+	})
 })
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
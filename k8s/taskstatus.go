@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"code.cloudfoundry.org/eirini/opi"
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
+	batch "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetTaskStatus derives the state of a task from the task container of its
+// most recent pod, falling back to the job status when the pod is gone.
+func GetTaskStatus(job batch.Job, pods []corev1.Pod) opi.TaskStatus {
+	status := opi.TaskStatus{State: opi.TaskPendingState}
+
+	if containerStatus, ok := getLatestTaskContainerStatus(pods); ok {
+		switch {
+		case containerStatus.State.Terminated != nil:
+			terminated := containerStatus.State.Terminated
+			exitCode := terminated.ExitCode
+			status.StartTime = terminated.StartedAt.Unix()
+			status.EndTime = terminated.FinishedAt.Unix()
+			status.ExitCode = &exitCode
+			status.State = opi.TaskSucceededState
+
+			if exitCode != 0 {
+				status.State = opi.TaskFailedState
+				status.FailureReason = terminated.Reason
+			}
+
+			return status
+		case containerStatus.State.Running != nil:
+			status.State = opi.TaskRunningState
+			status.StartTime = containerStatus.State.Running.StartedAt.Unix()
+
+			return status
+		}
+	}
+
+	if job.Status.StartTime != nil && (job.Status.Succeeded > 0 || job.Status.Failed > 0) {
+		status.StartTime = job.Status.StartTime.Unix()
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batch.JobComplete:
+			status.State = opi.TaskSucceededState
+			status.EndTime = condition.LastTransitionTime.Unix()
+		case batch.JobFailed:
+			status.State = opi.TaskFailedState
+			status.EndTime = condition.LastTransitionTime.Unix()
+			status.FailureReason = condition.Reason
+		}
+	}
+
+	return status
+}
+
+func getLatestTaskContainerStatus(pods []corev1.Pod) (corev1.ContainerStatus, bool) {
+	var latest *corev1.Pod
+
+	for i := range pods {
+		if latest == nil || latest.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			latest = &pods[i]
+		}
+	}
+
+	if latest == nil {
+		return corev1.ContainerStatus{}, false
+	}
+
+	containerName := latest.Annotations[AnnotationOpiTaskContainerName]
+	if containerName == "" {
+		containerName = opiTaskContainerName
+	}
+
+	for _, status := range latest.Status.ContainerStatuses {
+		if status.Name == containerName {
+			return status, true
+		}
+	}
+
+	return corev1.ContainerStatus{}, false
+}
+
+// ToTaskCRStatus converts the observed state of a task into the status of
+// its Task custom resource.
+func ToTaskCRStatus(status opi.TaskStatus) eiriniv1.TaskStatus {
+	crStatus := eiriniv1.TaskStatus{
+		Phase:         toTaskPhase(status.State),
+		ExitCode:      status.ExitCode,
+		FailureReason: status.FailureReason,
+	}
+
+	if status.StartTime != 0 {
+		startTime := metav1.Unix(status.StartTime, 0)
+		crStatus.StartTime = &startTime
+	}
+
+	if status.EndTime != 0 {
+		completionTime := metav1.Unix(status.EndTime, 0)
+		crStatus.CompletionTime = &completionTime
+	}
+
+	return crStatus
+}
+
+func toTaskPhase(state string) eiriniv1.TaskPhase {
+	switch state {
+	case opi.TaskRunningState:
+		return eiriniv1.TaskRunning
+	case opi.TaskSucceededState:
+		return eiriniv1.TaskSucceeded
+	case opi.TaskFailedState:
+		return eiriniv1.TaskFailed
+	default:
+		return eiriniv1.TaskPending
+	}
+}
@@ -42,3 +42,12 @@ func (c *Config) JSON() (string, error) {
 
 	return string(jsonBytes), nil
 }
+
+func ParseConfig(configJSON []byte) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(configJSON, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal docker config json")
+	}
+
+	return config, nil
+}
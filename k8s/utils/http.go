@@ -2,34 +2,38 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 
+	"code.cloudfoundry.org/eirini/tracing"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/propagation"
 )
 
-func Put(client *http.Client, uri string, body interface{}) error {
-	return do("PUT", client, uri, body)
+func Put(ctx context.Context, client *http.Client, uri string, body interface{}) error {
+	return do(ctx, "PUT", client, uri, body)
 }
 
-func Post(client *http.Client, uri string, body interface{}) error {
-	return do("POST", client, uri, body)
+func Post(ctx context.Context, client *http.Client, uri string, body interface{}) error {
+	return do(ctx, "POST", client, uri, body)
 }
 
-func do(method string, client *http.Client, uri string, body interface{}) error {
+func do(ctx context.Context, method string, client *http.Client, uri string, body interface{}) error {
 	bodyJSON, err := json.Marshal(body)
 	if err != nil {
 		return errors.Wrap(err, "cannot marshal body")
 	}
 
-	req, err := http.NewRequest(method, uri, bytes.NewBuffer(bodyJSON))
+	req, err := http.NewRequestWithContext(ctx, method, uri, bytes.NewBuffer(bodyJSON))
 	if err != nil {
 		return errors.Wrap(err, "failed to create request")
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	tracing.Propagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	resp, err := client.Do(req)
 	if err != nil {
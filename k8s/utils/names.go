@@ -10,6 +10,8 @@ import (
 	"github.com/pkg/errors"
 )
 
+const maxLabelLen = 63
+
 func SanitizeName(name, fallback string) string {
 	return SanitizeNameWithMaxStringLen(name, fallback, 40)
 }
@@ -25,6 +27,25 @@ func SanitizeNameWithMaxStringLen(name, fallback string, maxStringLen int) strin
 	return truncateString(fallback, maxStringLen)
 }
 
+// SanitizeLabelName is like SanitizeName, but returns a DNS-1123 label, as
+// required for container names.
+func SanitizeLabelName(name, fallback string) string {
+	validLabelRegex := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	sanitizedName := strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+
+	if validLabelRegex.MatchString(sanitizedName) {
+		return SuffixLabelName(sanitizedName, "")
+	}
+
+	return SuffixLabelName(fallback, "")
+}
+
+// SuffixLabelName appends the suffix to a DNS-1123 label, truncating the
+// label so that the result is still valid.
+func SuffixLabelName(name, suffix string) string {
+	return strings.TrimRight(truncateString(name, maxLabelLen-len(suffix)), "-") + suffix
+}
+
 func truncateString(str string, num int) string {
 	if len(str) > num {
 		return str[0:num]
@@ -1,6 +1,8 @@
 package utils_test
 
 import (
+	"strings"
+
 	. "code.cloudfoundry.org/eirini/k8s/utils"
 	"code.cloudfoundry.org/eirini/opi"
 	. "github.com/onsi/ginkgo"
@@ -26,6 +28,35 @@ var _ = Describe("Names", func() {
 		})
 	})
 
+	Describe("SanitizeLabelName", func() {
+		It("should lower case the names", func() {
+			Expect(SanitizeLabelName("ALL-CAPS-but-not", "guid")).To(Equal("all-caps-but-not"))
+		})
+
+		It("should replace underscores and dots with minus", func() {
+			Expect(SanitizeLabelName("under_score.dot", "guid")).To(Equal("under-score-dot"))
+		})
+
+		It("should fallback to give fallback string if name contains unsupported chracters", func() {
+			Expect(SanitizeLabelName("डोरा-дора-dora", "guid")).To(Equal("guid"))
+		})
+
+		It("truncates names to 63 characters without a trailing minus", func() {
+			name := SanitizeLabelName(strings.Repeat("a", 62)+"-bcd", "guid")
+			Expect(name).To(Equal(strings.Repeat("a", 62)))
+		})
+	})
+
+	Describe("SuffixLabelName", func() {
+		It("appends the suffix", func() {
+			Expect(SuffixLabelName("name", "-1")).To(Equal("name-1"))
+		})
+
+		It("truncates the name to make room for the suffix", func() {
+			Expect(SuffixLabelName(strings.Repeat("a", 63), "-12")).To(Equal(strings.Repeat("a", 60) + "-12"))
+		})
+	})
+
 	Describe("GetStatefulsetName", func() {
 		It("calculates the name of an app's backing statefulset", func() {
 			statefulsetName, err := GetStatefulsetName(&opi.LRP{
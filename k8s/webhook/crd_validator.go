@@ -0,0 +1,259 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
+	"code.cloudfoundry.org/lager"
+	"github.com/docker/distribution/reference"
+	"k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	maxPort = 65535
+
+	ValidateLRPPath  = "/validate-lrp"
+	ValidateTaskPath = "/validate-task"
+)
+
+var healthCheckTypes = sets.NewString("", "none", "process", "port", "http")
+
+// LRPValidator is a validating admission webhook rejecting LRP resources
+// that could not be turned into a workload.
+type LRPValidator struct {
+	logger  lager.Logger
+	decoder *admission.Decoder
+}
+
+func NewLRPValidator(logger lager.Logger) *LRPValidator {
+	return &LRPValidator{logger: logger}
+}
+
+func (v *LRPValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+
+	return nil
+}
+
+func (v *LRPValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	lrp := &eiriniv1.LRP{}
+
+	return validate(v.logger.Session("validate-lrp"), v.decoder, req, lrp, eiriniv1.Kind("LRP"), func() field.ErrorList {
+		return ValidateLRP(lrp)
+	})
+}
+
+// TaskValidator is a validating admission webhook rejecting Task resources
+// that could not be turned into a job.
+type TaskValidator struct {
+	logger  lager.Logger
+	decoder *admission.Decoder
+}
+
+func NewTaskValidator(logger lager.Logger) *TaskValidator {
+	return &TaskValidator{logger: logger}
+}
+
+func (v *TaskValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+
+	return nil
+}
+
+func (v *TaskValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	task := &eiriniv1.Task{}
+
+	return validate(v.logger.Session("validate-task"), v.decoder, req, task, eiriniv1.Kind("Task"), func() field.ErrorList {
+		return ValidateTask(task)
+	})
+}
+
+func validate(
+	logger lager.Logger,
+	decoder *admission.Decoder,
+	req admission.Request,
+	obj runtime.Object,
+	kind schema.GroupKind,
+	validateFn func() field.ErrorList,
+) admission.Response {
+	if req.Operation != v1beta1.Create && req.Operation != v1beta1.Update {
+		return admission.Allowed("only creates and updates are validated")
+	}
+
+	if decoder == nil {
+		err := errors.New("no decoder has been injected")
+		logger.Error("missing-decoder", err)
+
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if err := decoder.Decode(req, obj); err != nil {
+		logger.Error("failed-to-decode-request", err)
+
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if errs := validateFn(); len(errs) > 0 {
+		logger.Info("rejected", lager.Data{"name": req.Name, "namespace": req.Namespace, "errors": errs.ToAggregate().Error()})
+
+		// Denied only sets the status reason, which kubectl does not show
+		resp := admission.Denied("")
+		resp.Result = &apierrors.NewInvalid(kind, req.Name, errs).ErrStatus
+
+		return resp
+	}
+
+	return admission.Allowed("")
+}
+
+func ValidateLRP(lrp *eiriniv1.LRP) field.ErrorList {
+	spec := lrp.Spec
+	specPath := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	errs = append(errs, validateRequired(specPath.Child("GUID"), spec.GUID)...)
+	errs = append(errs, validateRequired(specPath.Child("version"), spec.Version)...)
+	errs = append(errs, validateImage(specPath.Child("image"), spec.Image)...)
+
+	if spec.Instances < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("instances"), spec.Instances, "must be greater than or equal to 0"))
+	}
+
+	if spec.DiskMB <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("diskMB"), spec.DiskMB, "must be greater than 0"))
+	}
+
+	if spec.MemoryMB < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("memoryMB"), spec.MemoryMB, "must be greater than or equal to 0"))
+	}
+
+	for i, port := range spec.Ports {
+		errs = append(errs, validatePort(specPath.Child("ports").Index(i), port)...)
+	}
+
+	errs = append(errs, validateHealthCheck(specPath.Child("health"), spec.Health)...)
+	errs = append(errs, validateSidecars(specPath.Child("sidecars"), spec.Sidecars)...)
+	errs = append(errs, validateVolumeMounts(specPath.Child("volumeMounts"), spec.VolumeMounts)...)
+
+	return errs
+}
+
+func ValidateTask(task *eiriniv1.Task) field.ErrorList {
+	spec := task.Spec
+	specPath := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	errs = append(errs, validateRequired(specPath.Child("guid"), spec.GUID)...)
+	errs = append(errs, validateImage(specPath.Child("image"), spec.Image)...)
+
+	if spec.DiskMB < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("diskMB"), spec.DiskMB, "must be greater than or equal to 0"))
+	}
+
+	if spec.MemoryMB < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("memoryMB"), spec.MemoryMB, "must be greater than or equal to 0"))
+	}
+
+	return errs
+}
+
+func validateRequired(fldPath *field.Path, value string) field.ErrorList {
+	if value == "" {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+
+	return nil
+}
+
+func validateImage(fldPath *field.Path, image string) field.ErrorList {
+	if image == "" {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+
+	if _, err := reference.ParseNormalizedNamed(image); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, image, err.Error())}
+	}
+
+	return nil
+}
+
+func validatePort(fldPath *field.Path, port int32) field.ErrorList {
+	if port < 1 || port > maxPort {
+		return field.ErrorList{field.Invalid(fldPath, port, "must be between 1 and 65535")}
+	}
+
+	return nil
+}
+
+func validateHealthCheck(fldPath *field.Path, health eiriniv1.Healtcheck) field.ErrorList {
+	if !healthCheckTypes.Has(health.Type) {
+		return field.ErrorList{field.NotSupported(fldPath.Child("type"), health.Type, healthCheckTypes.List())}
+	}
+
+	errs := field.ErrorList{}
+
+	if health.Type == "port" || health.Type == "http" {
+		errs = append(errs, validatePort(fldPath.Child("port"), health.Port)...)
+	}
+
+	if health.Type == "http" && health.Endpoint == "" {
+		errs = append(errs, field.Required(fldPath.Child("endpoint"), "required for http health checks"))
+	}
+
+	return errs
+}
+
+func validateSidecars(fldPath *field.Path, sidecars []eiriniv1.Sidecar) field.ErrorList {
+	errs := field.ErrorList{}
+	names := sets.NewString()
+
+	for i, sidecar := range sidecars {
+		sidecarPath := fldPath.Index(i)
+
+		switch {
+		case sidecar.Name == "":
+			errs = append(errs, field.Required(sidecarPath.Child("name"), ""))
+		case names.Has(sidecar.Name):
+			errs = append(errs, field.Duplicate(sidecarPath.Child("name"), sidecar.Name))
+		default:
+			names.Insert(sidecar.Name)
+		}
+
+		if len(sidecar.Command) == 0 {
+			errs = append(errs, field.Required(sidecarPath.Child("command"), ""))
+		}
+
+		if sidecar.MemoryMB < 0 {
+			errs = append(errs, field.Invalid(sidecarPath.Child("memoryMB"), sidecar.MemoryMB, "must be greater than or equal to 0"))
+		}
+	}
+
+	return errs
+}
+
+func validateVolumeMounts(fldPath *field.Path, volumeMounts []eiriniv1.VolumeMount) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for i, volumeMount := range volumeMounts {
+		mountPath := fldPath.Index(i)
+
+		if volumeMount.ClaimName == "" {
+			errs = append(errs, field.Required(mountPath.Child("claimName"), ""))
+		}
+
+		if !path.IsAbs(volumeMount.MountPath) {
+			errs = append(errs, field.Invalid(mountPath.Child("mountPath"), volumeMount.MountPath, "must be an absolute path"))
+		}
+	}
+
+	return errs
+}
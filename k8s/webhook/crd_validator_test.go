@@ -0,0 +1,293 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini/k8s/webhook"
+	eiriniv1 "code.cloudfoundry.org/eirini/pkg/apis/eirini/v1"
+	eirinischeme "code.cloudfoundry.org/eirini/pkg/generated/clientset/versioned/scheme"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("CRD validation", func() {
+	var (
+		handler   admission.Handler
+		operation v1beta1.Operation
+		object    runtime.Object
+		resp      admission.Response
+	)
+
+	injectDecoder := func(h admission.DecoderInjector) {
+		decoder, err := admission.NewDecoder(eirinischeme.Scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(h.InjectDecoder(decoder)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		operation = v1beta1.Create
+	})
+
+	JustBeforeEach(func() {
+		raw, err := json.Marshal(object)
+		Expect(err).NotTo(HaveOccurred())
+
+		resp = handler.Handle(context.Background(), admission.Request{
+			AdmissionRequest: v1beta1.AdmissionRequest{
+				Operation: operation,
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+	})
+
+	Describe("LRPValidator", func() {
+		var lrp *eiriniv1.LRP
+
+		BeforeEach(func() {
+			validator := webhook.NewLRPValidator(lagertest.NewTestLogger("lrp-validator"))
+			injectDecoder(validator)
+			handler = validator
+
+			lrp = &eiriniv1.LRP{
+				TypeMeta:   metav1.TypeMeta{Kind: "LRP", APIVersion: "eirini.cloudfoundry.org/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-lrp", Namespace: "my-ns"},
+				Spec: eiriniv1.LRPSpec{
+					GUID:      "guid",
+					Version:   "version",
+					Image:     "eirini/dorini:latest",
+					Instances: 2,
+					DiskMB:    512,
+					MemoryMB:  256,
+					Ports:     []int32{8080},
+					Health: eiriniv1.Healtcheck{
+						Type:     "http",
+						Port:     8080,
+						Endpoint: "/healthz",
+					},
+					Sidecars: []eiriniv1.Sidecar{
+						{Name: "sidecar", Command: []string{"run"}, MemoryMB: 64},
+					},
+					VolumeMounts: []eiriniv1.VolumeMount{
+						{MountPath: "/data", ClaimName: "claim"},
+					},
+				},
+			}
+			object = lrp
+		})
+
+		It("allows a valid LRP", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		When("the disk quota is zero", func() {
+			BeforeEach(func() {
+				lrp.Spec.DiskMB = 0
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Code).To(BeNumerically("==", http.StatusUnprocessableEntity))
+				Expect(resp.Result.Message).To(ContainSubstring("spec.diskMB: Invalid value: 0: must be greater than 0"))
+			})
+		})
+
+		When("the number of instances is negative", func() {
+			BeforeEach(func() {
+				lrp.Spec.Instances = -1
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.instances: Invalid value: -1"))
+			})
+		})
+
+		When("the health check type is not supported", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Type = "telepathy"
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(`spec.health.type: Unsupported value: "telepathy"`))
+			})
+		})
+
+		When("the http health check has no endpoint", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health.Endpoint = ""
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.health.endpoint: Required value"))
+			})
+		})
+
+		When("the port health check has an invalid port", func() {
+			BeforeEach(func() {
+				lrp.Spec.Health = eiriniv1.Healtcheck{Type: "port", Port: 0}
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.health.port: Invalid value: 0: must be between 1 and 65535"))
+			})
+		})
+
+		When("an exposed port is out of range", func() {
+			BeforeEach(func() {
+				lrp.Spec.Ports = []int32{8080, 70000}
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.ports[1]: Invalid value: 70000"))
+			})
+		})
+
+		When("the image reference is malformed", func() {
+			BeforeEach(func() {
+				lrp.Spec.Image = "Not A Valid/Image::ref"
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(`spec.image: Invalid value: "Not A Valid/Image::ref"`))
+			})
+		})
+
+		When("the image is missing", func() {
+			BeforeEach(func() {
+				lrp.Spec.Image = ""
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.image: Required value"))
+			})
+		})
+
+		When("two sidecars share a name", func() {
+			BeforeEach(func() {
+				lrp.Spec.Sidecars = append(lrp.Spec.Sidecars, eiriniv1.Sidecar{Name: "sidecar", Command: []string{"run"}})
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(`spec.sidecars[1].name: Duplicate value: "sidecar"`))
+			})
+		})
+
+		When("a volume mount path is relative", func() {
+			BeforeEach(func() {
+				lrp.Spec.VolumeMounts[0].MountPath = "data"
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(`spec.volumeMounts[0].mountPath: Invalid value: "data": must be an absolute path`))
+			})
+		})
+
+		When("several fields are invalid", func() {
+			BeforeEach(func() {
+				lrp.Spec.DiskMB = 0
+				lrp.Spec.Instances = -1
+			})
+
+			It("reports all of them", func() {
+				Expect(resp.Result.Message).To(ContainSubstring("spec.diskMB"))
+				Expect(resp.Result.Message).To(ContainSubstring("spec.instances"))
+			})
+		})
+
+		When("the LRP is being deleted", func() {
+			BeforeEach(func() {
+				operation = v1beta1.Delete
+				lrp.Spec.DiskMB = 0
+			})
+
+			It("allows the request", func() {
+				Expect(resp.Allowed).To(BeTrue())
+			})
+		})
+
+		When("the request cannot be decoded", func() {
+			BeforeEach(func() {
+				object = &runtime.Unknown{Raw: []byte(`{"spec": "nope"}`)}
+			})
+
+			It("returns a bad request error", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Code).To(BeNumerically("==", http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("TaskValidator", func() {
+		var task *eiriniv1.Task
+
+		BeforeEach(func() {
+			validator := webhook.NewTaskValidator(lagertest.NewTestLogger("task-validator"))
+			injectDecoder(validator)
+			handler = validator
+
+			task = &eiriniv1.Task{
+				TypeMeta:   metav1.TypeMeta{Kind: "Task", APIVersion: "eirini.cloudfoundry.org/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "my-task", Namespace: "my-ns"},
+				Spec: eiriniv1.TaskSpec{
+					GUID:     "guid",
+					Image:    "eirini/busybox",
+					MemoryMB: 256,
+					DiskMB:   512,
+				},
+			}
+			object = task
+		})
+
+		It("allows a valid task", func() {
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		When("the guid is missing", func() {
+			BeforeEach(func() {
+				task.Spec.GUID = ""
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.guid: Required value"))
+			})
+		})
+
+		When("the image reference is malformed", func() {
+			BeforeEach(func() {
+				task.Spec.Image = "UPPER/case"
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring(`spec.image: Invalid value: "UPPER/case"`))
+			})
+		})
+
+		When("the memory limit is negative", func() {
+			BeforeEach(func() {
+				task.Spec.MemoryMB = -1
+			})
+
+			It("denies the request", func() {
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Message).To(ContainSubstring("spec.memoryMB: Invalid value: -1"))
+			})
+		})
+	})
+})
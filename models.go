@@ -11,6 +11,10 @@ const (
 	EnvStagingGUID        = "STAGING_GUID"
 	EnvCompletionCallback = "COMPLETION_CALLBACK"
 	EnvEiriniAddress      = "EIRINI_ADDRESS"
+	EnvBuildpacks         = "BUILDPACKS"
+	EnvCFStack            = "CF_STACK"
+	EnvDropletHash        = "DROPLET_HASH"
+	EnvDropletDestination = "DROPLET_DESTINATION"
 
 	EnvPodName              = "POD_NAME"
 	EnvCFInstanceIP         = "CF_INSTANCE_IP"
@@ -27,6 +31,12 @@ const (
 	RecipeOutputLocation   = "/out"
 	RecipePacksBuilderPath = "/packs/builder"
 
+	// Buildpack apps:
+	DropletVolumeName = "droplet"
+	DropletMountPath  = "/home/vcap"
+	DropletAppDir     = "/home/vcap/app"
+	LauncherPath      = "/lifecycle/launcher"
+
 	AppMetricsEmissionIntervalInSecs = 15
 
 	// Staging TLS:
@@ -63,6 +73,10 @@ var ErrNotFound = errors.New("not found")
 
 var ErrInvalidInstanceIndex = errors.New("invalid instance index")
 
+var ErrConflict = errors.New("conflict")
+
+var ErrInvalidRequest = errors.New("invalid request")
+
 type Config struct {
 	Properties         Properties `yaml:"opi"`
 	WorkloadsNamespace string
@@ -97,6 +111,86 @@ type Properties struct { //nolint:maligned
 	UnsafeAllowAutomountServiceAccountToken bool `yaml:"unsafe_allow_automount_service_account_token"`
 
 	ServePlaintext bool `yaml:"serve_plaintext"`
+
+	// ServerReadTimeoutSeconds and ServerWriteTimeoutSeconds bound the time
+	// the OPI servers spend reading a request and writing its response. They
+	// default to 30 and 60 seconds. Log streams are exempt from the write
+	// timeout.
+	ServerReadTimeoutSeconds  int `yaml:"server_read_timeout_seconds"`
+	ServerWriteTimeoutSeconds int `yaml:"server_write_timeout_seconds"`
+	// ShutdownTimeoutSeconds is how long in-flight requests are given to
+	// complete on SIGTERM. It defaults to 20 seconds.
+	ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds"`
+	// CertReloadIntervalSeconds is how often the server certificate, key and
+	// client CA are checked for changes on disk. It defaults to 60 seconds.
+	CertReloadIntervalSeconds int `yaml:"cert_reload_interval_seconds"`
+	// RequestTimeoutSeconds is the deadline for serving a single OPI request,
+	// including the Kubernetes calls made on its behalf. It defaults to 30
+	// seconds.
+	RequestTimeoutSeconds int `yaml:"request_timeout_seconds"`
+
+	// MetricsPort serves Prometheus metrics over plain HTTP when set, both
+	// from OPI and from the eirini-controller. It must differ from the ports
+	// serving the OPI API.
+	MetricsPort int `yaml:"metrics_port"`
+
+	// HealthPort serves the OPI readiness check on /readyz over plain HTTP
+	// when set. OPI only starts serving the API, and reports ready, once its
+	// caches have synced. It must differ from the ports serving the OPI API
+	// and metrics.
+	HealthPort int `yaml:"health_port"`
+
+	// TracingExporter enables tracing of OPI requests. The only supported
+	// exporter is "stdout"; tracing is disabled when it is empty.
+	TracingExporter string `yaml:"tracing_exporter"`
+
+	// AuditLogPath is the file mutating OPI requests are recorded in, one JSON
+	// event per line, together with the client certificate that made them.
+	// Auditing is disabled when it is empty.
+	AuditLogPath string `yaml:"audit_log_path"`
+	// ClientCNAllowlist restricts the endpoint groups ("apps", "stage" and
+	// "tasks") to client certificates with the listed common names. Groups
+	// that are not listed accept any client.
+	ClientCNAllowlist map[string][]string `yaml:"client_cn_allowlist"`
+
+	PlacementTags map[string]PlacementTag `yaml:"placement_tags"`
+
+	// StackImages maps CF stacks (e.g. cflinuxfs3) to the images buildpack
+	// droplets run on. The images are expected to contain the launcher.
+	StackImages            map[string]string `yaml:"stack_images"`
+	DropletDownloaderImage string            `yaml:"droplet_downloader_image"`
+
+	DownloaderImage string `yaml:"downloader_image"`
+	ExecutorImage   string `yaml:"executor_image"`
+	UploaderImage   string `yaml:"uploader_image"`
+	EiriniAddress   string `yaml:"eirini_address"`
+
+	// CRDValidationWebhookPort enables the validating admission webhook for
+	// LRP and Task resources when set. The serving certificate and key are
+	// read from CRDValidationWebhookCertDir as tls.crt and tls.key.
+	CRDValidationWebhookPort    int    `yaml:"crd_validation_webhook_port"`
+	CRDValidationWebhookCertDir string `yaml:"crd_validation_webhook_cert_dir"`
+}
+
+// PlacementTag describes how workloads requesting a placement tag (e.g. an
+// isolation segment) are scheduled onto nodes.
+type PlacementTag struct {
+	NodeSelector map[string]string         `yaml:"node_selector"`
+	Tolerations  []Toleration              `yaml:"tolerations"`
+	NodeAffinity []NodeSelectorRequirement `yaml:"node_affinity"`
+}
+
+type Toleration struct {
+	Key      string `yaml:"key"`
+	Operator string `yaml:"operator"`
+	Value    string `yaml:"value"`
+	Effect   string `yaml:"effect"`
+}
+
+type NodeSelectorRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values"`
 }
 
 type EventReporterConfig struct {
@@ -109,6 +203,13 @@ type EventReporterConfig struct {
 
 	WorkloadsNamespace string
 
+	// MetricsPort serves Prometheus metrics over plain HTTP when set.
+	MetricsPort int `yaml:"metrics_port"`
+
+	// TracingExporter enables tracing of the callbacks to CC, linked to the
+	// OPI request that caused them. See Properties.TracingExporter.
+	TracingExporter string `yaml:"tracing_exporter"`
+
 	KubeConfig `yaml:",inline"`
 }
 
@@ -145,6 +246,13 @@ type TaskReporterConfig struct {
 
 	WorkloadsNamespace string
 
+	// MetricsPort serves Prometheus metrics over plain HTTP when set.
+	MetricsPort int `yaml:"metrics_port"`
+
+	// TracingExporter enables tracing of the callbacks to CC, linked to the
+	// OPI request that caused them. See Properties.TracingExporter.
+	TracingExporter string `yaml:"tracing_exporter"`
+
 	KubeConfig `yaml:",inline"`
 }
 
@@ -158,3 +266,25 @@ type InstanceIndexEnvInjectorConfig struct {
 
 	KubeConfig `yaml:",inline"`
 }
+
+type SSHProxyConfig struct {
+	// ListenAddress defaults to :2222, the port of Diego's SSH proxy.
+	ListenAddress string `yaml:"listen_address"`
+	HostKeyPath   string `yaml:"host_key_path"`
+
+	// CCAPIURL is the public API of the cloud controller, which authorises
+	// access to an instance for the token of the user.
+	CCAPIURL string `yaml:"cc_api_url"`
+	// UAATokenURL is the token endpoint of UAA, where one-time codes are
+	// exchanged for a token of the user as the UAAClientName client. The
+	// client name defaults to ssh-proxy, like Diego's.
+	UAATokenURL         string `yaml:"uaa_token_url"`
+	UAAClientName       string `yaml:"uaa_client_name"`
+	UAAClientSecretPath string `yaml:"uaa_client_secret_path"`
+	// CACertPath is the CA bundle trusted for UAA and the cloud controller.
+	CACertPath string `yaml:"ca_cert_path"`
+
+	WorkloadsNamespace string
+
+	KubeConfig `yaml:",inline"`
+}
@@ -9,12 +9,43 @@ type VolumeMount struct {
 	MountDir string `json:"mount_dir"`
 }
 
+type Sidecar struct {
+	Name     string   `json:"name"`
+	Command  []string `json:"command"`
+	MemoryMB int64    `json:"memory_mb"`
+}
+
+// DesiredLRP is what eirini reads back from the workload of an LRP. The
+// field names follow DesireLRPRequest. Private registry credentials are
+// never returned.
 type DesiredLRP struct {
-	ProcessGUID string                     `json:"process_guid"`
-	Instances   int32                      `json:"instances"`
-	Routes      map[string]json.RawMessage `json:"routes,omitempty"`
-	Annotation  string                     `json:"annotation"`
-	Image       string                     `json:"image"`
+	ProcessGUID             string                     `json:"process_guid"`
+	ProcessType             string                     `json:"process_type"`
+	AppGUID                 string                     `json:"app_guid"`
+	AppName                 string                     `json:"app_name"`
+	SpaceGUID               string                     `json:"space_guid"`
+	SpaceName               string                     `json:"space_name"`
+	OrganizationGUID        string                     `json:"organization_guid"`
+	OrganizationName        string                     `json:"organization_name"`
+	Instances               int32                      `json:"instances"`
+	Routes                  map[string]json.RawMessage `json:"routes,omitempty"`
+	Annotation              string                     `json:"annotation"`
+	Image                   string                     `json:"image"`
+	Command                 []string                   `json:"command,omitempty"`
+	Environment             map[string]string          `json:"environment,omitempty"`
+	Ports                   []int32                    `json:"ports,omitempty"`
+	HealthCheckType         string                     `json:"health_check_type"`
+	HealthCheckHTTPEndpoint string                     `json:"health_check_http_endpoint,omitempty"`
+	HealthCheckTimeoutMs    uint                       `json:"health_check_timeout_ms,omitempty"`
+	MemoryMB                int64                      `json:"memory_mb"`
+	DiskMB                  int64                      `json:"disk_mb"`
+	CPUWeight               uint8                      `json:"cpu_weight"`
+	RunsAsRoot              bool                       `json:"runs_as_root"`
+	PlacementTags           []string                   `json:"placement_tags,omitempty"`
+	EgressRules             []json.RawMessage          `json:"egress_rules,omitempty"`
+	VolumeMounts            []VolumeMount              `json:"volume_mounts,omitempty"`
+	Sidecars                []Sidecar                  `json:"sidecars,omitempty"`
+	UserDefinedAnnotations  map[string]string          `json:"user_defined_annotations,omitempty"`
 }
 
 type DesiredLRPResponse struct {
@@ -48,6 +79,7 @@ type DesireLRPRequest struct {
 	DiskMB                  int64                      `json:"disk_mb"`
 	CPUWeight               uint8                      `json:"cpu_weight"`
 	VolumeMounts            []VolumeMount              `json:"volume_mounts"`
+	Sidecars                []Sidecar                  `json:"sidecars"`
 	Lifecycle               Lifecycle                  `json:"lifecycle"`
 	UserDefinedAnnotations  map[string]string          `json:"user_defined_annotations"`
 	LRP                     string
@@ -73,7 +105,8 @@ type DesiredLRPLifecycleResponse struct {
 }
 
 type Lifecycle struct {
-	DockerLifecycle *DockerLifecycle `json:"docker_lifecycle"`
+	DockerLifecycle    *DockerLifecycle    `json:"docker_lifecycle"`
+	BuildpackLifecycle *BuildpackLifecycle `json:"buildpack_lifecycle"`
 }
 
 type DockerLifecycle struct {
@@ -83,6 +116,14 @@ type DockerLifecycle struct {
 	RegistryPassword string   `json:"registry_password"`
 }
 
+type BuildpackLifecycle struct {
+	DropletGUID  string `json:"droplet_guid"`
+	DropletHash  string `json:"droplet_hash"`
+	DropletURI   string `json:"droplet_uri"`
+	Stack        string `json:"stack"`
+	StartCommand string `json:"start_command"`
+}
+
 type TaskRequest struct {
 	GUID               string                `json:"guid"`
 	Name               string                `json:"name"`
@@ -96,10 +137,28 @@ type TaskRequest struct {
 	CompletionCallback string                `json:"completion_callback"`
 	Environment        []EnvironmentVariable `json:"environment"`
 	Lifecycle          Lifecycle             `json:"lifecycle"`
+	MemoryMB           int64                 `json:"memory_mb"`
+	DiskMB             int64                 `json:"disk_mb"`
+	CPUWeight          uint8                 `json:"cpu_weight"`
+	PlacementTags      []string              `json:"placement_tags"`
 }
 
 type TaskResponse struct {
-	GUID string `json:"guid"`
+	GUID          string `json:"guid"`
+	AppGUID       string `json:"app_guid,omitempty"`
+	AppName       string `json:"app_name,omitempty"`
+	State         string `json:"state,omitempty"`
+	StartedAt     int64  `json:"started_at,omitempty"`
+	FinishedAt    int64  `json:"finished_at,omitempty"`
+	ExitCode      *int32 `json:"exit_code,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// TasksFilter narrows down the tasks returned by GET /tasks. Empty fields
+// match any task.
+type TasksFilter struct {
+	AppGUID string
+	State   string
 }
 
 type TasksResponse []TaskResponse
@@ -134,7 +193,8 @@ type StagingCompletedRequest struct {
 }
 
 type StagingLifecycle struct {
-	DockerLifecycle *StagingDockerLifecycle `json:"docker_lifecycle"`
+	DockerLifecycle    *StagingDockerLifecycle    `json:"docker_lifecycle"`
+	BuildpackLifecycle *StagingBuildpackLifecycle `json:"buildpack_lifecycle"`
 }
 
 type StagingDockerLifecycle struct {
@@ -143,6 +203,20 @@ type StagingDockerLifecycle struct {
 	RegistryPassword string `json:"registry_password"`
 }
 
+type StagingBuildpackLifecycle struct {
+	AppBitsDownloadURI string      `json:"app_bits_download_uri"`
+	DropletUploadURI   string      `json:"droplet_upload_uri"`
+	Buildpacks         []Buildpack `json:"buildpacks"`
+	Stack              string      `json:"stack"`
+}
+
+type Buildpack struct {
+	Name       string `json:"name"`
+	Key        string `json:"key"`
+	URL        string `json:"url"`
+	SkipDetect bool   `json:"skip_detect"`
+}
+
 type EnvironmentVariable struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -172,6 +246,35 @@ type Instance struct {
 	Since          int64  `json:"since"`
 	State          string `json:"state"`
 	PlacementError string `json:"placement_error,omitempty"`
+	Outdated       bool   `json:"outdated,omitempty"`
+}
+
+type GetInstanceStatsResponse struct {
+	Error       string           `json:"error,omitempty"`
+	ProcessGUID string           `json:"process_guid"`
+	Instances   []*InstanceStats `json:"instances"`
+}
+
+type InstanceStats struct {
+	Instance
+	Host       string        `json:"host"`
+	InternalIP string        `json:"internal_ip"`
+	Ports      []PortMapping `json:"ports"`
+	Uptime     int64         `json:"uptime"`
+	MemQuota   uint64        `json:"mem_quota"`
+	DiskQuota  uint64        `json:"disk_quota"`
+	Usage      Usage         `json:"usage"`
+}
+
+type PortMapping struct {
+	ContainerPort uint32 `json:"container_port"`
+	HostPort      uint32 `json:"host_port,omitempty"`
+}
+
+type Usage struct {
+	CPU  float64 `json:"cpu"`
+	Mem  uint64  `json:"mem"`
+	Disk uint64  `json:"disk"`
 }
 
 type Route struct {
@@ -189,6 +292,27 @@ type AppCrashedRequest struct {
 	CrashTimestamp  int64  `json:"crash_timestamp"`
 }
 
+type EgressRule struct {
+	Protocol     string     `json:"protocol"`
+	Destinations []string   `json:"destinations"`
+	Ports        []int32    `json:"ports,omitempty"`
+	PortRange    *PortRange `json:"port_range,omitempty"`
+	IcmpInfo     *ICMPInfo  `json:"icmp_info,omitempty"`
+	Log          bool       `json:"log"`
+}
+
+type PortRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+type ICMPInfo struct {
+	Type int32 `json:"type"`
+	Code int32 `json:"code"`
+}
+
 type Error struct {
-	Message string `json:"message"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
 }
@@ -2,6 +2,7 @@ package opi
 
 import (
 	"fmt"
+	"time"
 )
 
 const (
@@ -11,6 +12,15 @@ const (
 	CrashedState            = "CRASHED"
 	UnknownState            = "UNKNOWN"
 	InsufficientMemoryError = "Insufficient resources: memory"
+
+	uuidLength = 36
+)
+
+const (
+	TaskPendingState   = "PENDING"
+	TaskRunningState   = "RUNNING"
+	TaskSucceededState = "SUCCEEDED"
+	TaskFailedState    = "FAILED"
 )
 
 type LRPIdentifier struct {
@@ -21,6 +31,17 @@ func (i *LRPIdentifier) ProcessGUID() string {
 	return fmt.Sprintf("%s-%s", i.GUID, i.Version)
 }
 
+// ParseProcessGUID splits a process guid back into its identifier. The
+// version is a UUID, so it is the last 36 characters of the process guid.
+func ParseProcessGUID(processGUID string) (LRPIdentifier, error) {
+	separator := len(processGUID) - uuidLength - 1
+	if separator < 1 || processGUID[separator] != '-' {
+		return LRPIdentifier{}, fmt.Errorf("invalid process guid %q", processGUID)
+	}
+
+	return LRPIdentifier{GUID: processGUID[:separator], Version: processGUID[separator+1:]}, nil
+}
+
 // An LRP, or long-running-process, is a stateless process
 // where the scheduler should attempt to keep N copies running,
 // killing and recreating as needed to maintain that guarantee.
@@ -50,6 +71,28 @@ type LRP struct {
 	AppURIs                []Route
 	LastUpdated            string
 	UserDefinedAnnotations map[string]string
+	PlacementTags          []string
+	EgressRules            []EgressRule
+	Sidecars               []Sidecar
+	Droplet                *Droplet
+	// RestartGeneration is bumped to roll the instances of an LRP
+	// desired through the LRP custom resource.
+	RestartGeneration int64
+}
+
+// A Sidecar is an additional process running next to the main LRP process.
+// It shares the image and the environment of the LRP.
+type Sidecar struct {
+	Name     string
+	Command  []string
+	MemoryMB int64
+}
+
+// A Droplet is a staged buildpack app. It is downloaded into the workload
+// before the app starts on top of the stack image.
+type Droplet struct {
+	URL  string
+	Hash string
 }
 
 type Route struct {
@@ -57,6 +100,20 @@ type Route struct {
 	Port     int32  `json:"port"`
 }
 
+// An EgressRule allows outbound traffic from an LRP to a set of destinations,
+// much like a rule of a CF application security group.
+type EgressRule struct {
+	Protocol     string     `json:"protocol"`
+	Destinations []string   `json:"destinations"`
+	Ports        []int32    `json:"ports,omitempty"`
+	PortRange    *PortRange `json:"port_range,omitempty"`
+}
+
+type PortRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
 type PrivateRegistry struct {
 	Server   string
 	Username string
@@ -73,13 +130,45 @@ type Instance struct {
 	Since          int64
 	State          string
 	PlacementError string
+	// Outdated is set while the instance is yet to be replaced by a
+	// rolling restart or update.
+	Outdated bool
+}
+
+// InstanceStats is an instance along with where it runs and how much of its
+// quota it uses.
+type InstanceStats struct {
+	Instance
+	Host       string
+	InternalIP string
+	Ports      []PortMapping
+	// Uptime is the number of seconds the app has been running for since it
+	// was last (re)started.
+	Uptime int64
+	Usage  InstanceUsage
+}
+
+// PortMapping maps a port of the app to a port of the host it runs on.
+// Instances are reached on their internal IP, so HostPort is only set when
+// the port is also bound on the host.
+type PortMapping struct {
+	ContainerPort uint32
+	HostPort      uint32
+}
+
+type InstanceUsage struct {
+	CPU         float64
+	Memory      float64
+	MemoryQuota float64
+	Disk        float64
+	DiskQuota   float64
 }
 
 type Healtcheck struct {
-	Type      string
-	Port      int32
-	Endpoint  string
-	TimeoutMs uint
+	Type      string `json:"type"`
+	Port      int32  `json:"port,omitempty"`
+	Endpoint  string `json:"endpoint,omitempty"`
+	TimeoutMs uint   `json:"timeout_ms,omitempty"`
 }
 
 // A Task is a one-off process that is run exactly once and returns a
@@ -101,4 +190,27 @@ type Task struct {
 	MemoryMB           int64
 	DiskMB             int64
 	CPUWeight          uint8
+	PlacementTags      []string
+	Droplet            *Droplet
+	Status             TaskStatus
+}
+
+// TaskStatus is the observed state of a Task. StartTime and EndTime are
+// unix timestamps in seconds and are zero until the task has started and
+// finished respectively. ExitCode is only set once the task has finished.
+type TaskStatus struct {
+	State         string
+	StartTime     int64
+	EndTime       int64
+	ExitCode      *int32
+	FailureReason string
+}
+
+// LogOptions select the logs streamed for an LRP or a task. Index selects a
+// single instance of an LRP; when it is nil the logs of all instances are
+// interleaved line by line. SinceTime, when set, skips older log lines.
+type LogOptions struct {
+	Index     *int
+	Follow    bool
+	SinceTime *time.Time
 }
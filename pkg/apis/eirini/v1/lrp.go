@@ -42,10 +42,44 @@ type LRPSpec struct {
 	LastUpdated            string            `json:"lastUpdated"`
 	UserDefinedAnnotations map[string]string `json:"userDefinedAnnotations,omitempty"`
 	AppRoutes              []Route           `json:"appRoutes"`
+	PlacementTags          []string          `json:"placementTags,omitempty"`
+	Sidecars               []Sidecar         `json:"sidecars,omitempty"`
+	// RestartGeneration triggers a rolling restart of the instances
+	// whenever it changes
+	RestartGeneration int64 `json:"restartGeneration,omitempty"`
 }
 
 type LRPStatus struct {
-	Replicas int32 `json:"replicas"`
+	Replicas           int32               `json:"replicas"`
+	ObservedGeneration int64               `json:"observedGeneration,omitempty"`
+	Conditions         []meta_v1.Condition `json:"conditions,omitempty"`
+	Instances          []LRPInstance       `json:"instances,omitempty"`
+}
+
+const (
+	// LRPReady is true when all desired instances are ready
+	LRPReady = "Ready"
+	// LRPProgressing is true while the underlying workload is being rolled out or scaled
+	LRPProgressing = "Progressing"
+	// LRPDegraded is true when instances are missing or crashed outside of a rollout
+	LRPDegraded = "Degraded"
+	// LRPCrashLooping is true when at least one instance is in a crash loop
+	LRPCrashLooping = "CrashLooping"
+)
+
+type LRPInstance struct {
+	Index           int           `json:"index"`
+	PodName         string        `json:"podName"`
+	State           string        `json:"state"`
+	Since           *meta_v1.Time `json:"since,omitempty"`
+	CrashCount      int32         `json:"crashCount"`
+	LastCrashReason string        `json:"lastCrashReason,omitempty"`
+}
+
+type Sidecar struct {
+	Name     string   `json:"name"`
+	Command  []string `json:"command"`
+	MemoryMB int64    `json:"memoryMB"`
 }
 
 type Route struct {
@@ -5,15 +5,20 @@ import (
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Exit Code",type=integer,JSONPath=`.status.exitCode`
+// +kubebuilder:printcolumn:name="Started",type=date,JSONPath=`.status.startTime`
+// +kubebuilder:printcolumn:name="Completed",type=date,JSONPath=`.status.completionTime`
 
 // Task describes a short-lived job running alongside an LRP
 type Task struct {
 	meta_v1.TypeMeta   `json:",inline"`
 	meta_v1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec TaskSpec `json:"spec"`
+	Spec   TaskSpec   `json:"spec"`
+	Status TaskStatus `json:"status,omitempty"`
 }
 
 type TaskSpec struct {
@@ -33,6 +38,28 @@ type TaskSpec struct {
 	MemoryMB           int64             `json:"memoryMB"`
 	DiskMB             int64             `json:"diskMB"`
 	CPUWeight          uint8             `json:"cpuWeight"`
+	PlacementTags      []string          `json:"placementTags,omitempty"`
+}
+
+type TaskPhase string
+
+const (
+	TaskPending   TaskPhase = "Pending"
+	TaskRunning   TaskPhase = "Running"
+	TaskSucceeded TaskPhase = "Succeeded"
+	TaskFailed    TaskPhase = "Failed"
+)
+
+// TaskStatus is written by two components: the eirini-controller owns the
+// progress and outcome of the task, while the task-reporter only sets
+// CompletionCallbackAcked once the CC has acknowledged the completion.
+type TaskStatus struct {
+	Phase                   TaskPhase     `json:"phase,omitempty"`
+	StartTime               *meta_v1.Time `json:"startTime,omitempty"`
+	CompletionTime          *meta_v1.Time `json:"completionTime,omitempty"`
+	ExitCode                *int32        `json:"exitCode,omitempty"`
+	FailureReason           string        `json:"failureReason,omitempty"`
+	CompletionCallbackAcked bool          `json:"completionCallbackAcked,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,133 @@
+package sshproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/eirini/opi"
+	"github.com/pkg/errors"
+)
+
+//counterfeiter:generate . Authenticator
+
+var ErrAccessDenied = errors.New("access denied")
+
+// An Authenticator checks that the holder of a one-time code may access an
+// instance of an app and returns the LRP the instance belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, appGUID string, index int, code string) (opi.LRPIdentifier, error)
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+type sshAccessResponse struct {
+	ProcessGUID string `json:"process_guid"`
+}
+
+// CCAuthenticator redeems one-time codes issued by UAA (e.g. via cf
+// ssh-code) the way Diego's SSH proxy does: the code is exchanged with UAA
+// for a token of the user, with which the cloud controller is asked whether
+// the user may access the instance. Codes are invalidated by UAA once
+// redeemed.
+type CCAuthenticator struct {
+	client          *http.Client
+	ccAPIURL        string
+	uaaTokenURL     string
+	uaaClientName   string
+	uaaClientSecret string
+}
+
+func NewCCAuthenticator(client *http.Client, ccAPIURL, uaaTokenURL, uaaClientName, uaaClientSecret string) *CCAuthenticator {
+	return &CCAuthenticator{
+		client:          client,
+		ccAPIURL:        strings.TrimSuffix(ccAPIURL, "/"),
+		uaaTokenURL:     uaaTokenURL,
+		uaaClientName:   uaaClientName,
+		uaaClientSecret: uaaClientSecret,
+	}
+}
+
+func (a *CCAuthenticator) Authenticate(ctx context.Context, appGUID string, index int, code string) (opi.LRPIdentifier, error) {
+	token, err := a.exchangeCode(ctx, code)
+	if err != nil {
+		return opi.LRPIdentifier{}, err
+	}
+
+	return a.getSSHAccess(ctx, token, appGUID, index)
+}
+
+func (a *CCAuthenticator) exchangeCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.uaaTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create token request")
+	}
+
+	req.SetBasicAuth(a.uaaClientName, a.uaaClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "request to uaa failed")
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		return "", errors.Wrapf(ErrAccessDenied, "uaa responded with status %d", resp.StatusCode)
+	default:
+		return "", fmt.Errorf("uaa responded with unexpected status %d", resp.StatusCode)
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", errors.Wrap(err, "failed to decode uaa response")
+	}
+
+	return token.TokenType + " " + token.AccessToken, nil
+}
+
+func (a *CCAuthenticator) getSSHAccess(ctx context.Context, token, appGUID string, index int) (opi.LRPIdentifier, error) {
+	url := fmt.Sprintf("%s/internal/apps/%s/ssh_access/%s", a.ccAPIURL, appGUID, strconv.Itoa(index))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return opi.LRPIdentifier{}, errors.Wrap(err, "failed to create request")
+	}
+
+	req.Header.Set("Authorization", token)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return opi.LRPIdentifier{}, errors.Wrap(err, "request to cloud controller failed")
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return opi.LRPIdentifier{}, errors.Wrapf(ErrAccessDenied, "cloud controller responded with status %d", resp.StatusCode)
+	default:
+		return opi.LRPIdentifier{}, fmt.Errorf("cloud controller responded with unexpected status %d", resp.StatusCode)
+	}
+
+	var access sshAccessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&access); err != nil {
+		return opi.LRPIdentifier{}, errors.Wrap(err, "failed to decode cloud controller response")
+	}
+
+	return opi.ParseProcessGUID(access.ProcessGUID)
+}
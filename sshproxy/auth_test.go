@@ -0,0 +1,135 @@
+package sshproxy_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/sshproxy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("CCAuthenticator", func() {
+	var (
+		uaa           *ghttp.Server
+		cc            *ghttp.Server
+		authenticator *sshproxy.CCAuthenticator
+		identifier    opi.LRPIdentifier
+		err           error
+	)
+
+	BeforeEach(func() {
+		uaa = ghttp.NewServer()
+		cc = ghttp.NewServer()
+		authenticator = sshproxy.NewCCAuthenticator(http.DefaultClient, cc.URL()+"/", uaa.URL()+"/oauth/token", "ssh-proxy", "the-secret")
+
+		uaa.RouteToHandler("POST", "/oauth/token", ghttp.CombineHandlers(
+			ghttp.VerifyBasicAuth("ssh-proxy", "the-secret"),
+			ghttp.VerifyContentType("application/x-www-form-urlencoded"),
+			ghttp.VerifyForm(url.Values{
+				"grant_type": {"authorization_code"},
+				"code":       {"the-code"},
+			}),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+				"access_token": "the-token",
+				"token_type":   "bearer",
+			}),
+		))
+	})
+
+	AfterEach(func() {
+		uaa.Close()
+		cc.Close()
+	})
+
+	JustBeforeEach(func() {
+		identifier, err = authenticator.Authenticate(context.Background(), "app-guid", 2, "the-code")
+	})
+
+	When("the code grants access to the instance", func() {
+		BeforeEach(func() {
+			cc.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/internal/apps/app-guid/ssh_access/2"),
+				ghttp.VerifyHeaderKV("Authorization", "bearer the-token"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+					"process_guid": "1b4e28ba-2fa1-11d2-883f-0016d3cca427-5f6b2c4d-8e1a-4b7c-9d3e-0a1b2c3d4e5f",
+				}),
+			))
+		})
+
+		It("exchanges the code for a token", func() {
+			Expect(uaa.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns the LRP of the instance", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identifier).To(Equal(opi.LRPIdentifier{
+				GUID:    "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+				Version: "5f6b2c4d-8e1a-4b7c-9d3e-0a1b2c3d4e5f",
+			}))
+		})
+	})
+
+	When("uaa rejects the code", func() {
+		BeforeEach(func() {
+			uaa.RouteToHandler("POST", "/oauth/token", ghttp.RespondWith(http.StatusUnauthorized, nil))
+		})
+
+		It("returns ErrAccessDenied", func() {
+			Expect(err).To(MatchError(ContainSubstring("uaa responded with status 401")))
+			Expect(err).To(MatchError(sshproxy.ErrAccessDenied))
+		})
+
+		It("does not ask the cloud controller", func() {
+			Expect(cc.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	When("uaa fails", func() {
+		BeforeEach(func() {
+			uaa.RouteToHandler("POST", "/oauth/token", ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("uaa responded with unexpected status 500")))
+			Expect(err).NotTo(MatchError(sshproxy.ErrAccessDenied))
+		})
+	})
+
+	When("cloud controller denies access", func() {
+		BeforeEach(func() {
+			cc.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, nil))
+		})
+
+		It("returns ErrAccessDenied", func() {
+			Expect(err).To(MatchError(ContainSubstring("status 403")))
+			Expect(err).To(MatchError(sshproxy.ErrAccessDenied))
+		})
+	})
+
+	When("cloud controller fails", func() {
+		BeforeEach(func() {
+			cc.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, nil))
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring("unexpected status 500")))
+			Expect(err).NotTo(MatchError(sshproxy.ErrAccessDenied))
+		})
+	})
+
+	When("the process guid is invalid", func() {
+		BeforeEach(func() {
+			cc.AppendHandlers(ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]string{
+				"process_guid": "not-a-process-guid",
+			}))
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
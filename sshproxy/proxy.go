@@ -0,0 +1,234 @@
+package sshproxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+//counterfeiter:generate . PodSessions
+
+const (
+	userPrefix = "cf:"
+
+	extensionNamespace = "namespace"
+	extensionPod       = "pod"
+
+	authTimeout = 30 * time.Second
+)
+
+// PodSessions opens exec and port forwarding sessions into pods. See
+// k8s/client.PodSession.
+type PodSessions interface {
+	Exec(namespace, name, container string, command []string, streams remotecommand.StreamOptions) error
+	PortForward(ctx context.Context, namespace, name string, port int, stream io.ReadWriter) error
+}
+
+// Proxy is an SSH server giving access to app instances, like Diego's SSH
+// proxy does. Users log in as cf:<app-guid>/<index> with a one-time code as
+// the password. Sessions run in the opi container of the instance and local
+// port forwarding reaches the ports the instance listens on.
+type Proxy struct {
+	logger        lager.Logger
+	config        *ssh.ServerConfig
+	authenticator Authenticator
+	statefulSets  StatefulSetGetter
+	sessions      PodSessions
+}
+
+func NewProxy(logger lager.Logger, hostKey ssh.Signer, authenticator Authenticator, statefulSets StatefulSetGetter, sessions PodSessions) *Proxy {
+	proxy := &Proxy{
+		logger:        logger,
+		authenticator: authenticator,
+		statefulSets:  statefulSets,
+		sessions:      sessions,
+	}
+
+	proxy.config = &ssh.ServerConfig{PasswordCallback: proxy.authenticate}
+	proxy.config.AddHostKey(hostKey)
+
+	return proxy
+}
+
+// Serve handles the connections accepted by the listener until it is closed.
+func (p *Proxy) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if strings.Contains(err.Error(), "use of closed network connection") {
+				return nil
+			}
+
+			return errors.Wrap(err, "failed to accept connection")
+		}
+
+		go p.handleConn(conn)
+	}
+}
+
+func (p *Proxy) handleConn(conn net.Conn) {
+	logger := p.logger.Session("connection", lager.Data{"remote-addr": conn.RemoteAddr().String()})
+	defer conn.Close()
+
+	serverConn, channels, requests, err := ssh.NewServerConn(conn, p.config)
+	if err != nil {
+		logger.Info("handshake-failed", lager.Data{"reason": err.Error()})
+
+		return
+	}
+	defer serverConn.Close()
+
+	target := Target{
+		Namespace: serverConn.Permissions.Extensions[extensionNamespace],
+		Pod:       serverConn.Permissions.Extensions[extensionPod],
+	}
+	logger = logger.WithData(lager.Data{"user": serverConn.User(), "namespace": target.Namespace, "pod": target.Pod})
+	logger.Info("connected")
+
+	defer logger.Info("disconnected")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// remote port forwarding and other global requests are not supported
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go p.handleSession(logger, target, newChannel)
+		case "direct-tcpip":
+			go p.handleDirectTCPIP(ctx, logger, target, newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, fmt.Sprintf("unsupported channel type %q", newChannel.ChannelType()))
+		}
+	}
+}
+
+func (p *Proxy) authenticate(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	logger := p.logger.Session("authenticate", lager.Data{"user": meta.User(), "remote-addr": meta.RemoteAddr().String()})
+
+	appGUID, index, err := parseUser(meta.User())
+	if err != nil {
+		logger.Info("invalid-user", lager.Data{"reason": err.Error()})
+
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authTimeout)
+	defer cancel()
+
+	identifier, err := p.authenticator.Authenticate(ctx, appGUID, index, string(password))
+	if err != nil {
+		logger.Error("authentication-failed", err)
+
+		return nil, err
+	}
+
+	target, err := resolveTarget(ctx, p.statefulSets, identifier, index)
+	if err != nil {
+		logger.Error("failed-to-resolve-instance", err)
+
+		return nil, err
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			extensionNamespace: target.Namespace,
+			extensionPod:       target.Pod,
+		},
+	}, nil
+}
+
+// parseUser reads the app guid and the instance index from a user name such
+// as cf:<app-guid>/<index>. The index defaults to 0.
+func parseUser(user string) (string, int, error) {
+	if !strings.HasPrefix(user, userPrefix) {
+		return "", 0, fmt.Errorf("user %q does not start with %q", user, userPrefix)
+	}
+
+	appGUID := strings.TrimPrefix(user, userPrefix)
+	index := 0
+
+	if separator := strings.LastIndex(appGUID, "/"); separator >= 0 {
+		var err error
+
+		index, err = strconv.Atoi(appGUID[separator+1:])
+		if err != nil || index < 0 {
+			return "", 0, fmt.Errorf("invalid instance index in user %q", user)
+		}
+
+		appGUID = appGUID[:separator]
+	}
+
+	if appGUID == "" {
+		return "", 0, fmt.Errorf("missing app guid in user %q", user)
+	}
+
+	return appGUID, index, nil
+}
+
+type directTCPIPPayload struct {
+	Host           string
+	Port           uint32
+	OriginatorHost string
+	OriginatorPort uint32
+}
+
+// handleDirectTCPIP forwards a local port of the user to a port of the
+// instance. Only ports on localhost are reachable, as they are from within
+// the instance.
+func (p *Proxy) handleDirectTCPIP(ctx context.Context, logger lager.Logger, target Target, newChannel ssh.NewChannel) {
+	var payload directTCPIPPayload
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid direct-tcpip payload")
+
+		return
+	}
+
+	if !isLoopback(payload.Host) {
+		_ = newChannel.Reject(ssh.Prohibited, "only ports on localhost can be forwarded")
+
+		return
+	}
+
+	if payload.Port == 0 || payload.Port > 65535 {
+		_ = newChannel.Reject(ssh.ConnectionFailed, fmt.Sprintf("invalid port %d", payload.Port))
+
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Error("failed-to-accept-channel", err)
+
+		return
+	}
+	defer channel.Close()
+
+	go ssh.DiscardRequests(requests)
+
+	logger = logger.Session("port-forward", lager.Data{"port": payload.Port})
+	if err := p.sessions.PortForward(ctx, target.Namespace, target.Pod, int(payload.Port), channel); err != nil {
+		logger.Error("port-forward-failed", err)
+	}
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,300 @@
+package sshproxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/sshproxy"
+	"code.cloudfoundry.org/eirini/sshproxy/sshproxyfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+var _ = Describe("Proxy", func() {
+	var (
+		authenticator *sshproxyfakes.FakeAuthenticator
+		statefulSets  *sshproxyfakes.FakeStatefulSetGetter
+		sessions      *sshproxyfakes.FakePodSessions
+		listener      net.Listener
+		serveErr      chan error
+		user          string
+		client        *ssh.Client
+		dialErr       error
+	)
+
+	BeforeEach(func() {
+		authenticator = new(sshproxyfakes.FakeAuthenticator)
+		authenticator.AuthenticateReturns(opi.LRPIdentifier{GUID: "app-guid", Version: "version"}, nil)
+
+		replicas := int32(2)
+		statefulSets = new(sshproxyfakes.FakeStatefulSetGetter)
+		statefulSets.GetByLRPIdentifierReturns([]appsv1.StatefulSet{{
+			ObjectMeta: metav1.ObjectMeta{Name: "the-app-sts", Namespace: "the-namespace"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		}}, nil)
+
+		sessions = new(sshproxyfakes.FakePodSessions)
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err := ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		proxy := sshproxy.NewProxy(lagertest.NewTestLogger("ssh-proxy"), hostKey, authenticator, statefulSets, sessions)
+		serveErr = make(chan error, 1)
+
+		go func() {
+			serveErr <- proxy.Serve(listener)
+		}()
+
+		user = "cf:app-guid/1"
+	})
+
+	JustBeforeEach(func() {
+		client, dialErr = ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		})
+	})
+
+	AfterEach(func() {
+		if client != nil {
+			client.Close()
+		}
+
+		Expect(listener.Close()).To(Succeed())
+		Eventually(serveErr).Should(Receive(BeNil()))
+	})
+
+	It("authenticates the user with the code", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+		Expect(authenticator.AuthenticateCallCount()).To(Equal(1))
+		_, appGUID, index, code := authenticator.AuthenticateArgsForCall(0)
+		Expect(appGUID).To(Equal("app-guid"))
+		Expect(index).To(Equal(1))
+		Expect(code).To(Equal("the-code"))
+	})
+
+	It("looks up the statefulset of the LRP", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+		Expect(statefulSets.GetByLRPIdentifierCallCount()).To(Equal(1))
+		_, identifier := statefulSets.GetByLRPIdentifierArgsForCall(0)
+		Expect(identifier).To(Equal(opi.LRPIdentifier{GUID: "app-guid", Version: "version"}))
+	})
+
+	When("the user has no index", func() {
+		BeforeEach(func() {
+			user = "cf:app-guid"
+		})
+
+		It("connects to the first instance", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+			_, _, index, _ := authenticator.AuthenticateArgsForCall(0)
+			Expect(index).To(BeZero())
+		})
+	})
+
+	When("the user is not a cf user", func() {
+		BeforeEach(func() {
+			user = "root"
+		})
+
+		It("fails to connect", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(authenticator.AuthenticateCallCount()).To(BeZero())
+		})
+	})
+
+	When("the code is rejected", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(opi.LRPIdentifier{}, sshproxy.ErrAccessDenied)
+		})
+
+		It("fails to connect", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(statefulSets.GetByLRPIdentifierCallCount()).To(BeZero())
+		})
+	})
+
+	When("the instance does not exist", func() {
+		BeforeEach(func() {
+			user = "cf:app-guid/2"
+		})
+
+		It("fails to connect", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	When("listing the statefulsets fails", func() {
+		BeforeEach(func() {
+			statefulSets.GetByLRPIdentifierReturns(nil, errors.New("boom"))
+		})
+
+		It("fails to connect", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	Describe("sessions", func() {
+		var session *ssh.Session
+
+		JustBeforeEach(func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			var err error
+			session, err = client.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			session.Close()
+		})
+
+		It("runs the command in the opi container of the instance", func() {
+			sessions.ExecStub = func(_, _, _ string, _ []string, streams remotecommand.StreamOptions) error {
+				_, err := io.WriteString(streams.Stdout, "hello")
+
+				return err
+			}
+
+			output, err := session.Output("echo hello")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(Equal("hello"))
+
+			Expect(sessions.ExecCallCount()).To(Equal(1))
+			namespace, pod, container, command, streams := sessions.ExecArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(pod).To(Equal("the-app-sts-1"))
+			Expect(container).To(Equal(k8s.OPIContainerName))
+			Expect(command).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+			Expect(streams.Tty).To(BeFalse())
+			Expect(streams.Stderr).NotTo(BeNil())
+		})
+
+		It("runs a login shell when no command is given", func() {
+			Expect(session.Shell()).To(Succeed())
+			Expect(session.Wait()).To(Succeed())
+
+			_, _, _, command, _ := sessions.ExecArgsForCall(0)
+			Expect(command[2]).To(ContainSubstring("bash -l"))
+		})
+
+		It("exports the requested environment", func() {
+			Expect(session.Setenv("GREETING", "it's me")).To(Succeed())
+			Expect(session.Run("env")).To(Succeed())
+
+			_, _, _, command, _ := sessions.ExecArgsForCall(0)
+			Expect(command[2]).To(Equal("export GREETING='it'\\''s me'\nenv"))
+		})
+
+		It("rejects invalid environment variable names", func() {
+			Expect(session.Setenv("NOT A NAME", "value")).NotTo(Succeed())
+		})
+
+		It("sets up a terminal when requested", func() {
+			Expect(session.RequestPty("xterm", 40, 80, ssh.TerminalModes{})).To(Succeed())
+
+			var size *remotecommand.TerminalSize
+
+			sessions.ExecStub = func(_, _, _ string, _ []string, streams remotecommand.StreamOptions) error {
+				size = streams.TerminalSizeQueue.Next()
+
+				return nil
+			}
+
+			Expect(session.Run("top")).To(Succeed())
+
+			_, _, _, command, streams := sessions.ExecArgsForCall(0)
+			Expect(streams.Tty).To(BeTrue())
+			Expect(streams.Stderr).To(BeNil())
+			Expect(command[2]).To(Equal("export TERM='xterm'\ntop"))
+			Expect(size).To(Equal(&remotecommand.TerminalSize{Width: 80, Height: 40}))
+		})
+
+		It("exits with the exit status of the command", func() {
+			sessions.ExecReturns(exec.CodeExitError{Err: errors.New("exit 3"), Code: 3})
+
+			err := session.Run("exit 3")
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(3))
+		})
+
+		It("exits with 255 when the command cannot be run", func() {
+			sessions.ExecReturns(errors.New("boom"))
+
+			err := session.Run("ls")
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(255))
+		})
+
+		It("does not support subsystems", func() {
+			Expect(session.RequestSubsystem("sftp")).NotTo(Succeed())
+		})
+	})
+
+	Describe("local port forwarding", func() {
+		JustBeforeEach(func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+		})
+
+		It("forwards to the port of the instance", func() {
+			sessions.PortForwardStub = func(_ context.Context, _, _ string, _ int, stream io.ReadWriter) error {
+				_, err := io.WriteString(stream, "pong")
+
+				return err
+			}
+
+			conn, err := client.Dial("tcp", "localhost:8080")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			Expect(ioutil.ReadAll(conn)).To(Equal([]byte("pong")))
+
+			Expect(sessions.PortForwardCallCount()).To(Equal(1))
+			_, namespace, pod, port, _ := sessions.PortForwardArgsForCall(0)
+			Expect(namespace).To(Equal("the-namespace"))
+			Expect(pod).To(Equal("the-app-sts-1"))
+			Expect(port).To(Equal(8080))
+		})
+
+		It("only forwards to localhost", func() {
+			_, err := client.Dial("tcp", "10.0.0.1:8080")
+			Expect(err).To(MatchError(ContainSubstring("only ports on localhost can be forwarded")))
+			Expect(sessions.PortForwardCallCount()).To(BeZero())
+		})
+
+		It("does not support remote port forwarding", func() {
+			_, err := client.Listen("tcp", "localhost:0")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("other channels", func() {
+		It("rejects them", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+			_, _, err := client.OpenChannel("x11", nil)
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("%q", "x11"))))
+		})
+	})
+})
@@ -0,0 +1,232 @@
+package sshproxy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/lager"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+const (
+	// exitStatusProxyError is what OpenSSH exits with when the session
+	// could not be established
+	exitStatusProxyError = 255
+
+	loginShell = "if command -v bash >/dev/null; then exec bash -l; else exec sh -l; fi"
+
+	maxPendingResizes = 16
+)
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type envRequest struct {
+	Name, Value string
+}
+
+type ptyRequest struct {
+	Term          string
+	Columns, Rows uint32
+	Width, Height uint32
+	Modes         string
+}
+
+type windowChangeRequest struct {
+	Columns, Rows uint32
+	Width, Height uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatusRequest struct {
+	Status uint32
+}
+
+type session struct {
+	logger   lager.Logger
+	target   Target
+	sessions PodSessions
+	channel  ssh.Channel
+
+	env     []envRequest
+	term    string
+	tty     bool
+	sizes   sizeQueue
+	started bool
+}
+
+// handleSession runs a shell or a command in the opi container of the
+// instance. The environment and terminal requested by the user are set up
+// by the /bin/sh script which runs the command.
+func (p *Proxy) handleSession(logger lager.Logger, target Target, newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Error("failed-to-accept-channel", err)
+
+		return
+	}
+
+	s := &session{
+		logger:   logger.Session("session"),
+		target:   target,
+		sessions: p.sessions,
+		channel:  channel,
+		sizes:    make(sizeQueue, maxPendingResizes),
+	}
+
+	for req := range requests {
+		s.handleRequest(req)
+	}
+
+	close(s.sizes)
+}
+
+func (s *session) handleRequest(req *ssh.Request) {
+	switch req.Type {
+	case "env":
+		var env envRequest
+		if err := ssh.Unmarshal(req.Payload, &env); err != nil || !envNamePattern.MatchString(env.Name) {
+			reply(req, false)
+
+			return
+		}
+
+		s.env = append(s.env, env)
+		reply(req, true)
+	case "pty-req":
+		var pty ptyRequest
+		if err := ssh.Unmarshal(req.Payload, &pty); err != nil {
+			reply(req, false)
+
+			return
+		}
+
+		s.tty, s.term = true, pty.Term
+		s.sizes.push(pty.Columns, pty.Rows)
+		reply(req, true)
+	case "window-change":
+		var change windowChangeRequest
+		if err := ssh.Unmarshal(req.Payload, &change); err == nil {
+			s.sizes.push(change.Columns, change.Rows)
+		}
+
+		reply(req, true)
+	case "shell":
+		s.start(req, loginShell)
+	case "exec":
+		var command execRequest
+		if err := ssh.Unmarshal(req.Payload, &command); err != nil {
+			reply(req, false)
+
+			return
+		}
+
+		s.start(req, command.Command)
+	default:
+		// e.g. subsystems such as sftp, X11 and agent forwarding
+		reply(req, false)
+	}
+}
+
+func (s *session) start(req *ssh.Request, command string) {
+	if s.started {
+		reply(req, false)
+
+		return
+	}
+
+	s.started = true
+	reply(req, true)
+
+	streams := remotecommand.StreamOptions{
+		Stdin:  s.channel,
+		Stdout: s.channel,
+		Tty:    s.tty,
+	}
+
+	if s.tty {
+		streams.TerminalSizeQueue = s.sizes
+	} else {
+		streams.Stderr = s.channel.Stderr()
+	}
+
+	go s.run([]string{"/bin/sh", "-c", s.script(command)}, streams)
+}
+
+func (s *session) run(command []string, streams remotecommand.StreamOptions) {
+	defer s.channel.Close()
+
+	err := s.sessions.Exec(s.target.Namespace, s.target.Pod, k8s.OPIContainerName, command, streams)
+
+	status := uint32(0)
+
+	var exitErr exec.ExitError
+
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		status = uint32(exitErr.ExitStatus())
+	default:
+		s.logger.Error("exec-failed", err)
+		fmt.Fprintf(s.channel.Stderr(), "failed to run command: %s\r\n", err.Error())
+
+		status = exitStatusProxyError
+	}
+
+	_, _ = s.channel.SendRequest("exit-status", false, ssh.Marshal(exitStatusRequest{Status: status}))
+}
+
+// script exports the requested environment before running the command.
+func (s *session) script(command string) string {
+	var script strings.Builder
+
+	for _, env := range s.env {
+		fmt.Fprintf(&script, "export %s=%s\n", env.Name, shellQuote(env.Value))
+	}
+
+	if s.term != "" {
+		fmt.Fprintf(&script, "export TERM=%s\n", shellQuote(s.term))
+	}
+
+	script.WriteString(command)
+
+	return script.String()
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func reply(req *ssh.Request, ok bool) {
+	if req.WantReply {
+		_ = req.Reply(ok, nil)
+	}
+}
+
+// sizeQueue passes the terminal size changes on to the exec session. When
+// the changes come faster than they are applied, the latest ones are
+// dropped.
+type sizeQueue chan remotecommand.TerminalSize
+
+func (q sizeQueue) push(columns, rows uint32) {
+	select {
+	case q <- remotecommand.TerminalSize{Width: uint16(columns), Height: uint16(rows)}:
+	default:
+	}
+}
+
+func (q sizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+
+	return &size
+}
@@ -0,0 +1,13 @@
+package sshproxy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSshproxy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sshproxy Suite")
+}
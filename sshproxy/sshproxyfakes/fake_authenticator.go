@@ -0,0 +1,124 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package sshproxyfakes
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/sshproxy"
+)
+
+type FakeAuthenticator struct {
+	AuthenticateStub        func(context.Context, string, int, string) (opi.LRPIdentifier, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 string
+	}
+	authenticateReturns struct {
+		result1 opi.LRPIdentifier
+		result2 error
+	}
+	authenticateReturnsOnCall map[int]struct {
+		result1 opi.LRPIdentifier
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthenticator) Authenticate(arg1 context.Context, arg2 string, arg3 int, arg4 string) (opi.LRPIdentifier, error) {
+	fake.authenticateMutex.Lock()
+	ret, specificReturn := fake.authenticateReturnsOnCall[len(fake.authenticateArgsForCall)]
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AuthenticateStub
+	fakeReturns := fake.authenticateReturns
+	fake.recordInvocation("Authenticate", []interface{}{arg1, arg2, arg3, arg4})
+	fake.authenticateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuthenticator) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeAuthenticator) AuthenticateCalls(stub func(context.Context, string, int, string) (opi.LRPIdentifier, error)) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = stub
+}
+
+func (fake *FakeAuthenticator) AuthenticateArgsForCall(i int) (context.Context, string, int, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	argsForCall := fake.authenticateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeAuthenticator) AuthenticateReturns(result1 opi.LRPIdentifier, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 opi.LRPIdentifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthenticator) AuthenticateReturnsOnCall(i int, result1 opi.LRPIdentifier, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	if fake.authenticateReturnsOnCall == nil {
+		fake.authenticateReturnsOnCall = make(map[int]struct {
+			result1 opi.LRPIdentifier
+			result2 error
+		})
+	}
+	fake.authenticateReturnsOnCall[i] = struct {
+		result1 opi.LRPIdentifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthenticator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuthenticator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.Authenticator = new(FakeAuthenticator)
@@ -0,0 +1,209 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package sshproxyfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/eirini/sshproxy"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+type FakePodSessions struct {
+	ExecStub        func(string, string, string, []string, remotecommand.StreamOptions) error
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []string
+		arg5 remotecommand.StreamOptions
+	}
+	execReturns struct {
+		result1 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PortForwardStub        func(context.Context, string, string, int, io.ReadWriter) error
+	portForwardMutex       sync.RWMutex
+	portForwardArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 io.ReadWriter
+	}
+	portForwardReturns struct {
+		result1 error
+	}
+	portForwardReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePodSessions) Exec(arg1 string, arg2 string, arg3 string, arg4 []string, arg5 remotecommand.StreamOptions) error {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 []string
+		arg5 remotecommand.StreamOptions
+	}{arg1, arg2, arg3, arg4Copy, arg5})
+	stub := fake.ExecStub
+	fakeReturns := fake.execReturns
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3, arg4Copy, arg5})
+	fake.execMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePodSessions) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *FakePodSessions) ExecCalls(stub func(string, string, string, []string, remotecommand.StreamOptions) error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *FakePodSessions) ExecArgsForCall(i int) (string, string, string, []string, remotecommand.StreamOptions) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePodSessions) ExecReturns(result1 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePodSessions) ExecReturnsOnCall(i int, result1 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePodSessions) PortForward(arg1 context.Context, arg2 string, arg3 string, arg4 int, arg5 io.ReadWriter) error {
+	fake.portForwardMutex.Lock()
+	ret, specificReturn := fake.portForwardReturnsOnCall[len(fake.portForwardArgsForCall)]
+	fake.portForwardArgsForCall = append(fake.portForwardArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+		arg5 io.ReadWriter
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PortForwardStub
+	fakeReturns := fake.portForwardReturns
+	fake.recordInvocation("PortForward", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.portForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePodSessions) PortForwardCallCount() int {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	return len(fake.portForwardArgsForCall)
+}
+
+func (fake *FakePodSessions) PortForwardCalls(stub func(context.Context, string, string, int, io.ReadWriter) error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = stub
+}
+
+func (fake *FakePodSessions) PortForwardArgsForCall(i int) (context.Context, string, string, int, io.ReadWriter) {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	argsForCall := fake.portForwardArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePodSessions) PortForwardReturns(result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	fake.portForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePodSessions) PortForwardReturnsOnCall(i int, result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	if fake.portForwardReturnsOnCall == nil {
+		fake.portForwardReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.portForwardReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePodSessions) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePodSessions) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.PodSessions = new(FakePodSessions)
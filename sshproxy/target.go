@@ -0,0 +1,50 @@
+package sshproxy
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/eirini"
+	"code.cloudfoundry.org/eirini/opi"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+//counterfeiter:generate . StatefulSetGetter
+
+type StatefulSetGetter interface {
+	GetByLRPIdentifier(ctx context.Context, id opi.LRPIdentifier) ([]appsv1.StatefulSet, error)
+}
+
+// A Target is the pod running an app instance.
+type Target struct {
+	Namespace string
+	Pod       string
+}
+
+// resolveTarget finds the statefulset of the LRP by its labels. The pods of a
+// statefulset are named after it, suffixed with their index.
+func resolveTarget(ctx context.Context, statefulSets StatefulSetGetter, identifier opi.LRPIdentifier, index int) (Target, error) {
+	stses, err := statefulSets.GetByLRPIdentifier(ctx, identifier)
+	if err != nil {
+		return Target{}, errors.Wrap(err, "failed to list statefulsets")
+	}
+
+	switch len(stses) {
+	case 0:
+		return Target{}, errors.Wrapf(eirini.ErrNotFound, "no statefulset for process %s", identifier.ProcessGUID())
+	case 1:
+	default:
+		return Target{}, fmt.Errorf("multiple statefulsets found for process %s", identifier.ProcessGUID())
+	}
+
+	sts := stses[0]
+	if sts.Spec.Replicas != nil && int32(index) >= *sts.Spec.Replicas {
+		return Target{}, errors.Wrapf(eirini.ErrNotFound, "instance %d of process %s", index, identifier.ProcessGUID())
+	}
+
+	return Target{
+		Namespace: sts.Namespace,
+		Pod:       fmt.Sprintf("%s-%d", sts.Name, index),
+	}, nil
+}
@@ -1,13 +1,16 @@
 package stager
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/tracing"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/runtimeschema/cc_messages"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //counterfeiter:generate . CallbackClient
@@ -28,14 +31,16 @@ func NewCallbackStagingCompleter(logger lager.Logger, callbackClient CallbackCli
 	}
 }
 
-func (s *CallbackStagingCompleter) CompleteStaging(taskCompletedRequest cf.StagingCompletedRequest) error {
+func (s *CallbackStagingCompleter) CompleteStaging(ctx context.Context, taskCompletedRequest cf.StagingCompletedRequest) error {
 	l := s.logger.Session("complete-staging", lager.Data{"task-guid": taskCompletedRequest.TaskGUID})
 
+	_, span := tracing.Start(ctx, "staging-completed-callback", trace.WithSpanKind(trace.SpanKindClient))
+
 	callbackURI, err := s.getCallbackURI(taskCompletedRequest)
 	if err != nil {
 		l.Error("failed-to-parse-callback-uri", err)
 
-		return err
+		return tracing.End(span, err)
 	}
 
 	_, err = url.Parse(callbackURI)
@@ -45,7 +50,7 @@ func (s *CallbackStagingCompleter) CompleteStaging(taskCompletedRequest cf.Stagi
 
 	response := s.constructStagingResponse(taskCompletedRequest)
 
-	return errors.Wrap(s.callbackClient.Post(callbackURI, response), "callback-response-unsuccessful")
+	return tracing.End(span, errors.Wrap(s.callbackClient.Post(callbackURI, response), "callback-response-unsuccessful"))
 }
 
 func (s *CallbackStagingCompleter) constructStagingResponse(taskCompletedRequest cf.StagingCompletedRequest) cc_messages.StagingResponseForCC {
@@ -1,6 +1,8 @@
 package stager_test
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/eirini/models/cf"
 	"code.cloudfoundry.org/eirini/stager"
 	"code.cloudfoundry.org/eirini/stager/stagerfakes"
@@ -35,7 +37,7 @@ var _ = Describe("StagingCompleter", func() {
 	})
 
 	JustBeforeEach(func() {
-		err = stagingCompleter.CompleteStaging(taskCompletedRequest)
+		err = stagingCompleter.CompleteStaging(context.Background(), taskCompletedRequest)
 	})
 
 	It("should not return an error", func() {
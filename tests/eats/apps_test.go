@@ -351,10 +351,10 @@ var _ = Describe("Apps", func() {
 		})
 
 		When("the app instance does not exist", func() {
-			It("should return 400", func() {
+			It("should return 422", func() {
 				resp, err := stopLRPInstance(lrpGUID, lrpVersion, 99)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(resp.StatusCode).To(Equal(http.StatusUnprocessableEntity))
 			})
 		})
 
@@ -0,0 +1,211 @@
+package integration_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/k8s"
+	"code.cloudfoundry.org/eirini/k8s/client"
+	"code.cloudfoundry.org/eirini/opi"
+	"code.cloudfoundry.org/eirini/tests"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		cache *client.Cache
+		stop  chan struct{}
+		guid  string
+	)
+
+	BeforeEach(func() {
+		guid = tests.GenerateGUID()
+		cache = client.NewCache(fixture.Clientset, fixture.Namespace, 0)
+		stop = make(chan struct{})
+	})
+
+	JustBeforeEach(func() {
+		cache.Start(stop)
+		Expect(cache.WaitForSync(stop)).To(BeTrue())
+	})
+
+	AfterEach(func() {
+		close(stop)
+	})
+
+	It("reports that it has synced", func() {
+		Expect(cache.HasSynced()).To(BeTrue())
+	})
+
+	Describe("CachedPod", func() {
+		var podClient *client.CachedPod
+
+		BeforeEach(func() {
+			podClient = client.NewCachedPod(fixture.Clientset, fixture.Namespace, cache)
+
+			createPod(fixture.Namespace, "one", map[string]string{
+				k8s.LabelSourceType: "APP",
+				k8s.LabelGUID:       guid,
+				k8s.LabelVersion:    "42",
+			})
+			createPod(fixture.Namespace, "two", map[string]string{
+				k8s.LabelSourceType: "TASK",
+				k8s.LabelGUID:       guid,
+			})
+			createPod(fixture.Namespace, "sadpod", map[string]string{})
+		})
+
+		It("lists the eirini pods", func() {
+			Eventually(func() []string {
+				pods, err := podClient.GetAll(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+
+				return podNames(pods)
+			}).Should(ConsistOf("one", "two"))
+		})
+
+		It("lists the pods of an LRP", func() {
+			Eventually(func() []string {
+				pods, err := podClient.GetByLRPIdentifier(context.Background(), opi.LRPIdentifier{GUID: guid, Version: "42"})
+				Expect(err).NotTo(HaveOccurred())
+
+				return podNames(pods)
+			}).Should(ConsistOf("one"))
+		})
+
+		It("lists the pods of a task", func() {
+			Eventually(func() []string {
+				pods, err := podClient.GetByTaskGUID(context.Background(), guid)
+				Expect(err).NotTo(HaveOccurred())
+
+				return podNames(pods)
+			}).Should(ConsistOf("two"))
+		})
+	})
+
+	Describe("CachedStatefulSet", func() {
+		var statefulSetClient *client.CachedStatefulSet
+
+		BeforeEach(func() {
+			statefulSetClient = client.NewCachedStatefulSet(fixture.Clientset, fixture.Namespace, cache)
+
+			createStatefulSet(fixture.Namespace, "one", map[string]string{
+				k8s.LabelSourceType: "APP",
+				k8s.LabelGUID:       guid,
+				k8s.LabelVersion:    "42",
+			})
+			createStatefulSet(fixture.Namespace, "two", map[string]string{
+				k8s.LabelSourceType: "APP",
+			})
+		})
+
+		It("lists the statefulsets with the source type", func() {
+			Eventually(func() []string {
+				statefulSets, err := statefulSetClient.GetBySourceType(context.Background(), "APP")
+				Expect(err).NotTo(HaveOccurred())
+
+				return statefulSetNames(statefulSets)
+			}).Should(ConsistOf("one", "two"))
+		})
+
+		It("lists the statefulsets of an LRP", func() {
+			Eventually(func() []string {
+				statefulSets, err := statefulSetClient.GetByLRPIdentifier(context.Background(), opi.LRPIdentifier{GUID: guid, Version: "42"})
+				Expect(err).NotTo(HaveOccurred())
+
+				return statefulSetNames(statefulSets)
+			}).Should(ConsistOf("one"))
+		})
+	})
+
+	Describe("CachedJob", func() {
+		var jobsClient *client.CachedJob
+
+		BeforeEach(func() {
+			jobsClient = client.NewCachedJob(fixture.Clientset, fixture.Namespace, cache)
+
+			createJob(fixture.Namespace, "running", map[string]string{
+				k8s.LabelSourceType: "TASK",
+				k8s.LabelGUID:       guid,
+			})
+			createJob(fixture.Namespace, "completed", map[string]string{
+				k8s.LabelSourceType:    "TASK",
+				k8s.LabelGUID:          guid,
+				k8s.LabelTaskCompleted: k8s.TaskCompletedTrue,
+			})
+		})
+
+		It("lists the jobs of a task that have not completed", func() {
+			Eventually(func() []string {
+				jobs, err := jobsClient.GetByGUID(context.Background(), guid, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				return jobNames(jobs)
+			}).Should(ConsistOf("running"))
+		})
+
+		It("lists all jobs when including completed ones", func() {
+			Eventually(func() []string {
+				jobs, err := jobsClient.List(context.Background(), true)
+				Expect(err).NotTo(HaveOccurred())
+
+				return jobNames(jobs)
+			}).Should(ConsistOf("running", "completed"))
+		})
+	})
+
+	Describe("CachedEvent", func() {
+		var (
+			eventClient *client.CachedEvent
+			pod         corev1.Pod
+		)
+
+		BeforeEach(func() {
+			eventClient = client.NewCachedEvent(fixture.Clientset, cache)
+
+			pod = corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "the-pod",
+					Namespace: fixture.Namespace,
+					UID:       types.UID(tests.GenerateGUID()),
+				},
+			}
+
+			createEvent(fixture.Namespace, "the-event", corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				UID:       pod.UID,
+			})
+			createEvent(fixture.Namespace, "another-event", corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "another-pod",
+				Namespace: fixture.Namespace,
+				UID:       types.UID(tests.GenerateGUID()),
+			})
+		})
+
+		It("lists the events belonging to a pod", func() {
+			Eventually(func() []string {
+				events, err := eventClient.GetByPod(context.Background(), pod)
+				Expect(err).NotTo(HaveOccurred())
+
+				return eventNames(events)
+			}).Should(ConsistOf("the-event"))
+		})
+	})
+
+	When("the cache has not synced", func() {
+		JustBeforeEach(func() {
+			cache = client.NewCache(fixture.Clientset, fixture.Namespace, 0)
+		})
+
+		It("refuses to serve reads", func() {
+			_, err := client.NewCachedPod(fixture.Clientset, fixture.Namespace, cache).GetAll(context.Background())
+			Expect(err).To(MatchError(client.ErrCacheNotSynced))
+		})
+	})
+})
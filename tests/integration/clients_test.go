@@ -41,7 +41,7 @@ var _ = Describe("Pod", func() {
 
 		It("lists all eirini pods across all namespaces", func() {
 			Eventually(func() []string {
-				pods, err := podClient.GetAll()
+				pods, err := podClient.GetAll(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 
 				return podNames(pods)
@@ -58,7 +58,7 @@ var _ = Describe("Pod", func() {
 
 			It("lists eirini pods from the configured namespace only", func() {
 				Eventually(func() []string {
-					pods, err := podClient.GetAll()
+					pods, err := podClient.GetAll(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 
 					return podNames(pods)
@@ -96,7 +96,7 @@ var _ = Describe("Pod", func() {
 		})
 
 		It("lists all pods matching the specified LRP identifier", func() {
-			pods, err := podClient.GetByLRPIdentifier(opi.LRPIdentifier{GUID: guid, Version: "42"})
+			pods, err := podClient.GetByLRPIdentifier(context.Background(), opi.LRPIdentifier{GUID: guid, Version: "42"})
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []string { return podNames(pods) }).Should(ConsistOf("four", "five", "six"))
@@ -111,7 +111,7 @@ var _ = Describe("Pod", func() {
 		It("deletes a pod", func() {
 			Eventually(func() []string { return podNames(listAllPods()) }).Should(ContainElement("foo"))
 
-			err := podClient.Delete(fixture.Namespace, "foo")
+			err := podClient.Delete(context.Background(), fixture.Namespace, "foo")
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []string { return podNames(listAllPods()) }).ShouldNot(ContainElement("foo"))
@@ -119,7 +119,7 @@ var _ = Describe("Pod", func() {
 
 		Context("when it fails", func() {
 			It("returns the error", func() {
-				err := podClient.Delete(fixture.Namespace, "bar")
+				err := podClient.Delete(context.Background(), fixture.Namespace, "bar")
 
 				Expect(err).To(MatchError(ContainSubstring(`"bar" not found`)))
 			})
@@ -144,7 +144,7 @@ var _ = Describe("Pod", func() {
 			pods := listAllPods()
 			pod := pods[0]
 
-			_, err := podClient.SetAnnotation(&pod, key, value)
+			_, err := podClient.SetAnnotation(context.Background(), &pod, key, value)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -186,7 +186,7 @@ var _ = Describe("PodDisruptionBudgets", func() {
 
 	Describe("Create", func() {
 		It("creates a PDB", func() {
-			_, err := pdbClient.Create(fixture.Namespace, &policyv1beta1.PodDisruptionBudget{
+			_, err := pdbClient.Create(context.Background(), fixture.Namespace, &policyv1beta1.PodDisruptionBudget{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "foo",
 				},
@@ -208,7 +208,7 @@ var _ = Describe("PodDisruptionBudgets", func() {
 		It("deletes a PDB", func() {
 			Eventually(func() []policyv1beta1.PodDisruptionBudget { return listPDBs(fixture.Namespace) }).ShouldNot(BeEmpty())
 
-			err := pdbClient.Delete(fixture.Namespace, "foo")
+			err := pdbClient.Delete(context.Background(), fixture.Namespace, "foo")
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []policyv1beta1.PodDisruptionBudget { return listPDBs(fixture.Namespace) }).Should(BeEmpty())
@@ -225,7 +225,7 @@ var _ = Describe("StatefulSets", func() {
 
 	Describe("Create", func() {
 		It("creates a StatefulSet", func() {
-			_, err := statefulSetClient.Create(fixture.Namespace, &appsv1.StatefulSet{
+			_, err := statefulSetClient.Create(context.Background(), fixture.Namespace, &appsv1.StatefulSet{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "foo",
 				},
@@ -269,7 +269,7 @@ var _ = Describe("StatefulSets", func() {
 		})
 
 		It("retrieves a StatefulSet by namespace and name", func() {
-			statefulSet, err := statefulSetClient.Get(fixture.Namespace, "foo")
+			statefulSet, err := statefulSetClient.Get(context.Background(), fixture.Namespace, "foo")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(statefulSet.Name).To(Equal("foo"))
@@ -297,14 +297,14 @@ var _ = Describe("StatefulSets", func() {
 
 		It("lists all StatefulSets with the specified source type", func() {
 			Eventually(func() []string {
-				statefulSets, err := statefulSetClient.GetBySourceType("FOO")
+				statefulSets, err := statefulSetClient.GetBySourceType(context.Background(), "FOO")
 				Expect(err).NotTo(HaveOccurred())
 
 				return statefulSetNames(statefulSets)
 			}).Should(ContainElements("one", "three"))
 
 			Consistently(func() []string {
-				statefulSets, err := statefulSetClient.GetBySourceType("FOO")
+				statefulSets, err := statefulSetClient.GetBySourceType(context.Background(), "FOO")
 				Expect(err).NotTo(HaveOccurred())
 
 				return statefulSetNames(statefulSets)
@@ -332,7 +332,7 @@ var _ = Describe("StatefulSets", func() {
 		})
 
 		It("lists all StatefulSets matching the specified LRP identifier", func() {
-			statefulSets, err := statefulSetClient.GetByLRPIdentifier(opi.LRPIdentifier{GUID: guid, Version: "42"})
+			statefulSets, err := statefulSetClient.GetByLRPIdentifier(context.Background(), opi.LRPIdentifier{GUID: guid, Version: "42"})
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []string { return statefulSetNames(statefulSets) }).Should(ConsistOf("one", "two"))
@@ -351,7 +351,7 @@ var _ = Describe("StatefulSets", func() {
 		It("updates a StatefulSet", func() {
 			statefulSet.Labels["label"] = "new-value"
 
-			newStatefulSet, err := statefulSetClient.Update(fixture.Namespace, statefulSet)
+			newStatefulSet, err := statefulSetClient.Update(context.Background(), fixture.Namespace, statefulSet)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(newStatefulSet.Labels["label"]).To(Equal("new-value"))
 
@@ -369,7 +369,7 @@ var _ = Describe("StatefulSets", func() {
 		It("deletes a StatefulSet", func() {
 			Eventually(func() []appsv1.StatefulSet { return listStatefulSets(fixture.Namespace) }).ShouldNot(BeEmpty())
 
-			err := statefulSetClient.Delete(fixture.Namespace, "foo")
+			err := statefulSetClient.Delete(context.Background(), fixture.Namespace, "foo")
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []appsv1.StatefulSet { return listStatefulSets(fixture.Namespace) }).Should(BeEmpty())
@@ -388,7 +388,7 @@ var _ = Describe("Jobs", func() {
 		It("creates a Job", func() {
 			runAsNonRoot := true
 			runAsUser := int64(2000)
-			_, err := jobsClient.Create(fixture.Namespace, &batchv1.Job{
+			_, err := jobsClient.Create(context.Background(), fixture.Namespace, &batchv1.Job{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "foo",
 				},
@@ -429,7 +429,7 @@ var _ = Describe("Jobs", func() {
 		It("deletes a Job", func() {
 			Eventually(func() []batchv1.Job { return listJobs(fixture.Namespace) }).ShouldNot(BeEmpty())
 
-			err := jobsClient.Delete(fixture.Namespace, "foo")
+			err := jobsClient.Delete(context.Background(), fixture.Namespace, "foo")
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []batchv1.Job { return listJobs(fixture.Namespace) }).Should(BeEmpty())
@@ -455,7 +455,7 @@ var _ = Describe("Jobs", func() {
 
 		getJobGUIDs := func(guid string, includeCompleted bool) func() []string {
 			return func() []string {
-				jobs, err := jobsClient.GetByGUID(guid, includeCompleted)
+				jobs, err := jobsClient.GetByGUID(context.Background(), guid, includeCompleted)
 				Expect(err).NotTo(HaveOccurred())
 
 				return jobNames(jobs)
@@ -513,7 +513,7 @@ var _ = Describe("Jobs", func() {
 
 		listJobGUIDs := func(includeCompleted bool) func() []string {
 			return func() []string {
-				jobs, err := jobsClient.List(includeCompleted)
+				jobs, err := jobsClient.List(context.Background(), includeCompleted)
 				Expect(err).NotTo(HaveOccurred())
 
 				return jobGUIDs(jobs)
@@ -580,7 +580,7 @@ var _ = Describe("Jobs", func() {
 			job, err := getJob(taskGUID)
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = jobsClient.SetLabel(job, label, value)
+			_, err = jobsClient.SetLabel(context.Background(), job, label, value)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -636,7 +636,7 @@ var _ = Describe("StagingJobs", func() {
 
 		It("gets all jobs matching the specified guid", func() {
 			Eventually(func() []string {
-				jobs, err := jobsClient.GetByGUID("bar", false)
+				jobs, err := jobsClient.GetByGUID(context.Background(), "bar", false)
 				Expect(err).NotTo(HaveOccurred())
 
 				return jobNames(jobs)
@@ -681,7 +681,7 @@ var _ = Describe("StagingJobs", func() {
 
 		listJobGUIDs := func(includeCompleted bool) func() []string {
 			return func() []string {
-				jobs, err := jobsClient.List(includeCompleted)
+				jobs, err := jobsClient.List(context.Background(), includeCompleted)
 				Expect(err).NotTo(HaveOccurred())
 
 				return jobGUIDs(jobs)
@@ -746,7 +746,7 @@ var _ = Describe("Secrets", func() {
 		})
 
 		It("retrieves a Secret by namespace and name", func() {
-			secret, err := secretClient.Get(fixture.Namespace, "foo")
+			secret, err := secretClient.Get(context.Background(), fixture.Namespace, "foo")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(secret.Name).To(Equal("foo"))
@@ -756,7 +756,7 @@ var _ = Describe("Secrets", func() {
 
 	Describe("Create", func() {
 		It("creates the secret in the namespace", func() {
-			_, createErr := secretClient.Create(fixture.Namespace, &corev1.Secret{
+			_, createErr := secretClient.Create(context.Background(), fixture.Namespace, &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "very-secret",
 				},
@@ -774,7 +774,7 @@ var _ = Describe("Secrets", func() {
 		})
 
 		It("updates the existing secret", func() {
-			_, err := secretClient.Update(fixture.Namespace, &corev1.Secret{
+			_, err := secretClient.Update(context.Background(), fixture.Namespace, &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "top-secret",
 					Labels: map[string]string{
@@ -800,7 +800,7 @@ var _ = Describe("Secrets", func() {
 				return secretNames(listSecrets(fixture.Namespace))
 			}).Should(ContainElement("open-secret"))
 
-			err := secretClient.Delete(fixture.Namespace, "open-secret")
+			err := secretClient.Delete(context.Background(), fixture.Namespace, "open-secret")
 
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(func() []string {
@@ -844,7 +844,7 @@ var _ = Describe("Events", func() {
 
 		It("lists the events beloging to a pod", func() {
 			Eventually(func() []string {
-				events, err := eventClient.GetByPod(pod)
+				events, err := eventClient.GetByPod(context.Background(), pod)
 				Expect(err).NotTo(HaveOccurred())
 
 				return eventNames(events)
@@ -887,7 +887,7 @@ var _ = Describe("Events", func() {
 
 		It("lists the events beloging to an LRP instance with a specific reason", func() {
 			Eventually(func() string {
-				event, err := eventClient.GetByInstanceAndReason(fixture.Namespace, metav1.OwnerReference{
+				event, err := eventClient.GetByInstanceAndReason(context.Background(), fixture.Namespace, metav1.OwnerReference{
 					Name: ownerRef.Name,
 					UID:  ownerRef.UID,
 					Kind: ownerRef.Kind,
@@ -901,7 +901,7 @@ var _ = Describe("Events", func() {
 
 	Describe("Create", func() {
 		It("creates the secret in the namespace", func() {
-			_, createErr := eventClient.Create(fixture.Namespace, &corev1.Event{
+			_, createErr := eventClient.Create(context.Background(), fixture.Namespace, &corev1.Event{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "a-party",
 					Namespace: fixture.Namespace,
@@ -927,7 +927,7 @@ var _ = Describe("Events", func() {
 
 		It("updates the existing secret", func() {
 			event.Reason = "the reason"
-			_, err := eventClient.Update(fixture.Namespace, event)
+			_, err := eventClient.Update(context.Background(), fixture.Namespace, event)
 			Expect(err).NotTo(HaveOccurred())
 
 			event, err := getEvent(fixture.Namespace, "whatever")
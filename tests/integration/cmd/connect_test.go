@@ -216,6 +216,46 @@ var _ = Describe("connect command", func() {
 					return err
 				}, "10s").Should(Succeed())
 			})
+
+			It("serves TLS as well", func() {
+				Eventually(func() error {
+					_, err := makeRequest()
+
+					return err
+				}, "10s").Should(Succeed())
+			})
+		})
+	})
+
+	When("the health port is set", func() {
+		BeforeEach(func() {
+			config.Properties.HealthPort = fixture.NextAvailablePort()
+		})
+
+		It("reports ready once its caches have synced", func() {
+			Eventually(func() (int, error) {
+				resp, err := http.Get(fmt.Sprintf("http://localhost:%d/readyz", config.Properties.HealthPort))
+				if err != nil {
+					return 0, err
+				}
+				defer resp.Body.Close()
+
+				return resp.StatusCode, nil
+			}, "10s").Should(Equal(http.StatusOK))
+		})
+	})
+
+	When("it receives a SIGTERM", func() {
+		It("drains the servers and exits cleanly", func() {
+			Eventually(func() error {
+				_, err := makeRequest()
+
+				return err
+			}, "10s").Should(Succeed())
+
+			session.Terminate()
+			Eventually(session, "10s").Should(gexec.Exit(0))
+			Expect(session.Out).To(gbytes.Say("shutting-down"))
 		})
 	})
 })
@@ -87,6 +87,7 @@ var _ = Describe("Events", func() {
 				Secrets:                   client.NewSecret(fixture.Clientset),
 				StatefulSets:              client.NewStatefulSet(fixture.Clientset, fixture.Namespace),
 				PodDisruptionBudgets:      client.NewPodDisruptionBudget(fixture.Clientset),
+				NetworkPolicies:           client.NewNetworkPolicy(fixture.Clientset),
 				EventsClient:              client.NewEvent(fixture.Clientset),
 				StatefulSetToLRPMapper:    k8s.StatefulSetToLRP,
 				RegistrySecretName:        "registry-secret",
@@ -104,7 +105,7 @@ var _ = Describe("Events", func() {
 				Image:           "eirini/busybox",
 				LRPIdentifier:   opi.LRPIdentifier{GUID: tests.GenerateGUID(), Version: tests.GenerateGUID()},
 			}
-			Expect(lrpDesirer.Desire(fixture.Namespace, &lrp)).To(Succeed())
+			Expect(lrpDesirer.Desire(context.Background(), fixture.Namespace, &lrp)).To(Succeed())
 		})
 
 		When("the LRP does not terminate", func() {
@@ -228,10 +229,13 @@ var _ = Describe("Events", func() {
 			taskDesirer = k8s.NewTaskDesirer(
 				logger,
 				client.NewJob(fixture.Clientset, fixture.Namespace),
+				client.NewPod(fixture.Clientset, fixture.Namespace),
 				nil,
 				tests.GetApplicationServiceAccount(),
 				"",
 				false,
+				nil,
+				"",
 			)
 		})
 
@@ -241,7 +245,7 @@ var _ = Describe("Events", func() {
 				Image:   "eirini/busybox",
 				GUID:    tests.GenerateGUID(),
 			}
-			Expect(taskDesirer.Desire(fixture.Namespace, &task)).To(Succeed())
+			Expect(taskDesirer.Desire(context.Background(), fixture.Namespace, &task)).To(Succeed())
 		})
 
 		It("should not send crash events", func() {
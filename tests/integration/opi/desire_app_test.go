@@ -101,8 +101,8 @@ var _ = Describe("Desire App", func() {
 		}`
 		})
 
-		It("should return a 400 Bad Request HTTP code", func() {
-			Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		It("should return a 422 Unprocessable Entity HTTP code", func() {
+			Expect(response.StatusCode).To(Equal(http.StatusUnprocessableEntity))
 		})
 	})
 
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/eirini"
 	"code.cloudfoundry.org/eirini/k8s"
 	"code.cloudfoundry.org/eirini/models/cf"
+	"code.cloudfoundry.org/eirini/opi"
 	"code.cloudfoundry.org/eirini/tests"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -65,17 +66,31 @@ var _ = Describe("Tasks completion", func() {
 		Eventually(taskReporterSession.Terminate()).Should(gexec.Exit())
 	})
 
-	It("does not list completed tasks that have not reached their ttl", func() {
-		Eventually(listTasks).Should(BeEmpty())
+	It("lists completed tasks that have not reached their ttl", func() {
+		Eventually(func() ([]string, error) {
+			tasks, err := listTasks()
+			if err != nil {
+				return nil, err
+			}
+
+			states := []string{}
+			for _, task := range tasks {
+				if task.GUID == taskGUID {
+					states = append(states, task.State)
+				}
+			}
+
+			return states, nil
+		}).Should(ConsistOf(opi.TaskSucceededState))
 		Expect(getJob(taskGUID)).NotTo(BeNil())
 	})
 
-	It("does not get a completed tasks that has not reached its ttl", func() {
-		Eventually(func() error {
-			_, err := getTask(taskGUID)
+	It("gets a completed task that has not reached its ttl", func() {
+		Eventually(func() (string, error) {
+			task, err := getTask(taskGUID)
 
-			return err
-		}).Should(MatchError(ContainSubstring("404 Not Found")))
+			return task.State, err
+		}).Should(Equal(opi.TaskSucceededState))
 		Expect(getJob(taskGUID)).NotTo(BeNil())
 	})
 })
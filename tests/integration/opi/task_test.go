@@ -426,7 +426,8 @@ var _ = Describe("Tasks", func() {
 			err = json.NewDecoder(resp.Body).Decode(&tasks)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(tasks).To(ConsistOf(cf.TaskResponse{GUID: request.GUID}))
+			Expect(tasks).To(HaveLen(1))
+			Expect(tasks[0].GUID).To(Equal(request.GUID))
 		})
 
 		When("the task is marked as completed", func() {
@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/eirini/k8s"
@@ -38,6 +39,7 @@ var _ = Describe("Routes", func() {
 			Secrets:                   client.NewSecret(fixture.Clientset),
 			StatefulSets:              client.NewStatefulSet(fixture.Clientset, fixture.Namespace),
 			PodDisruptionBudgets:      client.NewPodDisruptionBudget(fixture.Clientset),
+			NetworkPolicies:           client.NewNetworkPolicy(fixture.Clientset),
 			EventsClient:              client.NewEvent(fixture.Clientset),
 			StatefulSetToLRPMapper:    k8s.StatefulSetToLRP,
 			RegistrySecretName:        "registry-secret",
@@ -62,7 +64,7 @@ var _ = Describe("Routes", func() {
 
 		When("an LRP is desired", func() {
 			It("sends register routes message", func() {
-				Expect(desirer.Desire(fixture.Namespace, odinLRP)).To(Succeed())
+				Expect(desirer.Desire(context.Background(), fixture.Namespace, odinLRP)).To(Succeed())
 				Eventually(func() bool {
 					pods := listPods(odinLRP.LRPIdentifier)
 					if len(pods) < 1 {
@@ -105,7 +107,7 @@ else
 	done;
 fi;`,
 					}
-					err := desirer.Desire(fixture.Namespace, odinLRP)
+					err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 					Expect(err).ToNot(HaveOccurred())
 					Eventually(func() bool {
 						pods := listPods(odinLRP.LRPIdentifier)
@@ -144,7 +146,7 @@ fi;`,
 		)
 
 		BeforeEach(func() {
-			err := desirer.Desire(fixture.Namespace, odinLRP)
+			err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 			Expect(err).ToNot(HaveOccurred())
 			Eventually(func() bool {
 				pods := listPods(odinLRP.LRPIdentifier)
@@ -191,7 +193,7 @@ fi;`,
 
 		When("an app is stopped", func() {
 			It("sends unregister routes message", func() {
-				Expect(desirer.Stop(odinLRP.LRPIdentifier)).To(Succeed())
+				Expect(desirer.Stop(context.Background(), odinLRP.LRPIdentifier)).To(Succeed())
 				pods := listPods(odinLRP.LRPIdentifier)
 
 				Eventually(fakeRouteEmitter.EmitCallCount).Should(Equal(2))
@@ -229,7 +231,7 @@ fi;`,
 					{Hostname: "foo.example.com", Port: 8080},
 					{Hostname: "bar.example.com", Port: 9090},
 				}
-				Expect(desirer.Update(odinLRP)).To(Succeed())
+				Expect(desirer.Update(context.Background(), odinLRP)).To(Succeed())
 				pods := listPods(odinLRP.LRPIdentifier)
 
 				Eventually(fakeRouteEmitter.EmitCallCount).Should(Equal(4))
@@ -262,7 +264,7 @@ fi;`,
 
 		BeforeEach(func() {
 			odinLRP.TargetInstances = 2
-			Expect(desirer.Desire(fixture.Namespace, odinLRP)).To(Succeed())
+			Expect(desirer.Desire(context.Background(), fixture.Namespace, odinLRP)).To(Succeed())
 			Eventually(func() bool {
 				pods := listPods(odinLRP.LRPIdentifier)
 				if len(pods) < 2 {
@@ -304,7 +306,7 @@ fi;`,
 			It("sends unregister routes message", func() {
 				pods := listPods(odinLRP.LRPIdentifier)
 				odinLRP.TargetInstances = 1
-				Expect(desirer.Update(odinLRP)).To(Succeed())
+				Expect(desirer.Update(context.Background(), odinLRP)).To(Succeed())
 
 				Eventually(fakeRouteEmitter.EmitCallCount).Should(Equal(1))
 				Expect(fakeRouteEmitter.EmitArgsForCall(0)).To(Equal(route.Message{
@@ -323,7 +325,7 @@ fi;`,
 		When("an app instance is stopped", func() {
 			It("sends unregister routes message", func() {
 				pods := listPods(odinLRP.LRPIdentifier)
-				Expect(desirer.StopInstance(odinLRP.LRPIdentifier, 0)).To(Succeed())
+				Expect(desirer.StopInstance(context.Background(), odinLRP.LRPIdentifier, 0)).To(Succeed())
 
 				Eventually(fakeRouteEmitter.EmitCallCount).Should(Equal(1))
 				Expect(fakeRouteEmitter.EmitArgsForCall(0)).To(Equal(route.Message{
@@ -44,6 +44,7 @@ var _ = Describe("StatefulSetDesirer", func() {
 			Secrets:                   client.NewSecret(fixture.Clientset),
 			StatefulSets:              client.NewStatefulSet(fixture.Clientset, fixture.Namespace),
 			PodDisruptionBudgets:      client.NewPodDisruptionBudget(fixture.Clientset),
+			NetworkPolicies:           client.NewNetworkPolicy(fixture.Clientset),
 			EventsClient:              client.NewEvent(fixture.Clientset),
 			StatefulSetToLRPMapper:    k8s.StatefulSetToLRP,
 			RegistrySecretName:        "registry-secret",
@@ -56,9 +57,9 @@ var _ = Describe("StatefulSetDesirer", func() {
 
 	Describe("Desire", func() {
 		JustBeforeEach(func() {
-			err := desirer.Desire(fixture.Namespace, odinLRP)
+			err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 			Expect(err).ToNot(HaveOccurred())
-			err = desirer.Desire(fixture.Namespace, thorLRP)
+			err = desirer.Desire(context.Background(), fixture.Namespace, thorLRP)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -200,7 +201,7 @@ var _ = Describe("StatefulSetDesirer", func() {
 
 		Context("when we create the same StatefulSet again", func() {
 			It("should not error", func() {
-				err := desirer.Desire(fixture.Namespace, odinLRP)
+				err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
@@ -255,7 +256,7 @@ var _ = Describe("StatefulSetDesirer", func() {
 		var statefulsetName string
 
 		JustBeforeEach(func() {
-			err := desirer.Desire(fixture.Namespace, odinLRP)
+			err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 			Expect(err).ToNot(HaveOccurred())
 
 			Eventually(func() []corev1.Pod {
@@ -264,7 +265,7 @@ var _ = Describe("StatefulSetDesirer", func() {
 
 			statefulsetName = getStatefulSetForLRP(odinLRP).Name
 
-			err = desirer.Stop(odinLRP.LRPIdentifier)
+			err = desirer.Stop(context.Background(), odinLRP.LRPIdentifier)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -333,10 +334,10 @@ var _ = Describe("StatefulSetDesirer", func() {
 
 		JustBeforeEach(func() {
 			odinLRP.TargetInstances = instancesBefore
-			Expect(desirer.Desire(fixture.Namespace, odinLRP)).To(Succeed())
+			Expect(desirer.Desire(context.Background(), fixture.Namespace, odinLRP)).To(Succeed())
 
 			odinLRP.TargetInstances = instancesAfter
-			Expect(desirer.Update(odinLRP)).To(Succeed())
+			Expect(desirer.Update(context.Background(), odinLRP)).To(Succeed())
 		})
 
 		Context("when scaling up from 1 to 2 instances", func() {
@@ -396,14 +397,14 @@ var _ = Describe("StatefulSetDesirer", func() {
 
 	Describe("Get", func() {
 		numberOfInstancesFn := func() int {
-			lrp, err := desirer.Get(odinLRP.LRPIdentifier)
+			lrp, err := desirer.Get(context.Background(), odinLRP.LRPIdentifier)
 			Expect(err).ToNot(HaveOccurred())
 
 			return lrp.RunningInstances
 		}
 
 		JustBeforeEach(func() {
-			err := desirer.Desire(fixture.Namespace, odinLRP)
+			err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -444,7 +445,7 @@ fi;`,
 			})
 
 			It("can still get the LRP", func() {
-				lrp, err := desirer.Get(odinLRP.LRPIdentifier)
+				lrp, err := desirer.Get(context.Background(), odinLRP.LRPIdentifier)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(lrp.GUID).To(Equal(odinLRP.GUID))
 			})
@@ -453,14 +454,14 @@ fi;`,
 
 	Describe("GetInstances", func() {
 		instancesFn := func() []*opi.Instance {
-			instances, err := desirer.GetInstances(odinLRP.LRPIdentifier)
+			instances, err := desirer.GetInstances(context.Background(), odinLRP.LRPIdentifier)
 			Expect(err).ToNot(HaveOccurred())
 
 			return instances
 		}
 
 		JustBeforeEach(func() {
-			err := desirer.Desire(fixture.Namespace, odinLRP)
+			err := desirer.Desire(context.Background(), fixture.Namespace, odinLRP)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
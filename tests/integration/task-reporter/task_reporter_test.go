@@ -65,10 +65,13 @@ var _ = Describe("TaskReporter", func() {
 		taskDesirer = k8s.NewTaskDesirer(
 			lagertest.NewTestLogger("test-task-desirer"),
 			client.NewJob(fixture.Clientset, fixture.Namespace),
+			client.NewPod(fixture.Clientset, fixture.Namespace),
 			client.NewSecret(fixture.Clientset),
 			"",
 			"",
 			false,
+			nil,
+			"",
 		)
 
 		taskGUID := tests.GenerateGUID()
@@ -101,7 +104,7 @@ var _ = Describe("TaskReporter", func() {
 
 		session, configFile = eiriniBins.TaskReporter.Run(config)
 		Eventually(session).Should(gbytes.Say("Starting workers"))
-		Expect(taskDesirer.Desire(fixture.Namespace, task)).To(Succeed())
+		Expect(taskDesirer.Desire(context.Background(), fixture.Namespace, task)).To(Succeed())
 		taskSubmittedAt = time.Now()
 	})
 
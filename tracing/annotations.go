@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	AnnotationTraceParent = "cloudfoundry.org/traceparent"
+	AnnotationTraceState  = "cloudfoundry.org/tracestate"
+)
+
+// annotationCarrier stores the W3C trace context fields under eirini's
+// annotation keys.
+type annotationCarrier map[string]string
+
+var annotationKeys = map[string]string{
+	"traceparent": AnnotationTraceParent,
+	"tracestate":  AnnotationTraceState,
+}
+
+func (c annotationCarrier) Get(key string) string {
+	return c[annotationKeys[key]]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	if annotation, ok := annotationKeys[key]; ok {
+		c[annotation] = value
+	}
+}
+
+func (c annotationCarrier) Keys() []string {
+	keys := []string{}
+
+	for field, annotation := range annotationKeys {
+		if _, ok := c[annotation]; ok {
+			keys = append(keys, field)
+		}
+	}
+
+	return keys
+}
+
+// InjectAnnotations stores the span context of ctx in annotations. It does
+// nothing when ctx carries no span, so that objects created outside of a
+// traced request keep their annotations unchanged.
+func InjectAnnotations(ctx context.Context, annotations map[string]string) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	propagation.TraceContext{}.Inject(ctx, annotationCarrier(annotations))
+}
+
+// SpanContextFromAnnotations returns the span context stored by
+// InjectAnnotations. It is invalid when there is none.
+func SpanContextFromAnnotations(annotations map[string]string) trace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), annotationCarrier(annotations))
+
+	return trace.SpanContextFromContext(ctx)
+}
+
+// StartLinked starts a span that links back to the span context stored in
+// annotations, if any. It is used for work that happens long after the
+// request that caused it, such as the callbacks to CC.
+func StartLinked(ctx context.Context, name string, annotations map[string]string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return StartLinkedTo(ctx, name, SpanContextFromAnnotations(annotations), opts...)
+}
+
+// StartLinkedTo starts a span that links back to spanContext, if it is
+// valid.
+func StartLinkedTo(ctx context.Context, name string, spanContext trace.SpanContext, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if spanContext.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: spanContext}))
+	}
+
+	return Start(ctx, name, opts...)
+}
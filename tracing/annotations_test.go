@@ -0,0 +1,75 @@
+package tracing_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/eirini/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Annotations", func() {
+	var (
+		exporter    *tracetest.InMemoryExporter
+		annotations map[string]string
+	)
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+		annotations = map[string]string{"foo": "bar"}
+	})
+
+	When("the context carries a span", func() {
+		var span trace.Span
+
+		BeforeEach(func() {
+			var ctx context.Context
+			ctx, span = tracing.Start(context.Background(), "desire")
+			tracing.InjectAnnotations(ctx, annotations)
+			span.End()
+		})
+
+		It("stores the span context in the annotations", func() {
+			Expect(annotations).To(HaveKey(tracing.AnnotationTraceParent))
+			Expect(annotations).To(HaveKeyWithValue("foo", "bar"))
+			Expect(tracing.SpanContextFromAnnotations(annotations).SpanID()).To(Equal(span.SpanContext().SpanID()))
+		})
+
+		It("links spans back to it", func() {
+			_, linked := tracing.StartLinked(context.Background(), "callback", annotations)
+			linked.End()
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[1].Name).To(Equal("callback"))
+			Expect(spans[1].Parent.IsValid()).To(BeFalse())
+			Expect(spans[1].Links).To(HaveLen(1))
+			Expect(spans[1].Links[0].SpanContext.SpanID()).To(Equal(span.SpanContext().SpanID()))
+		})
+	})
+
+	When("the context carries no span", func() {
+		BeforeEach(func() {
+			tracing.InjectAnnotations(context.Background(), annotations)
+		})
+
+		It("leaves the annotations unchanged", func() {
+			Expect(annotations).To(Equal(map[string]string{"foo": "bar"}))
+		})
+
+		It("does not link spans", func() {
+			_, linked := tracing.StartLinked(context.Background(), "callback", annotations)
+			linked.End()
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Links).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WriterExporter writes every span as a JSON line. It is meant for
+// development and tests, where there is no collector to send spans to.
+type WriterExporter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+type exportedSpan struct {
+	Name         string            `json:"name"`
+	Kind         string            `json:"kind"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+	Links        []exportedLink    `json:"links,omitempty"`
+	Status       string            `json:"status"`
+	Error        string            `json:"error,omitempty"`
+}
+
+type exportedLink struct {
+	TraceID string `json:"trace_id"`
+	SpanID  string `json:"span_id"`
+}
+
+func NewWriterExporter(writer io.Writer) *WriterExporter {
+	return &WriterExporter{encoder: json.NewEncoder(writer)}
+}
+
+func (e *WriterExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, span := range spans {
+		if err := e.encoder.Encode(toExportedSpan(span)); err != nil {
+			return errors.Wrap(err, "failed to write span")
+		}
+	}
+
+	return nil
+}
+
+func (e *WriterExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func toExportedSpan(span sdktrace.ReadOnlySpan) exportedSpan {
+	exported := exportedSpan{
+		Name:    span.Name(),
+		Kind:    span.SpanKind().String(),
+		TraceID: span.SpanContext().TraceID().String(),
+		SpanID:  span.SpanContext().SpanID().String(),
+		Start:   span.StartTime(),
+		End:     span.EndTime(),
+		Status:  span.Status().Code.String(),
+		Error:   span.Status().Description,
+	}
+
+	if span.Parent().IsValid() {
+		exported.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	if attributes := span.Attributes(); len(attributes) > 0 {
+		exported.Attributes = map[string]string{}
+		for _, attribute := range attributes {
+			exported.Attributes[string(attribute.Key)] = attribute.Value.Emit()
+		}
+	}
+
+	for _, link := range span.Links() {
+		exported.Links = append(exported.Links, exportedLink{
+			TraceID: link.SpanContext.TraceID().String(),
+			SpanID:  link.SpanContext.SpanID().String(),
+		})
+	}
+
+	return exported
+}
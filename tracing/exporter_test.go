@@ -0,0 +1,63 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/eirini/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("WriterExporter", func() {
+	var (
+		buffer   *gbytes.Buffer
+		provider *sdktrace.TracerProvider
+	)
+
+	BeforeEach(func() {
+		buffer = gbytes.NewBuffer()
+		provider = tracing.NewTracerProvider("test", sdktrace.WithSyncer(tracing.NewWriterExporter(buffer)))
+	})
+
+	It("writes every span as a JSON line", func() {
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		_, child := provider.Tracer("test").Start(ctx, "child", trace.WithAttributes(attribute.String("foo", "bar")))
+		_ = tracing.End(child, errors.New("boom"))
+		parent.End()
+
+		lines := splitLines(buffer.Contents())
+		Expect(lines).To(HaveLen(2))
+
+		var span map[string]interface{}
+		Expect(json.Unmarshal(lines[0], &span)).To(Succeed())
+		Expect(span).To(HaveKeyWithValue("name", "child"))
+		Expect(span).To(HaveKeyWithValue("trace_id", parent.SpanContext().TraceID().String()))
+		Expect(span).To(HaveKeyWithValue("parent_span_id", parent.SpanContext().SpanID().String()))
+		Expect(span).To(HaveKeyWithValue("attributes", HaveKeyWithValue("foo", "bar")))
+		Expect(span).To(HaveKeyWithValue("status", "Error"))
+		Expect(span).To(HaveKeyWithValue("error", "boom"))
+
+		Expect(json.Unmarshal(lines[1], &span)).To(Succeed())
+		Expect(span).To(HaveKeyWithValue("name", "parent"))
+	})
+})
+
+func splitLines(contents []byte) [][]byte {
+	lines := [][]byte{}
+	start := 0
+
+	for i, b := range contents {
+		if b == '\n' {
+			lines = append(lines, contents[start:i])
+			start = i + 1
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,7 @@
+// Package tracing carries the trace context of CC requests through eirini.
+//
+// Spans are started from the W3C or B3 headers of incoming requests, the
+// context is stored in the annotations of the StatefulSets and Jobs created
+// on their behalf, and the callbacks to CC made later on are linked back to
+// it.
+package tracing
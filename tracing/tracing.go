@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	TracerName = "code.cloudfoundry.org/eirini"
+
+	// ExporterStdout writes every finished span to stdout as a JSON line.
+	ExporterStdout = "stdout"
+)
+
+// Tracer returns the eirini tracer of the global provider. Until Init is
+// called it is a no-op.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Start starts a span as a child of the one in ctx, if any.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End records err, if any, on the span and ends it. It returns err so that
+// it can wrap return statements.
+func End(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+
+	return err
+}
+
+// Propagator reads and writes both W3C trace context and B3 headers. CC
+// sends B3 headers when it is configured to trace requests. B3 is read from
+// either the single or the multiple headers and written to both.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader|b3.B3SingleHeader)),
+	)
+}
+
+// Init installs the global tracer provider for the named exporter and
+// returns a function that flushes and stops it. An empty exporter leaves
+// tracing disabled.
+func Init(serviceName, exporter string, stdout io.Writer) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+
+	switch exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		spanExporter = NewWriterExporter(stdout)
+	default:
+		return nil, errors.Errorf("unsupported tracing exporter %q", exporter)
+	}
+
+	provider := NewTracerProvider(serviceName, sdktrace.WithBatcher(spanExporter))
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// NewTracerProvider creates a provider that samples every trace that is not
+// started from an unsampled remote parent.
+func NewTracerProvider(serviceName string, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	}, opts...)
+
+	return sdktrace.NewTracerProvider(opts...)
+}
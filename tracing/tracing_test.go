@@ -0,0 +1,89 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/eirini/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("Propagator", func() {
+	var (
+		header      http.Header
+		spanContext trace.SpanContext
+	)
+
+	BeforeEach(func() {
+		header = http.Header{}
+	})
+
+	JustBeforeEach(func() {
+		ctx := tracing.Propagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+		spanContext = trace.SpanContextFromContext(ctx)
+	})
+
+	When("the W3C trace context header is set", func() {
+		BeforeEach(func() {
+			header.Set("traceparent", "00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01")
+		})
+
+		It("extracts the span context", func() {
+			Expect(spanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(spanContext.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+			Expect(spanContext.IsSampled()).To(BeTrue())
+		})
+	})
+
+	When("the multiple B3 headers are set", func() {
+		BeforeEach(func() {
+			header.Set("X-B3-TraceId", "463ac35c9f6413ad48485a3953bb6124")
+			header.Set("X-B3-SpanId", "a2fb4a1d1a96d312")
+			header.Set("X-B3-Sampled", "0")
+		})
+
+		It("extracts the span context", func() {
+			Expect(spanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(spanContext.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+			Expect(spanContext.IsSampled()).To(BeFalse())
+			Expect(spanContext.IsRemote()).To(BeTrue())
+		})
+	})
+
+	When("the single B3 header is set", func() {
+		BeforeEach(func() {
+			header.Set("b3", "463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-1-05e3ac9a4f6e3b90")
+		})
+
+		It("extracts the span context", func() {
+			Expect(spanContext.TraceID().String()).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+			Expect(spanContext.SpanID().String()).To(Equal("a2fb4a1d1a96d312"))
+			Expect(spanContext.IsSampled()).To(BeTrue())
+		})
+	})
+
+	It("injects the W3C and both B3 headers", func() {
+		traceID, err := trace.TraceIDFromHex("463ac35c9f6413ad48485a3953bb6124")
+		Expect(err).NotTo(HaveOccurred())
+		spanID, err := trace.SpanIDFromHex("a2fb4a1d1a96d312")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		injected := http.Header{}
+		tracing.Propagator().Inject(ctx, propagation.HeaderCarrier(injected))
+
+		Expect(injected.Get("traceparent")).To(Equal("00-463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-01"))
+		Expect(injected.Get("b3")).To(Equal("463ac35c9f6413ad48485a3953bb6124-a2fb4a1d1a96d312-1"))
+		Expect(injected.Get("X-B3-TraceId")).To(Equal("463ac35c9f6413ad48485a3953bb6124"))
+		Expect(injected.Get("X-B3-SpanId")).To(Equal("a2fb4a1d1a96d312"))
+		Expect(injected.Get("X-B3-Sampled")).To(Equal("1"))
+	})
+})
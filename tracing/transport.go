@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WrapTransport traces the requests made through rt that belong to a traced
+// operation. Requests without a span in their context, such as the informer
+// watches, are passed through untouched.
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return transport{delegate: rt}
+}
+
+type transport struct {
+	delegate http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return t.delegate.RoundTrip(req)
+	}
+
+	ctx, span := Start(req.Context(), "k8s "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.target", req.URL.Path),
+		),
+	)
+
+	resp, err := t.delegate.RoundTrip(req.WithContext(ctx))
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	}
+
+	_ = End(span, err)
+
+	return resp, err
+}
@@ -0,0 +1,66 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/eirini/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ = Describe("WrapTransport", func() {
+	var (
+		exporter *tracetest.InMemoryExporter
+		server   *httptest.Server
+		client   *http.Client
+	)
+
+	BeforeEach(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(tracing.NewTracerProvider("test", sdktrace.WithSyncer(exporter)))
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		client = &http.Client{Transport: tracing.WrapTransport(http.DefaultTransport)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/pods", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := client.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+	}
+
+	It("traces requests made on behalf of a traced operation", func() {
+		ctx, parent := tracing.Start(context.Background(), "operation")
+		get(ctx)
+		parent.End()
+
+		spans := exporter.GetSpans()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Name).To(Equal("k8s GET"))
+		Expect(spans[0].SpanKind).To(Equal(trace.SpanKindClient))
+		Expect(spans[0].Parent.SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(spans[0].Attributes).To(ContainElement(attribute.String("http.target", "/api/v1/pods")))
+		Expect(spans[0].Attributes).To(ContainElement(attribute.Int("http.status_code", http.StatusNotFound)))
+	})
+
+	It("does not trace other requests", func() {
+		get(context.Background())
+
+		Expect(exporter.GetSpans()).To(BeEmpty())
+	})
+})
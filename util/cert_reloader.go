@@ -0,0 +1,141 @@
+package util
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/tlsconfig"
+	"github.com/pkg/errors"
+)
+
+// CertReloader builds server TLS configs from a certificate, a key and a
+// client CA on disk. The files are re-read at most once per check interval,
+// during a handshake, so rotated certificates are picked up without a
+// restart. If the new files cannot be loaded (e.g. the key has been rotated
+// but the certificate has not yet) the previous config is kept.
+type CertReloader struct {
+	certPath      string
+	keyPath       string
+	caPath        string
+	checkInterval time.Duration
+	logger        lager.Logger
+
+	mutex       sync.Mutex
+	lastChecked time.Time
+	certPEM     []byte
+	keyPEM      []byte
+	caPEM       []byte
+	config      *tls.Config
+}
+
+func NewCertReloader(logger lager.Logger, certPath, keyPath, caPath string, checkInterval time.Duration) (*CertReloader, error) {
+	reloader := &CertReloader{
+		certPath:      certPath,
+		keyPath:       keyPath,
+		caPath:        caPath,
+		checkInterval: checkInterval,
+		logger:        logger,
+	}
+
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	reloader.lastChecked = time.Now()
+
+	return reloader, nil
+}
+
+// TLSConfig returns a server config requiring client certificates, which
+// delegates to the most recently loaded files on every handshake.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.GetConfigForClient,
+		// http.Server.ServeTLS insists on a certificate being configured
+		GetCertificate: r.getCertificate,
+	}
+}
+
+func (r *CertReloader) GetConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if time.Since(r.lastChecked) >= r.checkInterval {
+		r.lastChecked = time.Now()
+
+		if err := r.reload(); err != nil {
+			r.logger.Error("failed-to-reload-certificates", err)
+		}
+	}
+
+	return r.config, nil
+}
+
+func (r *CertReloader) reload() error {
+	certPEM, err := ioutil.ReadFile(filepath.Clean(r.certPath))
+	if err != nil {
+		return errors.Wrap(err, "failed to read certificate")
+	}
+
+	keyPEM, err := ioutil.ReadFile(filepath.Clean(r.keyPath))
+	if err != nil {
+		return errors.Wrap(err, "failed to read private key")
+	}
+
+	caPEM, err := ioutil.ReadFile(filepath.Clean(r.caPath))
+	if err != nil {
+		return errors.Wrap(err, "failed to read client CA")
+	}
+
+	if bytes.Equal(certPEM, r.certPEM) && bytes.Equal(keyPEM, r.keyPEM) && bytes.Equal(caPEM, r.caPEM) {
+		return nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return errors.Wrap(err, "failed to load key pair")
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return errors.New("failed to parse client CA")
+	}
+
+	config, err := tlsconfig.Build(
+		tlsconfig.WithInternalServiceDefaults(),
+	).Server(
+		tlsconfig.WithClientAuthentication(clientCAs),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to build TLS config")
+	}
+
+	config.Certificates = []tls.Certificate{cert}
+
+	r.certPEM, r.keyPEM, r.caPEM = certPEM, keyPEM, caPEM
+	r.config = config
+
+	if r.lastChecked.IsZero() {
+		r.logger.Info("certificates-loaded")
+	} else {
+		r.logger.Info("certificates-reloaded")
+	}
+
+	return nil
+}
+
+func (r *CertReloader) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	config, err := r.GetConfigForClient(hello)
+	if err != nil {
+		return nil, err
+	}
+
+	return &config.Certificates[0], nil
+}
@@ -0,0 +1,179 @@
+package util_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/eirini/util"
+	"code.cloudfoundry.org/lager/lagertest"
+	"code.cloudfoundry.org/tlsconfig/certtest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("CertReloader", func() {
+	var (
+		dir           string
+		certPath      string
+		keyPath       string
+		caPath        string
+		checkInterval time.Duration
+		logger        *lagertest.TestLogger
+		reloader      *util.CertReloader
+		reloaderErr   error
+	)
+
+	writeKeyPair := func(commonName string) {
+		authority, err := certtest.BuildCA(commonName + "-ca")
+		Expect(err).NotTo(HaveOccurred())
+
+		cert, err := authority.BuildSignedCertificate(commonName, certtest.WithDomains(commonName))
+		Expect(err).NotTo(HaveOccurred())
+
+		certPEM, keyPEM, err := cert.CertificatePEMAndPrivateKey()
+		Expect(err).NotTo(HaveOccurred())
+
+		caPEM, err := authority.CertificatePEM()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ioutil.WriteFile(certPath, certPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(keyPath, keyPEM, 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(caPath, caPEM, 0600)).To(Succeed())
+	}
+
+	servedCommonName := func() string {
+		config, err := reloader.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.Certificates).To(HaveLen(1))
+
+		cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		return cert.Subject.CommonName
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cert-reloader")
+		Expect(err).NotTo(HaveOccurred())
+
+		certPath = filepath.Join(dir, "tls.crt")
+		keyPath = filepath.Join(dir, "tls.key")
+		caPath = filepath.Join(dir, "ca.crt")
+		checkInterval = 0
+		logger = lagertest.NewTestLogger("cert-reloader")
+
+		writeKeyPair("first")
+	})
+
+	JustBeforeEach(func() {
+		reloader, reloaderErr = util.NewCertReloader(logger, certPath, keyPath, caPath, checkInterval)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("serves the certificate on disk", func() {
+		Expect(reloaderErr).NotTo(HaveOccurred())
+		Expect(servedCommonName()).To(Equal("first"))
+	})
+
+	It("requires client certificates signed by the CA", func() {
+		config, err := reloader.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.ClientAuth).To(Equal(tls.RequireAndVerifyClientCert))
+		Expect(config.ClientCAs.Subjects()).To(HaveLen(1))
+	})
+
+	It("provides a certificate to http.Server.ServeTLS", func() {
+		cert, err := reloader.TLSConfig().GetCertificate(&tls.ClientHelloInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.Certificate).NotTo(BeEmpty())
+	})
+
+	It("can be served by an http.Server", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		server := &http.Server{
+			Handler:   http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+			TLSConfig: reloader.TLSConfig(),
+		}
+		go func() {
+			_ = server.ServeTLS(listener, "", "")
+		}()
+		defer server.Close()
+
+		clientCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		Expect(err).NotTo(HaveOccurred())
+		caPEM, err := ioutil.ReadFile(caPath)
+		Expect(err).NotTo(HaveOccurred())
+		rootCAs := x509.NewCertPool()
+		Expect(rootCAs.AppendCertsFromPEM(caPEM)).To(BeTrue())
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			Certificates: []tls.Certificate{clientCert},
+			RootCAs:      rootCAs,
+			ServerName:   "first",
+		}}}
+
+		resp, err := client.Get("https://" + listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Body.Close()).To(Succeed())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	When("the certificates are rotated", func() {
+		JustBeforeEach(func() {
+			writeKeyPair("second")
+		})
+
+		It("serves the new certificate", func() {
+			Expect(servedCommonName()).To(Equal("second"))
+			Expect(logger).To(gbytes.Say("certificates-reloaded"))
+		})
+
+		When("the check interval has not elapsed", func() {
+			BeforeEach(func() {
+				checkInterval = time.Hour
+			})
+
+			It("keeps serving the old certificate", func() {
+				Expect(servedCommonName()).To(Equal("first"))
+			})
+		})
+	})
+
+	When("the new files are inconsistent", func() {
+		JustBeforeEach(func() {
+			Expect(ioutil.WriteFile(keyPath, []byte("half-written"), 0600)).To(Succeed())
+		})
+
+		It("keeps serving the old certificate", func() {
+			Expect(servedCommonName()).To(Equal("first"))
+		})
+
+		It("logs the failure", func() {
+			servedCommonName()
+			Expect(logger).To(gbytes.Say("failed-to-reload-certificates"))
+		})
+	})
+
+	When("the certificate cannot be loaded at startup", func() {
+		BeforeEach(func() {
+			Expect(os.Remove(caPath)).To(Succeed())
+		})
+
+		It("returns an error", func() {
+			Expect(reloaderErr).To(MatchError(ContainSubstring("failed to read client CA")))
+		})
+	})
+})